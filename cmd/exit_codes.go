@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/plugins"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
 )
 
@@ -43,6 +44,11 @@ func ExitCodeFromError(err error) int {
 		return ExitUsage
 	}
 
+	// Plugins exit with their own status
+	if exitErr, ok := errors.AsType[*plugins.ExitError](err); ok {
+		return exitErr.Code
+	}
+
 	// Well-known error types
 	if errors.Is(err, shared.ErrMissingAuth) ||
 		errors.Is(err, asc.ErrUnauthorized) ||
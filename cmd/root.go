@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -9,7 +10,9 @@ import (
 
 	"github.com/peterbourgon/ff/v3/ffcli"
 
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/plugins"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/registry"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/schema"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared/suggest"
 )
@@ -31,6 +34,7 @@ func RootCommand(version string) *ffcli.Command {
 
 	root.FlagSet.BoolVar(&versionRequested, "version", false, "Print version and exit")
 	shared.BindRootFlags(root.FlagSet)
+	root.Subcommands = append(root.Subcommands, schema.SchemaCommand(root))
 
 	rootSubcommandNames := make([]string, 0, len(root.Subcommands))
 	for _, sub := range root.Subcommands {
@@ -43,6 +47,15 @@ func RootCommand(version string) *ffcli.Command {
 			return nil
 		}
 		if len(args) > 0 {
+			// Unknown commands run an asc-<name> plugin from PATH when
+			// one exists; built-in commands always take precedence.
+			if plugin, ok := plugins.Find(args[0]); ok {
+				err := plugins.Run(ctx, plugin, args[1:])
+				if _, ok := errors.AsType[*plugins.ExitError](err); ok {
+					return shared.NewReportedError(err)
+				}
+				return err
+			}
 			unknown := shared.SanitizeTerminal(args[0])
 			fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", unknown)
 			if suggestions := suggest.Commands(args[0], rootSubcommandNames); len(suggestions) > 0 {
@@ -46,7 +46,7 @@ var rootUsageGroups = []rootCommandGroup{
 	},
 	{
 		title:    "REVIEW & RELEASE COMMANDS",
-		commands: []string{"review", "reviews", "submit", "validate", "publish"},
+		commands: []string{"review", "reviews", "submit", "validate", "publish", "release"},
 	},
 	{
 		title:    "MONETIZATION COMMANDS",
@@ -66,7 +66,7 @@ var rootUsageGroups = []rootCommandGroup{
 	},
 	{
 		title:    "UTILITY COMMANDS",
-		commands: []string{"version", "completion"},
+		commands: []string{"version", "completion", "schema", "plugins"},
 	},
 }
 
@@ -47,6 +47,14 @@ func Run(args []string, versionInfo string) int {
 		fmt.Fprint(os.Stderr, errfmt.FormatStderr(err))
 		return ExitUsage
 	}
+	if err := shared.ValidateCassetteFlags(); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.FormatStderr(err))
+		return ExitUsage
+	}
+	if err := shared.ValidateOutputSelectionFlags(); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.FormatStderr(err))
+		return ExitUsage
+	}
 
 	if versionRequested {
 		if err := root.Run(context.Background()); err != nil {
@@ -103,12 +111,12 @@ func Run(args []string, versionInfo string) int {
 	// Get command name (full subcommand path)
 	commandName := getCommandName(root, args)
 
-	// Write JUnit report if requested
-	if shared.ReportFormat() == shared.ReportFormatJUnit && shared.ReportFile() != "" {
-		reportErr := writeJUnitReport(commandName, runErr, elapsed)
+	// Write CI report if requested
+	if shared.ReportFormat() != "" {
+		reportErr := writeCIReport(shared.ReportFormat(), commandName, versionInfo, runErr, elapsed)
 		if reportErr != nil {
 			// Report write failure is a hard error - CI depends on it
-			fmt.Fprintf(os.Stderr, "Error: failed to write JUnit report: %v\n", reportErr)
+			fmt.Fprintf(os.Stderr, "Error: failed to write %s report: %v\n", reportDisplayName(shared.ReportFormat()), reportErr)
 			if runErr == nil {
 				return ExitError
 			}
@@ -256,6 +264,89 @@ func isBoolFlag(f *flag.Flag) bool {
 	return ok && v.IsBoolFlag()
 }
 
+// writeCIReport writes the report selected by --report.
+func writeCIReport(format, commandName, versionInfo string, runErr error, elapsed time.Duration) error {
+	switch format {
+	case shared.ReportFormatJUnit:
+		if shared.ReportFile() == "" {
+			return nil
+		}
+		return writeJUnitReport(commandName, runErr, elapsed)
+	case shared.ReportFormatSARIF:
+		return writeSARIFReport(commandName, versionInfo, runErr)
+	case shared.ReportFormatGitHub:
+		return writeGitHubAnnotations(commandName, runErr)
+	case shared.ReportFormatMarkdown:
+		return writeMarkdownSummary(commandName, runErr, elapsed)
+	default:
+		return fmt.Errorf("unsupported report format %q", format)
+	}
+}
+
+func reportDisplayName(format string) string {
+	switch format {
+	case shared.ReportFormatJUnit:
+		return "JUnit"
+	case shared.ReportFormatSARIF:
+		return "SARIF"
+	case shared.ReportFormatGitHub:
+		return "GitHub annotations"
+	case shared.ReportFormatMarkdown:
+		return "Markdown summary"
+	default:
+		return format
+	}
+}
+
+// reportFailure returns the run error message, ignoring help requests.
+func reportFailure(runErr error) string {
+	if runErr == nil || errors.Is(runErr, flag.ErrHelp) {
+		return ""
+	}
+	return runErr.Error()
+}
+
+// writeSARIFReport writes a SARIF log if --report sarif --report-file is configured.
+func writeSARIFReport(commandName, versionInfo string, runErr error) error {
+	report := shared.SARIFReport{
+		Command:     commandName,
+		ToolVersion: versionInfo,
+		Checks:      shared.ReportChecks(),
+		Failure:     reportFailure(runErr),
+	}
+	return report.Write(shared.ReportFile())
+}
+
+// writeGitHubAnnotations writes workflow commands to --report-file, or to
+// stderr so they never mix with structured stdout.
+func writeGitHubAnnotations(commandName string, runErr error) error {
+	report := shared.GitHubAnnotationsReport{
+		Command: commandName,
+		Checks:  shared.ReportCheckResults(),
+		Failure: reportFailure(runErr),
+	}
+	if path := shared.ReportFile(); path != "" {
+		return report.Write(path)
+	}
+	_, err := report.WriteTo(os.Stderr)
+	return err
+}
+
+// writeMarkdownSummary writes a Markdown summary to --report-file, or appends
+// it to $GITHUB_STEP_SUMMARY.
+func writeMarkdownSummary(commandName string, runErr error, elapsed time.Duration) error {
+	report := shared.MarkdownSummaryReport{
+		Command: commandName,
+		Time:    elapsed,
+		Checks:  shared.ReportCheckResults(),
+		Failure: reportFailure(runErr),
+	}
+	if path := shared.ReportFile(); path != "" {
+		return report.Write(path)
+	}
+	return report.Append(strings.TrimSpace(os.Getenv("GITHUB_STEP_SUMMARY")))
+}
+
 // writeJUnitReport writes a JUnit XML report if --report junit --report-file is configured.
 func writeJUnitReport(commandName string, runErr error, elapsed time.Duration) error {
 	reportFile := shared.ReportFile()
@@ -3,6 +3,7 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"flag"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/update"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/validation"
 )
 
 func TestRun_VersionFlag(t *testing.T) {
@@ -49,6 +51,22 @@ func TestRun_ReportFlagValidationError(t *testing.T) {
 	}
 }
 
+func TestRun_RecordAndReplayAreMutuallyExclusive(t *testing.T) {
+	t.Setenv("ASC_NO_UPDATE", "1")
+	resetReportFlags(t)
+
+	_, stderr := captureCommandOutput(t, func() {
+		code := Run([]string{"--record", t.TempDir(), "--replay", t.TempDir(), "completion", "--shell", "bash"}, "1.0.0")
+		if code != ExitUsage {
+			t.Fatalf("Run() exit code = %d, want %d", code, ExitUsage)
+		}
+	})
+
+	if !strings.Contains(stderr, "mutually exclusive") {
+		t.Fatalf("expected cassette validation error, got %q", stderr)
+	}
+}
+
 func TestRun_ReportWriteFailureReturnsExitError(t *testing.T) {
 	t.Setenv("ASC_NO_UPDATE", "1")
 	resetReportFlags(t)
@@ -326,6 +344,70 @@ func TestWriteJUnitReport(t *testing.T) {
 	}
 }
 
+func TestWriteCIReport_SARIFIncludesRecordedChecks(t *testing.T) {
+	resetReportFlags(t)
+	shared.ResetReportChecks()
+	t.Cleanup(shared.ResetReportChecks)
+
+	reportPath := filepath.Join(t.TempDir(), "report.sarif")
+	shared.SetReportFile(reportPath)
+	t.Cleanup(func() {
+		shared.SetReportFile("")
+	})
+
+	shared.RecordReportChecks([]validation.CheckResult{
+		{ID: "build.missing", Severity: validation.SeverityError, Message: "No build attached", Remediation: "Attach a build"},
+	})
+	runErr := shared.NewReportedError(errors.New("validate: found 1 blocking issue(s)"))
+	if err := writeCIReport(shared.ReportFormatSARIF, "asc validate", "1.2.3", runErr, time.Second); err != nil {
+		t.Fatalf("writeCIReport() error: %v", err)
+	}
+
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+	var log struct {
+		Runs []struct {
+			Results []struct {
+				RuleID string `json:"ruleId"`
+				Level  string `json:"level"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+	if len(log.Runs) != 1 || len(log.Runs[0].Results) != 1 {
+		t.Fatalf("unexpected SARIF results: %s", data)
+	}
+	if result := log.Runs[0].Results[0]; result.RuleID != "build.missing" || result.Level != "error" {
+		t.Fatalf("unexpected SARIF result: %+v", result)
+	}
+}
+
+func TestWriteCIReport_MarkdownAppendsToStepSummary(t *testing.T) {
+	resetReportFlags(t)
+	shared.ResetReportChecks()
+
+	summaryPath := filepath.Join(t.TempDir(), "summary.md")
+	t.Setenv("GITHUB_STEP_SUMMARY", summaryPath)
+
+	for i := 0; i < 2; i++ {
+		if err := writeCIReport(shared.ReportFormatMarkdown, "asc builds list", "1.2.3", nil, time.Second); err != nil {
+			t.Fatalf("writeCIReport() error: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(summaryPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+	if got := strings.Count(string(data), "### `asc builds list`"); got != 2 {
+		t.Fatalf("expected 2 appended summaries, got %d:\n%s", got, data)
+	}
+}
+
 func resetReportFlags(t *testing.T) {
 	t.Helper()
 	shared.SetReportFormat("")
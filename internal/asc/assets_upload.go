@@ -20,7 +20,8 @@ import (
 const maxAssetFileSize = int64(1024 * 1024 * 1024) // 1GB safety guardrail
 
 // UploadAsset uploads a file using the provided upload operations.
-func UploadAsset(ctx context.Context, filePath string, operations []UploadOperation) error {
+// Only the checkpoint options are honored.
+func UploadAsset(ctx context.Context, filePath string, operations []UploadOperation, opts ...UploadOption) error {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return err
@@ -32,15 +33,22 @@ func UploadAsset(ctx context.Context, filePath string, operations []UploadOperat
 		return err
 	}
 
-	return UploadAssetFromFile(ctx, file, info.Size(), operations)
+	return UploadAssetFromFile(ctx, file, info.Size(), operations, opts...)
 }
 
 // UploadAssetFromFile uploads a file using the provided upload operations.
-func UploadAssetFromFile(ctx context.Context, file *os.File, fileSize int64, operations []UploadOperation) error {
+// Only the checkpoint options are honored.
+func UploadAssetFromFile(ctx context.Context, file *os.File, fileSize int64, operations []UploadOperation, opts ...UploadOption) error {
 	if len(operations) == 0 {
 		return fmt.Errorf("no upload operations provided")
 	}
 
+	var uploadOpts UploadOptions
+	for _, opt := range opts {
+		opt(&uploadOpts)
+	}
+	checkpoint := openUploadCheckpointer(uploadOpts.CheckpointID, uploadOpts.ReservationID, file, fileSize)
+
 	client := &http.Client{Timeout: ResolveUploadTimeout()}
 
 	for i, op := range operations {
@@ -57,6 +65,9 @@ func UploadAssetFromFile(ctx context.Context, file *os.File, fileSize int64, ope
 		if op.Offset+op.Length > fileSize {
 			return fmt.Errorf("upload operation %d exceeds file size", i)
 		}
+		if checkpoint.completed(op) {
+			continue
+		}
 
 		reader := io.NewSectionReader(file, op.Offset, op.Length)
 		req, err := http.NewRequestWithContext(ctx, method, op.URL, reader)
@@ -78,8 +89,10 @@ func UploadAssetFromFile(ctx context.Context, file *os.File, fileSize int64, ope
 		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 			return fmt.Errorf("upload operation %d failed with status %d", i, resp.StatusCode)
 		}
+		checkpoint.record(op)
 	}
 
+	checkpoint.finish()
 	return nil
 }
 
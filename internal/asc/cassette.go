@@ -0,0 +1,373 @@
+package asc
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// CassetteMode selects whether HTTP interactions are recorded or replayed.
+type CassetteMode string
+
+const (
+	// CassetteModeOff sends requests to the network without recording.
+	CassetteModeOff CassetteMode = ""
+	// CassetteModeRecord sends requests to the network and stores redacted
+	// request/response pairs in the cassette directory.
+	CassetteModeRecord CassetteMode = "record"
+	// CassetteModeReplay serves recorded responses without network access.
+	CassetteModeReplay CassetteMode = "replay"
+)
+
+const cassetteBodyEncodingBase64 = "base64"
+
+var cassetteOverride struct {
+	mu   sync.RWMutex
+	mode CassetteMode
+	dir  string
+}
+
+var cassetteUnsafeChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// SetCassette configures record/replay for clients created afterwards.
+// Use CassetteModeOff to disable.
+func SetCassette(mode CassetteMode, dir string) {
+	cassetteOverride.mu.Lock()
+	defer cassetteOverride.mu.Unlock()
+	cassetteOverride.mode = mode
+	cassetteOverride.dir = strings.TrimSpace(dir)
+}
+
+func resolveCassette() (CassetteMode, string) {
+	cassetteOverride.mu.RLock()
+	defer cassetteOverride.mu.RUnlock()
+	return cassetteOverride.mode, cassetteOverride.dir
+}
+
+// cassetteInteraction is one recorded request/response pair.
+type cassetteInteraction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+}
+
+type cassetteRequest struct {
+	Method       string      `json:"method"`
+	URL          string      `json:"url"`
+	Headers      http.Header `json:"headers,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"bodyEncoding,omitempty"`
+}
+
+type cassetteResponse struct {
+	Status       int         `json:"status"`
+	Headers      http.Header `json:"headers,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"bodyEncoding,omitempty"`
+}
+
+// wrapCassetteTransport returns httpClient with its transport wrapped for the
+// configured cassette mode. The original client is never mutated.
+func wrapCassetteTransport(httpClient *http.Client) (*http.Client, error) {
+	mode, dir := resolveCassette()
+	if mode == CassetteModeOff {
+		return httpClient, nil
+	}
+
+	var transport http.RoundTripper
+	var err error
+	switch mode {
+	case CassetteModeRecord:
+		transport, err = NewRecordingTransport(dir, httpClient.Transport)
+	case CassetteModeReplay:
+		transport, err = NewReplayTransport(dir)
+	default:
+		return nil, fmt.Errorf("unknown cassette mode %q", mode)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped := *httpClient
+	wrapped.Transport = transport
+	return &wrapped, nil
+}
+
+// NewReplayClient creates a client that answers every request from the
+// cassette in dir. Requests are signed with a throwaway key, so replaying
+// needs no App Store Connect credentials.
+func NewReplayClient(dir string) (*Client, error) {
+	transport, err := NewReplayTransport(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure cassette: %w", err)
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate replay signing key: %w", err)
+	}
+	return &Client{
+		httpClient: &http.Client{Timeout: ResolveTimeout(), Transport: transport},
+		keyID:      "REPLAY",
+		issuerID:   "REPLAY",
+		privateKey: key,
+	}, nil
+}
+
+// recordingTransport stores every interaction that passes through it.
+type recordingTransport struct {
+	dir  string
+	next http.RoundTripper
+
+	mu  sync.Mutex
+	seq int
+}
+
+// NewRecordingTransport returns a transport that forwards to next (or
+// http.DefaultTransport) and writes redacted interactions to dir.
+// Recording appends to any interactions already in dir.
+func NewRecordingTransport(dir string, next http.RoundTripper) (http.RoundTripper, error) {
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		return nil, fmt.Errorf("cassette directory is required")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create cassette directory: %w", err)
+	}
+	existing, err := cassetteFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingTransport{dir: dir, next: next, seq: len(existing)}, nil
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		data, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("cassette: read request body: %w", err)
+		}
+		reqBody = data
+		req.Body = io.NopCloser(bytes.NewReader(data))
+	}
+
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("cassette: read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := cassetteInteraction{
+		Request: cassetteRequest{
+			Method:  req.Method,
+			URL:     sanitizeURLForLog(req.URL.String()),
+			Headers: redactCassetteHeaders(req.Header),
+		},
+		Response: cassetteResponse{
+			Status:  resp.StatusCode,
+			Headers: redactCassetteHeaders(resp.Header),
+		},
+	}
+	interaction.Request.Body, interaction.Request.BodyEncoding = encodeCassetteBody(reqBody)
+	interaction.Response.Body, interaction.Response.BodyEncoding = encodeCassetteBody(respBody)
+
+	if err := t.write(interaction); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (t *recordingTransport) write(interaction cassetteInteraction) error {
+	data, err := json.MarshalIndent(interaction, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cassette: encode interaction: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.seq++
+	name := fmt.Sprintf("%04d-%s-%s.json", t.seq, strings.ToUpper(interaction.Request.Method), cassetteSlug(interaction.Request.URL))
+	if err := os.WriteFile(filepath.Join(t.dir, name), append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("cassette: write interaction: %w", err)
+	}
+	return nil
+}
+
+// replayTransport serves recorded responses in the order they were captured.
+type replayTransport struct {
+	mu      sync.Mutex
+	pending map[string][]cassetteResponse
+}
+
+// NewReplayTransport returns a transport that answers requests from the
+// interactions recorded in dir. Requests are matched on method and redacted
+// URL; repeated requests receive recorded responses in order.
+func NewReplayTransport(dir string) (http.RoundTripper, error) {
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		return nil, fmt.Errorf("cassette directory is required")
+	}
+	files, err := cassetteFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("cassette directory %q has no recorded interactions", dir)
+	}
+
+	transport := &replayTransport{pending: make(map[string][]cassetteResponse)}
+	for _, name := range files {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("cassette: read %s: %w", name, err)
+		}
+		var interaction cassetteInteraction
+		if err := json.Unmarshal(data, &interaction); err != nil {
+			return nil, fmt.Errorf("cassette: parse %s: %w", name, err)
+		}
+		key := cassetteKey(interaction.Request.Method, interaction.Request.URL)
+		transport.pending[key] = append(transport.pending[key], interaction.Response)
+	}
+	return transport, nil
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	sanitizedURL := sanitizeURLForLog(req.URL.String())
+	key := cassetteKey(req.Method, sanitizedURL)
+
+	t.mu.Lock()
+	queue := t.pending[key]
+	if len(queue) == 0 {
+		t.mu.Unlock()
+		return nil, fmt.Errorf("cassette: no recorded response for %s %s", req.Method, sanitizedURL)
+	}
+	recorded := queue[0]
+	t.pending[key] = queue[1:]
+	t.mu.Unlock()
+
+	body, err := decodeCassetteBody(recorded.Body, recorded.BodyEncoding)
+	if err != nil {
+		return nil, fmt.Errorf("cassette: decode response body: %w", err)
+	}
+	headers := recorded.Headers.Clone()
+	if headers == nil {
+		headers = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        headers,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func cassetteFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read cassette directory: %w", err)
+	}
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		files = append(files, entry.Name())
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func cassetteKey(method, sanitizedURL string) string {
+	return strings.ToUpper(method) + " " + sanitizedURL
+}
+
+func cassetteSlug(rawURL string) string {
+	path := rawURL
+	if idx := strings.Index(path, "://"); idx >= 0 {
+		path = path[idx+3:]
+		if slash := strings.Index(path, "/"); slash >= 0 {
+			path = path[slash+1:]
+		}
+	}
+	if idx := strings.IndexAny(path, "?#"); idx >= 0 {
+		path = path[:idx]
+	}
+	slug := strings.Trim(cassetteUnsafeChars.ReplaceAllString(path, "_"), "_")
+	if len(slug) > 80 {
+		slug = slug[:80]
+	}
+	if slug == "" {
+		slug = "root"
+	}
+	return slug
+}
+
+// redactCassetteHeaders applies the same redaction as HTTP debug logging and
+// drops cookies, which are never needed for replay.
+func redactCassetteHeaders(headers http.Header) http.Header {
+	if len(headers) == 0 {
+		return nil
+	}
+	redacted := headers.Clone()
+	redacted.Del("Cookie")
+	redacted.Del("Set-Cookie")
+	if value := redacted.Get("Authorization"); value != "" {
+		redacted.Set("Authorization", sanitizeAuthHeader(value))
+	}
+	if value := redacted.Get("Location"); value != "" {
+		redacted.Set("Location", sanitizeURLForLog(value))
+	}
+	return redacted
+}
+
+func encodeCassetteBody(body []byte) (string, string) {
+	if len(body) == 0 {
+		return "", ""
+	}
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return base64.StdEncoding.EncodeToString(body), cassetteBodyEncodingBase64
+}
+
+func decodeCassetteBody(body, encoding string) ([]byte, error) {
+	switch encoding {
+	case "":
+		return []byte(body), nil
+	case cassetteBodyEncodingBase64:
+		return base64.StdEncoding.DecodeString(body)
+	default:
+		return nil, fmt.Errorf("unknown body encoding %q", encoding)
+	}
+}
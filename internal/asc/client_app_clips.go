@@ -1464,9 +1464,16 @@ func (c *Client) UploadAppClipAdvancedExperienceImage(ctx context.Context, fileP
 	fileName := info.Name()
 	fileSize := info.Size()
 
-	reservation, err := c.CreateAppClipAdvancedExperienceImage(ctx, fileName, fileSize)
+	checkpointID := AssetUploadCheckpointID("appClipAdvancedExperienceImages", fileName, fileSize)
+	reservation, err := c.resumableAppClipAdvancedExperienceImage(ctx, checkpointID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to reserve image upload: %w", err)
+		return nil, fmt.Errorf("failed to resume image upload: %w", err)
+	}
+	if reservation == nil {
+		reservation, err = c.CreateAppClipAdvancedExperienceImage(ctx, fileName, fileSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reserve image upload: %w", err)
+		}
 	}
 
 	imageID := reservation.Data.ID
@@ -1475,7 +1482,7 @@ func (c *Client) UploadAppClipAdvancedExperienceImage(ctx context.Context, fileP
 		return nil, fmt.Errorf("no upload operations returned from API")
 	}
 
-	if err := UploadAsset(ctx, filePath, operations); err != nil {
+	if err := UploadAsset(ctx, filePath, operations, WithAssetUploadCheckpoint(checkpointID, imageID)); err != nil {
 		return nil, fmt.Errorf("failed to upload image: %w", err)
 	}
 
@@ -1483,6 +1490,7 @@ func (c *Client) UploadAppClipAdvancedExperienceImage(ctx context.Context, fileP
 	if err != nil {
 		return nil, fmt.Errorf("failed to commit image upload: %w", err)
 	}
+	_ = RemoveUploadCheckpoint(checkpointID)
 
 	state := ""
 	if committed.Data.Attributes.AssetDeliveryState != nil {
@@ -1619,9 +1627,16 @@ func (c *Client) UploadAppClipHeaderImage(ctx context.Context, localizationID st
 	fileName := info.Name()
 	fileSize := info.Size()
 
-	reservation, err := c.CreateAppClipHeaderImage(ctx, localizationID, fileName, fileSize)
+	checkpointID := AssetUploadCheckpointID("appClipHeaderImages/"+localizationID, fileName, fileSize)
+	reservation, err := c.resumableAppClipHeaderImage(ctx, checkpointID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to reserve image upload: %w", err)
+		return nil, fmt.Errorf("failed to resume image upload: %w", err)
+	}
+	if reservation == nil {
+		reservation, err = c.CreateAppClipHeaderImage(ctx, localizationID, fileName, fileSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reserve image upload: %w", err)
+		}
 	}
 
 	imageID := reservation.Data.ID
@@ -1630,7 +1645,7 @@ func (c *Client) UploadAppClipHeaderImage(ctx context.Context, localizationID st
 		return nil, fmt.Errorf("no upload operations returned from API")
 	}
 
-	if err := UploadAsset(ctx, filePath, operations); err != nil {
+	if err := UploadAsset(ctx, filePath, operations, WithAssetUploadCheckpoint(checkpointID, imageID)); err != nil {
 		return nil, fmt.Errorf("failed to upload image: %w", err)
 	}
 
@@ -1638,6 +1653,7 @@ func (c *Client) UploadAppClipHeaderImage(ctx context.Context, localizationID st
 	if err != nil {
 		return nil, fmt.Errorf("failed to commit image upload: %w", err)
 	}
+	_ = RemoveUploadCheckpoint(checkpointID)
 
 	state := ""
 	if committed.Data.Attributes.AssetDeliveryState != nil {
@@ -1997,3 +2013,43 @@ func (c *Client) DeleteBetaAppClipInvocationLocalization(ctx context.Context, lo
 	_, err := c.do(ctx, http.MethodDelete, path, nil)
 	return err
 }
+
+// resumableAppClipAdvancedExperienceImage returns the reservation recorded
+// under checkpointID when it still awaits its upload, or nil.
+func (c *Client) resumableAppClipAdvancedExperienceImage(ctx context.Context, checkpointID string) (*AppClipAdvancedExperienceImageResponse, error) {
+	reservationID := AssetUploadReservation(checkpointID)
+	if reservationID == "" {
+		return nil, nil
+	}
+	resp, err := c.GetAppClipAdvancedExperienceImage(ctx, reservationID)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !AssetReservationResumable(assetDeliveryStateValue(resp.Data.Attributes.AssetDeliveryState), resp.Data.Attributes.UploadOperations) {
+		return nil, nil
+	}
+	return resp, nil
+}
+
+// resumableAppClipHeaderImage returns the reservation recorded under
+// checkpointID when it still awaits its upload, or nil.
+func (c *Client) resumableAppClipHeaderImage(ctx context.Context, checkpointID string) (*AppClipHeaderImageResponse, error) {
+	reservationID := AssetUploadReservation(checkpointID)
+	if reservationID == "" {
+		return nil, nil
+	}
+	resp, err := c.GetAppClipHeaderImage(ctx, reservationID)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !AssetReservationResumable(assetDeliveryStateValue(resp.Data.Attributes.AssetDeliveryState), resp.Data.Attributes.UploadOperations) {
+		return nil, nil
+	}
+	return resp, nil
+}
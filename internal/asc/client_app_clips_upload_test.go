@@ -35,6 +35,7 @@ func newUploadTestClient(t *testing.T, handler func(*http.Request) (*http.Respon
 }
 
 func TestUploadAppClipHeaderImage(t *testing.T) {
+	t.Setenv(UploadStateDirEnvVar, t.TempDir())
 	dir := t.TempDir()
 	filePath := filepath.Join(dir, "header.png")
 	if err := os.WriteFile(filePath, []byte("header-image"), 0o600); err != nil {
@@ -90,6 +91,7 @@ func TestUploadAppClipHeaderImage(t *testing.T) {
 }
 
 func TestUploadAppClipAdvancedExperienceImage(t *testing.T) {
+	t.Setenv(UploadStateDirEnvVar, t.TempDir())
 	dir := t.TempDir()
 	filePath := filepath.Join(dir, "advanced.png")
 	if err := os.WriteFile(filePath, []byte("advanced-image"), 0o600); err != nil {
@@ -140,3 +142,64 @@ func TestUploadAppClipAdvancedExperienceImage(t *testing.T) {
 		t.Fatalf("expected state COMPLETE, got %s", result.AssetDeliveryState)
 	}
 }
+
+func TestUploadAppClipHeaderImageResumesReservation(t *testing.T) {
+	t.Setenv(UploadStateDirEnvVar, t.TempDir())
+	filePath := filepath.Join(t.TempDir(), "header.png")
+	if err := os.WriteFile(filePath, []byte("header-image"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		t.Fatalf("stat file: %v", err)
+	}
+
+	uploads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uploads++
+		_, _ = io.Copy(io.Discard, r.Body)
+		_ = r.Body.Close()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	checkpointID := AssetUploadCheckpointID("appClipHeaderImages/loc-1", fileInfo.Name(), fileInfo.Size())
+	file, err := os.Open(filePath)
+	if err != nil {
+		t.Fatalf("open file: %v", err)
+	}
+	defer file.Close()
+	// An earlier run reserved img-1 and sent the only part before failing to commit.
+	operation := UploadOperation{Method: "PUT", URL: server.URL, Offset: 0, Length: fileInfo.Size()}
+	openUploadCheckpointer(checkpointID, "img-1", file, fileInfo.Size()).record(operation)
+
+	committed := false
+	client := newUploadTestClient(t, func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.URL.Path == "/v1/appClipHeaderImages":
+			t.Fatalf("expected the saved reservation to be reused, got %s %s", req.Method, req.URL.Path)
+		case req.URL.Path == "/v1/appClipHeaderImages/img-1" && req.Method == http.MethodGet:
+			body := fmt.Sprintf(`{"data":{"type":"appClipHeaderImages","id":"img-1","attributes":{"assetDeliveryState":{"state":"AWAITING_UPLOAD"},"uploadOperations":[{"method":"PUT","url":"%s","length":%d,"offset":0}]}}}`, server.URL, fileInfo.Size())
+			return jsonResponse(http.StatusOK, body), nil
+		case req.URL.Path == "/v1/appClipHeaderImages/img-1" && req.Method == http.MethodPatch:
+			committed = true
+			body := `{"data":{"type":"appClipHeaderImages","id":"img-1","attributes":{"assetDeliveryState":{"state":"UPLOAD_COMPLETE"}}}}`
+			return jsonResponse(http.StatusOK, body), nil
+		}
+		return jsonResponse(http.StatusNotFound, `{"errors":[{"title":"not found"}]}`), nil
+	})
+
+	result, err := client.UploadAppClipHeaderImage(context.Background(), "loc-1", filePath)
+	if err != nil {
+		t.Fatalf("UploadAppClipHeaderImage() error: %v", err)
+	}
+	if result.ID != "img-1" || !committed {
+		t.Fatalf("expected img-1 to be committed, got %+v (committed=%t)", result, committed)
+	}
+	if uploads != 0 {
+		t.Fatalf("expected checkpointed part to be skipped, got %d uploads", uploads)
+	}
+	if checkpoint, _ := LoadUploadCheckpoint(checkpointID); checkpoint != nil {
+		t.Fatalf("expected checkpoint to be removed after commit, got %+v", checkpoint)
+	}
+}
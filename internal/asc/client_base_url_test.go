@@ -0,0 +1,88 @@
+package asc
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/config"
+)
+
+func unsetBaseURLEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("ASC_BASE_URL", "")
+	_ = os.Unsetenv("ASC_BASE_URL")
+}
+
+func TestResolveBaseURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		env       *string
+		configURL string
+		want      string
+	}{
+		{name: "default", want: BaseURL},
+		{name: "env override", env: new("http://127.0.0.1:8080/"), want: "http://127.0.0.1:8080"},
+		{name: "env overrides config", env: new("https://env.example.com"), configURL: "https://config.example.com", want: "https://env.example.com"},
+		{name: "empty env ignores config", env: new(""), configURL: "https://config.example.com", want: BaseURL},
+		{name: "invalid env falls back", env: new("ftp://example.com"), want: BaseURL},
+		{name: "config override", configURL: "https://config.example.com/", want: "https://config.example.com"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			unsetBaseURLEnv(t)
+			if test.env != nil {
+				t.Setenv("ASC_BASE_URL", *test.env)
+			}
+			setConfigLoaderForTest(func() (*config.Config, error) {
+				return &config.Config{BaseURL: test.configURL}, nil
+			})
+			t.Cleanup(resetConfigCacheForTest)
+
+			if got := ResolveBaseURL(); got != test.want {
+				t.Fatalf("ResolveBaseURL() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestNewRequestUsesBaseURLOverride(t *testing.T) {
+	unsetBaseURLEnv(t)
+	t.Setenv("ASC_BASE_URL", "http://127.0.0.1:9999")
+
+	var gotURL string
+	client := newTestClient(t, func(req *http.Request) {
+		gotURL = req.URL.String()
+	}, jsonResponse(http.StatusOK, `{"data":[]}`))
+
+	if _, err := client.GetApps(context.Background()); err != nil {
+		t.Fatalf("GetApps() error: %v", err)
+	}
+	if gotURL != "http://127.0.0.1:9999/v1/apps" {
+		t.Fatalf("unexpected request URL %q", gotURL)
+	}
+}
+
+func TestValidateNextURLFollowsBaseURLOverride(t *testing.T) {
+	unsetBaseURLEnv(t)
+	t.Setenv("ASC_BASE_URL", "http://127.0.0.1:9999")
+
+	if err := validateNextURL("http://127.0.0.1:9999/v1/apps?cursor=abc"); err != nil {
+		t.Fatalf("expected override host to be accepted, got %v", err)
+	}
+	if err := validateNextURL("https://api.appstoreconnect.apple.com/v1/apps?cursor=abc"); err == nil {
+		t.Fatal("expected default host to be rejected while overridden")
+	}
+}
+
+func TestValidateNextURLRejectsPlainHTTPForHTTPSBase(t *testing.T) {
+	unsetBaseURLEnv(t)
+	setConfigLoaderForTest(func() (*config.Config, error) { return &config.Config{}, nil })
+	t.Cleanup(resetConfigCacheForTest)
+
+	if err := validateNextURL("http://api.appstoreconnect.apple.com/v1/apps?cursor=abc"); err == nil {
+		t.Fatal("expected insecure scheme to be rejected")
+	}
+}
@@ -28,6 +28,8 @@ const (
 	// tokenLifetime is the JWT token lifetime for App Store Connect API authentication.
 	// 10 minutes is a good balance between security (shorter-lived tokens) and usability.
 	tokenLifetime = 10 * time.Minute
+	// TokenLifetime is how long tokens from GenerateJWT stay valid.
+	TokenLifetime = tokenLifetime
 
 	// Retry defaults
 	DefaultMaxRetries = 3
@@ -403,6 +405,25 @@ func logRetry(delay time.Duration, attempt, maxRetries int, err error) {
 	retryLogger.Info("retrying request", "delay", delay.String(), "attempt", attempt, "maxRetries", maxRetries, "error", err)
 }
 
+// ResolveBaseURL returns the API base URL without a trailing slash.
+// Precedence: ASC_BASE_URL env > config base_url > BaseURL. Invalid overrides
+// are ignored so a bad value never redirects traffic to an unexpected host.
+func ResolveBaseURL() string {
+	if override, ok := envValue("ASC_BASE_URL"); ok {
+		if override != "" && config.ValidateBaseURL(override) == nil {
+			return strings.TrimRight(override, "/")
+		}
+		return BaseURL
+	}
+	cfg := loadConfig()
+	if cfg != nil {
+		if override := strings.TrimSpace(cfg.BaseURL); override != "" && config.ValidateBaseURL(override) == nil {
+			return strings.TrimRight(override, "/")
+		}
+	}
+	return BaseURL
+}
+
 // ResolveTimeout returns the request timeout, optionally overridden by config/env.
 func ResolveTimeout() time.Duration {
 	return ResolveTimeoutWithDefault(DefaultTimeout)
@@ -494,6 +515,11 @@ func newClientWithHTTPClient(keyID, issuerID, privateKeyPath string, httpClient
 		return nil, fmt.Errorf("failed to load private key: %w", err)
 	}
 
+	httpClient, err = wrapCassetteTransport(httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure cassette: %w", err)
+	}
+
 	return &Client{
 		httpClient: httpClient,
 		keyID:      keyID,
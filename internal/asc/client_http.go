@@ -27,7 +27,7 @@ func (c *Client) newRequest(ctx context.Context, method, path string, body io.Re
 
 	url := path
 	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
-		url = BaseURL + path
+		url = ResolveBaseURL() + path
 	}
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
@@ -258,7 +258,8 @@ func parseRetryAfterHeader(value string) time.Duration {
 }
 
 // validateNextURL validates that a pagination URL is safe to use.
-// It ensures the URL is on the same host as BaseURL and uses HTTPS.
+// It ensures the URL is on the same host as the resolved base URL and uses
+// HTTPS (or plain HTTP when the base URL itself is an HTTP override).
 func validateNextURL(nextURL string) error {
 	if nextURL == "" {
 		return nil
@@ -275,18 +276,19 @@ func validateNextURL(nextURL string) error {
 		return fmt.Errorf("invalid pagination URL: %w", err)
 	}
 
-	baseURL, err := url.Parse(BaseURL)
+	baseURL, err := url.Parse(ResolveBaseURL())
 	if err != nil {
 		return fmt.Errorf("invalid base URL: %w", err)
 	}
 
-	// Allow URLs on the same host as BaseURL
+	// Allow URLs on the same host as the base URL
 	if parsedURL.Host != baseURL.Host {
 		return fmt.Errorf("rejected pagination URL from untrusted host %q (expected %q)", parsedURL.Host, baseURL.Host)
 	}
 
-	// Require HTTPS for authentication endpoints
-	if parsedURL.Scheme != "https" {
+	// Require HTTPS for authentication endpoints unless the base URL was
+	// explicitly overridden to plain HTTP (e.g. a local mock server).
+	if parsedURL.Scheme != "https" && parsedURL.Scheme != baseURL.Scheme {
 		return fmt.Errorf("rejected pagination URL with insecure scheme %q (expected https)", parsedURL.Scheme)
 	}
 
@@ -3117,6 +3117,9 @@ func TestIsNotFoundAndUnauthorized(t *testing.T) {
 	if !IsNotFound(&APIError{Code: "NOT_FOUND", Title: "The specified resource does not exist"}) {
 		t.Fatal("expected IsNotFound to return true")
 	}
+	if !IsNotFound(fmt.Errorf("fetch: %w", &APIError{StatusCode: http.StatusNotFound})) {
+		t.Fatal("expected a 404 without an error code to be not found")
+	}
 	if IsNotFound(fmt.Errorf("something else")) {
 		t.Fatal("expected IsNotFound to return false")
 	}
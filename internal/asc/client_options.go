@@ -275,6 +275,9 @@ type LinkagesOption func(*linkagesQuery)
 // PricePointsOption is a functional option for GetAppPricePoints.
 type PricePointsOption func(*pricePointsQuery)
 
+// AppPricesOption is a functional option for app price schedule price endpoints.
+type AppPricesOption func(*appPricesQuery)
+
 // AccessibilityDeclarationsOption is a functional option for accessibility declarations.
 type AccessibilityDeclarationsOption func(*accessibilityDeclarationsQuery)
 
@@ -400,6 +403,13 @@ func WithFeedbackIncludeScreenshots() FeedbackOption {
 	}
 }
 
+// WithFeedbackIncludeBuild includes each submission's build.
+func WithFeedbackIncludeBuild() FeedbackOption {
+	return func(q *feedbackQuery) {
+		q.includeBuild = true
+	}
+}
+
 // WithCrashDeviceModels filters crashes by device model(s).
 func WithCrashDeviceModels(models []string) CrashOption {
 	return func(q *crashQuery) {
@@ -1001,6 +1011,13 @@ func WithCrashNextURL(next string) CrashOption {
 	}
 }
 
+// WithCrashIncludeBuild includes each crash submission's build.
+func WithCrashIncludeBuild() CrashOption {
+	return func(q *crashQuery) {
+		q.includeBuild = true
+	}
+}
+
 // WithCrashSort sets the sort order for crashes.
 func WithCrashSort(sort string) CrashOption {
 	return func(q *crashQuery) {
@@ -3146,6 +3163,31 @@ func WithPricePointsNextURL(next string) PricePointsOption {
 	}
 }
 
+// WithAppPricesInclude sets include for app price responses (e.g., appPricePoint, territory).
+func WithAppPricesInclude(include []string) AppPricesOption {
+	return func(q *appPricesQuery) {
+		q.include = normalizeList(include)
+	}
+}
+
+// WithAppPricesLimit sets the max number of app prices to return.
+func WithAppPricesLimit(limit int) AppPricesOption {
+	return func(q *appPricesQuery) {
+		if limit > 0 {
+			q.limit = limit
+		}
+	}
+}
+
+// WithAppPricesNextURL uses a next page URL directly.
+func WithAppPricesNextURL(next string) AppPricesOption {
+	return func(q *appPricesQuery) {
+		if strings.TrimSpace(next) != "" {
+			q.nextURL = strings.TrimSpace(next)
+		}
+	}
+}
+
 // WithPricePointsTerritory filters app price points by territory.
 func WithPricePointsTerritory(territory string) PricePointsOption {
 	return func(q *pricePointsQuery) {
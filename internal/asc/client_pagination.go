@@ -76,6 +76,49 @@ func PaginateAll(ctx context.Context, firstPage PaginatedResponse, fetchNext Pag
 	return result, nil
 }
 
+// StreamAll walks every page like PaginateAll but hands each page to emit as
+// it arrives instead of aggregating, so memory stays flat for long lists. It
+// returns an empty response of the same type as firstPage.
+func StreamAll(ctx context.Context, firstPage PaginatedResponse, fetchNext PaginateFunc, emit func(PaginatedResponse) error) (PaginatedResponse, error) {
+	if firstPage == nil {
+		return nil, nil
+	}
+	result, err := newEmptyPaginatedResponse(firstPage)
+	if err != nil {
+		return nil, err
+	}
+	if reflect.ValueOf(firstPage).IsNil() {
+		return result, nil
+	}
+
+	page := 1
+	seenNext := make(map[string]struct{})
+	for {
+		if err := emit(firstPage); err != nil {
+			return result, fmt.Errorf("page %d: %w", page, err)
+		}
+
+		links := firstPage.GetLinks()
+		if links == nil || links.Next == "" {
+			return result, nil
+		}
+		if _, ok := seenNext[links.Next]; ok {
+			return result, fmt.Errorf("page %d: %w", page+1, ErrRepeatedPaginationURL)
+		}
+		seenNext[links.Next] = struct{}{}
+		page++
+
+		nextPage, err := fetchNext(ctx, links.Next)
+		if err != nil {
+			return result, fmt.Errorf("page %d: %w", page, err)
+		}
+		if reflect.TypeOf(nextPage) != reflect.TypeOf(firstPage) {
+			return result, fmt.Errorf("page %d: unexpected response type (expected %T, got %T)", page, firstPage, nextPage)
+		}
+		firstPage = nextPage
+	}
+}
+
 // newEmptyPaginatedResponse creates a new zero-valued instance of the same
 // concrete type as src. The returned value is a pointer to a new struct that
 // satisfies PaginatedResponse.
@@ -10,6 +10,9 @@ import (
 
 const appPriceScheduleManualPriceID = "${local-manual-price-1}"
 
+// appPriceScheduleManualPriceIDFormat names the inline prices after the first.
+const appPriceScheduleManualPriceIDFormat = "${local-manual-price-%d}"
+
 // GetTerritories retrieves available territories.
 func (c *Client) GetTerritories(ctx context.Context, opts ...TerritoriesOption) (*TerritoriesResponse, error) {
 	query := &territoriesQuery{}
@@ -172,6 +175,18 @@ func (c *Client) CreateAppPriceSchedule(ctx context.Context, appID string, attrs
 		return nil, fmt.Errorf("base territory ID is required")
 	}
 
+	manualPrices := []ResourceData{{Type: ResourceTypeAppPrices, ID: appPriceScheduleManualPriceID}}
+	included := []AppPriceCreateResource{appPriceCreateResource(appPriceScheduleManualPriceID, pricePointID, startDate)}
+	for i, price := range attrs.AdditionalPrices {
+		additionalPricePointID := strings.TrimSpace(price.PricePointID)
+		if additionalPricePointID == "" {
+			return nil, fmt.Errorf("manual price point ID is required")
+		}
+		id := fmt.Sprintf(appPriceScheduleManualPriceIDFormat, i+2)
+		manualPrices = append(manualPrices, ResourceData{Type: ResourceTypeAppPrices, ID: id})
+		included = append(included, appPriceCreateResource(id, additionalPricePointID, strings.TrimSpace(price.StartDate)))
+	}
+
 	payload := AppPriceScheduleCreateRequest{
 		Data: AppPriceScheduleCreateData{
 			Type: ResourceTypeAppPriceSchedules,
@@ -188,31 +203,10 @@ func (c *Client) CreateAppPriceSchedule(ctx context.Context, appID string, attrs
 						ID:   baseTerritoryID,
 					},
 				},
-				ManualPrices: RelationshipList{
-					Data: []ResourceData{
-						{
-							Type: ResourceTypeAppPrices,
-							ID:   appPriceScheduleManualPriceID,
-						},
-					},
-				},
-			},
-		},
-		Included: []AppPriceCreateResource{
-			{
-				Type:       ResourceTypeAppPrices,
-				ID:         appPriceScheduleManualPriceID,
-				Attributes: AppPriceAttributes{StartDate: startDate},
-				Relationships: AppPriceRelationships{
-					AppPricePoint: Relationship{
-						Data: ResourceData{
-							Type: ResourceTypeAppPricePoints,
-							ID:   pricePointID,
-						},
-					},
-				},
+				ManualPrices: RelationshipList{Data: manualPrices},
 			},
 		},
+		Included: included,
 	}
 
 	body, err := BuildRequestBody(payload)
@@ -233,6 +227,22 @@ func (c *Client) CreateAppPriceSchedule(ctx context.Context, appID string, attrs
 	return &response, nil
 }
 
+func appPriceCreateResource(id, pricePointID, startDate string) AppPriceCreateResource {
+	return AppPriceCreateResource{
+		Type:       ResourceTypeAppPrices,
+		ID:         id,
+		Attributes: AppPriceAttributes{StartDate: startDate},
+		Relationships: AppPriceRelationships{
+			AppPricePoint: Relationship{
+				Data: ResourceData{
+					Type: ResourceTypeAppPricePoints,
+					ID:   pricePointID,
+				},
+			},
+		},
+	}
+}
+
 // GetAppPriceScheduleBaseTerritory retrieves the base territory for a schedule.
 func (c *Client) GetAppPriceScheduleBaseTerritory(ctx context.Context, scheduleID string) (*TerritoryResponse, error) {
 	scheduleID = strings.TrimSpace(scheduleID)
@@ -252,9 +262,23 @@ func (c *Client) GetAppPriceScheduleBaseTerritory(ctx context.Context, scheduleI
 }
 
 // GetAppPriceScheduleManualPrices retrieves manual prices for a schedule.
-func (c *Client) GetAppPriceScheduleManualPrices(ctx context.Context, scheduleID string) (*AppPricesResponse, error) {
+func (c *Client) GetAppPriceScheduleManualPrices(ctx context.Context, scheduleID string, opts ...AppPricesOption) (*AppPricesResponse, error) {
+	query := &appPricesQuery{}
+	for _, opt := range opts {
+		opt(query)
+	}
+
 	scheduleID = strings.TrimSpace(scheduleID)
 	path := fmt.Sprintf("/v1/appPriceSchedules/%s/manualPrices", scheduleID)
+	if query.nextURL != "" {
+		// Validate nextURL to prevent credential exfiltration
+		if err := validateNextURL(query.nextURL); err != nil {
+			return nil, fmt.Errorf("manualPrices: %w", err)
+		}
+		path = query.nextURL
+	} else if queryString := buildAppPricesQuery(query); queryString != "" {
+		path += "?" + queryString
+	}
 
 	data, err := c.do(ctx, "GET", path, nil)
 	if err != nil {
@@ -270,9 +294,23 @@ func (c *Client) GetAppPriceScheduleManualPrices(ctx context.Context, scheduleID
 }
 
 // GetAppPriceScheduleAutomaticPrices retrieves automatic prices for a schedule.
-func (c *Client) GetAppPriceScheduleAutomaticPrices(ctx context.Context, scheduleID string) (*AppPricesResponse, error) {
+func (c *Client) GetAppPriceScheduleAutomaticPrices(ctx context.Context, scheduleID string, opts ...AppPricesOption) (*AppPricesResponse, error) {
+	query := &appPricesQuery{}
+	for _, opt := range opts {
+		opt(query)
+	}
+
 	scheduleID = strings.TrimSpace(scheduleID)
 	path := fmt.Sprintf("/v1/appPriceSchedules/%s/automaticPrices", scheduleID)
+	if query.nextURL != "" {
+		// Validate nextURL to prevent credential exfiltration
+		if err := validateNextURL(query.nextURL); err != nil {
+			return nil, fmt.Errorf("automaticPrices: %w", err)
+		}
+		path = query.nextURL
+	} else if queryString := buildAppPricesQuery(query); queryString != "" {
+		path += "?" + queryString
+	}
 
 	data, err := c.do(ctx, "GET", path, nil)
 	if err != nil {
@@ -23,6 +23,7 @@ type feedbackQuery struct {
 	testerIDs                 []string
 	sort                      string
 	includeScreenshots        bool
+	includeBuild              bool
 }
 
 type crashQuery struct {
@@ -35,6 +36,7 @@ type crashQuery struct {
 	buildPreReleaseVersionIDs []string
 	testerIDs                 []string
 	sort                      string
+	includeBuild              bool
 }
 
 type reviewQuery struct {
@@ -545,6 +547,11 @@ type pricePointsQuery struct {
 	territory string
 }
 
+type appPricesQuery struct {
+	listQuery
+	include []string
+}
+
 type accessibilityDeclarationsQuery struct {
 	listQuery
 	deviceFamilies []string
@@ -726,7 +733,7 @@ func buildNominationsDetailQuery(query *nominationsQuery) string {
 func buildFeedbackQuery(query *feedbackQuery) string {
 	values := url.Values{}
 	if query.includeScreenshots {
-		values.Set("fields[betaFeedbackScreenshotSubmissions]", strings.Join([]string{
+		fields := []string{
 			"createdDate",
 			"comment",
 			"email",
@@ -735,8 +742,13 @@ func buildFeedbackQuery(query *feedbackQuery) string {
 			"appPlatform",
 			"devicePlatform",
 			"screenshots",
-		}, ","))
+		}
+		if query.includeBuild {
+			fields = append(fields, "build")
+		}
+		values.Set("fields[betaFeedbackScreenshotSubmissions]", strings.Join(fields, ","))
 	}
+	addBuildInclude(values, query.includeBuild)
 	addCSV(values, "filter[deviceModel]", query.deviceModels)
 	addCSV(values, "filter[osVersion]", query.osVersions)
 	addCSV(values, "filter[appPlatform]", query.appPlatforms)
@@ -753,6 +765,7 @@ func buildFeedbackQuery(query *feedbackQuery) string {
 
 func buildCrashQuery(query *crashQuery) string {
 	values := url.Values{}
+	addBuildInclude(values, query.includeBuild)
 	addCSV(values, "filter[deviceModel]", query.deviceModels)
 	addCSV(values, "filter[osVersion]", query.osVersions)
 	addCSV(values, "filter[appPlatform]", query.appPlatforms)
@@ -767,6 +780,16 @@ func buildCrashQuery(query *crashQuery) string {
 	return values.Encode()
 }
 
+// addBuildInclude includes the related build with just its version so
+// submissions can be attributed to a build number.
+func addBuildInclude(values url.Values, include bool) {
+	if !include {
+		return
+	}
+	values.Set("include", "build")
+	values.Set("fields[builds]", "version,uploadedDate")
+}
+
 func buildBetaGroupsQuery(query *betaGroupsQuery) string {
 	values := url.Values{}
 	addLimit(values, query.limit)
@@ -1524,6 +1547,13 @@ func buildLinkagesQuery(query *linkagesQuery) string {
 	return values.Encode()
 }
 
+func buildAppPricesQuery(query *appPricesQuery) string {
+	values := url.Values{}
+	addCSV(values, "include", query.include)
+	addLimit(values, query.limit)
+	return values.Encode()
+}
+
 func buildPricePointsQuery(query *pricePointsQuery) string {
 	values := url.Values{}
 	if strings.TrimSpace(query.territory) != "" {
@@ -0,0 +1,64 @@
+package asc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// RawListResponse is a collection response whose resources are kept as raw
+// JSON, for endpoints without typed wrappers.
+type RawListResponse struct {
+	Data     []json.RawMessage `json:"data"`
+	Included []json.RawMessage `json:"included,omitempty"`
+	Links    Links             `json:"links"`
+	Meta     json.RawMessage   `json:"meta,omitempty"`
+}
+
+// GetLinks returns the links field for pagination.
+func (r *RawListResponse) GetLinks() *Links {
+	return &r.Links
+}
+
+// GetData returns the data field for aggregation.
+func (r *RawListResponse) GetData() any {
+	return r.Data
+}
+
+// Request performs an authenticated request against an arbitrary API path
+// (e.g. "/v1/apps?limit=5") and returns the raw response body. Absolute URLs
+// must point at the configured API host. GET requests are retried like typed
+// requests.
+func (c *Client) Request(ctx context.Context, method, path string, body io.Reader) ([]byte, error) {
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if method == "" {
+		method = http.MethodGet
+	}
+	path = strings.TrimSpace(path)
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		if err := validateNextURL(path); err != nil {
+			return nil, fmt.Errorf("request: %w", err)
+		}
+	} else if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("request: path must start with / (got %q)", path)
+	}
+	return c.do(ctx, method, path, body)
+}
+
+// GetRawList fetches a collection endpoint and decodes it as a RawListResponse.
+func (c *Client) GetRawList(ctx context.Context, path string) (*RawListResponse, error) {
+	data, err := c.Request(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response RawListResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response (is %s a collection endpoint?): %w", path, err)
+	}
+
+	return &response, nil
+}
@@ -124,6 +124,39 @@ func TestBuildFeedbackQuery_IncludesScreenshots(t *testing.T) {
 	}
 }
 
+func TestBuildCrashQuery_IncludesBuild(t *testing.T) {
+	query := &crashQuery{}
+	WithCrashIncludeBuild()(query)
+
+	values, err := url.ParseQuery(buildCrashQuery(query))
+	if err != nil {
+		t.Fatalf("failed to parse query: %v", err)
+	}
+	if got := values.Get("include"); got != "build" {
+		t.Fatalf("expected include=build, got %q", got)
+	}
+	if got := values.Get("fields[builds]"); got != "version,uploadedDate" {
+		t.Fatalf("expected fields[builds]=version,uploadedDate, got %q", got)
+	}
+}
+
+func TestBuildFeedbackQuery_IncludesBuildWithScreenshots(t *testing.T) {
+	query := &feedbackQuery{}
+	WithFeedbackIncludeScreenshots()(query)
+	WithFeedbackIncludeBuild()(query)
+
+	values, err := url.ParseQuery(buildFeedbackQuery(query))
+	if err != nil {
+		t.Fatalf("failed to parse query: %v", err)
+	}
+	if got := values.Get("include"); got != "build" {
+		t.Fatalf("expected include=build, got %q", got)
+	}
+	if got := values.Get("fields[betaFeedbackScreenshotSubmissions]"); !strings.HasSuffix(got, ",screenshots,build") {
+		t.Fatalf("expected build relationship in fields, got %q", got)
+	}
+}
+
 func TestBuildCrashQuery(t *testing.T) {
 	query := &crashQuery{}
 	opts := []CrashOption{
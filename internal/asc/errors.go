@@ -3,6 +3,7 @@ package asc
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"sort"
 	"strings"
 )
@@ -104,7 +105,7 @@ func formatAssociatedErrors(values map[string][]APIAssociatedError) string {
 func (e *APIError) Is(target error) bool {
 	switch target {
 	case ErrNotFound:
-		return strings.EqualFold(e.Code, "NOT_FOUND")
+		return strings.EqualFold(e.Code, "NOT_FOUND") || e.StatusCode == http.StatusNotFound
 	case ErrUnauthorized:
 		return strings.EqualFold(e.Code, "UNAUTHORIZED")
 	case ErrForbidden:
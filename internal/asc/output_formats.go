@@ -0,0 +1,102 @@
+package asc
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// delimitedOutput makes RenderTable and RenderMarkdown write CSV or TSV while
+// RenderDelimited is running.
+type delimitedOutput struct {
+	comma  rune
+	tables int
+	err    error
+}
+
+var activeDelimited *delimitedOutput
+
+// RenderDelimited runs render with table and Markdown output written as
+// delimiter-separated values (',' for CSV, '\t' for TSV), using the same
+// headers and rows. Multiple tables are separated by a blank line. Types
+// without registered rows, and renderers that print free-form text, are
+// rejected instead of falling back to JSON.
+func RenderDelimited(comma rune, render func() error) error {
+	output := &delimitedOutput{comma: comma}
+	previous := activeDelimited
+	activeDelimited = output
+	defer func() {
+		activeDelimited = previous
+	}()
+	if err := render(); err != nil {
+		return err
+	}
+	if output.err == nil && output.tables == 0 {
+		return fmt.Errorf("%s output is not supported for this command", output.name())
+	}
+	return output.err
+}
+
+func (d *delimitedOutput) name() string {
+	if d.comma == '\t' {
+		return "tsv"
+	}
+	return "csv"
+}
+
+// render writes one table, keeping the first write error.
+func (d *delimitedOutput) render(headers []string, rows [][]string) {
+	if d.err == nil {
+		d.err = d.write(headers, rows)
+	}
+}
+
+func (d *delimitedOutput) write(headers []string, rows [][]string) error {
+	if d.tables > 0 {
+		if _, err := fmt.Fprintln(os.Stdout); err != nil {
+			return err
+		}
+	}
+	d.tables++
+	writer := csv.NewWriter(os.Stdout)
+	writer.Comma = d.comma
+	if err := writer.Write(headers); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+// PrintYAML prints data as YAML, keeping the field order of its JSON form.
+func PrintYAML(data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	// JSON is valid YAML, so decoding it into a node keeps key order; the
+	// flow and quoting styles are then cleared to emit block YAML.
+	var node yaml.Node
+	if err := yaml.Unmarshal(raw, &node); err != nil {
+		return fmt.Errorf("convert to yaml: %w", err)
+	}
+	clearYAMLStyle(&node)
+
+	enc := yaml.NewEncoder(os.Stdout)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func clearYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearYAMLStyle(child)
+	}
+}
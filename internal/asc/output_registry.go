@@ -335,7 +335,7 @@ func registerDirect[T any](fn func(*T, func([]string, [][]string)) error) {
 
 // renderByRegistry looks up the rows function for the given value and renders
 // using the provided render function (RenderTable or RenderMarkdown).
-// Falls back to JSON output for unregistered types.
+// Falls back to JSON output for unregistered types, except for CSV and TSV.
 func renderByRegistry(data any, render func([]string, [][]string)) error {
 	t := reflect.TypeOf(data)
 
@@ -354,5 +354,8 @@ func renderByRegistry(data any, render func([]string, [][]string)) error {
 		return nil
 	}
 
+	if activeDelimited != nil {
+		return fmt.Errorf("%s output is not supported for this command", activeDelimited.name())
+	}
 	return PrintJSON(data)
 }
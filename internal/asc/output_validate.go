@@ -39,6 +39,14 @@ func init() {
 		render(oh, or)
 		return nil
 	})
+
+	registerDirect(func(v *validation.IPAReport, render func([]string, [][]string)) error {
+		h, r := ipaValidationSummaryRows(v)
+		render(h, r)
+		oh, or := ipaValidationCheckRows(v)
+		render(oh, or)
+		return nil
+	})
 }
 
 func validationSummaryRows(report *validation.Report) ([]string, [][]string) {
@@ -184,6 +192,42 @@ func subscriptionsValidationCheckRows(report *validation.SubscriptionsReport) ([
 	return headers, rows
 }
 
+func ipaValidationSummaryRows(report *validation.IPAReport) ([]string, [][]string) {
+	headers := []string{"Path", "Bundle ID", "Version", "Build", "Errors", "Warnings", "Infos", "Blocking", "Strict"}
+	rows := [][]string{{
+		report.Path,
+		report.BundleID,
+		report.Version,
+		report.Build,
+		fmt.Sprintf("%d", report.Summary.Errors),
+		fmt.Sprintf("%d", report.Summary.Warnings),
+		fmt.Sprintf("%d", report.Summary.Infos),
+		fmt.Sprintf("%d", report.Summary.Blocking),
+		formatBool(report.Strict),
+	}}
+	return headers, rows
+}
+
+func ipaValidationCheckRows(report *validation.IPAReport) ([]string, [][]string) {
+	headers := []string{"Severity", "Check ID", "Field", "Resource", "Message", "Remediation"}
+	if report == nil || len(report.Checks) == 0 {
+		return headers, [][]string{{"info", "validation.ok", "", "", "No issues found", ""}}
+	}
+
+	rows := make([][]string, 0, len(report.Checks))
+	for _, check := range report.Checks {
+		rows = append(rows, []string{
+			string(check.Severity),
+			check.ID,
+			check.Field,
+			formatResource(check.ResourceType, check.ResourceID),
+			check.Message,
+			check.Remediation,
+		})
+	}
+	return headers, rows
+}
+
 func formatResource(resourceType, resourceID string) string {
 	if resourceType == "" && resourceID == "" {
 		return ""
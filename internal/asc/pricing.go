@@ -52,6 +52,18 @@ type AppPriceScheduleCreateAttributes struct {
 	PricePointID    string `json:"-"`
 	StartDate       string `json:"-"`
 	BaseTerritoryID string `json:"-"`
+	// AdditionalPrices are manual prices besides the new base price, such as
+	// other territories' prices or scheduled base price changes. A new
+	// schedule replaces the old one, so omitted manual prices are removed.
+	AdditionalPrices []AppPriceScheduleManualPrice `json:"-"`
+}
+
+// AppPriceScheduleManualPrice is a manual price in a new price schedule.
+// The price point determines the territory. An empty StartDate makes the
+// price effective immediately.
+type AppPriceScheduleManualPrice struct {
+	PricePointID string
+	StartDate    string
 }
 
 // AppPriceScheduleCreateRequest is a request to create a price schedule.
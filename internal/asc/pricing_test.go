@@ -208,6 +208,34 @@ func TestGetAppPriceScheduleManualPrices(t *testing.T) {
 	}
 }
 
+func TestGetAppPriceScheduleManualPrices_WithInclude(t *testing.T) {
+	resp := AppPricesResponse{
+		Data: []Resource[AppPriceAttributes]{{Type: ResourceTypeAppPrices, ID: "price-1"}},
+	}
+	body, _ := json.Marshal(resp)
+
+	client := newTestClient(t, func(req *http.Request) {
+		assertAuthorized(t, req)
+		values := req.URL.Query()
+		if values.Get("include") != "appPricePoint,territory" {
+			t.Fatalf("expected include=appPricePoint,territory, got %q", values.Get("include"))
+		}
+		if values.Get("limit") != "200" {
+			t.Fatalf("expected limit=200, got %q", values.Get("limit"))
+		}
+	}, jsonResponse(http.StatusOK, string(body)))
+
+	_, err := client.GetAppPriceScheduleManualPrices(
+		context.Background(),
+		"schedule-1",
+		WithAppPricesInclude([]string{"appPricePoint", "territory"}),
+		WithAppPricesLimit(200),
+	)
+	if err != nil {
+		t.Fatalf("GetAppPriceScheduleManualPrices() error: %v", err)
+	}
+}
+
 func TestGetAppPriceScheduleAutomaticPrices(t *testing.T) {
 	resp := AppPricesResponse{
 		Data: []Resource[AppPriceAttributes]{{Type: ResourceTypeAppPrices, ID: "price-1"}},
@@ -306,6 +334,47 @@ func TestCreateAppPriceSchedule(t *testing.T) {
 	}
 }
 
+func TestCreateAppPriceScheduleKeepsAdditionalPrices(t *testing.T) {
+	client := newTestClient(t, func(req *http.Request) {
+		var createReq AppPriceScheduleCreateRequest
+		if err := json.NewDecoder(req.Body).Decode(&createReq); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		manual := createReq.Data.Relationships.ManualPrices.Data
+		if len(manual) != 3 || len(createReq.Included) != 3 {
+			t.Fatalf("expected 3 manual prices, got %d relationships and %d included", len(manual), len(createReq.Included))
+		}
+		want := []struct{ pricePoint, startDate string }{
+			{"pp-usa", "2024-03-01"},
+			{"pp-gbr", ""},
+			{"pp-fra", "2024-06-01"},
+		}
+		seen := map[string]bool{}
+		for i, price := range createReq.Included {
+			if manual[i].ID != price.ID || seen[price.ID] {
+				t.Fatalf("expected unique included ids matching relationships, got %+v", manual)
+			}
+			seen[price.ID] = true
+			if price.Relationships.AppPricePoint.Data.ID != want[i].pricePoint || price.Attributes.StartDate != want[i].startDate {
+				t.Fatalf("price %d: got %s from %q, want %+v", i, price.Relationships.AppPricePoint.Data.ID, price.Attributes.StartDate, want[i])
+			}
+		}
+	}, jsonResponse(http.StatusCreated, `{"data":{"type":"appPriceSchedules","id":"schedule-1"}}`))
+
+	_, err := client.CreateAppPriceSchedule(context.Background(), "app-1", AppPriceScheduleCreateAttributes{
+		PricePointID:    "pp-usa",
+		StartDate:       "2024-03-01",
+		BaseTerritoryID: "USA",
+		AdditionalPrices: []AppPriceScheduleManualPrice{
+			{PricePointID: "pp-gbr"},
+			{PricePointID: "pp-fra", StartDate: "2024-06-01"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateAppPriceSchedule() error: %v", err)
+	}
+}
+
 func TestGetAppAvailabilityV2(t *testing.T) {
 	resp := AppAvailabilityV2Response{
 		Data: Resource[AppAvailabilityV2Attributes]{
@@ -0,0 +1,105 @@
+package asc
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// columnSelection restricts RenderTable and RenderMarkdown to a subset of
+// columns while RenderWithColumns is running.
+type columnSelection struct {
+	columns   []string
+	rendered  int
+	available []string
+}
+
+var activeColumns *columnSelection
+
+// RenderWithColumns runs render with table and Markdown output limited to the
+// named columns, in the order given. Column names match headers ignoring
+// case, spaces and punctuation ("bundle-id" matches "Bundle ID"). Tables that
+// lack any of the columns are skipped; it is an error if no table had them
+// all.
+func RenderWithColumns(columns []string, render func() error) error {
+	if len(columns) == 0 {
+		return render()
+	}
+	selection := &columnSelection{columns: columns}
+	previous := activeColumns
+	activeColumns = selection
+	defer func() {
+		activeColumns = previous
+	}()
+
+	if err := render(); err != nil {
+		return err
+	}
+	if selection.rendered == 0 && len(selection.available) > 0 {
+		return fmt.Errorf("--columns %s not found (available: %s)", strings.Join(columns, ","), strings.Join(selection.available, ", "))
+	}
+	return nil
+}
+
+// selectColumns applies the active column selection to headers and rows. It
+// returns false when the table should not be rendered.
+func selectColumns(headers []string, rows [][]string) ([]string, [][]string, bool) {
+	selection := activeColumns
+	if selection == nil {
+		return headers, rows, true
+	}
+
+	byKey := make(map[string]int, len(headers))
+	for i, header := range headers {
+		key := columnKey(header)
+		if _, exists := byKey[key]; !exists {
+			byKey[key] = i
+		}
+	}
+	indexes := make([]int, 0, len(selection.columns))
+	for _, column := range selection.columns {
+		i, ok := byKey[columnKey(column)]
+		if !ok {
+			selection.available = appendMissing(selection.available, headers)
+			return nil, nil, false
+		}
+		indexes = append(indexes, i)
+	}
+	selection.rendered++
+
+	selectedHeaders := make([]string, len(indexes))
+	for j, i := range indexes {
+		selectedHeaders[j] = headers[i]
+	}
+	selectedRows := make([][]string, len(rows))
+	for r, row := range rows {
+		selected := make([]string, len(indexes))
+		for j, i := range indexes {
+			if i < len(row) {
+				selected[j] = row[i]
+			}
+		}
+		selectedRows[r] = selected
+	}
+	return selectedHeaders, selectedRows, true
+}
+
+func columnKey(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+func appendMissing(values, candidates []string) []string {
+	for _, candidate := range candidates {
+		if !slices.Contains(values, candidate) {
+			values = append(values, candidate)
+		}
+	}
+	return values
+}
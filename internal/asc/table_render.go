@@ -12,6 +12,14 @@ import (
 // Headers preserve their original casing and are center-aligned.
 // Data rows are left-aligned for readability.
 func RenderTable(headers []string, rows [][]string) {
+	headers, rows, ok := selectColumns(headers, rows)
+	if !ok {
+		return
+	}
+	if activeDelimited != nil {
+		activeDelimited.render(headers, rows)
+		return
+	}
 	table := tablewriter.NewTable(os.Stdout,
 		tablewriter.WithConfig(tablewriter.Config{
 			Header: tw.CellConfig{
@@ -34,6 +42,14 @@ func RenderTable(headers []string, rows [][]string) {
 // Headers preserve their original casing. Data rows are left-aligned.
 // Pipe characters in cell values are escaped automatically by the renderer.
 func RenderMarkdown(headers []string, rows [][]string) {
+	headers, rows, ok := selectColumns(headers, rows)
+	if !ok {
+		return
+	}
+	if activeDelimited != nil {
+		activeDelimited.render(headers, rows)
+		return
+	}
 	table := tablewriter.NewTable(os.Stdout,
 		tablewriter.WithRenderer(renderer.NewMarkdown()),
 		tablewriter.WithConfig(tablewriter.Config{
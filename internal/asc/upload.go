@@ -17,9 +17,11 @@ import (
 
 // UploadOptions configure how upload operations are executed.
 type UploadOptions struct {
-	Concurrency int
-	Client      *http.Client
-	RetryOpts   RetryOptions
+	Concurrency   int
+	Client        *http.Client
+	RetryOpts     RetryOptions
+	CheckpointID  string
+	ReservationID string
 }
 
 // UploadOption configures upload options.
@@ -81,9 +83,6 @@ func ExecuteUploadOperations(ctx context.Context, filePath string, operations []
 	if uploadOpts.Client == nil {
 		uploadOpts.Client = newUploadClient()
 	}
-	if uploadOpts.Concurrency > len(operations) {
-		uploadOpts.Concurrency = len(operations)
-	}
 
 	file, err := openUploadSourceFile(filePath)
 	if err != nil {
@@ -115,6 +114,21 @@ func ExecuteUploadOperations(ctx context.Context, filePath string, operations []
 		}
 	}
 
+	checkpoint := openUploadCheckpointer(uploadOpts.CheckpointID, uploadOpts.ReservationID, file, size)
+	pending := make([]uploadTask, 0, len(operations))
+	for i, op := range operations {
+		if !checkpoint.completed(op) {
+			pending = append(pending, uploadTask{index: i, op: op})
+		}
+	}
+	if len(pending) == 0 {
+		checkpoint.finish()
+		return nil
+	}
+	if uploadOpts.Concurrency > len(pending) {
+		uploadOpts.Concurrency = len(pending)
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -140,6 +154,7 @@ func ExecuteUploadOperations(ctx context.Context, filePath string, operations []
 				setErr(err)
 				return
 			}
+			checkpoint.record(task.op)
 		}
 	}
 
@@ -149,16 +164,19 @@ func ExecuteUploadOperations(ctx context.Context, filePath string, operations []
 	}
 
 sendLoop:
-	for i, op := range operations {
+	for _, task := range pending {
 		select {
 		case <-ctx.Done():
 			break sendLoop
-		case jobs <- uploadTask{index: i, op: op}:
+		case jobs <- task:
 		}
 	}
 	close(jobs)
 
 	wg.Wait()
+	if firstErr == nil {
+		checkpoint.finish()
+	}
 	return firstErr
 }
 
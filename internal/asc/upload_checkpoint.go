@@ -0,0 +1,303 @@
+package asc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// UploadStateDirEnvVar overrides the directory upload checkpoints are kept in.
+const UploadStateDirEnvVar = "ASC_UPLOAD_STATE_DIR"
+
+// uploadCheckpointMaxAge bounds how long abandoned checkpoints are kept;
+// presigned upload URLs expire well before this.
+const uploadCheckpointMaxAge = 7 * 24 * time.Hour
+
+// assetUploadAwaitingState is the delivery state of an asset reservation
+// whose file has not been committed yet.
+const assetUploadAwaitingState = "AWAITING_UPLOAD"
+
+// UploadCheckpoint records the upload operations that completed for an
+// upload reservation, so a rerun with the same file can skip them. Asset
+// checkpoints also record the reservation the parts were sent to.
+type UploadCheckpoint struct {
+	UploadID      string                 `json:"uploadId"`
+	ReservationID string                 `json:"reservationId,omitempty"`
+	FileSize      int64                  `json:"fileSize"`
+	Parts         []UploadCheckpointPart `json:"parts"`
+	UpdatedAt     time.Time              `json:"updatedAt"`
+}
+
+// UploadCheckpointPart is a completed upload operation and the SHA-256 of
+// the bytes that were sent.
+type UploadCheckpointPart struct {
+	Offset int64  `json:"offset"`
+	Length int64  `json:"length"`
+	SHA256 string `json:"sha256"`
+}
+
+// WithUploadCheckpoint records completed operations under uploadID and skips
+// operations an earlier run already finished. The checkpoint is removed once
+// every operation succeeds.
+func WithUploadCheckpoint(uploadID string) UploadOption {
+	return func(opts *UploadOptions) {
+		opts.CheckpointID = strings.TrimSpace(uploadID)
+	}
+}
+
+// WithAssetUploadCheckpoint records completed operations of the asset
+// reservation reservationID under checkpointID (see AssetUploadCheckpointID).
+// Parts recorded for another reservation are discarded. The checkpoint is
+// kept after the upload so an interrupted commit can resume too; remove it
+// with RemoveUploadCheckpoint once the asset is committed.
+func WithAssetUploadCheckpoint(checkpointID, reservationID string) UploadOption {
+	return func(opts *UploadOptions) {
+		opts.CheckpointID = strings.TrimSpace(checkpointID)
+		opts.ReservationID = strings.TrimSpace(reservationID)
+	}
+}
+
+// AssetUploadCheckpointID returns the checkpoint ID for uploading a file to
+// target, such as "appScreenshotSets/SET_ID". Asset reservations get a new ID
+// on every request, so asset checkpoints are keyed on the file and where it
+// goes, and remember the reservation to resume.
+func AssetUploadCheckpointID(target, fileName string, fileSize int64) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%s\x00%d", strings.TrimSpace(target), fileName, fileSize)))
+	return "asset-" + hex.EncodeToString(sum[:16])
+}
+
+// AssetUploadReservation returns the reservation ID recorded under an asset
+// checkpoint ID, or "" when there is nothing to resume.
+func AssetUploadReservation(checkpointID string) string {
+	checkpoint, err := LoadUploadCheckpoint(checkpointID)
+	if err != nil || checkpoint == nil {
+		return ""
+	}
+	return checkpoint.ReservationID
+}
+
+// AssetReservationResumable reports whether an asset reservation fetched
+// again can take the rest of its upload: it still awaits the file and has
+// upload operations.
+func AssetReservationResumable(state string, operations []UploadOperation) bool {
+	return strings.EqualFold(strings.TrimSpace(state), assetUploadAwaitingState) && len(operations) > 0
+}
+
+func assetDeliveryStateValue(state *AssetDeliveryState) string {
+	if state == nil {
+		return ""
+	}
+	return state.State
+}
+
+// UploadStateDir returns the directory upload checkpoints are kept in.
+func UploadStateDir() (string, error) {
+	if dir := strings.TrimSpace(os.Getenv(UploadStateDirEnvVar)); dir != "" {
+		return filepath.Clean(dir), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".asc", "uploads"), nil
+}
+
+// UploadCheckpointPath returns the checkpoint file path for an upload ID.
+func UploadCheckpointPath(uploadID string) (string, error) {
+	uploadID = strings.TrimSpace(uploadID)
+	if uploadID == "" || uploadID != filepath.Base(uploadID) || strings.HasPrefix(uploadID, ".") {
+		return "", fmt.Errorf("invalid upload ID %q", uploadID)
+	}
+	dir, err := UploadStateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, uploadID+".json"), nil
+}
+
+// LoadUploadCheckpoint reads the checkpoint for an upload ID. It returns
+// nil without an error when no checkpoint exists.
+func LoadUploadCheckpoint(uploadID string) (*UploadCheckpoint, error) {
+	path, err := UploadCheckpointPath(uploadID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read upload checkpoint: %w", err)
+	}
+	var checkpoint UploadCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("parse upload checkpoint %s: %w", path, err)
+	}
+	return &checkpoint, nil
+}
+
+// RemoveUploadCheckpoint deletes the checkpoint for an upload ID, if any.
+func RemoveUploadCheckpoint(uploadID string) error {
+	path, err := UploadCheckpointPath(uploadID)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove upload checkpoint: %w", err)
+	}
+	return nil
+}
+
+// uploadCheckpointer tracks checkpoint state during one upload. Checkpoint
+// writes are best effort: a failure to persist never fails the upload.
+type uploadCheckpointer struct {
+	path  string
+	file  io.ReaderAt
+	keep  bool
+	mu    sync.Mutex
+	state UploadCheckpoint
+}
+
+// openUploadCheckpointer loads the checkpoint for uploadID, discarding it if
+// it was written for a file of a different size or another reservation. It
+// returns nil when checkpointing is disabled or unavailable. Asset
+// checkpoints (with a reservationID) are written right away so the
+// reservation is remembered even if no part completes.
+func openUploadCheckpointer(uploadID, reservationID string, file io.ReaderAt, size int64) *uploadCheckpointer {
+	if uploadID == "" {
+		return nil
+	}
+	path, err := UploadCheckpointPath(uploadID)
+	if err != nil {
+		return nil
+	}
+	pruneUploadCheckpoints(filepath.Dir(path), time.Now())
+
+	checkpointer := &uploadCheckpointer{
+		path:  path,
+		file:  file,
+		keep:  reservationID != "",
+		state: UploadCheckpoint{UploadID: uploadID, ReservationID: reservationID, FileSize: size},
+	}
+	existing, err := LoadUploadCheckpoint(uploadID)
+	if err == nil && existing != nil && existing.FileSize == size && existing.ReservationID == reservationID {
+		checkpointer.state.Parts = existing.Parts
+	} else if checkpointer.keep {
+		checkpointer.mu.Lock()
+		checkpointer.save()
+		checkpointer.mu.Unlock()
+	}
+	return checkpointer
+}
+
+// completed reports whether op was uploaded by an earlier run and the file
+// still holds the same bytes for it.
+func (c *uploadCheckpointer) completed(op UploadOperation) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	var recorded string
+	for _, part := range c.state.Parts {
+		if part.Offset == op.Offset && part.Length == op.Length {
+			recorded = part.SHA256
+			break
+		}
+	}
+	c.mu.Unlock()
+	if recorded == "" {
+		return false
+	}
+	sum, err := sectionSHA256(c.file, op.Offset, op.Length)
+	return err == nil && sum == recorded
+}
+
+// record marks op as uploaded and persists the checkpoint.
+func (c *uploadCheckpointer) record(op UploadOperation) {
+	if c == nil {
+		return
+	}
+	sum, err := sectionSHA256(c.file, op.Offset, op.Length)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.state.Parts = append(c.state.Parts, UploadCheckpointPart{Offset: op.Offset, Length: op.Length, SHA256: sum})
+	c.save()
+}
+
+// save persists the checkpoint. Callers hold c.mu.
+func (c *uploadCheckpointer) save() {
+	c.state.UpdatedAt = time.Now().UTC()
+	data, err := json.MarshalIndent(c.state, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = writeFileAtomic(c.path, data)
+}
+
+// finish removes the checkpoint after every operation succeeded. Asset
+// checkpoints are kept until the caller commits the reservation.
+func (c *uploadCheckpointer) finish() {
+	if c == nil || c.keep {
+		return
+	}
+	_ = os.Remove(c.path)
+}
+
+func sectionSHA256(file io.ReaderAt, offset, length int64) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, io.NewSectionReader(file, offset, length)); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".checkpoint-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// pruneUploadCheckpoints removes checkpoints older than
+// uploadCheckpointMaxAge, left behind by uploads that were never resumed.
+func pruneUploadCheckpoints(dir string, now time.Time) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || now.Sub(info.ModTime()) < uploadCheckpointMaxAge {
+			continue
+		}
+		_ = os.Remove(filepath.Join(dir, entry.Name()))
+	}
+}
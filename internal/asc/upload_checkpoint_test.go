@@ -0,0 +1,147 @@
+package asc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestExecuteUploadOperations_ResumesFromCheckpoint(t *testing.T) {
+	t.Setenv(UploadStateDirEnvVar, t.TempDir())
+	filePath := filepath.Join(t.TempDir(), "app.ipa")
+	if err := os.WriteFile(filePath, []byte("abcdefghij"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	var mu sync.Mutex
+	var received []string
+	failOp1 := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, r.URL.Path)
+		if failOp1 && r.URL.Path == "/op1" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ops := []UploadOperation{
+		{Method: "PUT", URL: server.URL + "/op0", Offset: 0, Length: 5},
+		{Method: "PUT", URL: server.URL + "/op1", Offset: 5, Length: 5},
+	}
+
+	if err := ExecuteUploadOperations(context.Background(), filePath, ops, WithUploadCheckpoint("UPLOAD_1")); err == nil {
+		t.Fatal("expected first upload to fail")
+	}
+	checkpoint, err := LoadUploadCheckpoint("UPLOAD_1")
+	if err != nil || checkpoint == nil {
+		t.Fatalf("expected checkpoint, got %v, %v", checkpoint, err)
+	}
+	if len(checkpoint.Parts) != 1 || checkpoint.Parts[0].Offset != 0 || checkpoint.FileSize != 10 {
+		t.Fatalf("unexpected checkpoint: %+v", checkpoint)
+	}
+
+	failOp1 = false
+	received = nil
+	if err := ExecuteUploadOperations(context.Background(), filePath, ops, WithUploadCheckpoint("UPLOAD_1")); err != nil {
+		t.Fatalf("resume error: %v", err)
+	}
+	if !slices.Equal(received, []string{"/op1"}) {
+		t.Fatalf("expected only op1 to be uploaded on resume, got %v", received)
+	}
+	if checkpoint, _ := LoadUploadCheckpoint("UPLOAD_1"); checkpoint != nil {
+		t.Fatalf("expected checkpoint to be removed after success, got %+v", checkpoint)
+	}
+}
+
+func TestUploadAssetFromFile_ResumesOnlySameReservation(t *testing.T) {
+	t.Setenv(UploadStateDirEnvVar, t.TempDir())
+
+	var received []string
+	failOp1 := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.URL.Path)
+		if failOp1 && r.URL.Path == "/op1" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ops := []UploadOperation{
+		{Method: "PUT", URL: server.URL + "/op0", Offset: 0, Length: 4},
+		{Method: "PUT", URL: server.URL + "/op1", Offset: 4, Length: 4},
+	}
+	file := createTempAssetFile(t, []byte("abcdefgh"))
+	checkpointID := AssetUploadCheckpointID("appScreenshotSets/SET_1", "shot.png", 8)
+
+	if err := UploadAssetFromFile(context.Background(), file, 8, ops, WithAssetUploadCheckpoint(checkpointID, "SHOT_1")); err == nil {
+		t.Fatal("expected first upload to fail")
+	}
+	if got := AssetUploadReservation(checkpointID); got != "SHOT_1" {
+		t.Fatalf("expected reservation SHOT_1 to be recorded, got %q", got)
+	}
+
+	failOp1 = false
+	received = nil
+	if err := UploadAssetFromFile(context.Background(), file, 8, ops, WithAssetUploadCheckpoint(checkpointID, "SHOT_1")); err != nil {
+		t.Fatalf("resume error: %v", err)
+	}
+	if !slices.Equal(received, []string{"/op1"}) {
+		t.Fatalf("expected only op1 on resume, got %v", received)
+	}
+	if got := AssetUploadReservation(checkpointID); got != "SHOT_1" {
+		t.Fatalf("expected checkpoint to be kept until commit, got reservation %q", got)
+	}
+
+	received = nil
+	if err := UploadAssetFromFile(context.Background(), file, 8, ops, WithAssetUploadCheckpoint(checkpointID, "SHOT_2")); err != nil {
+		t.Fatalf("new reservation error: %v", err)
+	}
+	if !slices.Equal(received, []string{"/op0", "/op1"}) {
+		t.Fatalf("expected a new reservation to upload every part, got %v", received)
+	}
+}
+
+func TestUploadCheckpointPathRejectsTraversal(t *testing.T) {
+	t.Setenv(UploadStateDirEnvVar, t.TempDir())
+	for _, id := range []string{"", "../escape", "a/b", ".hidden"} {
+		if _, err := UploadCheckpointPath(id); err == nil {
+			t.Fatalf("expected error for upload ID %q", id)
+		}
+	}
+}
+
+func TestPruneUploadCheckpoints(t *testing.T) {
+	dir := t.TempDir()
+	stale := filepath.Join(dir, "OLD.json")
+	fresh := filepath.Join(dir, "NEW.json")
+	for _, path := range []string{stale, fresh} {
+		if err := os.WriteFile(path, []byte("{}"), 0o600); err != nil {
+			t.Fatalf("write checkpoint: %v", err)
+		}
+	}
+	now := time.Now()
+	if err := os.Chtimes(stale, now, now.Add(-uploadCheckpointMaxAge-time.Hour)); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+
+	pruneUploadCheckpoints(dir, now)
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Fatalf("expected stale checkpoint to be removed, got %v", err)
+	}
+	if _, err := os.Stat(fresh); err != nil {
+		t.Fatalf("expected fresh checkpoint to remain, got %v", err)
+	}
+}
@@ -77,6 +77,7 @@ func doctor(options DoctorOptions, resolver MigrationSuggestionResolver) DoctorR
 func inspectStorage(options DoctorOptions) DoctorSection {
 	checks := []DoctorCheck{}
 
+	settings := ResolveKeyringSettings()
 	if shouldBypassKeychain() {
 		checks = append(checks, DoctorCheck{
 			Status:  DoctorInfo,
@@ -92,7 +93,12 @@ func inspectStorage(options DoctorOptions) DoctorSection {
 		checks = append(checks, DoctorCheck{
 			Status:         status,
 			Message:        message,
-			Recommendation: "Consider using --bypass-keychain or setting ASC_BYPASS_KEYCHAIN to 1/true/yes/on",
+			Recommendation: "Consider setting keyring_backend to \"file\" with ASC_KEYRING_PASSPHRASE for an encrypted file keyring, or use --bypass-keychain / ASC_BYPASS_KEYCHAIN=1",
+		})
+	} else if settings.Backend == config.KeyringBackendFile {
+		checks = append(checks, DoctorCheck{
+			Status:  DoctorOK,
+			Message: fmt.Sprintf("Encrypted file keyring is selected (%s)", settings.FileDir),
 		})
 	} else {
 		checks = append(checks, DoctorCheck{
@@ -100,6 +106,9 @@ func inspectStorage(options DoctorOptions) DoctorSection {
 			Message: "System keychain is available",
 		})
 	}
+	if !shouldBypassKeychain() && settings.UsesFile() {
+		checks = append(checks, inspectFileKeyring(settings, options)...)
+	}
 
 	configPath, err := config.Path()
 	if err != nil {
@@ -162,6 +171,113 @@ func inspectStorage(options DoctorOptions) DoctorSection {
 	return DoctorSection{Title: "Storage", Checks: checks}
 }
 
+// inspectFileKeyring checks the encrypted file keyring: its passphrase
+// source, directory and item permissions, and (when selected explicitly)
+// that the passphrase decrypts the stored items.
+func inspectFileKeyring(settings KeyringSettings, options DoctorOptions) []DoctorCheck {
+	checks := []DoctorCheck{}
+
+	switch {
+	case settings.PassphraseEnv:
+		checks = append(checks, DoctorCheck{
+			Status:  DoctorInfo,
+			Message: "Keyring passphrase is read from ASC_KEYRING_PASSPHRASE",
+		})
+	case settings.PassphraseFile != "":
+		checks = append(checks, checkSecretPermissions(settings.PassphraseFile, "Keyring passphrase file", 0o600, options))
+	default:
+		checks = append(checks, DoctorCheck{
+			Status:         DoctorFail,
+			Message:        ErrKeyringPassphraseMissing.Error(),
+			Recommendation: "Set ASC_KEYRING_PASSPHRASE, or keyring_passphrase_file / ASC_KEYRING_PASSPHRASE_FILE to a 0600 file",
+		})
+	}
+
+	if _, err := os.Stat(settings.FileDir); os.IsNotExist(err) {
+		checks = append(checks, DoctorCheck{
+			Status:  DoctorInfo,
+			Message: fmt.Sprintf("Encrypted keyring directory %s does not exist yet (created on first login)", settings.FileDir),
+		})
+		return checks
+	}
+	checks = append(checks, checkSecretPermissions(settings.FileDir, "Encrypted keyring directory", 0o700, options))
+
+	entries, err := os.ReadDir(settings.FileDir)
+	if err != nil {
+		return append(checks, DoctorCheck{
+			Status:  DoctorFail,
+			Message: fmt.Sprintf("Failed to read encrypted keyring directory: %v", err),
+		})
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(settings.FileDir, entry.Name())
+		if check := checkSecretPermissions(path, "Encrypted keyring item", 0o600, options); check.Status != DoctorOK || check.FixApplied {
+			checks = append(checks, check)
+		}
+	}
+
+	if settings.Backend != config.KeyringBackendFile || !settings.HasPassphrase() {
+		return checks
+	}
+	kr, err := openKeyring("")
+	if err == nil {
+		var keys []string
+		keys, err = kr.Keys()
+		if err == nil && len(keys) > 0 {
+			_, err = kr.Get(keys[0])
+		}
+	}
+	if err != nil {
+		checks = append(checks, DoctorCheck{
+			Status:         DoctorFail,
+			Message:        fmt.Sprintf("Failed to unlock encrypted keyring: %v", err),
+			Recommendation: "Check that the keyring passphrase matches the one used when the credentials were stored",
+		})
+	} else {
+		checks = append(checks, DoctorCheck{
+			Status:  DoctorOK,
+			Message: "Encrypted keyring unlocks with the configured passphrase",
+		})
+	}
+	return checks
+}
+
+// checkSecretPermissions reports whether path is restricted to its owner,
+// tightening it to mode when options.Fix is set.
+func checkSecretPermissions(path, label string, mode os.FileMode, options DoctorOptions) DoctorCheck {
+	info, err := os.Stat(path)
+	if err != nil {
+		return DoctorCheck{
+			Status:  DoctorFail,
+			Message: fmt.Sprintf("%s %s: %v", label, path, err),
+		}
+	}
+	if info.Mode().Perm()&0o077 == 0 {
+		return DoctorCheck{
+			Status:  DoctorOK,
+			Message: fmt.Sprintf("%s permissions are %#o (%s)", label, info.Mode().Perm(), path),
+		}
+	}
+
+	check := DoctorCheck{
+		Status:         DoctorWarn,
+		Message:        fmt.Sprintf("%s permissions are too permissive (%#o)", label, info.Mode().Perm()),
+		Recommendation: fmt.Sprintf("Run: chmod %o %q", mode, path),
+	}
+	if options.Fix {
+		if err := os.Chmod(path, mode); err == nil {
+			check.Status = DoctorOK
+			check.Message = fmt.Sprintf("%s permissions fixed to %#o (%s)", label, mode, path)
+			check.FixApplied = true
+			check.Recommendation = ""
+		}
+	}
+	return check
+}
+
 func inspectProfiles() DoctorSection {
 	checks := []DoctorCheck{}
 
@@ -356,11 +472,15 @@ func inspectEnvironment() DoctorSection {
 		"ASC_PROFILE",
 		"ASC_BYPASS_KEYCHAIN",
 		"ASC_STRICT_AUTH",
+		keyringBackendEnv,
+		keyringDirEnv,
+		keyringPassphraseEnv,
+		keyringPassphraseFileEnv,
 	}
 	for _, name := range envVars {
 		if value := strings.TrimSpace(os.Getenv(name)); value != "" {
 			message := fmt.Sprintf("%s is set", name)
-			if name == "ASC_PROFILE" {
+			if name == "ASC_PROFILE" || name == keyringBackendEnv {
 				message = fmt.Sprintf("%s is set (%s)", name, value)
 			}
 			checks = append(checks, DoctorCheck{
@@ -74,13 +74,7 @@ func keyringConfig(keychainName string) keyring.Config {
 		KeychainTrustApplication:       true,
 		KeychainSynchronizable:         false,
 		KeychainAccessibleWhenUnlocked: true,
-		AllowedBackends: []keyring.BackendType{
-			keyring.KeychainBackend,
-			keyring.WinCredBackend,
-			keyring.SecretServiceBackend,
-			keyring.KWalletBackend,
-			keyring.KeyCtlBackend,
-		},
+		AllowedBackends:                systemKeyringBackends,
 	}
 	if keychainName != "" {
 		cfg.KeychainName = keychainName
@@ -125,11 +119,11 @@ func KeychainAvailable() (bool, error) {
 }
 
 var keyringOpener = func() (keyring.Keyring, error) {
-	return keyring.Open(keyringConfig(""))
+	return openKeyring("")
 }
 
 var legacyKeyringOpener = func() (keyring.Keyring, error) {
-	return keyring.Open(keyringConfig(legacyKeychain))
+	return openKeyring(legacyKeychain)
 }
 
 // ValidateKeyFile validates that the private key file exists and is valid
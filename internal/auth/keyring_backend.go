@@ -0,0 +1,211 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/99designs/keyring"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/config"
+)
+
+const (
+	keyringBackendEnv        = "ASC_KEYRING_BACKEND"
+	keyringDirEnv            = "ASC_KEYRING_DIR"
+	keyringPassphraseEnv     = "ASC_KEYRING_PASSPHRASE"
+	keyringPassphraseFileEnv = "ASC_KEYRING_PASSPHRASE_FILE"
+)
+
+// KeyringStorageEncryptedFile is the storage name Description reports for the
+// encrypted file keyring.
+const KeyringStorageEncryptedFile = "Encrypted File"
+
+// ErrKeyringPassphraseMissing is returned when the encrypted file keyring is
+// selected but no passphrase source is configured.
+var ErrKeyringPassphraseMissing = errors.New("encrypted file keyring requires ASC_KEYRING_PASSPHRASE or ASC_KEYRING_PASSPHRASE_FILE")
+
+var systemKeyringBackends = []keyring.BackendType{
+	keyring.KeychainBackend,
+	keyring.WinCredBackend,
+	keyring.SecretServiceBackend,
+	keyring.KWalletBackend,
+	keyring.KeyCtlBackend,
+}
+
+var keyringBackendTypes = map[string]keyring.BackendType{
+	config.KeyringBackendKeychain:      keyring.KeychainBackend,
+	config.KeyringBackendWinCred:       keyring.WinCredBackend,
+	config.KeyringBackendSecretService: keyring.SecretServiceBackend,
+	config.KeyringBackendKWallet:       keyring.KWalletBackend,
+	config.KeyringBackendKeyCtl:        keyring.KeyCtlBackend,
+	config.KeyringBackendFile:          keyring.FileBackend,
+}
+
+// KeyringSettings describes which keyring backend credentials are stored in.
+// Environment variables take precedence over config.json.
+type KeyringSettings struct {
+	Backend        string
+	FileDir        string
+	PassphraseFile string
+	PassphraseEnv  bool
+}
+
+// ResolveKeyringSettings returns the keyring settings from the environment
+// and the active config file.
+func ResolveKeyringSettings() KeyringSettings {
+	var cfg config.Config
+	if loaded, err := config.Load(); err == nil && loaded != nil {
+		cfg = *loaded
+	}
+
+	settings := KeyringSettings{
+		Backend:        firstNonEmpty(os.Getenv(keyringBackendEnv), cfg.KeyringBackend, config.KeyringBackendAuto),
+		FileDir:        firstNonEmpty(os.Getenv(keyringDirEnv), cfg.KeyringFileDir),
+		PassphraseFile: firstNonEmpty(os.Getenv(keyringPassphraseFileEnv), cfg.KeyringPassphraseFile),
+		PassphraseEnv:  os.Getenv(keyringPassphraseEnv) != "",
+	}
+	settings.Backend = strings.ToLower(settings.Backend)
+	if settings.FileDir == "" {
+		settings.FileDir = filepath.Join("~", ".asc", "keyring")
+	}
+	settings.FileDir = expandHome(settings.FileDir)
+	settings.PassphraseFile = expandHome(settings.PassphraseFile)
+	return settings
+}
+
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			return trimmed
+		}
+	}
+	return ""
+}
+
+// HasPassphrase reports whether a passphrase source is configured for the
+// encrypted file keyring.
+func (s KeyringSettings) HasPassphrase() bool {
+	return s.PassphraseEnv || s.PassphraseFile != ""
+}
+
+// UsesFile reports whether the encrypted file keyring may be used: either it
+// is selected explicitly, or backend is auto and a passphrase is configured.
+func (s KeyringSettings) UsesFile() bool {
+	switch s.Backend {
+	case config.KeyringBackendFile:
+		return true
+	case config.KeyringBackendAuto:
+		return s.HasPassphrase()
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable name and location for the backend
+// credentials are stored in when the keyring is available.
+func (s KeyringSettings) Description() (string, string) {
+	switch {
+	case s.Backend == config.KeyringBackendFile:
+		return KeyringStorageEncryptedFile, s.FileDir
+	case s.Backend == config.KeyringBackendAuto && s.HasPassphrase() && !systemKeyringAvailable():
+		return KeyringStorageEncryptedFile, s.FileDir
+	case s.Backend == config.KeyringBackendAuto:
+		return "System Keychain", "system keychain"
+	default:
+		return fmt.Sprintf("System Keychain (%s)", s.Backend), "system keychain"
+	}
+}
+
+var systemKeyringAvailable = func() bool {
+	_, err := keyring.Open(keyringConfig(""))
+	return err == nil
+}
+
+// allowedBackends returns the keyring backends to try, in order.
+func (s KeyringSettings) allowedBackends() ([]keyring.BackendType, error) {
+	if s.Backend == config.KeyringBackendAuto {
+		backends := append([]keyring.BackendType{}, systemKeyringBackends...)
+		if s.HasPassphrase() {
+			backends = append(backends, keyring.FileBackend)
+		}
+		return backends, nil
+	}
+	backend, ok := keyringBackendTypes[s.Backend]
+	if !ok {
+		return nil, config.ValidateKeyringBackend(s.Backend)
+	}
+	if backend == keyring.FileBackend && !s.HasPassphrase() {
+		return nil, ErrKeyringPassphraseMissing
+	}
+	return []keyring.BackendType{backend}, nil
+}
+
+// passphrase returns the file keyring passphrase from ASC_KEYRING_PASSPHRASE
+// or the passphrase file. The file must not be readable by group or others.
+func (s KeyringSettings) passphrase() (string, error) {
+	if value := os.Getenv(keyringPassphraseEnv); value != "" {
+		return value, nil
+	}
+	if s.PassphraseFile == "" {
+		return "", ErrKeyringPassphraseMissing
+	}
+	info, err := os.Stat(s.PassphraseFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read keyring passphrase file: %w", err)
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		return "", fmt.Errorf("keyring passphrase file is too permissive; run: chmod 600 %q", s.PassphraseFile)
+	}
+	data, err := os.ReadFile(s.PassphraseFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read keyring passphrase file: %w", err)
+	}
+	value := strings.TrimRight(string(data), "\r\n")
+	if value == "" {
+		return "", fmt.Errorf("keyring passphrase file %s is empty", s.PassphraseFile)
+	}
+	return value, nil
+}
+
+// openKeyring opens the configured keyring. A non-empty keychainName selects
+// the legacy macOS keychain, which never resolves to the file backend.
+func openKeyring(keychainName string) (keyring.Keyring, error) {
+	settings := ResolveKeyringSettings()
+	backends, err := settings.allowedBackends()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := keyringConfig(keychainName)
+	cfg.AllowedBackends = backends
+	if keychainName != "" {
+		cfg.AllowedBackends = nil
+		for _, backend := range backends {
+			if backend != keyring.FileBackend {
+				cfg.AllowedBackends = append(cfg.AllowedBackends, backend)
+			}
+		}
+		if len(cfg.AllowedBackends) == 0 {
+			return nil, keyring.ErrNoAvailImpl
+		}
+	}
+	cfg.FileDir = settings.FileDir
+	cfg.FilePasswordFunc = func(string) (string, error) {
+		return settings.passphrase()
+	}
+	return keyring.Open(cfg)
+}
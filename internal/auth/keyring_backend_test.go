@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/99designs/keyring"
+)
+
+func setFileKeyringEnv(t *testing.T) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "keyring")
+	t.Setenv(keyringBackendEnv, "file")
+	t.Setenv(keyringDirEnv, dir)
+	t.Setenv(keyringPassphraseEnv, "correct horse")
+	t.Setenv(keyringPassphraseFileEnv, "")
+	return dir
+}
+
+func TestKeyringSettingsAllowedBackends(t *testing.T) {
+	auto := KeyringSettings{Backend: "auto"}
+	backends, err := auto.allowedBackends()
+	if err != nil || slices.Contains(backends, keyring.FileBackend) {
+		t.Fatalf("expected system backends only without a passphrase, got %v, %v", backends, err)
+	}
+
+	auto.PassphraseEnv = true
+	backends, err = auto.allowedBackends()
+	if err != nil || backends[len(backends)-1] != keyring.FileBackend {
+		t.Fatalf("expected file backend last with a passphrase, got %v, %v", backends, err)
+	}
+
+	if _, err := (KeyringSettings{Backend: "file"}).allowedBackends(); !errors.Is(err, ErrKeyringPassphraseMissing) {
+		t.Fatalf("expected ErrKeyringPassphraseMissing, got %v", err)
+	}
+	if _, err := (KeyringSettings{Backend: "plaintext"}).allowedBackends(); err == nil {
+		t.Fatal("expected error for unknown backend")
+	}
+	backends, err = (KeyringSettings{Backend: "keyctl"}).allowedBackends()
+	if err != nil || !slices.Equal(backends, []keyring.BackendType{keyring.KeyCtlBackend}) {
+		t.Fatalf("expected keyctl only, got %v, %v", backends, err)
+	}
+}
+
+func TestOpenKeyringFileBackendRoundTrip(t *testing.T) {
+	dir := setFileKeyringEnv(t)
+
+	kr, err := openKeyring("")
+	if err != nil {
+		t.Fatalf("openKeyring() error: %v", err)
+	}
+	if err := kr.Set(keyring.Item{Key: keyringKey("CI"), Data: []byte(`{"key_id":"KEY"}`)}); err != nil {
+		t.Fatalf("Set() error: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected one keyring file, got %v, %v", entries, err)
+	}
+	info, err := entries[0].Info()
+	if err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected 0600 keyring file, got %v, %v", info, err)
+	}
+
+	item, err := kr.Get(keyringKey("CI"))
+	if err != nil || string(item.Data) != `{"key_id":"KEY"}` {
+		t.Fatalf("Get() = %q, %v", item.Data, err)
+	}
+
+	t.Setenv(keyringPassphraseEnv, "wrong")
+	wrong, err := openKeyring("")
+	if err != nil {
+		t.Fatalf("openKeyring() error: %v", err)
+	}
+	if _, err := wrong.Get(keyringKey("CI")); err == nil {
+		t.Fatal("expected wrong passphrase to fail to decrypt")
+	}
+
+	if _, err := openKeyring(legacyKeychain); !errors.Is(err, keyring.ErrNoAvailImpl) {
+		t.Fatalf("expected legacy keychain to skip the file backend, got %v", err)
+	}
+}
+
+func TestKeyringPassphraseFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "passphrase")
+	if err := os.WriteFile(path, []byte("secret\n"), 0o644); err != nil {
+		t.Fatalf("write passphrase: %v", err)
+	}
+	t.Setenv(keyringPassphraseEnv, "")
+	settings := KeyringSettings{Backend: "file", PassphraseFile: path}
+
+	if _, err := settings.passphrase(); err == nil {
+		t.Fatal("expected error for group-readable passphrase file")
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		t.Fatalf("chmod: %v", err)
+	}
+	value, err := settings.passphrase()
+	if err != nil || value != "secret" {
+		t.Fatalf("passphrase() = %q, %v", value, err)
+	}
+}
+
+func TestDoctorFileKeyringChecks(t *testing.T) {
+	dir := setFileKeyringEnv(t)
+	passphrasePath := filepath.Join(t.TempDir(), "passphrase")
+	if err := os.WriteFile(passphrasePath, []byte("correct horse"), 0o644); err != nil {
+		t.Fatalf("write passphrase: %v", err)
+	}
+	kr, err := openKeyring("")
+	if err != nil {
+		t.Fatalf("openKeyring() error: %v", err)
+	}
+	if err := kr.Set(keyring.Item{Key: keyringKey("CI"), Data: []byte("{}")}); err != nil {
+		t.Fatalf("Set() error: %v", err)
+	}
+	if err := os.Chmod(dir, 0o755); err != nil {
+		t.Fatalf("chmod: %v", err)
+	}
+	t.Setenv(keyringPassphraseEnv, "")
+	t.Setenv(keyringPassphraseFileEnv, passphrasePath)
+
+	section := findDoctorSection(t, Doctor(DoctorOptions{}), "Storage")
+	if !sectionHasStatus(section, DoctorWarn, "Keyring passphrase file permissions are too permissive") {
+		t.Fatalf("expected passphrase file warning, got %#v", section.Checks)
+	}
+	if !sectionHasStatus(section, DoctorWarn, "Encrypted keyring directory permissions are too permissive") {
+		t.Fatalf("expected keyring directory warning, got %#v", section.Checks)
+	}
+
+	section = findDoctorSection(t, Doctor(DoctorOptions{Fix: true}), "Storage")
+	if !sectionHasStatus(section, DoctorOK, "Encrypted keyring unlocks") {
+		t.Fatalf("expected keyring to unlock after fixing permissions, got %#v", section.Checks)
+	}
+	info, err := os.Stat(dir)
+	if err != nil || info.Mode().Perm() != 0o700 {
+		t.Fatalf("expected keyring directory fixed to 0700, got %v, %v", info, err)
+	}
+}
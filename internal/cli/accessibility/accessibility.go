@@ -94,7 +94,10 @@ Examples:
 				return fmt.Errorf("accessibility list: %w", err)
 			}
 
-			resolvedAppID := shared.ResolveAppID(*appID)
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("accessibility list: %w", err)
+			}
 			if resolvedAppID == "" && strings.TrimSpace(*next) == "" {
 				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
 				return flag.ErrHelp
@@ -221,7 +224,10 @@ Examples:
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
-			resolvedAppID := shared.ResolveAppID(*appID)
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("accessibility create: %w", err)
+			}
 			if resolvedAppID == "" {
 				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
 				return flag.ErrHelp
@@ -103,7 +103,10 @@ Examples:
 		Exec: func(ctx context.Context, args []string) error {
 			appInfoValue := strings.TrimSpace(*appInfoID)
 			versionValue := strings.TrimSpace(*versionID)
-			appValue := strings.TrimSpace(shared.ResolveAppID(strings.TrimSpace(*appID)))
+			appValue, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("age-rating get: %w", err)
+			}
 
 			if appInfoValue != "" && versionValue != "" {
 				return fmt.Errorf("age-rating get: only one of --app-info-id or --version-id is allowed")
@@ -190,7 +193,10 @@ Examples:
 			idValue := strings.TrimSpace(*id)
 			appInfoValue := strings.TrimSpace(*appInfoID)
 			versionValue := strings.TrimSpace(*versionID)
-			appValue := strings.TrimSpace(shared.ResolveAppID(strings.TrimSpace(*appID)))
+			appValue, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("age-rating set: %w", err)
+			}
 
 			if idValue == "" {
 				if appInfoValue != "" && versionValue != "" {
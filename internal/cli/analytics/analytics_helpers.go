@@ -160,7 +160,31 @@ func matchAnalyticsInstanceDate(attrs asc.AnalyticsReportInstanceAttributes, dat
 	return strings.HasPrefix(attrs.ProcessingDate, date)
 }
 
-func fetchAnalyticsReports(ctx context.Context, client *asc.Client, requestID string, limit int, next string, paginate bool) ([]asc.Resource[asc.AnalyticsReportAttributes], asc.Links, error) {
+// ReportClient is the subset of the App Store Connect client used to walk
+// analytics reports, their instances and segments.
+type ReportClient interface {
+	GetAnalyticsReports(ctx context.Context, requestID string, opts ...asc.AnalyticsReportsOption) (*asc.AnalyticsReportsResponse, error)
+	GetAnalyticsReportInstances(ctx context.Context, reportID string, opts ...asc.AnalyticsReportInstancesOption) (*asc.AnalyticsReportInstancesResponse, error)
+	GetAnalyticsReportSegments(ctx context.Context, instanceID string, opts ...asc.AnalyticsReportSegmentsOption) (*asc.AnalyticsReportSegmentsResponse, error)
+}
+
+// FetchReports returns every report for an analytics report request.
+func FetchReports(ctx context.Context, client ReportClient, requestID string) ([]asc.Resource[asc.AnalyticsReportAttributes], error) {
+	reports, _, err := fetchAnalyticsReports(ctx, client, requestID, 0, "", true)
+	return reports, err
+}
+
+// FetchReportInstances returns every instance of an analytics report.
+func FetchReportInstances(ctx context.Context, client ReportClient, reportID string) ([]asc.Resource[asc.AnalyticsReportInstanceAttributes], error) {
+	return fetchAnalyticsReportInstances(ctx, client, reportID)
+}
+
+// FetchReportSegments returns every downloadable segment of a report instance.
+func FetchReportSegments(ctx context.Context, client ReportClient, instanceID string) ([]asc.Resource[asc.AnalyticsReportSegmentAttributes], error) {
+	return fetchAnalyticsReportSegments(ctx, client, instanceID)
+}
+
+func fetchAnalyticsReports(ctx context.Context, client ReportClient, requestID string, limit int, next string, paginate bool) ([]asc.Resource[asc.AnalyticsReportAttributes], asc.Links, error) {
 	var (
 		all   []asc.Resource[asc.AnalyticsReportAttributes]
 		links asc.Links
@@ -207,7 +231,7 @@ func fetchAnalyticsReports(ctx context.Context, client *asc.Client, requestID st
 	return all, links, nil
 }
 
-func fetchAnalyticsReportInstances(ctx context.Context, client *asc.Client, reportID string) ([]asc.Resource[asc.AnalyticsReportInstanceAttributes], error) {
+func fetchAnalyticsReportInstances(ctx context.Context, client ReportClient, reportID string) ([]asc.Resource[asc.AnalyticsReportInstanceAttributes], error) {
 	var (
 		all  []asc.Resource[asc.AnalyticsReportInstanceAttributes]
 		next string
@@ -237,7 +261,7 @@ func fetchAnalyticsReportInstances(ctx context.Context, client *asc.Client, repo
 	return all, nil
 }
 
-func fetchAnalyticsReportSegments(ctx context.Context, client *asc.Client, instanceID string) ([]asc.Resource[asc.AnalyticsReportSegmentAttributes], error) {
+func fetchAnalyticsReportSegments(ctx context.Context, client ReportClient, instanceID string) ([]asc.Resource[asc.AnalyticsReportSegmentAttributes], error) {
 	var (
 		all  []asc.Resource[asc.AnalyticsReportSegmentAttributes]
 		next string
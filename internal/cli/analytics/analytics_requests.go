@@ -33,7 +33,10 @@ Examples:
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
-			resolvedAppID := shared.ResolveAppID(*appID)
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("analytics request: %w", err)
+			}
 			if resolvedAppID == "" {
 				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
 				return flag.ErrHelp
@@ -128,7 +131,10 @@ Examples:
 				normalizedState = stateValue
 			}
 
-			resolvedAppID := shared.ResolveAppID(*appID)
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("analytics requests: %w", err)
+			}
 			if resolvedAppID == "" && strings.TrimSpace(*next) == "" && strings.TrimSpace(*requestID) == "" {
 				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
 				return flag.ErrHelp
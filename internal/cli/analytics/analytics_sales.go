@@ -29,17 +29,23 @@ func AnalyticsSalesCommand() *ffcli.Command {
 
 	return &ffcli.Command{
 		Name:       "sales",
-		ShortUsage: "asc analytics sales [flags]",
+		ShortUsage: "asc analytics sales [flags] | asc analytics sales summarize [flags]",
 		ShortHelp:  "Download sales and trends reports.",
 		LongHelp: `Download sales and trends reports.
 
+Use 'asc analytics sales summarize' to aggregate downloaded reports.
+
 Examples:
   asc analytics sales --vendor "12345678" --type SALES --subtype SUMMARY --frequency DAILY --date "2024-01-20"
   asc analytics sales --vendor "12345678" --type SUBSCRIPTION --subtype DETAILED --frequency MONTHLY --date "2024-01"
   asc analytics sales --vendor "12345678" --type SALES --subtype SUMMARY --frequency DAILY --date "2024-01-20" --decompress
-  asc analytics sales --vendor "12345678" --type SALES --subtype SUMMARY --frequency DAILY --date "2024-01-20" --output "reports/daily_sales.tsv.gz"`,
+  asc analytics sales --vendor "12345678" --type SALES --subtype SUMMARY --frequency DAILY --date "2024-01-20" --output "reports/daily_sales.tsv.gz"
+  asc analytics sales summarize --file "sales_report_2024-01-20_SALES.tsv.gz" --group-by sku,territory`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			AnalyticsSalesSummarizeCommand(),
+		},
 		Exec: func(ctx context.Context, args []string) error {
 			vendorNumber := shared.ResolveVendorNumber(*vendor)
 			if vendorNumber == "" {
@@ -0,0 +1,245 @@
+package analytics
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/salesreports"
+)
+
+type salesSummaryResult struct {
+	Files      []string `json:"files"`
+	RatesFiles []string `json:"ratesFiles,omitempty"`
+	*salesreports.Summary
+}
+
+// AnalyticsSalesSummarizeCommand aggregates downloaded sales reports.
+func AnalyticsSalesSummarizeCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("summarize", flag.ExitOnError)
+
+	files := fs.String("file", "", "Sales report file(s), comma-separated (.tsv or .tsv.gz)")
+	rates := fs.String("rates", "", "Finance report file(s) with exchange rates, comma-separated (.tsv or .tsv.gz)")
+	groupBy := fs.String("group-by", salesreports.GroupBySKU, "Group by: "+strings.Join(salesreports.GroupByValues, ", ")+" (comma-separated)")
+	from := fs.String("from", "", "Only include rows that begin on or after this date (YYYY-MM-DD)")
+	to := fs.String("to", "", "Only include rows that begin on or before this date (YYYY-MM-DD)")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "summarize",
+		ShortUsage: "asc analytics sales summarize --file REPORT[,REPORT...] [flags]",
+		ShortHelp:  "Aggregate units, proceeds and customer price from sales reports.",
+		LongHelp: `Aggregate units, proceeds and customer price from sales reports.
+
+Reads sales and trends reports downloaded with 'asc analytics sales' (gzip
+or decompressed), in either the SUMMARY or DETAILED layout, and totals units,
+developer proceeds and customer price per group. Per-unit amounts are
+multiplied by units before they are summed.
+
+Without --rates, groups are split by proceeds and customer currency. With
+--rates, every amount is converted into the bank account currency using the
+exchange rates in the given finance reports ('asc finance reports'). When
+several finance reports list the same currency, the last one wins.
+
+Examples:
+  asc analytics sales summarize --file sales_report_2024-01-20_SALES.tsv.gz
+  asc analytics sales summarize --file "jan.tsv.gz,feb.tsv.gz" --group-by sku,territory --output table
+  asc analytics sales summarize --file "reports/daily.tsv" --group-by product-type,date --from 2024-01-01 --to 2024-01-31
+  asc analytics sales summarize --file "jan.tsv.gz" --rates "finance_report_2024-01_FINANCE_DETAIL_Z1.tsv.gz"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			reportPaths := shared.SplitCSV(*files)
+			if len(reportPaths) == 0 {
+				fmt.Fprintln(os.Stderr, "Error: --file is required")
+				return flag.ErrHelp
+			}
+			dimensions, err := salesreports.NormalizeGroupBy(shared.SplitCSV(*groupBy))
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+			fromDate, err := parseSummaryDate(*from, "--from")
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+			toDate, err := parseSummaryDate(*to, "--to")
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+			if !fromDate.IsZero() && !toDate.IsZero() && toDate.Before(fromDate) {
+				return shared.UsageError("--to must not be before --from")
+			}
+
+			rows, err := readSalesReports(reportPaths)
+			if err != nil {
+				return fmt.Errorf("analytics sales summarize: %w", err)
+			}
+
+			ratesPaths := shared.SplitCSV(*rates)
+			var exchangeRates *salesreports.ExchangeRates
+			if len(ratesPaths) > 0 {
+				exchangeRates, err = readExchangeRates(ratesPaths)
+				if err != nil {
+					return fmt.Errorf("analytics sales summarize: %w", err)
+				}
+			}
+
+			summary, err := salesreports.Summarize(rows, salesreports.Options{
+				GroupBy: dimensions,
+				From:    fromDate,
+				To:      toDate,
+				Rates:   exchangeRates,
+			})
+			if err != nil {
+				return fmt.Errorf("analytics sales summarize: %w", err)
+			}
+
+			result := &salesSummaryResult{Files: reportPaths, RatesFiles: ratesPaths, Summary: summary}
+			return shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderSalesSummary(result, asc.RenderTable) },
+				func() error { return renderSalesSummary(result, asc.RenderMarkdown) },
+			)
+		},
+	}
+}
+
+func parseSummaryDate(value, flagName string) (time.Time, error) {
+	if strings.TrimSpace(value) == "" {
+		return time.Time{}, nil
+	}
+	normalized, err := shared.NormalizeDate(value, flagName)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse("2006-01-02", normalized)
+}
+
+func readSalesReports(paths []string) ([]salesreports.Row, error) {
+	rows := make([]salesreports.Row, 0)
+	for _, path := range paths {
+		reader, err := salesreports.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := salesreports.ParseSales(reader)
+		_ = reader.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		rows = append(rows, parsed...)
+	}
+	return rows, nil
+}
+
+func readExchangeRates(paths []string) (*salesreports.ExchangeRates, error) {
+	merged := &salesreports.ExchangeRates{}
+	for _, path := range paths {
+		reader, err := salesreports.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		rates, err := salesreports.ParseExchangeRates(reader)
+		_ = reader.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if err := merged.Merge(rates); err != nil {
+			return nil, err
+		}
+	}
+	return merged, nil
+}
+
+func renderSalesSummary(result *salesSummaryResult, render func([]string, [][]string)) error {
+	if result == nil || result.Summary == nil {
+		return fmt.Errorf("summary is nil")
+	}
+	summary := result.Summary
+	converted := summary.Currency != ""
+
+	headers := make([]string, 0, len(summary.GroupBy)+6)
+	for _, dimension := range summary.GroupBy {
+		switch dimension {
+		case salesreports.GroupBySKU:
+			headers = append(headers, "SKU", "Title")
+		case salesreports.GroupByTerritory:
+			headers = append(headers, "Territory")
+		case salesreports.GroupByProductType:
+			headers = append(headers, "Product Type")
+		case salesreports.GroupByDate:
+			headers = append(headers, "Date")
+		}
+	}
+	if converted {
+		headers = append(headers, "Units", "Proceeds ("+summary.Currency+")", "Customer Price ("+summary.Currency+")")
+	} else {
+		headers = append(headers, "Units", "Proceeds", "Proceeds Currency", "Customer Price", "Customer Currency")
+	}
+
+	rows := make([][]string, 0, len(summary.Groups)+1)
+	for _, group := range summary.Groups {
+		row := make([]string, 0, len(headers))
+		for _, dimension := range summary.GroupBy {
+			switch dimension {
+			case salesreports.GroupBySKU:
+				row = append(row, group.SKU, group.Title)
+			case salesreports.GroupByTerritory:
+				row = append(row, group.Territory)
+			case salesreports.GroupByProductType:
+				row = append(row, group.ProductType)
+			case salesreports.GroupByDate:
+				row = append(row, group.Date)
+			}
+		}
+		row = append(row, strconv.FormatInt(group.Units, 10), formatAmount(group.Proceeds))
+		if converted {
+			row = append(row, formatAmount(group.CustomerPrice))
+		} else {
+			row = append(row, group.ProceedsCurrency, formatAmount(group.CustomerPrice), group.CustomerCurrency)
+		}
+		rows = append(rows, row)
+	}
+
+	unitsColumn := len(headers) - 3
+	if !converted {
+		unitsColumn = len(headers) - 5
+	}
+	total := make([]string, len(headers))
+	if unitsColumn > 0 {
+		total[0] = "Total"
+	}
+	total[unitsColumn] = strconv.FormatInt(summary.Totals.Units, 10)
+	if summary.Totals.Proceeds != nil {
+		total[unitsColumn+1] = formatAmount(*summary.Totals.Proceeds)
+		if !converted {
+			total[unitsColumn+2] = summary.Groups[0].ProceedsCurrency
+		}
+	}
+	if summary.Totals.CustomerPrice != nil {
+		if converted {
+			total[unitsColumn+2] = formatAmount(*summary.Totals.CustomerPrice)
+		} else {
+			total[unitsColumn+3] = formatAmount(*summary.Totals.CustomerPrice)
+			total[unitsColumn+4] = summary.Groups[0].CustomerCurrency
+		}
+	}
+	rows = append(rows, total)
+
+	render(headers, rows)
+	return nil
+}
+
+func formatAmount(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
@@ -0,0 +1,333 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/jsonquery"
+)
+
+var httpMethods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodPost:   true,
+	http.MethodPatch:  true,
+	http.MethodPut:    true,
+	http.MethodDelete: true,
+	http.MethodHead:   true,
+}
+
+// APICommand returns the api command.
+func APICommand() *ffcli.Command {
+	fs := flag.NewFlagSet("api", flag.ExitOnError)
+
+	var fields []field
+	method := fs.String("method", "", "HTTP method (default GET, or POST when fields or --input are given)")
+	fs.StringVar(method, "X", "", "Shorthand for --method")
+	fs.Var(fieldFlag{fields: &fields}, "raw-field", "String field as key=value (repeatable)")
+	fs.Var(fieldFlag{fields: &fields}, "f", "Shorthand for --raw-field")
+	fs.Var(fieldFlag{fields: &fields, typed: true}, "field", "Typed field as key=value: true, false, null, integers and @file are converted (repeatable)")
+	fs.Var(fieldFlag{fields: &fields, typed: true}, "F", "Shorthand for --field")
+	input := fs.String("input", "", "Read the request body from a file (- for stdin)")
+	include := fs.String("include", "", "Comma-separated relationships to include")
+	var filters pairFlag
+	fs.Var(&filters, "filter", "Filter as key=value, sent as filter[key]=value (repeatable)")
+	limit := fs.Int("limit", 0, "Maximum resources per page (1-200)")
+	paginate := fs.Bool("paginate", false, "Fetch all pages of a GET collection and merge data and included")
+	query := fs.String("query", "", "Filter the JSON response with a jq-style expression (e.g. '.data[].id')")
+	pretty := shared.BindPrettyJSONFlag(fs)
+
+	return &ffcli.Command{
+		Name:       "api",
+		ShortUsage: "asc api [METHOD] <path> [flags]",
+		ShortHelp:  "Make an authenticated App Store Connect API request.",
+		LongHelp: `Make an authenticated App Store Connect API request.
+
+The path is relative to the API base URL (e.g. /v1/apps). Requests use the
+configured credentials, retry GET requests on rate limits and honor --debug
+and --api-debug logging with secrets redacted.
+
+Fields set with -f (strings) or -F (typed) become query parameters for GET,
+HEAD and DELETE requests. For other methods they build the JSON body, with
+dotted keys creating nested objects and a trailing [] appending to an array.
+With --input, the body is read from the file and fields become query
+parameters.
+
+--paginate follows links.next for GET collection endpoints and merges data
+and included from every page. --query filters the JSON response with a jq
+subset (paths, |, select, map, length, keys, has, contains, comparisons);
+string results print without quotes.
+
+Examples:
+  asc api /v1/apps --limit 5
+  asc api GET /v1/apps/APP_ID/appStoreVersions --filter platform=IOS --include build
+  asc api /v1/apps --paginate --query '.data[] | {id, name: .attributes.name}'
+  asc api GET /v1/builds -f 'filter[app]=APP_ID' -f 'sort=-uploadedDate' --query '.data[0].id'
+  asc api PATCH /v1/apps/APP_ID -f data.type=apps -f data.id=APP_ID -f data.attributes.primaryLocale=en-US
+  asc api POST /v1/betaGroups --input group.json
+  asc api DELETE /v1/betaGroups/GROUP_ID`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			positional, err := parseInterspersed(fs, args)
+			if err != nil {
+				return err
+			}
+
+			requestMethod, path, err := resolveMethodAndPath(positional, *method)
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+			inputValue := strings.TrimSpace(*input)
+			if requestMethod == "" {
+				requestMethod = http.MethodGet
+				if len(fields) > 0 || inputValue != "" {
+					requestMethod = http.MethodPost
+				}
+			}
+			if *limit != 0 && (*limit < 1 || *limit > 200) {
+				return shared.UsageError("--limit must be between 1 and 200")
+			}
+			if *paginate && requestMethod != http.MethodGet {
+				return shared.UsageError("--paginate requires a GET request")
+			}
+			if inputValue != "" && !methodHasBody(requestMethod) {
+				return shared.UsageErrorf("--input cannot be used with %s requests", requestMethod)
+			}
+
+			var filter *jsonquery.Query
+			if strings.TrimSpace(*query) != "" {
+				filter, err = jsonquery.Parse(*query)
+				if err != nil {
+					return shared.UsageErrorf("--query: %v", err)
+				}
+			}
+
+			fieldsInQuery := !methodHasBody(requestMethod) || inputValue != ""
+			target, err := buildTarget(path, *include, filters.pairs, *limit, fields, fieldsInQuery)
+			if err != nil {
+				return fmt.Errorf("api: %w", err)
+			}
+
+			var body io.Reader
+			switch {
+			case inputValue != "":
+				data, err := readInput(inputValue)
+				if err != nil {
+					return fmt.Errorf("api: --input: %w", err)
+				}
+				body = bytes.NewReader(data)
+			case len(fields) > 0 && !fieldsInQuery:
+				payload, err := buildBody(fields, os.Stdin)
+				if err != nil {
+					return fmt.Errorf("api: %w", err)
+				}
+				data, err := json.Marshal(payload)
+				if err != nil {
+					return fmt.Errorf("api: %w", err)
+				}
+				body = bytes.NewReader(data)
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("api: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			var response []byte
+			if *paginate {
+				response, err = fetchAllPages(requestCtx, client, target)
+			} else {
+				response, err = client.Request(requestCtx, requestMethod, target, body)
+			}
+			if err != nil {
+				return fmt.Errorf("api: %w", err)
+			}
+
+			return printResponse(response, filter, *pretty)
+		},
+	}
+}
+
+// parseInterspersed allows flags after the positional method and path, as in
+// "asc api GET /v1/apps --paginate".
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for len(args) > 0 {
+		if strings.HasPrefix(args[0], "-") && args[0] != "-" {
+			if args[0] == "--" {
+				positional = append(positional, args[1:]...)
+				break
+			}
+			if err := fs.Parse(args); err != nil {
+				return nil, err
+			}
+			args = fs.Args()
+			continue
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+	return positional, nil
+}
+
+func resolveMethodAndPath(positional []string, methodFlag string) (string, string, error) {
+	method := strings.ToUpper(strings.TrimSpace(methodFlag))
+	var path string
+	switch len(positional) {
+	case 1:
+		path = positional[0]
+	case 2:
+		positionalMethod := strings.ToUpper(positional[0])
+		if !httpMethods[positionalMethod] {
+			return "", "", fmt.Errorf("unknown HTTP method %q", positional[0])
+		}
+		if method != "" && method != positionalMethod {
+			return "", "", fmt.Errorf("method %s conflicts with --method %s", positionalMethod, method)
+		}
+		method = positionalMethod
+		path = positional[1]
+	case 0:
+		return "", "", fmt.Errorf("path is required (e.g. /v1/apps)")
+	default:
+		return "", "", fmt.Errorf("unexpected arguments: %s", strings.Join(positional[2:], " "))
+	}
+	if method != "" && !httpMethods[method] {
+		return "", "", fmt.Errorf("--method must be one of: GET, POST, PATCH, PUT, DELETE, HEAD")
+	}
+
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", "", fmt.Errorf("path is required (e.g. /v1/apps)")
+	}
+	if !strings.HasPrefix(path, "/") && !strings.HasPrefix(path, "https://") && !strings.HasPrefix(path, "http://") {
+		path = "/" + path
+	}
+	return method, path, nil
+}
+
+func methodHasBody(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
+		return false
+	default:
+		return true
+	}
+}
+
+// buildTarget adds the query helpers to path, keeping any query string the
+// caller already wrote.
+func buildTarget(path, include string, filters [][2]string, limit int, fields []field, fieldsInQuery bool) (string, error) {
+	base, rawQuery, _ := strings.Cut(path, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", fmt.Errorf("invalid query string: %w", err)
+	}
+	if values := shared.SplitCSV(include); len(values) > 0 {
+		query.Set("include", strings.Join(values, ","))
+	}
+	for _, filter := range filters {
+		query.Add("filter["+filter[0]+"]", filter[1])
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	if fieldsInQuery {
+		if err := addQueryFields(query, fields, os.Stdin); err != nil {
+			return "", err
+		}
+	}
+	if len(query) == 0 {
+		return base, nil
+	}
+	return base + "?" + query.Encode(), nil
+}
+
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
+
+// fetchAllPages follows links.next and returns a single document with the
+// data and included resources of every page.
+func fetchAllPages(ctx context.Context, client *asc.Client, target string) ([]byte, error) {
+	var (
+		included []json.RawMessage
+		first    *asc.RawListResponse
+	)
+	fetch := func(ctx context.Context, path string) (asc.PaginatedResponse, error) {
+		page, err := client.GetRawList(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		if first == nil {
+			first = page
+		}
+		included = append(included, page.Included...)
+		return page, nil
+	}
+
+	// The merged document is printed as JSON, so pages are aggregated even
+	// when NDJSON streaming is the default output.
+	var result asc.PaginatedResponse
+	err := shared.WithSpinner("", func() error {
+		firstPage, err := fetch(ctx, target)
+		if err != nil {
+			return err
+		}
+		result, err = asc.PaginateAll(ctx, firstPage, fetch)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	merged, ok := result.(*asc.RawListResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected paginated response type %T", result)
+	}
+	if merged.Data == nil {
+		merged.Data = []json.RawMessage{}
+	}
+	merged.Included = included
+	if first != nil {
+		merged.Links = asc.Links{Self: first.Links.Self}
+		merged.Meta = first.Meta
+	}
+	return json.Marshal(merged)
+}
+
+func printResponse(response []byte, filter *jsonquery.Query, pretty bool) error {
+	if len(bytes.TrimSpace(response)) == 0 {
+		return nil
+	}
+	if !json.Valid(response) {
+		_, err := os.Stdout.Write(response)
+		return err
+	}
+	if filter != nil {
+		results, err := filter.RunJSON(response)
+		if err != nil {
+			return fmt.Errorf("api: --query: %w", err)
+		}
+		return jsonquery.WriteResults(os.Stdout, results, pretty)
+	}
+	return shared.PrintOutput(json.RawMessage(response), "json", pretty)
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// field is a single -f/-F key=value pair.
+type field struct {
+	key   string
+	value string
+	typed bool
+}
+
+// fieldFlag collects repeatable -f (raw) and -F (typed) fields into a shared
+// list so their order is preserved.
+type fieldFlag struct {
+	fields *[]field
+	typed  bool
+}
+
+func (f fieldFlag) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return fmt.Errorf("must be in the form key=value")
+	}
+	*f.fields = append(*f.fields, field{key: key, value: val, typed: f.typed})
+	return nil
+}
+
+func (f fieldFlag) String() string {
+	if f.fields == nil {
+		return ""
+	}
+	keys := make([]string, 0, len(*f.fields))
+	for _, fld := range *f.fields {
+		if fld.typed == f.typed {
+			keys = append(keys, fld.key)
+		}
+	}
+	return strings.Join(keys, ",")
+}
+
+// pairFlag collects repeatable key=value flags such as --filter.
+type pairFlag struct {
+	pairs [][2]string
+}
+
+func (p *pairFlag) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return fmt.Errorf("must be in the form key=value")
+	}
+	p.pairs = append(p.pairs, [2]string{key, val})
+	return nil
+}
+
+func (p *pairFlag) String() string {
+	if p == nil {
+		return ""
+	}
+	keys := make([]string, 0, len(p.pairs))
+	for _, pair := range p.pairs {
+		keys = append(keys, pair[0])
+	}
+	return strings.Join(keys, ",")
+}
+
+// resolveFieldValue returns the JSON value for a field. -f values are always
+// strings; -F values convert true, false, null and integers, and read
+// "@path" (or "@-" for stdin) as a string.
+func resolveFieldValue(fld field, stdin io.Reader) (any, error) {
+	if !fld.typed {
+		return fld.value, nil
+	}
+	switch fld.value {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if n, err := strconv.ParseInt(fld.value, 10, 64); err == nil {
+		return n, nil
+	}
+	if path, ok := strings.CutPrefix(fld.value, "@"); ok {
+		var (
+			data []byte
+			err  error
+		)
+		if path == "-" {
+			data, err = io.ReadAll(stdin)
+		} else {
+			data, err = os.ReadFile(path)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", fld.key, err)
+		}
+		return string(data), nil
+	}
+	return fld.value, nil
+}
+
+// buildBody turns fields into a nested JSON object. Dotted keys create nested
+// objects (data.attributes.name=X) and a trailing [] appends to an array
+// (data.relationships.builds.data[]=...).
+func buildBody(fields []field, stdin io.Reader) (map[string]any, error) {
+	body := map[string]any{}
+	for _, fld := range fields {
+		value, err := resolveFieldValue(fld, stdin)
+		if err != nil {
+			return nil, err
+		}
+		if err := setBodyField(body, fld.key, value); err != nil {
+			return nil, err
+		}
+	}
+	return body, nil
+}
+
+func setBodyField(body map[string]any, key string, value any) error {
+	parts := strings.Split(key, ".")
+	for _, part := range parts {
+		if part == "" || part == "[]" {
+			return fmt.Errorf("field %q has an empty path segment", key)
+		}
+	}
+
+	current := body
+	for _, part := range parts[:len(parts)-1] {
+		existing, ok := current[part]
+		if !ok {
+			next := map[string]any{}
+			current[part] = next
+			current = next
+			continue
+		}
+		next, ok := existing.(map[string]any)
+		if !ok {
+			return fmt.Errorf("field %q conflicts with an earlier field", key)
+		}
+		current = next
+	}
+
+	last := parts[len(parts)-1]
+	if name, ok := strings.CutSuffix(last, "[]"); ok {
+		existing, exists := current[name]
+		values, isArray := existing.([]any)
+		if exists && !isArray {
+			return fmt.Errorf("field %q conflicts with an earlier field", key)
+		}
+		current[name] = append(values, value)
+		return nil
+	}
+	if _, exists := current[last]; exists {
+		return fmt.Errorf("field %q is set more than once", key)
+	}
+	current[last] = value
+	return nil
+}
+
+// addQueryFields adds fields as query parameters, keeping keys such as
+// filter[platform] verbatim.
+func addQueryFields(query url.Values, fields []field, stdin io.Reader) error {
+	for _, fld := range fields {
+		value, err := resolveFieldValue(fld, stdin)
+		if err != nil {
+			return err
+		}
+		if value == nil {
+			value = ""
+		}
+		query.Add(fld.key, fmt.Sprint(value))
+	}
+	return nil
+}
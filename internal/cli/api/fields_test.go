@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildBodyNestsDottedKeys(t *testing.T) {
+	fields := []field{
+		{key: "data.type", value: "builds"},
+		{key: "data.attributes.usesNonExemptEncryption", value: "false", typed: true},
+		{key: "data.attributes.count", value: "3", typed: true},
+		{key: "data.tags[]", value: "a"},
+		{key: "data.tags[]", value: "b"},
+	}
+	body, err := buildBody(fields, strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("buildBody() error: %v", err)
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	want := `{"data":{"attributes":{"count":3,"usesNonExemptEncryption":false},"tags":["a","b"],"type":"builds"}}`
+	if string(data) != want {
+		t.Fatalf("buildBody() = %s, want %s", data, want)
+	}
+}
+
+func TestBuildBodyRejectsConflicts(t *testing.T) {
+	tests := [][]field{
+		{{key: "data", value: "x"}, {key: "data.type", value: "apps"}},
+		{{key: "data.type", value: "apps"}, {key: "data.type", value: "builds"}},
+		{{key: "data..type", value: "apps"}},
+	}
+	for _, fields := range tests {
+		if _, err := buildBody(fields, strings.NewReader("")); err == nil {
+			t.Errorf("buildBody(%+v) expected error", fields)
+		}
+	}
+}
+
+func TestResolveFieldValueReadsFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(path, []byte("What's new"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	value, err := resolveFieldValue(field{key: "whatsNew", value: "@" + path, typed: true}, strings.NewReader(""))
+	if err != nil || value != "What's new" {
+		t.Fatalf("resolveFieldValue() = %v, %v", value, err)
+	}
+	value, err = resolveFieldValue(field{key: "whatsNew", value: "@" + path}, strings.NewReader(""))
+	if err != nil || value != "@"+path {
+		t.Fatalf("raw fields must not read files, got %v, %v", value, err)
+	}
+}
+
+func TestBuildTargetKeepsExistingQuery(t *testing.T) {
+	target, err := buildTarget("/v1/builds?sort=-uploadedDate", "app, buildBetaDetail", [][2]string{{"app", "APP_1"}}, 10,
+		[]field{{key: "fields[builds]", value: "version"}}, true)
+	if err != nil {
+		t.Fatalf("buildTarget() error: %v", err)
+	}
+	path, rawQuery, _ := strings.Cut(target, "?")
+	query, _ := url.ParseQuery(rawQuery)
+	if path != "/v1/builds" || query.Get("sort") != "-uploadedDate" || query.Get("include") != "app,buildBetaDetail" ||
+		query.Get("filter[app]") != "APP_1" || query.Get("limit") != "10" || query.Get("fields[builds]") != "version" {
+		t.Fatalf("unexpected target %q", target)
+	}
+}
@@ -79,7 +79,10 @@ Examples:
 				return fmt.Errorf("app-events list: %w", err)
 			}
 
-			resolvedAppID := shared.ResolveAppID(*appID)
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("app-events list: %w", err)
+			}
 			if resolvedAppID == "" && strings.TrimSpace(*next) == "" {
 				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
 				return flag.ErrHelp
@@ -196,7 +199,10 @@ Examples:
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
-			resolvedAppID := shared.ResolveAppID(*appID)
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("app-events create: %w", err)
+			}
 			if resolvedAppID == "" {
 				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
 				return flag.ErrHelp
@@ -259,7 +259,10 @@ Examples:
 
 			appClipValue := strings.TrimSpace(*appClipID)
 			bundleValue := strings.TrimSpace(*bundleID)
-			appValue := strings.TrimSpace(shared.ResolveAppID(*appID))
+			appValue, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("app-clips advanced-experiences create: %w", err)
+			}
 			if appClipValue == "" && bundleValue == "" {
 				fmt.Fprintln(os.Stderr, "Error: --app-clip-id or --bundle-id is required")
 				return flag.ErrHelp
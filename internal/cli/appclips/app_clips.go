@@ -81,7 +81,10 @@ Examples:
 				return fmt.Errorf("app-clips list: %w", err)
 			}
 
-			appValue := strings.TrimSpace(shared.ResolveAppID(*appID))
+			appValue, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("app-clips list: %w", err)
+			}
 			if appValue == "" {
 				fmt.Fprintln(os.Stderr, "Error: --app is required")
 				return flag.ErrHelp
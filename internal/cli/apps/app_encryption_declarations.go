@@ -85,7 +85,10 @@ Examples:
 				return fmt.Errorf("apps app-encryption-declarations list: %w", err)
 			}
 
-			resolvedAppID := shared.ResolveAppID(*appID)
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("apps app-encryption-declarations list: %w", err)
+			}
 			if resolvedAppID == "" && strings.TrimSpace(*next) == "" {
 				fmt.Fprintln(os.Stderr, "Error: --id is required (or set ASC_APP_ID)")
 				return flag.ErrHelp
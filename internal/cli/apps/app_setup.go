@@ -454,7 +454,10 @@ Examples:
 
 				return shared.PrintOutput(&result, *output.Output, *output.Pretty)
 			case shared.LocalizationTypeAppInfo:
-				resolvedAppID := shared.ResolveAppID(*appID)
+				resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+				if err != nil {
+					return fmt.Errorf("app-setup localizations upload: %w", err)
+				}
 				if resolvedAppID == "" {
 					fmt.Fprintln(os.Stderr, "Error: --app is required for app-info localizations")
 					return flag.ErrHelp
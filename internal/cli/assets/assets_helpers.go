@@ -30,6 +30,12 @@ func ContextWithAssetUploadTimeout(ctx context.Context) (context.Context, contex
 	return contextWithAssetUploadTimeout(ctx)
 }
 
+// CollectAssetFiles returns the file at path, or the files in the directory
+// at path in name order.
+func CollectAssetFiles(path string) ([]string, error) {
+	return collectAssetFiles(path)
+}
+
 func collectAssetFiles(path string) ([]string, error) {
 	info, err := os.Lstat(path)
 	if err != nil {
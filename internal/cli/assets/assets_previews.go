@@ -623,21 +623,29 @@ func uploadPreviewAsset(ctx context.Context, client *asc.Client, setID, filePath
 		return asc.AssetUploadResultItem{}, err
 	}
 
-	created, err := client.CreateAppPreview(ctx, setID, info.Name(), info.Size(), mimeType)
+	checkpointID := asc.AssetUploadCheckpointID("appPreviewSets/"+setID, info.Name(), info.Size())
+	created, err := resumablePreviewReservation(ctx, client, checkpointID)
 	if err != nil {
 		return asc.AssetUploadResultItem{}, err
 	}
+	if created == nil {
+		created, err = client.CreateAppPreview(ctx, setID, info.Name(), info.Size(), mimeType)
+		if err != nil {
+			return asc.AssetUploadResultItem{}, err
+		}
+	}
 	if len(created.Data.Attributes.UploadOperations) == 0 {
 		return asc.AssetUploadResultItem{}, fmt.Errorf("no upload operations returned for %q", info.Name())
 	}
 
-	if err := asc.UploadAssetFromFile(ctx, file, info.Size(), created.Data.Attributes.UploadOperations); err != nil {
+	if err := asc.UploadAssetFromFile(ctx, file, info.Size(), created.Data.Attributes.UploadOperations, asc.WithAssetUploadCheckpoint(checkpointID, created.Data.ID)); err != nil {
 		return asc.AssetUploadResultItem{}, err
 	}
 
 	if _, err := client.UpdateAppPreview(ctx, created.Data.ID, true, checksum.Hash); err != nil {
 		return asc.AssetUploadResultItem{}, err
 	}
+	_ = asc.RemoveUploadCheckpoint(checkpointID)
 
 	state, err := waitForPreviewDelivery(ctx, client, created.Data.ID)
 	if err != nil {
@@ -676,3 +684,27 @@ func waitForPreviewDelivery(ctx context.Context, client *asc.Client, previewID s
 		return resp.Data.Attributes.AssetDeliveryState, nil
 	})
 }
+
+// resumablePreviewReservation returns the preview reservation recorded under
+// checkpointID by an interrupted upload when it still awaits its file, or nil.
+func resumablePreviewReservation(ctx context.Context, client *asc.Client, checkpointID string) (*asc.AppPreviewResponse, error) {
+	reservationID := asc.AssetUploadReservation(checkpointID)
+	if reservationID == "" {
+		return nil, nil
+	}
+	resp, err := client.GetAppPreview(ctx, reservationID)
+	if err != nil {
+		if asc.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	state := ""
+	if resp.Data.Attributes.AssetDeliveryState != nil {
+		state = resp.Data.Attributes.AssetDeliveryState.State
+	}
+	if !asc.AssetReservationResumable(state, resp.Data.Attributes.UploadOperations) {
+		return nil, nil
+	}
+	return resp, nil
+}
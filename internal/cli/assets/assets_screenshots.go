@@ -616,6 +616,12 @@ Examples:
 	}
 }
 
+// NormalizeScreenshotDisplayType validates a screenshot display type, adding
+// the APP_ prefix when it is omitted (IPHONE_65 is APP_IPHONE_65).
+func NormalizeScreenshotDisplayType(input string) (string, error) {
+	return normalizeScreenshotDisplayType(input)
+}
+
 func normalizeScreenshotDisplayType(input string) (string, error) {
 	value := strings.ToUpper(strings.TrimSpace(input))
 	if value == "" {
@@ -682,21 +688,29 @@ func uploadScreenshotAsset(ctx context.Context, client *asc.Client, setID, fileP
 		return asc.AssetUploadResultItem{}, err
 	}
 
-	created, err := client.CreateAppScreenshot(ctx, setID, info.Name(), info.Size())
+	checkpointID := asc.AssetUploadCheckpointID("appScreenshotSets/"+setID, info.Name(), info.Size())
+	created, err := resumableScreenshotReservation(ctx, client, checkpointID)
 	if err != nil {
 		return asc.AssetUploadResultItem{}, err
 	}
+	if created == nil {
+		created, err = client.CreateAppScreenshot(ctx, setID, info.Name(), info.Size())
+		if err != nil {
+			return asc.AssetUploadResultItem{}, err
+		}
+	}
 	if len(created.Data.Attributes.UploadOperations) == 0 {
 		return asc.AssetUploadResultItem{}, fmt.Errorf("no upload operations returned for %q", info.Name())
 	}
 
-	if err := asc.UploadAssetFromFile(ctx, file, info.Size(), created.Data.Attributes.UploadOperations); err != nil {
+	if err := asc.UploadAssetFromFile(ctx, file, info.Size(), created.Data.Attributes.UploadOperations, asc.WithAssetUploadCheckpoint(checkpointID, created.Data.ID)); err != nil {
 		return asc.AssetUploadResultItem{}, err
 	}
 
 	if _, err := client.UpdateAppScreenshot(ctx, created.Data.ID, true, checksum.Hash); err != nil {
 		return asc.AssetUploadResultItem{}, err
 	}
+	_ = asc.RemoveUploadCheckpoint(checkpointID)
 
 	state, err := waitForScreenshotDelivery(ctx, client, created.Data.ID)
 	if err != nil {
@@ -725,3 +739,27 @@ func waitForScreenshotDelivery(ctx context.Context, client *asc.Client, screensh
 		return resp.Data.Attributes.AssetDeliveryState, nil
 	})
 }
+
+// resumableScreenshotReservation returns the screenshot reservation recorded under
+// checkpointID by an interrupted upload when it still awaits its file, or nil.
+func resumableScreenshotReservation(ctx context.Context, client *asc.Client, checkpointID string) (*asc.AppScreenshotResponse, error) {
+	reservationID := asc.AssetUploadReservation(checkpointID)
+	if reservationID == "" {
+		return nil, nil
+	}
+	resp, err := client.GetAppScreenshot(ctx, reservationID)
+	if err != nil {
+		if asc.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	state := ""
+	if resp.Data.Attributes.AssetDeliveryState != nil {
+		state = resp.Data.Attributes.AssetDeliveryState.State
+	}
+	if !asc.AssetReservationResumable(state, resp.Data.Attributes.UploadOperations) {
+		return nil, nil
+	}
+	return resp, nil
+}
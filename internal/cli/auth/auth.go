@@ -385,6 +385,9 @@ func loginStorageMessage(bypassKeychain, local bool) (string, error) {
 		return "", err
 	}
 	if keychainAvailable {
+		if backend, location := authsvc.ResolveKeyringSettings().Description(); backend == authsvc.KeyringStorageEncryptedFile {
+			return fmt.Sprintf("Storing credentials in encrypted file keyring at %s", location), nil
+		}
 		return "Storing credentials in system keychain", nil
 	}
 	path, err := config.Path()
@@ -418,6 +421,11 @@ with a local config fallback (restricted permissions). Use --bypass-keychain to
 explicitly bypass keychain and write credentials to ~/.asc/config.json instead.
 Add --local to write ./.asc/config.json for the current repo.
 
+On machines without a system keychain (such as headless Linux CI), set
+ASC_KEYRING_BACKEND=file (or keyring_backend in config.json) and
+ASC_KEYRING_PASSPHRASE or ASC_KEYRING_PASSPHRASE_FILE to store credentials in
+an encrypted file keyring under ~/.asc/keyring (override with ASC_KEYRING_DIR).
+
 Examples:
   asc auth login --name "MyKey" --key-id "ABC123" --issuer-id "DEF456" --private-key /path/to/AuthKey.p8
   asc auth login --bypass-keychain --local --name "MyKey" --key-id "ABC123" --issuer-id "DEF456" --private-key /path/to/AuthKey.p8
@@ -635,8 +643,7 @@ Examples:
 			bypassKeychain := authsvc.ShouldBypassKeychain()
 			keychainAvailable, keychainErr := authsvc.KeychainAvailable()
 			configPath, configErr := config.Path()
-			storageBackend := "System Keychain"
-			storageLocation := "system keychain"
+			storageBackend, storageLocation := authsvc.ResolveKeyringSettings().Description()
 			var warnings []string
 			if listWarning != nil {
 				warnings = append(warnings, listWarning.Error())
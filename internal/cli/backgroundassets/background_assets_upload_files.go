@@ -225,16 +225,23 @@ Examples:
 			requestCtx, cancel := shared.ContextWithTimeout(ctx)
 			defer cancel()
 
-			resp, err := client.CreateBackgroundAssetUploadFile(requestCtx, versionIDValue, filepath.Base(pathValue), info.Size(), typeValue)
+			checkpointID := asc.AssetUploadCheckpointID("backgroundAssetVersions/"+versionIDValue+"/"+string(typeValue), filepath.Base(pathValue), info.Size())
+			resp, err := resumableBackgroundAssetUploadFile(requestCtx, client, checkpointID)
 			if err != nil {
-				return fmt.Errorf("background-assets upload-files create: failed to create: %w", err)
+				return fmt.Errorf("background-assets upload-files create: failed to resume: %w", err)
+			}
+			if resp == nil {
+				resp, err = client.CreateBackgroundAssetUploadFile(requestCtx, versionIDValue, filepath.Base(pathValue), info.Size(), typeValue)
+				if err != nil {
+					return fmt.Errorf("background-assets upload-files create: failed to create: %w", err)
+				}
 			}
 			if resp == nil || len(resp.Data.Attributes.UploadOperations) == 0 {
 				return fmt.Errorf("background-assets upload-files create: no upload operations returned")
 			}
 
 			uploadCtx, uploadCancel := shared.ContextWithUploadTimeout(ctx)
-			err = asc.ExecuteUploadOperations(uploadCtx, pathValue, resp.Data.Attributes.UploadOperations)
+			err = asc.ExecuteUploadOperations(uploadCtx, pathValue, resp.Data.Attributes.UploadOperations, asc.WithAssetUploadCheckpoint(checkpointID, resp.Data.ID))
 			uploadCancel()
 			if err != nil {
 				return fmt.Errorf("background-assets upload-files create: upload failed: %w", err)
@@ -268,12 +275,37 @@ Examples:
 			if err != nil {
 				return fmt.Errorf("background-assets upload-files create: failed to commit upload: %w", err)
 			}
+			_ = asc.RemoveUploadCheckpoint(checkpointID)
 
 			return shared.PrintOutput(commitResp, *output.Output, *output.Pretty)
 		},
 	}
 }
 
+// resumableBackgroundAssetUploadFile returns the upload file reserved under
+// checkpointID by an interrupted upload when it still awaits its file, or nil.
+func resumableBackgroundAssetUploadFile(ctx context.Context, client *asc.Client, checkpointID string) (*asc.BackgroundAssetUploadFileResponse, error) {
+	reservationID := asc.AssetUploadReservation(checkpointID)
+	if reservationID == "" {
+		return nil, nil
+	}
+	resp, err := client.GetBackgroundAssetUploadFile(ctx, reservationID)
+	if err != nil {
+		if asc.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	state := ""
+	if resp.Data.Attributes.AssetDeliveryState != nil && resp.Data.Attributes.AssetDeliveryState.State != nil {
+		state = *resp.Data.Attributes.AssetDeliveryState.State
+	}
+	if !asc.AssetReservationResumable(state, resp.Data.Attributes.UploadOperations) {
+		return nil, nil
+	}
+	return resp, nil
+}
+
 // BackgroundAssetsUploadFilesUpdateCommand returns the upload files update subcommand.
 func BackgroundAssetsUploadFilesUpdateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("update", flag.ExitOnError)
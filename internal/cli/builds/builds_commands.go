@@ -27,6 +27,7 @@ func BuildsUploadCommand() *ffcli.Command {
 	buildNumber := fs.String("build-number", "", "CFBundleVersion (e.g., 123, auto-extracted from IPA if not provided)")
 	platform := fs.String("platform", "", "Platform: IOS, MAC_OS, TV_OS, VISION_OS (auto-detected for --pkg)")
 	dryRun := fs.Bool("dry-run", false, "Reserve upload operations without uploading the file")
+	skipLint := fs.Bool("skip-lint", false, "Skip the offline IPA checks run before uploading")
 	concurrency := fs.Int("concurrency", 1, "Upload concurrency (default 1)")
 	verifyChecksum := fs.Bool("checksum", false, "Verify upload checksums if provided by API")
 	testNotes := fs.String("test-notes", "", "What to Test notes (requires build processing)")
@@ -47,17 +48,29 @@ the file. Use --dry-run to only reserve the upload operations.
 Use --ipa for iOS, tvOS, and visionOS apps. Use --pkg for macOS apps.
 When using --pkg, the platform is automatically set to MAC_OS.
 
+Before uploading an IPA, the checks from 'asc ipa lint' run locally (icon
+alpha, export compliance key, bundle ID and profile mismatches, and privacy
+manifest declarations). Errors stop the upload; use --skip-lint to bypass.
+
+Completed parts are checkpointed under ~/.asc/uploads (or ASC_UPLOAD_STATE_DIR).
+If the upload is interrupted, 'asc builds uploads resume' sends only the
+remaining parts.
+
 Examples:
   asc builds upload --app "123456789" --ipa "path/to/app.ipa"
   asc builds upload --ipa "app.ipa" --version "1.0.0" --build-number "123"
   asc builds upload --app "123456789" --ipa "app.ipa" --dry-run
+  asc builds upload --app "123456789" --ipa "app.ipa" --skip-lint
   asc builds upload --app "123456789" --ipa "app.ipa" --test-notes "Test flow" --locale "en-US" --wait
   asc builds upload --app "123456789" --pkg "path/to/app.pkg" --version "1.0.0" --build-number "123"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
 			// Validate required flags
-			resolvedAppID := shared.ResolveAppID(*appID)
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("builds upload: %w", err)
+			}
 			if resolvedAppID == "" {
 				fmt.Fprintf(os.Stderr, "Error: --app is required (or set ASC_APP_ID)\n\n")
 				return flag.ErrHelp
@@ -213,6 +226,12 @@ Examples:
 				return fmt.Errorf("builds upload: %w", err)
 			}
 
+			if hasIPA && !*skipLint {
+				if err := shared.LintIPABeforeUpload(ctx, client, resolvedAppID, filePath); err != nil {
+					return fmt.Errorf("builds upload: %w", err)
+				}
+			}
+
 			timeoutValue := asc.ResolveTimeout()
 			if *wait || testNotesValue != "" {
 				timeoutValue = asc.ResolveTimeoutWithDefault(buildWaitDefaultTimeout)
@@ -281,58 +300,22 @@ Examples:
 
 				uploadOpts := []asc.UploadOption{
 					asc.WithUploadConcurrency(*concurrency),
+					asc.WithUploadCheckpoint(uploadResp.Data.ID),
 				}
 				uploadCtx, uploadCancel := shared.ContextWithUploadTimeout(ctx)
 				err = asc.ExecuteUploadOperations(uploadCtx, filePath, fileResp.Data.Attributes.UploadOperations, uploadOpts...)
 				uploadCancel()
 				if err != nil {
-					return fmt.Errorf("builds upload: upload failed: %w", err)
-				}
-
-				var verifiedChecksums *asc.Checksums
-				var checksumVerified *bool
-				if *verifyChecksum {
-					src := fileResp.Data.Attributes.SourceFileChecksums
-					if src == nil || (src.File == nil && src.Composite == nil) {
-						fmt.Fprintln(os.Stderr, "Warning: --checksum requested but API provided no checksums to verify; skipping")
-					} else {
-						checksums, err := asc.VerifySourceFileChecksums(filePath, src)
-						if err != nil {
-							return fmt.Errorf("builds upload: checksum verification failed: %w", err)
-						}
-						verifiedChecksums = checksums
-						verified := true
-						checksumVerified = &verified
+					fileFlag := "--ipa"
+					if hasPKG {
+						fileFlag = "--pkg"
 					}
+					return fmt.Errorf("builds upload: upload failed: %w (resume with: asc builds uploads resume --id %q %s %q)", err, uploadResp.Data.ID, fileFlag, filePath)
 				}
 
-				uploaded := true
-				updateReq := asc.BuildUploadFileUpdateRequest{
-					Data: asc.BuildUploadFileUpdateData{
-						Type: asc.ResourceTypeBuildUploadFiles,
-						ID:   fileResp.Data.ID,
-						Attributes: &asc.BuildUploadFileUpdateAttributes{
-							Uploaded:            &uploaded,
-							SourceFileChecksums: verifiedChecksums,
-						},
-					},
-				}
-
-				commitCtx, commitCancel := shared.ContextWithUploadTimeout(ctx)
-				commitResp, err := client.UpdateBuildUploadFile(commitCtx, fileResp.Data.ID, updateReq)
-				commitCancel()
-				if err != nil {
-					return fmt.Errorf("builds upload: failed to commit upload: %w", err)
-				}
-
-				if commitResp != nil && commitResp.Data.Attributes.Uploaded != nil {
-					result.Uploaded = commitResp.Data.Attributes.Uploaded
-				} else {
-					result.Uploaded = &uploaded
+				if err := commitBuildUploadFile(ctx, client, fileResp.Data.ID, filePath, fileResp.Data.Attributes.SourceFileChecksums, *verifyChecksum, result); err != nil {
+					return fmt.Errorf("builds upload: %w", err)
 				}
-				result.ChecksumVerified = checksumVerified
-				result.SourceFileChecksums = verifiedChecksums
-				result.Operations = nil
 
 				if *wait || testNotesValue != "" {
 					buildResp, err := shared.WaitForBuildByNumber(requestCtx, client, resolvedAppID, versionValue, buildNumberValue, string(platformValue), *pollInterval)
@@ -462,7 +445,10 @@ Examples:
 				return fmt.Errorf("builds: %w", err)
 			}
 
-			resolvedAppID := shared.ResolveAppID(*appID)
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("builds: %w", err)
+			}
 			if resolvedAppID == "" && strings.TrimSpace(*next) == "" {
 				fmt.Fprintf(os.Stderr, "Error: --app is required (or set ASC_APP_ID)\n\n")
 				return flag.ErrHelp
@@ -606,3 +592,52 @@ Examples:
 		},
 	}
 }
+
+// commitBuildUploadFile optionally verifies the source checksums, marks the
+// build upload file as uploaded and records the outcome on result.
+func commitBuildUploadFile(ctx context.Context, client *asc.Client, fileID, filePath string, expected *asc.Checksums, verifyChecksum bool, result *asc.BuildUploadResult) error {
+	var verifiedChecksums *asc.Checksums
+	var checksumVerified *bool
+	if verifyChecksum {
+		if expected == nil || (expected.File == nil && expected.Composite == nil) {
+			fmt.Fprintln(os.Stderr, "Warning: --checksum requested but API provided no checksums to verify; skipping")
+		} else {
+			checksums, err := asc.VerifySourceFileChecksums(filePath, expected)
+			if err != nil {
+				return fmt.Errorf("checksum verification failed: %w", err)
+			}
+			verifiedChecksums = checksums
+			verified := true
+			checksumVerified = &verified
+		}
+	}
+
+	uploaded := true
+	updateReq := asc.BuildUploadFileUpdateRequest{
+		Data: asc.BuildUploadFileUpdateData{
+			Type: asc.ResourceTypeBuildUploadFiles,
+			ID:   fileID,
+			Attributes: &asc.BuildUploadFileUpdateAttributes{
+				Uploaded:            &uploaded,
+				SourceFileChecksums: verifiedChecksums,
+			},
+		},
+	}
+
+	commitCtx, commitCancel := shared.ContextWithUploadTimeout(ctx)
+	commitResp, err := client.UpdateBuildUploadFile(commitCtx, fileID, updateReq)
+	commitCancel()
+	if err != nil {
+		return fmt.Errorf("failed to commit upload: %w", err)
+	}
+
+	if commitResp != nil && commitResp.Data.Attributes.Uploaded != nil {
+		result.Uploaded = commitResp.Data.Attributes.Uploaded
+	} else {
+		result.Uploaded = &uploaded
+	}
+	result.ChecksumVerified = checksumVerified
+	result.SourceFileChecksums = verifiedChecksums
+	result.Operations = nil
+	return nil
+}
@@ -27,6 +27,7 @@ Examples:
   asc builds uploads list --app "APP_ID"
   asc builds uploads get --id "UPLOAD_ID"
   asc builds uploads delete --id "UPLOAD_ID" --confirm
+  asc builds uploads resume --id "UPLOAD_ID" --ipa "app.ipa"
   asc builds uploads files list --upload "UPLOAD_ID"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
@@ -34,6 +35,7 @@ Examples:
 			BuildsUploadsListCommand(),
 			BuildsUploadsGetCommand(),
 			BuildsUploadsDeleteCommand(),
+			BuildsUploadsResumeCommand(),
 			BuildsUploadFilesCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
@@ -83,7 +85,10 @@ Examples:
 				return flag.ErrHelp
 			}
 
-			resolvedAppID := shared.ResolveAppID(*appID)
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("builds uploads list: %w", err)
+			}
 			if resolvedAppID == "" && strings.TrimSpace(*next) == "" {
 				fmt.Fprintf(os.Stderr, "Error: --app is required (or set ASC_APP_ID)\n\n")
 				return flag.ErrHelp
@@ -0,0 +1,162 @@
+package builds
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// BuildsUploadsResumeCommand returns the builds uploads resume subcommand.
+func BuildsUploadsResumeCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("uploads resume", flag.ExitOnError)
+
+	id := fs.String("id", "", "Build upload ID (required)")
+	ipaPath := fs.String("ipa", "", "Path to the .ipa file that was being uploaded")
+	pkgPath := fs.String("pkg", "", "Path to the .pkg file that was being uploaded")
+	concurrency := fs.Int("concurrency", 1, "Upload concurrency (default 1)")
+	verifyChecksum := fs.Bool("checksum", false, "Verify upload checksums if provided by API")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "resume",
+		ShortUsage: "asc builds uploads resume --id \"UPLOAD_ID\" --ipa PATH [flags]",
+		ShortHelp:  "Resume an interrupted build upload.",
+		LongHelp: `Resume an interrupted build upload.
+
+'asc builds upload' and 'asc publish' checkpoint each completed upload part
+under ~/.asc/uploads (or ASC_UPLOAD_STATE_DIR). Resuming with the same file
+re-sends only the parts that are missing or whose bytes changed, then commits
+the upload. Presigned upload URLs expire; if they have, start a new upload.
+
+Examples:
+  asc builds uploads resume --id "UPLOAD_ID" --ipa "app.ipa"
+  asc builds uploads resume --id "UPLOAD_ID" --pkg "app.pkg" --concurrency 4 --checksum`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			uploadID := strings.TrimSpace(*id)
+			if uploadID == "" {
+				fmt.Fprintln(os.Stderr, "Error: --id is required")
+				return flag.ErrHelp
+			}
+			hasIPA := strings.TrimSpace(*ipaPath) != ""
+			hasPKG := strings.TrimSpace(*pkgPath) != ""
+			if !hasIPA && !hasPKG {
+				fmt.Fprintln(os.Stderr, "Error: --ipa or --pkg is required")
+				return flag.ErrHelp
+			}
+			if hasIPA && hasPKG {
+				fmt.Fprintln(os.Stderr, "Error: --ipa and --pkg are mutually exclusive")
+				return flag.ErrHelp
+			}
+			if *concurrency < 1 {
+				return fmt.Errorf("builds uploads resume: --concurrency must be at least 1")
+			}
+
+			filePath := strings.TrimSpace(*ipaPath)
+			if hasPKG {
+				filePath = strings.TrimSpace(*pkgPath)
+			}
+			fileInfo, err := os.Stat(filePath)
+			if err != nil {
+				return fmt.Errorf("builds uploads resume: %w", err)
+			}
+			if fileInfo.IsDir() {
+				return fmt.Errorf("builds uploads resume: %s must be a file", filePath)
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("builds uploads resume: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			uploadFile, err := pendingBuildUploadFile(requestCtx, client, uploadID, fileInfo.Name())
+			if err != nil {
+				return fmt.Errorf("builds uploads resume: %w", err)
+			}
+			attrs := uploadFile.Attributes
+			if attrs.FileSize != 0 && attrs.FileSize != fileInfo.Size() {
+				return fmt.Errorf("builds uploads resume: %s is %d bytes but the upload reserved %d bytes for %s", filePath, fileInfo.Size(), attrs.FileSize, attrs.FileName)
+			}
+			if len(attrs.UploadOperations) == 0 {
+				return fmt.Errorf("builds uploads resume: no upload operations returned for file %s; the reservation may have expired, start a new upload with 'asc builds upload'", uploadFile.ID)
+			}
+
+			checkpoint, err := asc.LoadUploadCheckpoint(uploadID)
+			if err != nil {
+				return fmt.Errorf("builds uploads resume: %w", err)
+			}
+			if checkpoint != nil {
+				fmt.Fprintf(os.Stderr, "Resuming upload %s: %d of %d part(s) already uploaded\n", uploadID, len(checkpoint.Parts), len(attrs.UploadOperations))
+			} else {
+				fmt.Fprintf(os.Stderr, "No checkpoint found for upload %s; uploading all %d part(s)\n", uploadID, len(attrs.UploadOperations))
+			}
+
+			uploadCtx, uploadCancel := shared.ContextWithUploadTimeout(ctx)
+			err = asc.ExecuteUploadOperations(uploadCtx, filePath, attrs.UploadOperations,
+				asc.WithUploadConcurrency(*concurrency),
+				asc.WithUploadCheckpoint(uploadID),
+			)
+			uploadCancel()
+			if err != nil {
+				return fmt.Errorf("builds uploads resume: upload failed: %w", err)
+			}
+
+			result := &asc.BuildUploadResult{
+				UploadID: uploadID,
+				FileID:   uploadFile.ID,
+				FileName: attrs.FileName,
+				FileSize: attrs.FileSize,
+			}
+			if err := commitBuildUploadFile(ctx, client, uploadFile.ID, filePath, attrs.SourceFileChecksums, *verifyChecksum, result); err != nil {
+				return fmt.Errorf("builds uploads resume: %w", err)
+			}
+
+			return shared.PrintOutput(result, *output.Output, *output.Pretty)
+		},
+	}
+}
+
+// pendingBuildUploadFile returns the upload's file that has not been
+// committed, preferring one whose name matches the local file.
+func pendingBuildUploadFile(ctx context.Context, client *asc.Client, uploadID, fileName string) (*asc.Resource[asc.BuildUploadFileAttributes], error) {
+	resp, err := client.GetBuildUploadFiles(ctx, uploadID, asc.WithBuildUploadFilesLimit(200))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch upload files: %w", err)
+	}
+
+	var pending *asc.Resource[asc.BuildUploadFileAttributes]
+	for i := range resp.Data {
+		file := &resp.Data[i]
+		if file.Attributes.Uploaded != nil && *file.Attributes.Uploaded {
+			continue
+		}
+		if pending == nil || strings.EqualFold(filepath.Base(file.Attributes.FileName), fileName) {
+			pending = file
+		}
+	}
+	if pending == nil {
+		return nil, fmt.Errorf("upload %s has no files awaiting upload", uploadID)
+	}
+
+	// List responses may omit upload operations; fetch the file itself.
+	if len(pending.Attributes.UploadOperations) == 0 {
+		fileResp, err := client.GetBuildUploadFile(ctx, pending.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch upload file: %w", err)
+		}
+		pending = &fileResp.Data
+	}
+	return pending, nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"flag"
 	"io"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -50,6 +51,21 @@ func TestAnalyticsValidationErrors(t *testing.T) {
 			args:    []string{"analytics", "requests", "delete", "--request-id", "11111111-1111-1111-1111-111111111111"},
 			wantErr: "--confirm is required",
 		},
+		{
+			name:    "sales summarize missing file",
+			args:    []string{"analytics", "sales", "summarize"},
+			wantErr: "--file is required",
+		},
+		{
+			name:    "sales summarize invalid group-by",
+			args:    []string{"analytics", "sales", "summarize", "--file", "report.tsv", "--group-by", "month"},
+			wantErr: "unsupported group-by",
+		},
+		{
+			name:    "sales summarize invalid from",
+			args:    []string{"analytics", "sales", "summarize", "--file", "report.tsv", "--from", "01/02/2024"},
+			wantErr: "--from must be in YYYY-MM-DD format",
+		},
 	}
 
 	for _, test := range tests {
@@ -76,3 +92,31 @@ func TestAnalyticsValidationErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestAnalyticsSalesSummarizeTableOutput(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "sales.tsv")
+	writeFile(t, reportPath, "SKU\tTitle\tUnits\tDeveloper Proceeds\tCurrency of Proceeds\tCustomer Price\tCustomer Currency\tCountry Code\tBegin Date\n"+
+		"com.example.app\tExample App\t3\t0.70\tUSD\t0.99\tUSD\tUS\t01/20/2024\n"+
+		"com.example.app\tExample App\t1\t0.70\tUSD\t0.99\tUSD\tCA\t01/20/2024\n")
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"analytics", "sales", "summarize", "--file", reportPath, "--output", "table"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	if stderr != "" {
+		t.Fatalf("expected empty stderr, got %q", stderr)
+	}
+	for _, want := range []string{"com.example.app", "Example App", "2.80", "3.96", "Total"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected output to contain %q, got %q", want, stdout)
+		}
+	}
+}
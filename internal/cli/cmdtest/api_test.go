@@ -0,0 +1,131 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runAPICommand(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	var runErr error
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse(append([]string{"api"}, args...)); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		runErr = root.Run(context.Background())
+	})
+	return stdout, stderr, runErr
+}
+
+func TestAPIPaginatesAndFiltersOutput(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	requests := 0
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests++
+		if req.Method != http.MethodGet || req.URL.Path != "/v1/apps/APP_1/appStoreVersions" {
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+		}
+		if req.Header.Get("Authorization") == "" {
+			t.Fatal("expected an authenticated request")
+		}
+		query := req.URL.Query()
+		if query.Get("cursor") == "" {
+			if query.Get("filter[platform]") != "IOS" || query.Get("include") != "build" || query.Get("limit") != "2" {
+				t.Fatalf("unexpected query: %s", req.URL.RawQuery)
+			}
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"appStoreVersions","id":"V1","attributes":{"versionString":"1.0"}}],"included":[{"type":"builds","id":"B1"}],"links":{"self":"https://api.appstoreconnect.apple.com/v1/apps/APP_1/appStoreVersions","next":"https://api.appstoreconnect.apple.com/v1/apps/APP_1/appStoreVersions?cursor=2"}}`)
+		}
+		return jsonResponse(http.StatusOK, `{"data":[{"type":"appStoreVersions","id":"V2","attributes":{"versionString":"1.1"}}],"included":[{"type":"builds","id":"B2"}],"links":{}}`)
+	})
+
+	stdout, _, err := runAPICommand(t, "GET", "/v1/apps/APP_1/appStoreVersions",
+		"--filter", "platform=IOS", "--include", "build", "--limit", "2", "--paginate",
+		"--query", "[.data[].attributes.versionString], [.included[].id]")
+	if err != nil {
+		t.Fatalf("run error: %v", err)
+	}
+	if requests != 2 {
+		t.Fatalf("expected 2 requests, got %d", requests)
+	}
+	if want := "[\"1.0\",\"1.1\"]\n[\"B1\",\"B2\"]\n"; stdout != want {
+		t.Fatalf("stdout = %q, want %q", stdout, want)
+	}
+}
+
+func TestAPIBuildsJSONAPIBodyFromFields(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	var body map[string]any
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodPatch || req.URL.Path != "/v1/apps/APP_1" {
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		return jsonResponse(http.StatusOK, `{"data":{"type":"apps","id":"APP_1","attributes":{"primaryLocale":"en-US"}}}`)
+	})
+
+	stdout, _, err := runAPICommand(t, "-X", "PATCH", "/v1/apps/APP_1",
+		"-f", "data.type=apps", "-f", "data.id=APP_1",
+		"-f", "data.attributes.primaryLocale=en-US", "-F", "data.attributes.availableInNewTerritories=true")
+	if err != nil {
+		t.Fatalf("run error: %v", err)
+	}
+
+	data, _ := body["data"].(map[string]any)
+	attrs, _ := data["attributes"].(map[string]any)
+	if data["type"] != "apps" || data["id"] != "APP_1" || attrs["primaryLocale"] != "en-US" || attrs["availableInNewTerritories"] != true {
+		t.Fatalf("unexpected body: %#v", body)
+	}
+	if !strings.Contains(stdout, `"primaryLocale":"en-US"`) {
+		t.Fatalf("expected response JSON, got %q", stdout)
+	}
+}
+
+func TestAPIValidationErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"missing path", nil, "path is required"},
+		{"unknown method", []string{"FETCH", "/v1/apps"}, `unknown HTTP method "FETCH"`},
+		{"paginate post", []string{"POST", "/v1/apps", "--paginate"}, "--paginate requires a GET request"},
+		{"bad query", []string{"/v1/apps", "--query", ".data["}, "--query"},
+		{"bad limit", []string{"/v1/apps", "--limit", "500"}, "--limit must be between 1 and 200"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, stderr, err := runAPICommand(t, test.args...)
+			if !errors.Is(err, flag.ErrHelp) {
+				t.Fatalf("expected flag.ErrHelp, got %v", err)
+			}
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected stderr to contain %q, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package cmdtest
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAppFlagResolvesBundleIDAndCachesIt(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	t.Setenv("ASC_APP_ID", "")
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	var paths []string
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		paths = append(paths, req.URL.Path)
+		switch req.URL.Path {
+		case "/v1/apps":
+			if got := req.URL.Query().Get("filter[bundleId]"); got != "com.example.app" {
+				t.Fatalf("expected bundle ID filter, got %q", got)
+			}
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"apps","id":"123456789","attributes":{"name":"Example","bundleId":"com.example.app"}}]}`)
+		case "/v1/apps/123456789/webhooks":
+			return jsonResponse(http.StatusOK, `{"data":[],"links":{}}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	for range 2 {
+		root := RootCommand("1.2.3")
+		root.FlagSet.SetOutput(io.Discard)
+		_, _ = captureOutput(t, func() {
+			if err := root.Parse([]string{"webhooks", "list", "--app", "com.example.app"}); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			if err := root.Run(context.Background()); err != nil {
+				t.Fatalf("run error: %v", err)
+			}
+		})
+	}
+
+	want := "/v1/apps,/v1/apps/123456789/webhooks,/v1/apps/123456789/webhooks"
+	if got := strings.Join(paths, ","); got != want {
+		t.Fatalf("expected the bundle ID to be looked up once, got %s", got)
+	}
+}
+
+func TestAppFlagReportsAmbiguousName(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	t.Setenv("ASC_APP_ID", "")
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/v1/apps" {
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+		}
+		if req.URL.Query().Get("filter[name]") == "" {
+			return jsonResponse(http.StatusOK, `{"data":[]}`)
+		}
+		return jsonResponse(http.StatusOK, `{"data":[
+			{"type":"apps","id":"1","attributes":{"name":"Tally Pro","bundleId":"com.example.pro"}},
+			{"type":"apps","id":"2","attributes":{"name":"Tally Lite","bundleId":"com.example.lite"}}
+		]}`)
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	var runErr error
+	_, _ = captureOutput(t, func() {
+		if err := root.Parse([]string{"webhooks", "list", "--app", "Tally"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		runErr = root.Run(context.Background())
+	})
+
+	if runErr == nil || errors.Is(runErr, flag.ErrHelp) {
+		t.Fatalf("expected ambiguity error, got %v", runErr)
+	}
+	for _, want := range []string{`--app "Tally": ambiguous app`, "1 (Tally Pro, com.example.pro)", "2 (Tally Lite, com.example.lite)"} {
+		if !strings.Contains(runErr.Error(), want) {
+			t.Fatalf("expected %q in error, got %q", want, runErr.Error())
+		}
+	}
+}
+
+func TestAppFlagNotFoundFailsBeforeUsingRawValue(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	t.Setenv("ASC_APP_ID", "")
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	// iap prices creates its client before resolving --app.
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/v1/apps" {
+			t.Fatalf("unexpected request with unresolved app: %s %s", req.Method, req.URL.String())
+		}
+		return jsonResponse(http.StatusOK, `{"data":[]}`)
+	})
+
+	for range 2 {
+		root := RootCommand("1.2.3")
+		root.FlagSet.SetOutput(io.Discard)
+		var runErr error
+		_, _ = captureOutput(t, func() {
+			if err := root.Parse([]string{"iap", "prices", "--app", "com.example.missing"}); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			runErr = root.Run(context.Background())
+		})
+		if runErr == nil || !strings.Contains(runErr.Error(), `iap prices: --app "com.example.missing": no app found`) {
+			t.Fatalf("expected not-found error, got %v", runErr)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestApplyExportValidationErrors(t *testing.T) {
+	t.Setenv("ASC_APP_ID", "")
+
+	manifestPath := filepath.Join(t.TempDir(), "app.yaml")
+	writeFile(t, manifestPath, "versionLocalizations:\n  en-US:\n    description: Hello\n")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "apply missing file",
+			args:    []string{"apply", "--app", "APP_ID"},
+			wantErr: "--file is required",
+		},
+		{
+			name:    "apply missing app",
+			args:    []string{"apply", "-f", manifestPath},
+			wantErr: "--app is required",
+		},
+		{
+			name:    "apply version sections without version",
+			args:    []string{"apply", "-f", manifestPath, "--app", "APP_ID"},
+			wantErr: "--version-id or --version is required",
+		},
+		{
+			name:    "apply version and version-id",
+			args:    []string{"apply", "-f", manifestPath, "--app", "APP_ID", "--version", "1.0", "--version-id", "VERSION_ID"},
+			wantErr: "--version-id and --version are mutually exclusive",
+		},
+		{
+			name:    "export missing app",
+			args:    []string{"export"},
+			wantErr: "--app is required",
+		},
+		{
+			name:    "export invalid platform",
+			args:    []string{"export", "--app", "APP_ID", "--version", "1.0", "--platform", "WATCH"},
+			wantErr: "--platform",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+
+			stdout, stderr := captureOutput(t, func() {
+				if err := root.Parse(test.args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				err := root.Run(context.Background())
+				if !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected ErrHelp, got %v", err)
+				}
+			})
+
+			if stdout != "" {
+				t.Fatalf("expected empty stdout, got %q", stdout)
+			}
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected error %q, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
+
+func TestApplyDryRunPlansWithoutMutating(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_APP_ID", "")
+
+	manifestPath := filepath.Join(t.TempDir(), "app.yaml")
+	writeFile(t, manifestPath, `app:
+  id: app-1
+  appInfoId: info-1
+categories:
+  primary: PRODUCTIVITY
+  secondary: GAMES
+`)
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet {
+			t.Fatalf("expected only GET requests during dry run, got %s %s", req.Method, req.URL.Path)
+		}
+		switch req.URL.Path {
+		case "/v1/appInfos/info-1/relationships/primaryCategory":
+			return jsonResponse(http.StatusOK, `{"data":{"type":"appCategories","id":"PRODUCTIVITY"}}`)
+		case "/v1/appInfos/info-1/relationships/secondaryCategory":
+			return jsonResponse(http.StatusOK, `{"data":null}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"apply", "-f", manifestPath, "--dry-run"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	if stderr != "" {
+		t.Fatalf("expected empty stderr, got %q", stderr)
+	}
+
+	var summary struct {
+		AppID   string `json:"appId"`
+		DryRun  bool   `json:"dryRun"`
+		Planned int    `json:"planned"`
+		Applied int    `json:"applied"`
+		Changes []struct {
+			Section string `json:"section"`
+			Action  string `json:"action"`
+			Fields  []struct {
+				Field   string `json:"field"`
+				Current string `json:"current"`
+				Desired string `json:"desired"`
+			} `json:"fields"`
+		} `json:"changes"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &summary); err != nil {
+		t.Fatalf("unmarshal summary: %v\n%s", err, stdout)
+	}
+	if summary.AppID != "app-1" || !summary.DryRun || summary.Planned != 1 || summary.Applied != 0 {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+	change := summary.Changes[0]
+	if change.Section != "categories" || len(change.Fields) != 1 || change.Fields[0].Field != "secondary" || change.Fields[0].Desired != "GAMES" {
+		t.Fatalf("unexpected change: %+v", change)
+	}
+}
@@ -289,8 +289,8 @@ func TestBuildsLatestOutputErrors(t *testing.T) {
 	}{
 		{
 			name:    "unsupported output",
-			args:    []string{"builds", "latest", "--app", "app-1", "--output", "yaml"},
-			wantErr: "unsupported format: yaml",
+			args:    []string{"builds", "latest", "--app", "app-1", "--output", "xml"},
+			wantErr: "unsupported format: xml",
 		},
 		{
 			name:    "pretty with table",
@@ -135,6 +135,21 @@ func TestBuildsParityValidationErrors(t *testing.T) {
 			args:    []string{"builds", "uploads", "delete", "--id", "UPLOAD_ID"},
 			wantErr: "--confirm is required",
 		},
+		{
+			name:    "builds uploads resume missing id",
+			args:    []string{"builds", "uploads", "resume", "--ipa", "app.ipa"},
+			wantErr: "--id is required",
+		},
+		{
+			name:    "builds uploads resume missing file",
+			args:    []string{"builds", "uploads", "resume", "--id", "UPLOAD_ID"},
+			wantErr: "--ipa or --pkg is required",
+		},
+		{
+			name:    "builds uploads resume ipa and pkg",
+			args:    []string{"builds", "uploads", "resume", "--id", "UPLOAD_ID", "--ipa", "app.ipa", "--pkg", "app.pkg"},
+			wantErr: "--ipa and --pkg are mutually exclusive",
+		},
 		{
 			name:    "builds uploads files list missing upload",
 			args:    []string{"builds", "uploads", "files", "list"},
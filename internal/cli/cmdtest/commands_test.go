@@ -1701,6 +1701,16 @@ func TestDevicesValidationErrors(t *testing.T) {
 			args:    []string{"devices", "register", "--name", "My Device", "--udid", "UDID"},
 			wantErr: "--platform is required",
 		},
+		{
+			name:    "devices import missing file",
+			args:    []string{"devices", "import"},
+			wantErr: "--file is required",
+		},
+		{
+			name:    "devices export missing file",
+			args:    []string{"devices", "export"},
+			wantErr: "--file is required",
+		},
 	}
 
 	for _, test := range tests {
@@ -2921,6 +2931,11 @@ func TestTestFlightSyncValidationErrors(t *testing.T) {
 			args:    []string{"testflight", "sync", "pull", "--app", "APP_ID", "--output", "./testflight.yaml", "--tester", "tester@example.com"},
 			wantErr: "--tester requires --include-testers",
 		},
+		{
+			name:    "testflight sync push missing input",
+			args:    []string{"testflight", "sync", "push", "--app", "APP_ID"},
+			wantErr: "--input is required",
+		},
 	}
 
 	for _, test := range tests {
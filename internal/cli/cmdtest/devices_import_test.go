@@ -0,0 +1,137 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type devicesImportOutput struct {
+	Skipped int `json:"skipped"`
+	Results []struct {
+		UDID   string `json:"udid"`
+		Status string `json:"status"`
+		Reason string `json:"reason"`
+	} `json:"results"`
+	Slots []struct {
+		DeviceClass string `json:"deviceClass"`
+		Registered  int    `json:"registered"`
+		Planned     int    `json:"planned"`
+		Remaining   int    `json:"remaining"`
+	} `json:"slots"`
+}
+
+func TestDevicesImportDryRunSkipsRegisteredDevices(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	devicesFile := filepath.Join(t.TempDir(), "devices.txt")
+	content := "Device ID\tDevice Name\tDevice Platform\n" +
+		"00008030-001A2D3E0C38802E\tRegistered iPhone\tios\n" +
+		"00008030-00000000000000AA\tNew iPhone\tios\n"
+	if err := os.WriteFile(devicesFile, []byte(content), 0o600); err != nil {
+		t.Fatalf("write devices file: %v", err)
+	}
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet || req.URL.Path != "/v1/devices" {
+			t.Fatalf("unexpected request in dry run: %s %s", req.Method, req.URL.String())
+		}
+		return jsonResponse(http.StatusOK, `{"data":[{"type":"devices","id":"DEVICE_1","attributes":{"name":"Registered iPhone","udid":"00008030-001a2d3e0c38802e","platform":"IOS","deviceClass":"IPHONE","status":"ENABLED"}}],"links":{}}`)
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"devices", "import", "--file", devicesFile, "--dry-run"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var summary devicesImportOutput
+	if err := json.Unmarshal([]byte(stdout), &summary); err != nil {
+		t.Fatalf("unmarshal output: %v\n%s", err, stdout)
+	}
+	if summary.Skipped != 1 || len(summary.Results) != 2 {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+	if summary.Results[0].Reason != "already registered" || summary.Results[1].Status != "would-register" {
+		t.Fatalf("unexpected results: %+v", summary.Results)
+	}
+	if slot := summary.Slots[0]; slot.DeviceClass != "IPHONE" || slot.Registered != 1 || slot.Planned != 1 || slot.Remaining != 98 {
+		t.Fatalf("unexpected iPhone slots: %+v", slot)
+	}
+}
+
+func TestDevicesImportFailsBeforeRegisteringWhenSlotsRunOut(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	devicesFile := filepath.Join(t.TempDir(), "devices.txt")
+	content := "Device ID\tDevice Name\tDevice Platform\n" +
+		"00008030-00000000000000AA\tNew iPhone\tios\n" +
+		"00008030-00000000000000BB\tOther iPhone\tios\n"
+	if err := os.WriteFile(devicesFile, []byte(content), 0o600); err != nil {
+		t.Fatalf("write devices file: %v", err)
+	}
+
+	// 99 of the 100 iPhone slots are used.
+	devices := make([]string, 0, 99)
+	for i := range 99 {
+		devices = append(devices, fmt.Sprintf(`{"type":"devices","id":"DEVICE_%d","attributes":{"udid":"00008030-%016X","platform":"IOS","deviceClass":"IPHONE","status":"ENABLED"}}`, i, i+1000))
+	}
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet || req.URL.Path != "/v1/devices" {
+			t.Fatalf("unexpected request when slots run out: %s %s", req.Method, req.URL.String())
+		}
+		return jsonResponse(http.StatusOK, `{"data":[`+strings.Join(devices, ",")+`],"links":{}}`)
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"devices", "import", "--file", devicesFile}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		runErr = root.Run(context.Background())
+	})
+	if runErr == nil {
+		t.Fatal("expected error when planned devices exceed the remaining slots")
+	}
+
+	var summary devicesImportOutput
+	if err := json.Unmarshal([]byte(stdout), &summary); err != nil {
+		t.Fatalf("unmarshal output: %v\n%s", err, stdout)
+	}
+	for _, result := range summary.Results {
+		if result.Status != "blocked" {
+			t.Fatalf("expected %s to be blocked, got %+v", result.UDID, result)
+		}
+	}
+	if slot := summary.Slots[0]; slot.DeviceClass != "IPHONE" || slot.Registered != 99 || slot.Planned != 2 || slot.Remaining != 0 {
+		t.Fatalf("unexpected iPhone slots: %+v", slot)
+	}
+}
@@ -0,0 +1,192 @@
+package cmdtest
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeIPA(t *testing.T, files map[string]string) string {
+	t.Helper()
+	ipaPath := filepath.Join(t.TempDir(), "Demo.ipa")
+	file, err := os.Create(ipaPath)
+	if err != nil {
+		t.Fatalf("create ipa: %v", err)
+	}
+	writer := zip.NewWriter(file)
+	for name, content := range files {
+		entry, err := writer.Create(name)
+		if err != nil {
+			t.Fatalf("create entry: %v", err)
+		}
+		if _, err := entry.Write([]byte(content)); err != nil {
+			t.Fatalf("write entry: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close zip: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("close ipa: %v", err)
+	}
+	return ipaPath
+}
+
+func TestIPAInspectValidationErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "missing ipa",
+			args:    []string{"ipa", "inspect"},
+			wantErr: "--ipa is required",
+		},
+		{
+			name:    "lint missing ipa",
+			args:    []string{"ipa", "lint"},
+			wantErr: "--ipa is required",
+		},
+		{
+			name:    "lint bundle id with app",
+			args:    []string{"ipa", "lint", "--ipa", "app.ipa", "--bundle-id", "com.example.app", "--app", "123"},
+			wantErr: "--bundle-id and --app are mutually exclusive",
+		},
+		{
+			name:    "invalid depth",
+			args:    []string{"ipa", "inspect", "--ipa", "app.ipa", "--depth", "0"},
+			wantErr: "--depth must be at least 1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+
+			stdout, stderr := captureOutput(t, func() {
+				if err := root.Parse(test.args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				err := root.Run(context.Background())
+				if !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected ErrHelp, got %v", err)
+				}
+			})
+
+			if stdout != "" {
+				t.Fatalf("expected empty stdout, got %q", stdout)
+			}
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected error %q, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
+
+func TestIPAInspectOutputsJSON(t *testing.T) {
+	ipaPath := writeIPA(t, map[string]string{
+		"Payload/Demo.app/Info.plist": `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0"><dict>
+<key>CFBundleIdentifier</key><string>com.example.demo</string>
+<key>CFBundleShortVersionString</key><string>2.0</string>
+<key>CFBundleVersion</key><string>7</string>
+</dict></plist>`,
+		"Payload/Demo.app/PlugIns/Share.appex/Info.plist": `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0"><dict>
+<key>CFBundleIdentifier</key><string>com.example.demo.share</string>
+</dict></plist>`,
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"ipa", "inspect", "--ipa", ipaPath}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+	if stderr != "" {
+		t.Fatalf("expected empty stderr, got %q", stderr)
+	}
+
+	var report struct {
+		App struct {
+			BundleID string `json:"bundleId"`
+			Version  string `json:"version"`
+			Build    string `json:"build"`
+		} `json:"app"`
+		Extensions []struct {
+			Path string `json:"path"`
+		} `json:"extensions"`
+		Files int `json:"files"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("parse output: %v\n%s", err, stdout)
+	}
+	if report.App.BundleID != "com.example.demo" || report.App.Version != "2.0" || report.App.Build != "7" {
+		t.Fatalf("unexpected app: %+v", report.App)
+	}
+	if len(report.Extensions) != 1 || report.Extensions[0].Path != "Demo.app/PlugIns/Share.appex" {
+		t.Fatalf("unexpected extensions: %+v", report.Extensions)
+	}
+	if report.Files != 2 {
+		t.Fatalf("expected 2 files, got %d", report.Files)
+	}
+}
+
+func TestIPALintReportsBlockingIssues(t *testing.T) {
+	ipaPath := writeIPA(t, map[string]string{
+		"Payload/Demo.app/Info.plist": `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0"><dict>
+<key>CFBundleIdentifier</key><string>com.example.demo</string>
+<key>CFBundleShortVersionString</key><string>2.0</string>
+<key>CFBundleVersion</key><string>7</string>
+<key>ITSAppUsesNonExemptEncryption</key><false/>
+</dict></plist>`,
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"ipa", "lint", "--ipa", ipaPath, "--bundle-id", "com.example.other"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		runErr = root.Run(context.Background())
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), "found 1 blocking issue(s)") {
+		t.Fatalf("expected blocking issue error, got %v", runErr)
+	}
+
+	var report struct {
+		BundleID string `json:"bundleId"`
+		Checks   []struct {
+			ID       string `json:"id"`
+			Severity string `json:"severity"`
+		} `json:"checks"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("parse output: %v\n%s", err, stdout)
+	}
+	if report.BundleID != "com.example.demo" {
+		t.Fatalf("unexpected bundle ID %q", report.BundleID)
+	}
+	ids := make([]string, 0, len(report.Checks))
+	for _, check := range report.Checks {
+		ids = append(ids, check.ID)
+	}
+	if strings.Join(ids, ",") != "ipa.bundle_id.mismatch,ipa.profile.missing" {
+		t.Fatalf("unexpected checks: %v", ids)
+	}
+}
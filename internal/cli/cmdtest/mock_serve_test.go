@@ -0,0 +1,100 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/mockserver"
+)
+
+func TestMockServeValidationErrors(t *testing.T) {
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"mock", "serve"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected ErrHelp, got %v", err)
+		}
+	})
+
+	if stdout != "" {
+		t.Fatalf("expected empty stdout, got %q", stdout)
+	}
+	if !strings.Contains(stderr, "--dir is required") {
+		t.Fatalf("expected --dir error, got %q", stderr)
+	}
+}
+
+func TestAppsListAgainstMockServerWithBaseURLOverride(t *testing.T) {
+	setupSubmitCancelAuth(t)
+
+	fixtures := t.TempDir()
+	writeMockFixture(t, fixtures, "GET/v1/apps.json",
+		`{"data":[{"type":"apps","id":"app-1","attributes":{"name":"One"}}],"links":{"next":"https://api.appstoreconnect.apple.com/v1/apps?cursor=page2&limit=200"}}`)
+	writeMockFixture(t, fixtures, "GET/v1/apps.cursor=page2&limit=200.json",
+		`{"data":[{"type":"apps","id":"app-2","attributes":{"name":"Two"}}],"links":{}}`)
+
+	var handler http.Handler
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	mock, err := mockserver.New(fixtures, server.URL, io.Discard)
+	if err != nil {
+		t.Fatalf("mockserver.New() error: %v", err)
+	}
+	handler = mock
+	t.Setenv("ASC_BASE_URL", server.URL)
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"apps", "list", "--paginate"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	if stderr != "" {
+		t.Fatalf("expected empty stderr, got %q", stderr)
+	}
+
+	var payload struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &payload); err != nil {
+		t.Fatalf("failed to parse JSON output: %v (stdout=%q)", err, stdout)
+	}
+	if len(payload.Data) != 2 || payload.Data[0].ID != "app-1" || payload.Data[1].ID != "app-2" {
+		t.Fatalf("unexpected apps: %+v", payload.Data)
+	}
+}
+
+func writeMockFixture(t *testing.T, root, rel, body string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir error: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatalf("write fixture error: %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package cmdtest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+	"testing"
+)
+
+func TestRootQueryFlagFiltersCommandOutput(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/v1/apps" {
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+		}
+		return jsonResponse(http.StatusOK, `{"data":[
+			{"type":"apps","id":"1","attributes":{"name":"Tally","bundleId":"com.example.tally","sku":"TALLY"}},
+			{"type":"apps","id":"2","attributes":{"name":"Notes","bundleId":"com.example.notes","sku":"NOTES"}}
+		],"links":{}}`)
+	})
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"query", []string{"--query", ".data[].attributes.bundleId", "apps", "list"}, "com.example.tally\ncom.example.notes\n"},
+		{"fields", []string{"--fields", "id,name", "apps", "list"}, `[{"id":"1","name":"Tally"},{"id":"2","name":"Notes"}]` + "\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+			stdout, _ := captureOutput(t, func() {
+				if err := root.Parse(test.args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				if err := root.Run(context.Background()); err != nil {
+					t.Fatalf("run error: %v", err)
+				}
+			})
+			if stdout != test.want {
+				t.Fatalf("stdout = %q, want %q", stdout, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/cmd"
+)
+
+// writePlugin installs an executable shell script named asc-<name> in a new
+// PATH directory.
+func writePlugin(t *testing.T, name, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin scripts require a POSIX shell")
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "asc-"+name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatalf("write plugin: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return path
+}
+
+func TestRunUnknownCommandRunsPlugin(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_PROFILE", "")
+	writePlugin(t, "hello", `echo "args=$*"
+echo "profile=$ASC_PROFILE"
+echo "key=$ASC_KEY_ID issuer=$ASC_ISSUER_ID"
+echo "token=${ASC_TOKEN:+set} expires=${ASC_TOKEN_EXPIRES_AT:+set}"
+echo "bin=${ASC_BIN:+set}"
+echo "oops" >&2
+exit 7
+`)
+
+	var code int
+	stdout, stderr := captureOutput(t, func() {
+		code = cmd.Run([]string{"hello", "--flag", "value", "extra"}, "1.2.3")
+	})
+
+	if code != 7 {
+		t.Fatalf("expected plugin exit status 7, got %d (stderr %q)", code, stderr)
+	}
+	for _, want := range []string{
+		"args=--flag value extra",
+		"key=TEST_KEY issuer=TEST_ISSUER",
+		"token=set expires=set",
+		"bin=set",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected %q in plugin output, got %q", want, stdout)
+		}
+	}
+	if strings.TrimSpace(stderr) != "oops" {
+		t.Fatalf("expected only plugin stderr, got %q", stderr)
+	}
+}
+
+func TestBuiltinCommandsTakePrecedenceOverPlugins(t *testing.T) {
+	writePlugin(t, "version", "echo plugin\n")
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"version"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+	if strings.Contains(stdout, "plugin") {
+		t.Fatalf("expected built-in version command, got %q", stdout)
+	}
+}
+
+func TestUnknownCommandWithoutPluginPrintsSuggestions(t *testing.T) {
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	_, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"definitely-not-a-plugin"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		_ = root.Run(context.Background())
+	})
+	if !strings.Contains(stderr, "Unknown command: definitely-not-a-plugin") {
+		t.Fatalf("expected unknown command error, got %q", stderr)
+	}
+}
+
+func TestPluginsList(t *testing.T) {
+	path := writePlugin(t, "hello", "exit 0\n")
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"plugins", "list"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var plugins []struct {
+		Name string `json:"name"`
+		Path string `json:"path"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &plugins); err != nil {
+		t.Fatalf("invalid JSON %q: %v", stdout, err)
+	}
+	for _, plugin := range plugins {
+		if plugin.Name == "hello" {
+			if plugin.Path != path {
+				t.Fatalf("expected path %s, got %s", path, plugin.Path)
+			}
+			return
+		}
+	}
+	t.Fatalf("expected hello plugin in %v", plugins)
+}
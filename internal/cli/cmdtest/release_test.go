@@ -0,0 +1,492 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReleaseRunValidationErrors(t *testing.T) {
+	t.Setenv("ASC_APP_ID", "")
+
+	planPath := filepath.Join(t.TempDir(), "release.yaml")
+	writeFile(t, planPath, "version: 1.2.0\n")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "missing plan",
+			args:    []string{"release", "run", "--confirm"},
+			wantErr: "--plan is required",
+		},
+		{
+			name:    "missing confirm",
+			args:    []string{"release", "run", "--plan", planPath},
+			wantErr: "--confirm is required",
+		},
+		{
+			name:    "missing app",
+			args:    []string{"release", "run", "--plan", planPath, "--dry-run"},
+			wantErr: "--app is required",
+		},
+		{
+			name:    "unknown from step",
+			args:    []string{"release", "run", "--plan", planPath, "--app", "app-1", "--from", "deploy", "--dry-run"},
+			wantErr: "--from must be one of",
+		},
+		{
+			name:    "status without plan",
+			args:    []string{"release", "status"},
+			wantErr: "--plan or --state is required",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+
+			stdout, stderr := captureOutput(t, func() {
+				if err := root.Parse(test.args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				err := root.Run(context.Background())
+				if !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected ErrHelp, got %v", err)
+				}
+			})
+
+			if stdout != "" {
+				t.Fatalf("expected empty stdout, got %q", stdout)
+			}
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected error %q, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
+
+type releaseStepOutput struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Error   string `json:"error"`
+}
+
+type releaseStateOutput struct {
+	AppID     string              `json:"appId"`
+	VersionID string              `json:"versionId"`
+	Status    string              `json:"status"`
+	DryRun    bool                `json:"dryRun"`
+	Steps     []releaseStepOutput `json:"steps"`
+}
+
+func TestReleaseRunDryRunReportsSteps(t *testing.T) {
+	t.Setenv("ASC_APP_ID", "")
+
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, "release.yaml")
+	writeFile(t, planPath, "app: app-1\nversion: 1.2.0\nrelease: phased\nvalidate:\n  skip: true\n")
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Fatalf("unexpected request during dry run: %s %s", req.Method, req.URL.String())
+		return nil, nil
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"release", "run", "--plan", planPath, "--dry-run"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var state releaseStateOutput
+	if err := json.Unmarshal([]byte(stdout), &state); err != nil {
+		t.Fatalf("unmarshal state: %v\n%s", err, stdout)
+	}
+	if !state.DryRun || state.AppID != "app-1" || len(state.Steps) != 10 {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+	want := map[string]string{
+		"version":        "pending",
+		"build":          "skipped",
+		"phased-release": "pending",
+		"validate":       "skipped",
+		"release":        "pending",
+	}
+	for _, s := range state.Steps {
+		if status, ok := want[s.Name]; ok && s.Status != status {
+			t.Fatalf("step %s: expected %s, got %s", s.Name, status, s.Status)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "release.state.json")); !os.IsNotExist(err) {
+		t.Fatalf("expected dry run not to save state, got %v", err)
+	}
+}
+
+func TestReleaseRunResumesFromSavedState(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_APP_ID", "")
+
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, "release.yaml")
+	writeFile(t, planPath, "app: app-1\nversion: 1.2.0\n")
+
+	// Dry run to get a state with the plan hash, then mark everything up to
+	// the review as completed, as if an earlier run had timed out there.
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"release", "run", "--plan", planPath, "--dry-run"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+	var saved map[string]any
+	if err := json.Unmarshal([]byte(stdout), &saved); err != nil {
+		t.Fatalf("unmarshal state: %v", err)
+	}
+	delete(saved, "dryRun")
+	saved["status"] = "failed"
+	saved["versionId"] = "version-1"
+	for _, raw := range saved["steps"].([]any) {
+		s := raw.(map[string]any)
+		switch s["name"] {
+		case "wait-for-review":
+			s["status"] = "failed"
+			s["error"] = "version still WAITING_FOR_REVIEW after 1h0m0s; rerun to keep waiting"
+		case "release":
+		default:
+			if s["status"] == "pending" {
+				s["status"] = "completed"
+			}
+		}
+	}
+	data, err := json.Marshal(saved)
+	if err != nil {
+		t.Fatalf("marshal state: %v", err)
+	}
+	statePath := filepath.Join(dir, "release.state.json")
+	writeFile(t, statePath, string(data))
+
+	var requests []string
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/appStoreVersions/version-1":
+			return jsonResponse(http.StatusOK, `{"data":{"type":"appStoreVersions","id":"version-1","attributes":{"versionString":"1.2.0","appVersionState":"PENDING_DEVELOPER_RELEASE"}}}`)
+		case req.Method == http.MethodPost && req.URL.Path == "/v1/appStoreVersionReleaseRequests":
+			return jsonResponse(http.StatusCreated, `{"data":{"type":"appStoreVersionReleaseRequests","id":"release-1"}}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	root = RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"release", "run", "--plan", planPath, "--confirm"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var state releaseStateOutput
+	if err := json.Unmarshal([]byte(stdout), &state); err != nil {
+		t.Fatalf("unmarshal state: %v\n%s", err, stdout)
+	}
+	if state.Status != "completed" || state.VersionID != "version-1" {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+	statuses := map[string]releaseStepOutput{}
+	for _, s := range state.Steps {
+		statuses[s.Name] = s
+	}
+	if s := statuses["wait-for-review"]; s.Status != "completed" || s.Error != "" || !strings.Contains(s.Message, "PENDING_DEVELOPER_RELEASE") {
+		t.Fatalf("unexpected wait-for-review step: %+v", s)
+	}
+	if s := statuses["release"]; s.Status != "completed" || s.Message != "released" {
+		t.Fatalf("unexpected release step: %+v", s)
+	}
+	if statuses["version"].Status != "completed" || statuses["build"].Status != "skipped" {
+		t.Fatalf("expected earlier steps to be kept, got %+v", state.Steps)
+	}
+	if len(requests) != 3 || requests[2] != "POST /v1/appStoreVersionReleaseRequests" {
+		t.Fatalf("unexpected requests: %v", requests)
+	}
+	if !strings.Contains(stderr, "[9/10] wait-for-review: running") || !strings.Contains(stderr, "[1/10] version: completed") {
+		t.Fatalf("expected step progress on stderr, got %q", stderr)
+	}
+
+	persisted, err := os.ReadFile(statePath)
+	if err != nil {
+		t.Fatalf("read state: %v", err)
+	}
+	if !strings.Contains(string(persisted), `"status": "completed"`) {
+		t.Fatalf("expected completed state on disk, got %s", persisted)
+	}
+}
+
+func TestReleaseRunResumeReusesSavedSubmission(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     string
+		wantItems bool
+	}{
+		{
+			name:  "version already added",
+			items: `{"data":[{"type":"reviewSubmissionItems","id":"item-1","relationships":{"appStoreVersion":{"data":{"type":"appStoreVersions","id":"version-1"}}}}],"links":{}}`,
+		},
+		{
+			name:      "version not added yet",
+			items:     `{"data":[],"links":{}}`,
+			wantItems: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setupAuth(t)
+			t.Setenv("ASC_APP_ID", "")
+
+			dir := t.TempDir()
+			planPath := filepath.Join(dir, "release.yaml")
+			writeFile(t, planPath, "app: app-1\nversion: 1.2.0\nrelease: after-approval\nreview:\n  wait: false\n")
+
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+			stdout, _ := captureOutput(t, func() {
+				if err := root.Parse([]string{"release", "run", "--plan", planPath, "--dry-run"}); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				if err := root.Run(context.Background()); err != nil {
+					t.Fatalf("run error: %v", err)
+				}
+			})
+			var saved map[string]any
+			if err := json.Unmarshal([]byte(stdout), &saved); err != nil {
+				t.Fatalf("unmarshal state: %v", err)
+			}
+			// An earlier run created the submission and then failed to submit it.
+			delete(saved, "dryRun")
+			saved["status"] = "failed"
+			saved["versionId"] = "version-1"
+			saved["submissionId"] = "submission-1"
+			for _, raw := range saved["steps"].([]any) {
+				s := raw.(map[string]any)
+				switch {
+				case s["name"] == "submit":
+					s["status"] = "failed"
+					s["error"] = "submit for review: request timed out"
+				case s["status"] == "pending":
+					s["status"] = "completed"
+				}
+			}
+			data, err := json.Marshal(saved)
+			if err != nil {
+				t.Fatalf("marshal state: %v", err)
+			}
+			writeFile(t, filepath.Join(dir, "release.state.json"), string(data))
+
+			var requests []string
+			originalTransport := http.DefaultTransport
+			t.Cleanup(func() {
+				http.DefaultTransport = originalTransport
+			})
+			http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				requests = append(requests, req.Method+" "+req.URL.Path)
+				switch {
+				case req.Method == http.MethodGet && req.URL.Path == "/v1/appStoreVersions/version-1":
+					return jsonResponse(http.StatusOK, `{"data":{"type":"appStoreVersions","id":"version-1","attributes":{"versionString":"1.2.0","appVersionState":"PREPARE_FOR_SUBMISSION"}}}`)
+				case req.Method == http.MethodGet && req.URL.Path == "/v1/reviewSubmissions/submission-1/items":
+					return jsonResponse(http.StatusOK, test.items)
+				case req.Method == http.MethodPost && req.URL.Path == "/v1/reviewSubmissionItems":
+					return jsonResponse(http.StatusCreated, `{"data":{"type":"reviewSubmissionItems","id":"item-1"}}`)
+				case req.Method == http.MethodPatch && req.URL.Path == "/v1/reviewSubmissions/submission-1":
+					return jsonResponse(http.StatusOK, `{"data":{"type":"reviewSubmissions","id":"submission-1","attributes":{"state":"WAITING_FOR_REVIEW"}}}`)
+				default:
+					t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+					return nil, nil
+				}
+			})
+
+			root = RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+			stdout, _ = captureOutput(t, func() {
+				if err := root.Parse([]string{"release", "run", "--plan", planPath, "--confirm"}); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				if err := root.Run(context.Background()); err != nil {
+					t.Fatalf("run error: %v", err)
+				}
+			})
+
+			var state releaseStateOutput
+			if err := json.Unmarshal([]byte(stdout), &state); err != nil {
+				t.Fatalf("unmarshal state: %v\n%s", err, stdout)
+			}
+			if state.Status != "completed" {
+				t.Fatalf("unexpected state: %+v", state)
+			}
+			for _, s := range state.Steps {
+				if s.Name == "submit" && (s.Status != "completed" || s.Message != "submitted for review (submission-1)") {
+					t.Fatalf("unexpected submit step: %+v", s)
+				}
+			}
+			want := []string{
+				"GET /v1/appStoreVersions/version-1",
+				"GET /v1/reviewSubmissions/submission-1/items",
+			}
+			if test.wantItems {
+				want = append(want, "POST /v1/reviewSubmissionItems")
+			}
+			want = append(want, "PATCH /v1/reviewSubmissions/submission-1")
+			if got := strings.Join(requests, ","); got != strings.Join(want, ",") {
+				t.Fatalf("expected the saved submission to be reused, got %s", got)
+			}
+		})
+	}
+}
+
+func TestReleaseRunScreenshotsReplacesIncompleteUploads(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_APP_ID", "")
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "shots"), 0o755); err != nil {
+		t.Fatalf("mkdir shots: %v", err)
+	}
+	writePNG(t, filepath.Join(dir, "shots", "delivered.png"), 1242, 2688)
+	writePNG(t, filepath.Join(dir, "shots", "failed.png"), 1242, 2688)
+	planPath := filepath.Join(dir, "release.yaml")
+	writeFile(t, planPath, "app: app-1\nversion: 1.2.0\nscreenshots:\n  - locale: en-US\n    displayType: APP_IPHONE_65\n    path: shots\n")
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"release", "run", "--plan", planPath, "--dry-run"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+	var saved map[string]any
+	if err := json.Unmarshal([]byte(stdout), &saved); err != nil {
+		t.Fatalf("unmarshal state: %v", err)
+	}
+	// An earlier run failed while uploading screenshots.
+	delete(saved, "dryRun")
+	saved["status"] = "failed"
+	saved["versionId"] = "version-1"
+	for _, raw := range saved["steps"].([]any) {
+		s := raw.(map[string]any)
+		switch {
+		case s["name"] == "screenshots":
+			s["status"] = "failed"
+			s["error"] = "upload failed.png: request timed out"
+		case s["status"] == "pending":
+			s["status"] = "completed"
+		}
+	}
+	data, err := json.Marshal(saved)
+	if err != nil {
+		t.Fatalf("marshal state: %v", err)
+	}
+	writeFile(t, filepath.Join(dir, "release.state.json"), string(data))
+
+	var requests []string
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Host == "upload.example.com" {
+			requests = append(requests, req.Method+" upload")
+			return jsonResponse(http.StatusOK, "")
+		}
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/appStoreVersions/version-1/appStoreVersionLocalizations":
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"appStoreVersionLocalizations","id":"loc-1","attributes":{"locale":"en-US"}}]}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/appStoreVersionLocalizations/loc-1/appScreenshotSets":
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"appScreenshotSets","id":"set-1","attributes":{"screenshotDisplayType":"APP_IPHONE_65"}}]}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/appScreenshotSets/set-1/appScreenshots":
+			return jsonResponse(http.StatusOK, `{"data":[`+
+				`{"type":"appScreenshots","id":"shot-delivered","attributes":{"fileName":"delivered.png","assetDeliveryState":{"state":"COMPLETE"}}},`+
+				`{"type":"appScreenshots","id":"shot-failed","attributes":{"fileName":"failed.png","assetDeliveryState":{"state":"FAILED"}}}]}`)
+		case req.Method == http.MethodDelete && req.URL.Path == "/v1/appScreenshots/shot-failed":
+			return jsonResponse(http.StatusNoContent, "")
+		case req.Method == http.MethodPost && req.URL.Path == "/v1/appScreenshots":
+			return jsonResponse(http.StatusCreated, `{"data":{"type":"appScreenshots","id":"shot-new","attributes":{"fileName":"failed.png","uploadOperations":[{"method":"PUT","url":"https://upload.example.com/shot-new","length":1234,"offset":0}]}}}`)
+		case req.Method == http.MethodPatch && req.URL.Path == "/v1/appScreenshots/shot-new":
+			return jsonResponse(http.StatusOK, `{"data":{"type":"appScreenshots","id":"shot-new","attributes":{"fileName":"failed.png"}}}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/appScreenshots/shot-new":
+			return jsonResponse(http.StatusOK, `{"data":{"type":"appScreenshots","id":"shot-new","attributes":{"fileName":"failed.png","assetDeliveryState":{"state":"COMPLETE"}}}}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	root = RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	stdout, _ = captureOutput(t, func() {
+		if err := root.Parse([]string{"release", "run", "--plan", planPath, "--confirm"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var state releaseStateOutput
+	if err := json.Unmarshal([]byte(stdout), &state); err != nil {
+		t.Fatalf("unmarshal state: %v\n%s", err, stdout)
+	}
+	for _, s := range state.Steps {
+		if s.Name == "screenshots" && (s.Status != "completed" || s.Message != "uploaded 1 screenshot(s), 1 already present") {
+			t.Fatalf("unexpected screenshots step: %+v", s)
+		}
+	}
+	want := []string{
+		"DELETE /v1/appScreenshots/shot-failed",
+		"POST /v1/appScreenshots",
+		"PUT upload",
+		"PATCH /v1/appScreenshots/shot-new",
+	}
+	for _, request := range want {
+		if !slices.Contains(requests, request) {
+			t.Fatalf("expected %s, got %v", request, requests)
+		}
+	}
+}
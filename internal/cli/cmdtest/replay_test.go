@@ -0,0 +1,60 @@
+package cmdtest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReplayDoesNotRequireCredentials(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	t.Setenv("ASC_APP_ID", "")
+	cassetteDir := t.TempDir()
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/v1/apps/123456789/webhooks" {
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+		}
+		return jsonResponse(http.StatusOK, `{"data":[{"type":"webhooks","id":"webhook-1","attributes":{"name":"Builds"}}],"links":{}}`)
+	})
+
+	run := func(args ...string) string {
+		t.Helper()
+		root := RootCommand("1.2.3")
+		root.FlagSet.SetOutput(io.Discard)
+		stdout, _ := captureOutput(t, func() {
+			if err := root.Parse(args); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			if err := root.Run(context.Background()); err != nil {
+				t.Fatalf("run error: %v", err)
+			}
+		})
+		return stdout
+	}
+
+	run("--record", cassetteDir, "webhooks", "list", "--app", "123456789")
+
+	// Replay with no credentials and no network.
+	t.Setenv("ASC_KEY_ID", "")
+	t.Setenv("ASC_ISSUER_ID", "")
+	t.Setenv("ASC_PRIVATE_KEY_PATH", "")
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Fatalf("unexpected network request during replay: %s %s", req.Method, req.URL.String())
+		return nil, nil
+	})
+
+	stdout := run("--replay", cassetteDir, "webhooks", "list", "--app", "123456789")
+	if !strings.Contains(stdout, "webhook-1") {
+		t.Fatalf("expected replayed webhook, got %q", stdout)
+	}
+}
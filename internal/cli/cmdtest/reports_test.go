@@ -0,0 +1,71 @@
+package cmdtest
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestReportsSyncValidationErrors(t *testing.T) {
+	t.Setenv("ASC_VENDOR_NUMBER", "")
+	t.Setenv("ASC_ANALYTICS_VENDOR_NUMBER", "")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "missing db",
+			args:    []string{"reports", "sync", "--sales"},
+			wantErr: "--db is required",
+		},
+		{
+			name:    "missing source",
+			args:    []string{"reports", "sync", "--db", "reports.sqlite"},
+			wantErr: "at least one of --analytics-request, --sales or --finance-region is required",
+		},
+		{
+			name:    "missing vendor",
+			args:    []string{"reports", "sync", "--db", "reports.sqlite", "--sales"},
+			wantErr: "--vendor is required",
+		},
+		{
+			name:    "unknown finance region",
+			args:    []string{"reports", "sync", "--db", "reports.sqlite", "--vendor", "12345678", "--finance-region", "XX"},
+			wantErr: "--finance-region \"XX\"",
+		},
+		{
+			name:    "invalid since",
+			args:    []string{"reports", "sync", "--db", "reports.sqlite", "--analytics-request", "REQUEST_ID", "--since", "2024/01/01"},
+			wantErr: "--since",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+
+			stdout, stderr := captureOutput(t, func() {
+				if err := root.Parse(test.args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				err := root.Run(context.Background())
+				if !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected ErrHelp, got %v", err)
+				}
+			})
+
+			if stdout != "" {
+				t.Fatalf("expected empty stdout, got %q", stdout)
+			}
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected error %q, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSchemaDescribesCommandTree(t *testing.T) {
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"schema", "--command", "apps get"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var doc struct {
+		GlobalFlags []struct {
+			Name string `json:"name"`
+		} `json:"globalFlags"`
+		Commands []struct {
+			Name          string   `json:"name"`
+			Runnable      bool     `json:"runnable"`
+			Mutates       bool     `json:"mutates"`
+			OutputFormats []string `json:"outputFormats"`
+			Examples      []string `json:"examples"`
+			Flags         []struct {
+				Name     string `json:"name"`
+				Type     string `json:"type"`
+				Required bool   `json:"required"`
+			} `json:"flags"`
+		} `json:"commands"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &doc); err != nil {
+		t.Fatalf("invalid schema JSON: %v\n%s", err, stdout)
+	}
+
+	var globals []string
+	for _, f := range doc.GlobalFlags {
+		globals = append(globals, f.Name)
+	}
+	for _, want := range []string{"profile", "query", "version"} {
+		if !slices.Contains(globals, want) {
+			t.Fatalf("expected global flag %q in %v", want, globals)
+		}
+	}
+
+	if len(doc.Commands) != 1 || doc.Commands[0].Name != "apps get" {
+		t.Fatalf("expected only apps get, got %+v", doc.Commands)
+	}
+	cmd := doc.Commands[0]
+	if !cmd.Runnable || cmd.Mutates {
+		t.Fatalf("expected a runnable read-only command, got %+v", cmd)
+	}
+	if !slices.Contains(cmd.OutputFormats, "json") || !slices.Contains(cmd.OutputFormats, "table") {
+		t.Fatalf("expected output formats, got %v", cmd.OutputFormats)
+	}
+	if len(cmd.Examples) == 0 {
+		t.Fatal("expected examples")
+	}
+	requiredID := false
+	for _, f := range cmd.Flags {
+		if f.Name == "id" {
+			requiredID = f.Required && f.Type == "string"
+		}
+	}
+	if !requiredID {
+		t.Fatalf("expected --id to be a required string flag, got %+v", cmd.Flags)
+	}
+}
+
+func TestSchemaUnknownCommand(t *testing.T) {
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	_, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"schema", "--command", "apps nope"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err == nil {
+			t.Fatal("expected error for unknown command")
+		}
+	})
+	if !strings.Contains(stderr, `unknown command "apps nope"`) {
+		t.Fatalf("expected unknown command error, got %q", stderr)
+	}
+}
+
+func TestSchemaMarksStateChangingCommandsAsMutating(t *testing.T) {
+	for _, name := range []string{"publish testflight", "offer-codes generate", "notify slack", "webhooks serve", "mock serve"} {
+		t.Run(name, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+
+			stdout, _ := captureOutput(t, func() {
+				if err := root.Parse([]string{"schema", "--command", name}); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				if err := root.Run(context.Background()); err != nil {
+					t.Fatalf("run error: %v", err)
+				}
+			})
+
+			var doc struct {
+				Commands []struct {
+					Name    string `json:"name"`
+					Mutates bool   `json:"mutates"`
+				} `json:"commands"`
+			}
+			if err := json.Unmarshal([]byte(stdout), &doc); err != nil {
+				t.Fatalf("invalid schema JSON: %v\n%s", err, stdout)
+			}
+			if len(doc.Commands) != 1 || doc.Commands[0].Name != name || !doc.Commands[0].Mutates {
+				t.Fatalf("expected %s to mutate, got %+v", name, doc.Commands)
+			}
+		})
+	}
+}
+
+func TestSchemaMarksReplacingCommandsAsDestructive(t *testing.T) {
+	for _, name := range []string{"api", "apply", "testflight sync push"} {
+		t.Run(name, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+
+			stdout, _ := captureOutput(t, func() {
+				if err := root.Parse([]string{"schema", "--command", name}); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				if err := root.Run(context.Background()); err != nil {
+					t.Fatalf("run error: %v", err)
+				}
+			})
+
+			var doc struct {
+				Commands []struct {
+					Name        string `json:"name"`
+					Mutates     bool   `json:"mutates"`
+					Destructive bool   `json:"destructive"`
+				} `json:"commands"`
+			}
+			if err := json.Unmarshal([]byte(stdout), &doc); err != nil {
+				t.Fatalf("invalid schema JSON: %v\n%s", err, stdout)
+			}
+			if len(doc.Commands) != 1 || doc.Commands[0].Name != name || !doc.Commands[0].Mutates || !doc.Commands[0].Destructive {
+				t.Fatalf("expected %s to be destructive, got %+v", name, doc.Commands)
+			}
+		})
+	}
+}
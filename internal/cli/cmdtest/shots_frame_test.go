@@ -91,6 +91,139 @@ func TestShotsFrame_InvalidDevice(t *testing.T) {
 	}
 }
 
+func TestShotsFrame_InvalidRenderer(t *testing.T) {
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{
+			"screenshots",
+			"frame",
+			"--input", "/tmp/raw.png",
+			"--renderer", "imagemagick",
+		}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		err := root.Run(context.Background())
+		if !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected ErrHelp, got %v", err)
+		}
+	})
+
+	if stdout != "" {
+		t.Fatalf("expected empty stdout, got %q", stdout)
+	}
+	if !strings.Contains(stderr, "--renderer must be one of") {
+		t.Fatalf("expected invalid renderer error, got %q", stderr)
+	}
+}
+
+func TestShotsFrame_StyleFlagsRejectedWithConfig(t *testing.T) {
+	for _, flagName := range []string{"--background", "--caption", "--font"} {
+		t.Run(flagName, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+
+			stdout, stderr := captureOutput(t, func() {
+				if err := root.Parse([]string{
+					"screenshots",
+					"frame",
+					"--config", "/tmp/frame.yaml",
+					flagName, "value",
+				}); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				err := root.Run(context.Background())
+				if !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected ErrHelp, got %v", err)
+				}
+			})
+
+			if stdout != "" {
+				t.Fatalf("expected empty stdout, got %q", stdout)
+			}
+			if !strings.Contains(stderr, flagName+" only applies with --input") {
+				t.Fatalf("expected %s usage error, got %q", flagName, stderr)
+			}
+		})
+	}
+}
+
+func TestShotsFrame_NativeRendererConfigWithoutKoubou(t *testing.T) {
+	t.Setenv("ASC_APP_ID", "")
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "config.json"))
+	t.Setenv("PATH", t.TempDir())
+
+	configDir := t.TempDir()
+	writeFramePNG(t, filepath.Join(configDir, "screenshots", "raw.png"), makeRawImage(120, 260))
+	configPath := filepath.Join(configDir, "frame.yaml")
+	writeFile(t, configPath, `project:
+  name: "Demo"
+  output_dir: "./out"
+  device: "iPhone 17 - Teal - Portrait"
+  output_size: "iPhone6_7"
+defaults:
+  background:
+    type: radial
+    colors: ["#FFFFFF", "#DDE6F5"]
+screenshots:
+  home:
+    content:
+      - type: "text"
+        content: "Ship faster"
+        position: ["50%", "4%"]
+        size: 96
+        color: "#111111"
+        weight: bold
+      - type: "image"
+        asset: "screenshots/raw.png"
+        position: ["50%", "58%"]
+        scale: 0.8
+        frame: true
+`)
+
+	outputDir := filepath.Join(t.TempDir(), "framed")
+	root := RootCommand("1.2.3")
+	if err := root.Parse([]string{
+		"screenshots", "frame",
+		"--config", configPath,
+		"--output-dir", outputDir,
+		"--renderer", "native",
+		"--output", "json",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+	if stderr != "" {
+		t.Fatalf("expected empty stderr, got %q", stderr)
+	}
+
+	var result struct {
+		Path        string `json:"path"`
+		Device      string `json:"device"`
+		DisplayType string `json:"display_type"`
+		Width       int    `json:"width"`
+		Height      int    `json:"height"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("unmarshal frame output: %v\nstdout=%q", err, stdout)
+	}
+	if result.Path != filepath.Join(outputDir, "screenshot-iphone-17.png") {
+		t.Fatalf("unexpected output path %q", result.Path)
+	}
+	if result.Device != "iphone-17" || result.DisplayType != "APP_IPHONE_67" {
+		t.Fatalf("unexpected result metadata: %+v", result)
+	}
+	if result.Width != 1290 || result.Height != 2796 {
+		t.Fatalf("expected 1290x2796 output, got %dx%d", result.Width, result.Height)
+	}
+}
+
 func TestShotsFrame_DefaultDeviceIsIPhoneAir(t *testing.T) {
 	t.Setenv("ASC_APP_ID", "")
 	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "config.json"))
@@ -242,6 +375,7 @@ screenshots:
 	if err := root.Parse([]string{
 		"screenshots", "frame",
 		"--config", configPath,
+		"--renderer", "koubou",
 		"--output-dir", filepath.Join(t.TempDir(), "framed"),
 		"--output", "json",
 	}); err != nil {
@@ -300,6 +434,7 @@ screenshots:
 	if err := root.Parse([]string{
 		"screenshots", "frame",
 		"--config", configPath,
+		"--renderer", "koubou",
 		"--output-dir", outputDir,
 		"--output", "json",
 	}); err != nil {
@@ -323,8 +323,8 @@ func TestTestFlightMetricsPublicLinkOutputErrors(t *testing.T) {
 	}{
 		{
 			name:    "unsupported output",
-			args:    []string{"testflight", "metrics", "public-link", "--group", "group-1", "--output", "yaml"},
-			wantErr: "unsupported format: yaml",
+			args:    []string{"testflight", "metrics", "public-link", "--group", "group-1", "--output", "xml"},
+			wantErr: "unsupported format: xml",
 		},
 		{
 			name:    "pretty with table",
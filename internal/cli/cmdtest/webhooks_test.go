@@ -11,6 +11,9 @@ import (
 
 func TestWebhooksValidationErrors(t *testing.T) {
 	t.Setenv("ASC_APP_ID", "")
+	t.Setenv("ASC_WEBHOOK_SECRET", "")
+	t.Setenv("ASC_SLACK_WEBHOOK", "")
+	t.Setenv("ASC_TEAMS_WEBHOOK", "")
 	tests := []struct {
 		name    string
 		args    []string
@@ -111,6 +114,56 @@ func TestWebhooksValidationErrors(t *testing.T) {
 			args:    []string{"webhooks", "ping"},
 			wantErr: "--webhook-id is required",
 		},
+		{
+			name:    "serve missing secret",
+			args:    []string{"webhooks", "serve"},
+			wantErr: "--secret is required",
+		},
+		{
+			name:    "serve invalid port",
+			args:    []string{"webhooks", "serve", "--secret", "secret", "--port", "70000"},
+			wantErr: "--port must be between 1 and 65535",
+		},
+		{
+			name:    "serve invalid path",
+			args:    []string{"webhooks", "serve", "--secret", "secret", "--path", "hooks"},
+			wantErr: "--path must start with /",
+		},
+		{
+			name:    "serve unknown notify provider",
+			args:    []string{"webhooks", "serve", "--secret", "secret", "--notify", "pager"},
+			wantErr: "--notify must be one of: slack, teams, discord, webhook",
+		},
+		{
+			name:    "serve notify slack missing webhook",
+			args:    []string{"webhooks", "serve", "--secret", "secret", "--notify", "slack"},
+			wantErr: "a Slack webhook URL is required (or set ASC_SLACK_WEBHOOK)",
+		},
+		{
+			name:    "serve notify teams wrong host",
+			args:    []string{"webhooks", "serve", "--secret", "secret", "--notify", "teams", "--notify-url", "https://example.com/hook"},
+			wantErr: "webhook URL must target",
+		},
+		{
+			name:    "serve deprecated slack webhook alias",
+			args:    []string{"webhooks", "serve", "--secret", "secret", "--slack-webhook", "https://example.com/hook"},
+			wantErr: "--slack-webhook is deprecated; use --notify slack --notify-url",
+		},
+		{
+			name:    "serve deprecated slack webhook alias is validated",
+			args:    []string{"webhooks", "serve", "--secret", "secret", "--notify", "slack", "--slack-webhook", "https://example.com/hook"},
+			wantErr: "webhook URL must target hooks.slack.com",
+		},
+		{
+			name:    "serve slack webhook with notify url",
+			args:    []string{"webhooks", "serve", "--secret", "secret", "--notify", "slack", "--notify-url", "https://hooks.slack.com/services/x", "--slack-webhook", "https://hooks.slack.com/services/y"},
+			wantErr: "--slack-webhook and --notify-url are mutually exclusive",
+		},
+		{
+			name:    "serve slack webhook with other provider",
+			args:    []string{"webhooks", "serve", "--secret", "secret", "--notify", "teams", "--slack-webhook", "https://hooks.slack.com/services/x"},
+			wantErr: "--slack-webhook only applies with --notify slack",
+		},
 	}
 
 	for _, test := range tests {
@@ -33,12 +33,14 @@ func CrashesCommand() *ffcli.Command {
 
 	return &ffcli.Command{
 		Name:       "crashes",
-		ShortUsage: "asc crashes [flags]",
+		ShortUsage: "asc crashes [flags] | asc crashes <subcommand> [flags]",
 		ShortHelp:  "List and export TestFlight crash reports.",
 		LongHelp: `List and export TestFlight crash reports.
 
 This command fetches crash reports submitted by TestFlight beta testers,
-helping you identify and fix issues in your app.
+helping you identify and fix issues in your app. Use 'asc crashes group' to
+cluster submissions into issues and 'asc crashes symbolicate' to resolve
+exported crash logs with local dSYMs.
 
 Examples:
   asc crashes --app "123456789"
@@ -46,9 +48,15 @@ Examples:
   asc crashes --app "123456789" --device-model "iPhone15,3" --os-version "17.2"
   asc crashes --app "123456789" --sort -createdDate --limit 5
   asc crashes --next "<links.next>"
-  asc crashes --app "123456789" --paginate`,
+  asc crashes --app "123456789" --paginate
+  asc crashes group --app "123456789" --top 10 --output table
+  asc crashes symbolicate --dsym MyApp.app.dSYM --crash-log crash.crash`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			CrashesGroupCommand(),
+			CrashesSymbolicateCommand(),
+		},
 		Exec: func(ctx context.Context, args []string) error {
 			if *limit != 0 && (*limit < 1 || *limit > 200) {
 				return fmt.Errorf("crashes: --limit must be between 1 and 200")
@@ -60,7 +68,10 @@ Examples:
 				return fmt.Errorf("crashes: %w", err)
 			}
 
-			resolvedAppID := shared.ResolveAppID(*appID)
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("crashes: %w", err)
+			}
 			if resolvedAppID == "" && strings.TrimSpace(*next) == "" {
 				fmt.Fprintf(os.Stderr, "Error: --app is required (or set ASC_APP_ID)\n\n")
 				return flag.ErrHelp
@@ -0,0 +1,243 @@
+package crashes
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/crashgroup"
+)
+
+const (
+	defaultGroupMaxSubmissions = 500
+	// crashLogFetchWorkers bounds concurrent crash log downloads.
+	crashLogFetchWorkers = 4
+)
+
+// groupClient is the subset of the App Store Connect client used by group.
+type groupClient interface {
+	GetCrashes(ctx context.Context, appID string, opts ...asc.CrashOption) (*asc.CrashesResponse, error)
+	GetBetaFeedbackCrashSubmissionCrashLog(ctx context.Context, submissionID string) (*asc.BetaCrashLogResponse, error)
+}
+
+// groupReport is the output of crashes group.
+type groupReport struct {
+	Submissions int                `json:"submissions"`
+	Groups      []crashgroup.Group `json:"groups"`
+}
+
+type groupOptions struct {
+	AppID          string
+	Filters        []asc.CrashOption
+	Frames         int
+	MaxSubmissions int
+	Top            int
+}
+
+// CrashesGroupCommand returns the crashes group subcommand.
+func CrashesGroupCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("crashes group", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID env)")
+	buildID := fs.String("build", "", "Filter by build ID(s), comma-separated")
+	buildPreRelease := fs.String("build-pre-release-version", "", "Filter by pre-release version ID(s), comma-separated")
+	deviceModel := fs.String("device-model", "", "Filter by device model(s), comma-separated")
+	osVersion := fs.String("os-version", "", "Filter by OS version(s), comma-separated")
+	appPlatform := fs.String("app-platform", "", "Filter by app platform(s), comma-separated (IOS, MAC_OS, TV_OS, VISION_OS)")
+	frames := fs.Int("frames", crashgroup.DefaultFrames, "Number of top frames in the crash signature")
+	maxSubmissions := fs.Int("max-submissions", defaultGroupMaxSubmissions, "Maximum number of newest submissions to group")
+	top := fs.Int("top", 0, "Only show the N largest groups (0 = all)")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "group",
+		ShortUsage: "asc crashes group --app APP_ID [flags]",
+		ShortHelp:  "Group crash submissions into deduplicated issues.",
+		LongHelp: `Group crash submissions into deduplicated issues.
+
+Fetches the newest crash submissions with their crash logs and clusters them
+by signature: the exception type plus the top --frames frames of the crashing
+thread (or the last exception backtrace). Offsets and line numbers are
+ignored, so the same crash in different builds groups together; unsymbolicated
+frames are keyed by image offset.
+
+Each group reports counts per build, OS version and device model, and when it
+was first and last seen. Groups are sorted by count.
+
+Examples:
+  asc crashes group --app "123456789"
+  asc crashes group --app "123456789" --build "BUILD_ID" --top 10 --output table
+  asc crashes group --app "123456789" --frames 3 --max-submissions 1000 --output markdown`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("crashes group: %w", err)
+			}
+			if resolvedAppID == "" {
+				fmt.Fprintf(os.Stderr, "Error: --app is required (or set ASC_APP_ID)\n\n")
+				return flag.ErrHelp
+			}
+			if *frames < 1 {
+				return shared.UsageError("--frames must be at least 1")
+			}
+			if *maxSubmissions < 1 {
+				return shared.UsageError("--max-submissions must be at least 1")
+			}
+			if *top < 0 {
+				return shared.UsageError("--top must not be negative")
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("crashes group: %w", err)
+			}
+
+			report, err := groupCrashes(ctx, client, groupOptions{
+				AppID: resolvedAppID,
+				Filters: []asc.CrashOption{
+					asc.WithCrashBuildIDs(shared.SplitCSV(*buildID)),
+					asc.WithCrashBuildPreReleaseVersionIDs(shared.SplitCSV(*buildPreRelease)),
+					asc.WithCrashDeviceModels(shared.SplitCSV(*deviceModel)),
+					asc.WithCrashOSVersions(shared.SplitCSV(*osVersion)),
+					asc.WithCrashAppPlatforms(shared.SplitCSVUpper(*appPlatform)),
+				},
+				Frames:         *frames,
+				MaxSubmissions: *maxSubmissions,
+				Top:            *top,
+			})
+			if err != nil {
+				return fmt.Errorf("crashes group: %w", err)
+			}
+
+			return shared.PrintOutputWithRenderers(
+				report,
+				*output.Output,
+				*output.Pretty,
+				func() error { asc.RenderTable(crashgroup.Headers, crashgroup.Rows(report.Groups)); return nil },
+				func() error { asc.RenderMarkdown(crashgroup.Headers, crashgroup.Rows(report.Groups)); return nil },
+			)
+		},
+	}
+}
+
+func groupCrashes(ctx context.Context, client groupClient, opts groupOptions) (*groupReport, error) {
+	crashes, builds, err := fetchCrashSubmissions(ctx, client, opts)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := fetchCrashLogs(ctx, client, crashes)
+	if err != nil {
+		return nil, err
+	}
+
+	submissions := make([]crashgroup.Submission, 0, len(crashes))
+	for i, crash := range crashes {
+		buildID := crashgroup.RelatedBuildID(crash.Relationships)
+		build := builds[buildID]
+		if build == "" {
+			build = buildID
+		}
+		createdAt, _ := time.Parse(time.RFC3339, crash.Attributes.CreatedDate)
+		submissions = append(submissions, crashgroup.Submission{
+			ID:          crash.ID,
+			Signature:   crashgroup.CrashSignature(logs[i], opts.Frames),
+			Build:       build,
+			OSVersion:   crash.Attributes.OSVersion,
+			DeviceModel: crash.Attributes.DeviceModel,
+			CreatedAt:   createdAt,
+		})
+	}
+
+	groups := crashgroup.GroupSubmissions(submissions)
+	if opts.Top > 0 && len(groups) > opts.Top {
+		groups = groups[:opts.Top]
+	}
+	return &groupReport{Submissions: len(submissions), Groups: groups}, nil
+}
+
+// fetchCrashSubmissions pages through the newest submissions, up to
+// opts.MaxSubmissions, and returns build versions keyed by build ID.
+func fetchCrashSubmissions(ctx context.Context, client groupClient, opts groupOptions) ([]asc.Resource[asc.CrashAttributes], map[string]string, error) {
+	var crashes []asc.Resource[asc.CrashAttributes]
+	builds := map[string]string{}
+
+	pageOpts := append([]asc.CrashOption{}, opts.Filters...)
+	pageOpts = append(pageOpts,
+		asc.WithCrashIncludeBuild(),
+		asc.WithCrashSort("-createdDate"),
+		asc.WithCrashLimit(min(opts.MaxSubmissions, 200)),
+	)
+	for {
+		requestCtx, cancel := shared.ContextWithTimeout(ctx)
+		page, err := client.GetCrashes(requestCtx, opts.AppID, pageOpts...)
+		cancel()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch crashes: %w", err)
+		}
+		for id, version := range crashgroup.BuildLabels(page.Included) {
+			builds[id] = version
+		}
+		crashes = append(crashes, page.Data...)
+		if len(crashes) >= opts.MaxSubmissions {
+			return crashes[:opts.MaxSubmissions], builds, nil
+		}
+		next := strings.TrimSpace(page.Links.Next)
+		if next == "" {
+			return crashes, builds, nil
+		}
+		pageOpts = []asc.CrashOption{asc.WithCrashNextURL(next)}
+	}
+}
+
+// fetchCrashLogs returns each submission's crash log text, downloading the
+// crash log relationship when the attribute is not populated. Submissions
+// without a crash log get an empty string.
+func fetchCrashLogs(ctx context.Context, client groupClient, crashes []asc.Resource[asc.CrashAttributes]) ([]string, error) {
+	logs := make([]string, len(crashes))
+	errs := make([]error, len(crashes))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(crashLogFetchWorkers, len(crashes)) {
+		wg.Go(func() {
+			for i := range jobs {
+				if text := crashes[i].Attributes.CrashLog; strings.TrimSpace(text) != "" {
+					logs[i] = text
+					continue
+				}
+				requestCtx, cancel := shared.ContextWithTimeout(ctx)
+				resp, err := client.GetBetaFeedbackCrashSubmissionCrashLog(requestCtx, crashes[i].ID)
+				cancel()
+				if err != nil {
+					if !asc.IsNotFound(err) {
+						errs[i] = fmt.Errorf("failed to fetch crash log for %s: %w", crashes[i].ID, err)
+					}
+					continue
+				}
+				logs[i] = resp.Data.Attributes.LogText
+			}
+		})
+	}
+	for i := range crashes {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return logs, nil
+}
@@ -0,0 +1,149 @@
+package crashes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+type stubGroupClient struct {
+	pages [][]asc.Resource[asc.CrashAttributes]
+	logs  map[string]string
+
+	mu        sync.Mutex
+	pageCalls int
+	logCalls  []string
+}
+
+func (s *stubGroupClient) GetCrashes(ctx context.Context, appID string, opts ...asc.CrashOption) (*asc.CrashesResponse, error) {
+	page := s.pageCalls
+	s.pageCalls++
+	resp := &asc.CrashesResponse{
+		Data:     s.pages[page],
+		Included: json.RawMessage(`[{"type":"builds","id":"build-1","attributes":{"version":"42"}},{"type":"builds","id":"build-2","attributes":{"version":"43"}}]`),
+	}
+	if page+1 < len(s.pages) {
+		resp.Links.Next = fmt.Sprintf("https://api.appstoreconnect.apple.com/v1/apps/%s/betaFeedbackCrashSubmissions?cursor=%d", appID, page+1)
+	}
+	return resp, nil
+}
+
+func (s *stubGroupClient) GetBetaFeedbackCrashSubmissionCrashLog(ctx context.Context, submissionID string) (*asc.BetaCrashLogResponse, error) {
+	s.mu.Lock()
+	s.logCalls = append(s.logCalls, submissionID)
+	s.mu.Unlock()
+
+	text, ok := s.logs[submissionID]
+	if !ok {
+		return nil, asc.ErrNotFound
+	}
+	resp := &asc.BetaCrashLogResponse{}
+	resp.Data.Attributes.LogText = text
+	return resp, nil
+}
+
+const groupTestLog = `Process: MyApp [1]
+Exception Type:  EXC_BAD_ACCESS (SIGSEGV)
+
+Thread 0 Crashed:
+0   MyApp    	0x0000000100f4c9a8 ProfileViewController.load() + %d (ProfileViewController.swift:%d)
+1   UIKitCore	0x00000001b0000000 -[UIViewController loadViewIfRequired] + 1000
+`
+
+func crashResource(id, buildID, created, osVersion, crashLog string) asc.Resource[asc.CrashAttributes] {
+	return asc.Resource[asc.CrashAttributes]{
+		ID:            id,
+		Attributes:    asc.CrashAttributes{CreatedDate: created, OSVersion: osVersion, DeviceModel: "iPhone16,1", CrashLog: crashLog},
+		Relationships: json.RawMessage(`{"build":{"data":{"type":"builds","id":"` + buildID + `"}}}`),
+	}
+}
+
+func TestGroupCrashes(t *testing.T) {
+	client := &stubGroupClient{
+		pages: [][]asc.Resource[asc.CrashAttributes]{
+			{
+				crashResource("c1", "build-2", "2026-03-05T10:00:00Z", "18.2", fmt.Sprintf(groupTestLog, 12, 40)),
+				crashResource("c2", "build-1", "2026-03-04T10:00:00Z", "18.1", ""),
+			},
+			{
+				crashResource("c3", "build-1", "2026-03-01T10:00:00Z", "18.1", ""),
+				crashResource("c4", "build-3", "2026-03-02T10:00:00Z", "18.1", ""),
+			},
+		},
+		logs: map[string]string{
+			"c2": fmt.Sprintf(groupTestLog, 36, 42),
+			"c3": fmt.Sprintf(groupTestLog, 36, 42),
+		},
+	}
+
+	report, err := groupCrashes(context.Background(), client, groupOptions{AppID: "app-1", Frames: 5, MaxSubmissions: 10})
+	if err != nil {
+		t.Fatalf("groupCrashes() error: %v", err)
+	}
+	if report.Submissions != 4 || len(report.Groups) != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if client.pageCalls != 2 {
+		t.Fatalf("expected 2 page requests, got %d", client.pageCalls)
+	}
+	if len(client.logCalls) != 3 {
+		t.Fatalf("expected crash logs fetched only when missing, got %v", client.logCalls)
+	}
+
+	top := report.Groups[0]
+	if top.Count != 3 || top.Builds["42"] != 2 || top.Builds["43"] != 1 {
+		t.Fatalf("unexpected top group: %+v", top)
+	}
+	if !strings.HasPrefix(top.Title, "EXC_BAD_ACCESS (SIGSEGV) in MyApp ProfileViewController.load()") {
+		t.Fatalf("unexpected title %q", top.Title)
+	}
+	if top.FirstSeen.Format("2006-01-02") != "2026-03-01" || top.LastSeen.Format("2006-01-02") != "2026-03-05" {
+		t.Fatalf("unexpected first/last seen: %v %v", top.FirstSeen, top.LastSeen)
+	}
+	if missing := report.Groups[1]; missing.Title != "(no crash log)" || missing.Builds["build-3"] != 1 {
+		t.Fatalf("expected submission without log or build version in its own group: %+v", missing)
+	}
+}
+
+func TestGroupCrashesLimitsAndTop(t *testing.T) {
+	client := &stubGroupClient{
+		pages: [][]asc.Resource[asc.CrashAttributes]{
+			{
+				crashResource("c1", "build-1", "2026-03-05T10:00:00Z", "18.2", fmt.Sprintf(groupTestLog, 1, 1)),
+				crashResource("c2", "build-1", "2026-03-04T10:00:00Z", "18.1", "Exception Type: EXC_CRASH (SIGABRT)"),
+			},
+			{crashResource("c3", "build-1", "2026-03-01T10:00:00Z", "18.1", "")},
+		},
+	}
+	report, err := groupCrashes(context.Background(), client, groupOptions{AppID: "app-1", Frames: 5, MaxSubmissions: 2, Top: 1})
+	if err != nil {
+		t.Fatalf("groupCrashes() error: %v", err)
+	}
+	if client.pageCalls != 1 || report.Submissions != 2 || len(report.Groups) != 1 {
+		t.Fatalf("unexpected report %+v after %d pages", report, client.pageCalls)
+	}
+}
+
+func TestGroupCrashesPropagatesLogErrors(t *testing.T) {
+	client := &failingLogClient{stubGroupClient: stubGroupClient{
+		pages: [][]asc.Resource[asc.CrashAttributes]{{crashResource("c1", "build-1", "", "", "")}},
+	}}
+	_, err := groupCrashes(context.Background(), client, groupOptions{AppID: "app-1", Frames: 5, MaxSubmissions: 10})
+	if err == nil || !strings.Contains(err.Error(), "failed to fetch crash log for c1") {
+		t.Fatalf("expected crash log error, got %v", err)
+	}
+}
+
+type failingLogClient struct {
+	stubGroupClient
+}
+
+func (f *failingLogClient) GetBetaFeedbackCrashSubmissionCrashLog(ctx context.Context, submissionID string) (*asc.BetaCrashLogResponse, error) {
+	return nil, errors.New("boom")
+}
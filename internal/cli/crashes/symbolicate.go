@@ -0,0 +1,194 @@
+package crashes
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/symbolicate"
+)
+
+// crashLogExtensions are the files picked up from a --crash-log directory.
+var crashLogExtensions = map[string]bool{".crash": true, ".txt": true, ".log": true, ".json": true}
+
+// CrashesSymbolicateCommand returns the crashes symbolicate subcommand.
+func CrashesSymbolicateCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("crashes symbolicate", flag.ExitOnError)
+
+	dsym := fs.String("dsym", "", "dSYM bundle, folder of dSYMs or DWARF file; comma-separated for several")
+	crashLog := fs.String("crash-log", "", "Crash log file, folder of crash logs, or - for stdin")
+	outputDir := fs.String("output-dir", "", "Write symbolicated logs here (required when --crash-log is a folder)")
+
+	return &ffcli.Command{
+		Name:       "symbolicate",
+		ShortUsage: "asc crashes symbolicate --dsym PATH --crash-log PATH [--output-dir DIR]",
+		ShortHelp:  "Symbolicate crash logs with local dSYM files.",
+		LongHelp: `Symbolicate crash logs with local dSYM files.
+
+Reads the DWARF debug information in the dSYMs, matches each frame's binary
+image by UUID and rewrites it as "function + offset (File.swift:line)". No
+Xcode tools are needed, so this runs on Linux CI.
+
+--crash-log accepts a text crash report, the JSON output of
+'asc testflight beta-crash-logs get', a folder of exported logs (.crash, .txt,
+.log, .json) or - for stdin. A single report is written to stdout; a folder
+requires --output-dir. Frames from system libraries stay unsymbolicated; a
+summary of unmatched images is printed to stderr.
+
+Examples:
+  asc crashes symbolicate --dsym MyApp.app.dSYM --crash-log crash.crash
+  asc testflight beta-crash-logs get --id "CRASH_LOG_ID" | asc crashes symbolicate --dsym ./dSYMs --crash-log -
+  asc crashes symbolicate --dsym ./dSYMs --crash-log ./crashes --output-dir ./symbolicated`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			dsymPaths := shared.SplitCSV(*dsym)
+			if len(dsymPaths) == 0 {
+				fmt.Fprintln(os.Stderr, "Error: --dsym is required")
+				return flag.ErrHelp
+			}
+			input := strings.TrimSpace(*crashLog)
+			if input == "" {
+				fmt.Fprintln(os.Stderr, "Error: --crash-log is required")
+				return flag.ErrHelp
+			}
+			outDir := strings.TrimSpace(*outputDir)
+
+			var files []string
+			if input != "-" {
+				info, err := os.Stat(input)
+				if err != nil {
+					return fmt.Errorf("crashes symbolicate: %w", err)
+				}
+				if info.IsDir() {
+					if outDir == "" {
+						fmt.Fprintln(os.Stderr, "Error: --output-dir is required when --crash-log is a folder")
+						return flag.ErrHelp
+					}
+					files, err = crashLogFiles(input)
+					if err != nil {
+						return fmt.Errorf("crashes symbolicate: %w", err)
+					}
+					if len(files) == 0 {
+						return fmt.Errorf("crashes symbolicate: no crash logs found in %s", input)
+					}
+				} else {
+					files = []string{input}
+				}
+			} else {
+				files = []string{input}
+			}
+
+			var images []*symbolicate.Image
+			for _, path := range dsymPaths {
+				loaded, err := symbolicate.LoadDSYM(path)
+				if err != nil {
+					return fmt.Errorf("crashes symbolicate: %w", err)
+				}
+				images = append(images, loaded...)
+			}
+			symbolicator := symbolicate.New(images)
+
+			if outDir != "" {
+				if err := os.MkdirAll(outDir, 0o755); err != nil {
+					return fmt.Errorf("crashes symbolicate: %w", err)
+				}
+			}
+			for _, file := range files {
+				if err := symbolicateFile(symbolicator, file, outDir); err != nil {
+					return fmt.Errorf("crashes symbolicate: %s: %w", displayName(file), err)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func crashLogFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && crashLogExtensions[strings.ToLower(filepath.Ext(entry.Name()))] {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return files, nil
+}
+
+func symbolicateFile(symbolicator *symbolicate.Symbolicator, path, outDir string) error {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return err
+	}
+
+	reports, err := symbolicate.ExtractReports(data)
+	if err != nil {
+		return err
+	}
+	for i, report := range reports {
+		result, err := symbolicator.Symbolicate(report)
+		if err != nil {
+			return err
+		}
+
+		name := displayName(path)
+		if outDir == "" {
+			if i > 0 {
+				fmt.Fprintln(os.Stdout)
+			}
+			if _, err := io.WriteString(os.Stdout, result.Text); err != nil {
+				return err
+			}
+		} else {
+			name = outputName(path, i, len(reports))
+			if err := os.WriteFile(filepath.Join(outDir, name), []byte(result.Text), 0o644); err != nil {
+				return err
+			}
+		}
+		printSymbolicateSummary(name, result)
+	}
+	return nil
+}
+
+func outputName(path string, index, count int) string {
+	base := filepath.Base(path)
+	ext := filepath.Ext(base)
+	if strings.EqualFold(ext, ".json") {
+		base = strings.TrimSuffix(base, ext)
+		if count > 1 {
+			base = fmt.Sprintf("%s-%d", base, index+1)
+		}
+		return base + ".crash"
+	}
+	return base
+}
+
+func displayName(path string) string {
+	if path == "-" {
+		return "stdin"
+	}
+	return filepath.Base(path)
+}
+
+func printSymbolicateSummary(name string, result symbolicate.Result) {
+	fmt.Fprintf(os.Stderr, "%s: symbolicated %d of %d frames\n", name, result.Symbolicated, result.Frames)
+	for _, image := range result.MissingImages {
+		fmt.Fprintf(os.Stderr, "  no dSYM for %s <%s>\n", image.Name, image.UUID)
+	}
+}
@@ -0,0 +1,92 @@
+package crashes
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCrashesSymbolicateValidation(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "missing dsym", args: []string{"--crash-log", "crash.crash"}, wantErr: "--dsym is required"},
+		{name: "missing crash log", args: []string{"--dsym", "App.dSYM"}, wantErr: "--crash-log is required"},
+		{name: "folder without output dir", args: []string{"--dsym", "App.dSYM", "--crash-log", dir}, wantErr: "--output-dir is required"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := CrashesSymbolicateCommand()
+			cmd.FlagSet.SetOutput(io.Discard)
+			if err := cmd.FlagSet.Parse(test.args); err != nil {
+				t.Fatalf("failed to parse flags: %v", err)
+			}
+
+			stderr := captureStderr(t, func() {
+				if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected flag.ErrHelp, got %v", err)
+				}
+			})
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected %q, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
+
+func TestCrashesSymbolicateMissingDSYM(t *testing.T) {
+	crashLog := filepath.Join(t.TempDir(), "crash.crash")
+	if err := os.WriteFile(crashLog, []byte("Binary Images:\n"), 0o644); err != nil {
+		t.Fatalf("write crash log: %v", err)
+	}
+	cmd := CrashesSymbolicateCommand()
+	if err := cmd.FlagSet.Parse([]string{"--dsym", filepath.Join(t.TempDir(), "missing.dSYM"), "--crash-log", crashLog}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	err := cmd.Exec(context.Background(), nil)
+	if err == nil || errors.Is(err, flag.ErrHelp) || !strings.Contains(err.Error(), "crashes symbolicate:") {
+		t.Fatalf("expected runtime error, got %v", err)
+	}
+}
+
+func TestSymbolicateOutputName(t *testing.T) {
+	tests := []struct {
+		path  string
+		index int
+		count int
+		want  string
+	}{
+		{path: "logs/a.crash", count: 1, want: "a.crash"},
+		{path: "logs/b.json", count: 1, want: "b.crash"},
+		{path: "logs/b.json", index: 1, count: 3, want: "b-2.crash"},
+	}
+	for _, test := range tests {
+		if got := outputName(test.path, test.index, test.count); got != test.want {
+			t.Fatalf("outputName(%q, %d, %d) = %q, want %q", test.path, test.index, test.count, got, test.want)
+		}
+	}
+}
+
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	old := os.Stderr
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	os.Stderr = w
+	defer func() { os.Stderr = old }()
+
+	fn()
+	w.Close()
+	data, _ := io.ReadAll(r)
+	return string(data)
+}
@@ -0,0 +1,236 @@
+package devices
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	deviceFileFormatAuto  = "auto"
+	deviceFileFormatApple = "apple"
+	deviceFileFormatCSV   = "csv"
+)
+
+// deviceClassLimit is the number of devices per device class an Apple
+// Developer Program membership may register each membership year.
+const deviceClassLimit = 100
+
+var (
+	// Legacy iOS/tvOS UDIDs are 40 hex characters; newer devices (and Apple
+	// silicon Macs) use an 8-16 hex pair.
+	legacyUDIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+	modernUDIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{16}$`)
+	// Intel Macs register with their hardware UUID.
+	macUUIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// appleDevicePlatforms maps the platform column of Apple's device upload
+// file to API platform values.
+var appleDevicePlatforms = map[string]string{
+	"ios":      "IOS",
+	"mac":      "MAC_OS",
+	"macos":    "MAC_OS",
+	"tvos":     "TV_OS",
+	"visionos": "VISION_OS",
+}
+
+type deviceFileRow struct {
+	line     int
+	udid     string
+	name     string
+	platform string
+}
+
+// resolveDeviceFileFormat picks the file format from --format, falling back
+// to the file extension: .csv is CSV, anything else is Apple's tab-separated
+// format.
+func resolveDeviceFileFormat(value, path string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", deviceFileFormatAuto:
+		if strings.EqualFold(filepath.Ext(path), ".csv") {
+			return deviceFileFormatCSV, nil
+		}
+		return deviceFileFormatApple, nil
+	case deviceFileFormatApple, "tsv", "txt":
+		return deviceFileFormatApple, nil
+	case deviceFileFormatCSV:
+		return deviceFileFormatCSV, nil
+	default:
+		return "", fmt.Errorf("--format must be one of: auto, apple, csv")
+	}
+}
+
+// readDevicesFile parses an Apple device upload file or a CSV with the same
+// columns. A header row ("Device ID, Device Name, Device Platform" or
+// "udid, name, platform") is optional; rows without a platform use
+// defaultPlatform.
+func readDevicesFile(path, format, defaultPlatform string) ([]deviceFileRow, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return parseDevicesFile(file, format, defaultPlatform)
+}
+
+func parseDevicesFile(r io.Reader, format, defaultPlatform string) ([]deviceFileRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.Comment = '#'
+	if format == deviceFileFormatApple {
+		reader.Comma = '\t'
+	}
+
+	columns := map[string]int{"udid": 0, "name": 1, "platform": 2}
+	rows := make([]deviceFileRow, 0)
+	first := true
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read devices file: %w", err)
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		if first {
+			first = false
+			if header, ok := deviceFileHeader(record); ok {
+				columns = header
+				continue
+			}
+		}
+
+		get := func(column string) string {
+			idx, ok := columns[column]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+		platform := get("platform")
+		if platform == "" {
+			platform = defaultPlatform
+		}
+		rows = append(rows, deviceFileRow{
+			line:     line,
+			udid:     get("udid"),
+			name:     get("name"),
+			platform: platform,
+		})
+	}
+	if len(rows) == 0 {
+		return nil, shared.UsageError("devices file has no device rows")
+	}
+	return rows, nil
+}
+
+func deviceFileHeader(record []string) (map[string]int, bool) {
+	columns := map[string]int{}
+	for idx, value := range record {
+		key := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(value, "\ufeff")))
+		switch key {
+		case "device id", "udid", "identifier":
+			columns["udid"] = idx
+		case "device name", "name":
+			columns["name"] = idx
+		case "device platform", "platform":
+			columns["platform"] = idx
+		}
+	}
+	_, hasUDID := columns["udid"]
+	return columns, hasUDID
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeDeviceFilePlatform accepts Apple's file values (ios, mac) as well
+// as API platform values.
+func normalizeDeviceFilePlatform(value string) (string, error) {
+	if platform, ok := appleDevicePlatforms[strings.ToLower(strings.TrimSpace(value))]; ok {
+		return platform, nil
+	}
+	return normalizeDevicePlatform(value)
+}
+
+// validateDeviceUDID checks udid against the formats Apple issues for
+// platform.
+func validateDeviceUDID(udid, platform string) error {
+	switch {
+	case udid == "":
+		return fmt.Errorf("UDID is required")
+	case modernUDIDPattern.MatchString(udid):
+		return nil
+	case platform == "MAC_OS" && macUUIDPattern.MatchString(udid):
+		return nil
+	case (platform == "IOS" || platform == "TV_OS") && legacyUDIDPattern.MatchString(udid):
+		return nil
+	case platform == "MAC_OS":
+		return fmt.Errorf("invalid macOS UDID %q: expected a hardware UUID or 00000000-0000000000000000", udid)
+	case platform == "VISION_OS":
+		return fmt.Errorf("invalid visionOS UDID %q: expected 00000000-0000000000000000", udid)
+	default:
+		return fmt.Errorf("invalid UDID %q: expected 40 hex characters or 00000000-0000000000000000", udid)
+	}
+}
+
+// appleDevicePlatformValue maps an API platform to the value Apple's device
+// upload file uses.
+func appleDevicePlatformValue(platform asc.DevicePlatform) string {
+	switch platform {
+	case asc.DevicePlatformMacOS:
+		return "mac"
+	case "TV_OS":
+		return "tvos"
+	case "VISION_OS":
+		return "visionos"
+	default:
+		return "ios"
+	}
+}
+
+// formatDevicesFile renders devices in Apple's upload format or as CSV.
+func formatDevicesFile(devices []asc.Resource[asc.DeviceAttributes], format string) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	header := []string{"udid", "name", "platform"}
+	if format == deviceFileFormatApple {
+		writer.Comma = '\t'
+		header = []string{"Device ID", "Device Name", "Device Platform"}
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	for _, device := range devices {
+		attrs := device.Attributes
+		platform := string(attrs.Platform)
+		if format == deviceFileFormatApple {
+			platform = appleDevicePlatformValue(attrs.Platform)
+		}
+		if err := writer.Write([]string{attrs.UDID, attrs.Name, platform}); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
@@ -0,0 +1,136 @@
+package devices
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+const appleDevicesFile = "Device ID\tDevice Name\tDevice Platform\n" +
+	"00008030-001A2D3E0C38802E\tQA iPhone 15\tios\n" +
+	"\n" +
+	"A1B2C3D4-E5F6-A7B8-C9D0-E1F2A3B4C5D6\tQA Intel Mac\tmac\n"
+
+func TestParseDevicesFileAppleFormat(t *testing.T) {
+	rows, err := parseDevicesFile(strings.NewReader(appleDevicesFile), deviceFileFormatApple, "IOS")
+	if err != nil {
+		t.Fatalf("parseDevicesFile() error: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %+v", rows)
+	}
+	if rows[0].udid != "00008030-001A2D3E0C38802E" || rows[0].name != "QA iPhone 15" || rows[0].platform != "ios" || rows[0].line != 2 {
+		t.Fatalf("unexpected first row: %+v", rows[0])
+	}
+	if rows[1].platform != "mac" || rows[1].line != 4 {
+		t.Fatalf("unexpected second row: %+v", rows[1])
+	}
+}
+
+func TestParseDevicesFileCSVWithoutPlatformColumn(t *testing.T) {
+	input := "name,udid\n\"Tester, Inc iPad\",00008027-000A1B2C3D4E5F60\n"
+	rows, err := parseDevicesFile(strings.NewReader(input), deviceFileFormatCSV, "VISION_OS")
+	if err != nil {
+		t.Fatalf("parseDevicesFile() error: %v", err)
+	}
+	if len(rows) != 1 || rows[0].name != "Tester, Inc iPad" || rows[0].udid != "00008027-000A1B2C3D4E5F60" || rows[0].platform != "VISION_OS" {
+		t.Fatalf("unexpected rows: %+v", rows)
+	}
+}
+
+func TestValidateDeviceUDID(t *testing.T) {
+	tests := []struct {
+		udid     string
+		platform string
+		valid    bool
+	}{
+		{"00008030-001A2D3E0C38802E", "IOS", true},
+		{strings.Repeat("a1", 20), "IOS", true},
+		{strings.Repeat("a1", 20), "VISION_OS", false},
+		{"A1B2C3D4-E5F6-A7B8-C9D0-E1F2A3B4C5D6", "MAC_OS", true},
+		{"A1B2C3D4-E5F6-A7B8-C9D0-E1F2A3B4C5D6", "IOS", false},
+		{"not-a-udid", "IOS", false},
+		{"", "IOS", false},
+	}
+	for _, test := range tests {
+		err := validateDeviceUDID(test.udid, test.platform)
+		if (err == nil) != test.valid {
+			t.Errorf("validateDeviceUDID(%q, %s) error = %v, want valid=%v", test.udid, test.platform, err, test.valid)
+		}
+	}
+}
+
+func TestPlanDeviceImportSkipsRegisteredAndDuplicates(t *testing.T) {
+	rows := []deviceFileRow{
+		{line: 2, udid: "00008030-001A2D3E0C38802E", name: "Registered", platform: "ios"},
+		{line: 3, udid: "00008030-00000000000000AA", name: "New", platform: "ios"},
+		{line: 4, udid: "00008030-00000000000000aa", name: "New again", platform: "ios"},
+		{line: 5, udid: "bad", name: "Broken", platform: "ios"},
+		{line: 6, udid: "00008030-00000000000000BB", name: "Toaster", platform: "toaster"},
+	}
+	registered := map[string]string{"00008030-001a2d3e0c38802e": "DEVICE_1"}
+	summary := &devicesImportSummary{}
+
+	planned := planDeviceImport(rows, registered, summary)
+	if len(planned) != 1 || summary.Results[planned[0]].Line != 3 {
+		t.Fatalf("expected only line 3 to be planned, got %v", planned)
+	}
+	if summary.Skipped != 2 || summary.Invalid != 2 {
+		t.Fatalf("expected 2 skipped and 2 invalid, got %+v", summary)
+	}
+	if summary.Results[0].DeviceID != "DEVICE_1" || summary.Results[2].Reason != "duplicate of line 3" {
+		t.Fatalf("unexpected results: %+v", summary.Results)
+	}
+}
+
+func TestDeviceSlotsByClass(t *testing.T) {
+	devices := []asc.Resource[asc.DeviceAttributes]{
+		{Attributes: asc.DeviceAttributes{DeviceClass: asc.DeviceClassIPhone}},
+		{Attributes: asc.DeviceAttributes{DeviceClass: asc.DeviceClassIPhone, Status: asc.DeviceStatusDisabled}},
+		{Attributes: asc.DeviceAttributes{DeviceClass: "APPLE_VISION_PRO"}},
+	}
+	slots := deviceSlotsByClass(devices, map[string]int{"IPHONE": 3})
+	byClass := map[string]deviceSlots{}
+	for _, slot := range slots {
+		byClass[slot.DeviceClass] = slot
+	}
+	if byClass["IPHONE"].Registered != 2 || byClass["IPHONE"].Planned != 3 || byClass["IPHONE"].Remaining != 95 {
+		t.Fatalf("unexpected iPhone slots: %+v", byClass["IPHONE"])
+	}
+	if byClass["MAC"].Remaining != deviceClassLimit || byClass["APPLE_VISION_PRO"].Remaining != 99 {
+		t.Fatalf("unexpected slots: %+v", slots)
+	}
+	if full := fullDeviceClasses(slots); len(full) != 0 {
+		t.Fatalf("expected room for planned devices, got full classes %v", full)
+	}
+
+	slots = deviceSlotsByClass(devices, map[string]int{"IPHONE": deviceClassLimit - 1, "MAC": 1})
+	if full := fullDeviceClasses(slots); !reflect.DeepEqual(full, []string{"IPHONE"}) {
+		t.Fatalf("expected iPhone slots to be full, got %v", full)
+	}
+}
+
+func TestFormatDevicesFileRoundTrips(t *testing.T) {
+	devices := []asc.Resource[asc.DeviceAttributes]{
+		{Attributes: asc.DeviceAttributes{Name: "QA iPhone", UDID: "00008030-001A2D3E0C38802E", Platform: asc.DevicePlatformIOS}},
+		{Attributes: asc.DeviceAttributes{Name: "QA Mac", UDID: "A1B2C3D4-E5F6-A7B8-C9D0-E1F2A3B4C5D6", Platform: asc.DevicePlatformMacOS}},
+	}
+	for _, format := range []string{deviceFileFormatApple, deviceFileFormatCSV} {
+		data, err := formatDevicesFile(devices, format)
+		if err != nil {
+			t.Fatalf("formatDevicesFile(%s) error: %v", format, err)
+		}
+		rows, err := parseDevicesFile(strings.NewReader(string(data)), format, "IOS")
+		if err != nil {
+			t.Fatalf("parseDevicesFile(%s) error: %v", format, err)
+		}
+		if len(rows) != 2 || rows[1].name != "QA Mac" {
+			t.Fatalf("unexpected %s rows: %+v", format, rows)
+		}
+		if platform, err := normalizeDeviceFilePlatform(rows[1].platform); err != nil || platform != "MAC_OS" {
+			t.Fatalf("expected MAC_OS after %s round trip, got %q, %v", format, platform, err)
+		}
+	}
+}
@@ -0,0 +1,489 @@
+package devices
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	deviceImportStatusRegistered    = "registered"
+	deviceImportStatusWouldRegister = "would-register"
+	deviceImportStatusSkipped       = "skipped"
+	deviceImportStatusInvalid       = "invalid"
+	deviceImportStatusBlocked       = "blocked"
+	deviceImportStatusFailed        = "failed"
+)
+
+type devicesImportResult struct {
+	Line     int    `json:"line"`
+	UDID     string `json:"udid"`
+	Name     string `json:"name,omitempty"`
+	Platform string `json:"platform,omitempty"`
+	Status   string `json:"status"`
+	DeviceID string `json:"deviceId,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+type deviceSlots struct {
+	DeviceClass string `json:"deviceClass"`
+	Registered  int    `json:"registered"`
+	Planned     int    `json:"planned"`
+	Remaining   int    `json:"remaining"`
+}
+
+type devicesImportSummary struct {
+	InputFile  string                `json:"inputFile"`
+	Format     string                `json:"format"`
+	DryRun     bool                  `json:"dryRun"`
+	Total      int                   `json:"total"`
+	Registered int                   `json:"registered"`
+	Skipped    int                   `json:"skipped"`
+	Invalid    int                   `json:"invalid"`
+	Failed     int                   `json:"failed"`
+	Results    []devicesImportResult `json:"results"`
+	Slots      []deviceSlots         `json:"slots"`
+}
+
+type devicesExportSummary struct {
+	OutputFile string `json:"outputFile"`
+	Format     string `json:"format"`
+	Total      int    `json:"total"`
+}
+
+// DevicesImportCommand returns the devices import subcommand.
+func DevicesImportCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+
+	filePath := fs.String("file", "", "Devices file: Apple's tab-separated upload format or CSV (required)")
+	format := fs.String("format", deviceFileFormatAuto, "File format: auto (by extension), apple, csv")
+	platform := fs.String("platform", "IOS", "Platform for rows without one: "+strings.Join(devicePlatformList(), ", "))
+	dryRun := fs.Bool("dry-run", false, "Validate and print the plan without registering devices")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "import",
+		ShortUsage: "asc devices import --file devices.txt [flags]",
+		ShortHelp:  "Register devices in bulk from a devices file.",
+		LongHelp: `Register devices in bulk from a devices file.
+
+Accepts Apple's tab-separated device upload format (Device ID, Device Name,
+Device Platform) or a CSV with udid,name,platform columns. UDIDs are validated
+for their platform before anything is registered, and UDIDs that are already
+registered are skipped. The summary reports the remaining device slots per
+device class; disabled devices keep their slot until the membership renews.
+Nothing is registered when the file needs more slots than remain. Apple
+assigns iOS devices their class (iPhone, iPad, ...) only once registered, so
+new iOS devices are counted against the iPhone slots.
+
+Examples:
+  asc devices import --file "devices.txt" --dry-run
+  asc devices import --file "devices.txt"
+  asc devices import --file "qa-devices.csv" --platform IOS --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			inputValue := strings.TrimSpace(*filePath)
+			if inputValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --file is required")
+				return flag.ErrHelp
+			}
+			formatValue, err := resolveDeviceFileFormat(*format, inputValue)
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+			defaultPlatform, err := normalizeDevicePlatform(*platform)
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+
+			rows, err := readDevicesFile(inputValue, formatValue, defaultPlatform)
+			if err != nil {
+				return fmt.Errorf("devices import: %w", err)
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("devices import: %w", err)
+			}
+
+			listCtx, listCancel := shared.ContextWithTimeout(ctx)
+			existing, err := fetchAllDevices(listCtx, client)
+			listCancel()
+			if err != nil {
+				return fmt.Errorf("devices import: %w", err)
+			}
+
+			summary := &devicesImportSummary{
+				InputFile: filepath.Clean(inputValue),
+				Format:    formatValue,
+				DryRun:    *dryRun,
+				Total:     len(rows),
+			}
+			registered := make(map[string]string, len(existing))
+			for _, device := range existing {
+				registered[strings.ToLower(device.Attributes.UDID)] = device.ID
+			}
+			planned := planDeviceImport(rows, registered, summary)
+			summary.Slots = deviceSlotsByClass(existing, plannedDeviceClasses(summary.Results, planned))
+			full := fullDeviceClasses(summary.Slots)
+
+			// Nothing is registered unless every row in the file is valid and
+			// fits in the remaining slots.
+			for _, idx := range planned {
+				result := &summary.Results[idx]
+				switch {
+				case summary.Invalid > 0:
+					result.Status = deviceImportStatusBlocked
+					result.Reason = "not registered because the file has invalid rows"
+					continue
+				case len(full) > 0:
+					result.Status = deviceImportStatusBlocked
+					result.Reason = "not registered because the file exceeds the remaining " + strings.Join(full, ", ") + " slots"
+					continue
+				case *dryRun:
+					result.Status = deviceImportStatusWouldRegister
+					continue
+				}
+				createCtx, createCancel := shared.ContextWithTimeout(ctx)
+				created, err := client.CreateDevice(createCtx, asc.DeviceCreateAttributes{
+					Name:     result.Name,
+					UDID:     result.UDID,
+					Platform: asc.DevicePlatform(result.Platform),
+				})
+				createCancel()
+				if err != nil {
+					result.Status = deviceImportStatusFailed
+					result.Reason = err.Error()
+					summary.Failed++
+					continue
+				}
+				result.Status = deviceImportStatusRegistered
+				result.DeviceID = created.Data.ID
+				summary.Registered++
+				existing = append(existing, created.Data)
+			}
+			if summary.Registered > 0 || summary.Failed > 0 {
+				summary.Slots = deviceSlotsByClass(existing, nil)
+			}
+
+			if err := shared.PrintOutputWithRenderers(
+				summary,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderDevicesImportSummary(summary, false) },
+				func() error { return renderDevicesImportSummary(summary, true) },
+			); err != nil {
+				return err
+			}
+
+			if summary.Invalid > 0 {
+				return shared.NewReportedError(fmt.Errorf("devices import: %d invalid row(s); no devices were registered", summary.Invalid))
+			}
+			if len(full) > 0 {
+				return shared.NewReportedError(fmt.Errorf("devices import: not enough %s slots remain; no devices were registered", strings.Join(full, ", ")))
+			}
+			if summary.Failed > 0 {
+				return shared.NewReportedError(fmt.Errorf("devices import: %d device(s) failed to register", summary.Failed))
+			}
+			return nil
+		},
+	}
+}
+
+// planDeviceImport validates rows and records a result for each one. It
+// returns the indexes of results that still need to be registered.
+func planDeviceImport(rows []deviceFileRow, registered map[string]string, summary *devicesImportSummary) []int {
+	var planned []int
+	seen := make(map[string]int, len(rows))
+	for _, row := range rows {
+		result := devicesImportResult{Line: row.line, UDID: row.udid, Name: row.name}
+		platform, err := normalizeDeviceFilePlatform(row.platform)
+		switch {
+		case err != nil:
+			result.Status = deviceImportStatusInvalid
+			result.Reason = fmt.Sprintf("unknown platform %q", row.platform)
+		case row.name == "":
+			result.Platform = platform
+			result.Status = deviceImportStatusInvalid
+			result.Reason = "device name is required"
+		default:
+			result.Platform = platform
+			if err := validateDeviceUDID(row.udid, platform); err != nil {
+				result.Status = deviceImportStatusInvalid
+				result.Reason = err.Error()
+			}
+		}
+
+		key := strings.ToLower(row.udid)
+		if result.Status == "" {
+			if id, ok := registered[key]; ok {
+				result.Status = deviceImportStatusSkipped
+				result.DeviceID = id
+				result.Reason = "already registered"
+			} else if line, ok := seen[key]; ok {
+				result.Status = deviceImportStatusSkipped
+				result.Reason = fmt.Sprintf("duplicate of line %d", line)
+			}
+		}
+
+		switch result.Status {
+		case deviceImportStatusInvalid:
+			summary.Invalid++
+		case deviceImportStatusSkipped:
+			summary.Skipped++
+		default:
+			seen[key] = row.line
+			planned = append(planned, len(summary.Results))
+		}
+		summary.Results = append(summary.Results, result)
+	}
+	return planned
+}
+
+// deviceClassByPlatform maps a device platform to the device class its new
+// devices are counted against. Apple reports an iOS device's class only once
+// it is registered, so new iOS devices count as iPhones.
+var deviceClassByPlatform = map[string]string{
+	string(asc.DevicePlatformIOS):   string(asc.DeviceClassIPhone),
+	string(asc.DevicePlatformMacOS): string(asc.DeviceClassMac),
+	"TV_OS":                         string(asc.DeviceClassAppleTV),
+	"VISION_OS":                     "APPLE_VISION_PRO",
+}
+
+// plannedDeviceClasses counts the planned registrations per device class.
+func plannedDeviceClasses(results []devicesImportResult, planned []int) map[string]int {
+	counts := map[string]int{}
+	for _, idx := range planned {
+		if class := deviceClassByPlatform[results[idx].Platform]; class != "" {
+			counts[class]++
+		}
+	}
+	return counts
+}
+
+// deviceSlotsByClass counts registered and planned devices per device class.
+// Every class Apple limits is listed, even when no devices of that class are
+// registered.
+func deviceSlotsByClass(devices []asc.Resource[asc.DeviceAttributes], planned map[string]int) []deviceSlots {
+	counts := map[string]int{}
+	for _, device := range devices {
+		if class := string(device.Attributes.DeviceClass); class != "" {
+			counts[class]++
+		}
+	}
+	classes := []string{
+		string(asc.DeviceClassIPhone),
+		string(asc.DeviceClassIPad),
+		string(asc.DeviceClassIPod),
+		string(asc.DeviceClassAppleWatch),
+		string(asc.DeviceClassAppleTV),
+		string(asc.DeviceClassMac),
+	}
+	var others []string
+	for _, extra := range []map[string]int{counts, planned} {
+		for class := range extra {
+			if !slices.Contains(classes, class) && !slices.Contains(others, class) {
+				others = append(others, class)
+			}
+		}
+	}
+	slices.Sort(others)
+	classes = append(classes, others...)
+
+	slots := make([]deviceSlots, 0, len(classes))
+	for _, class := range classes {
+		slots = append(slots, deviceSlots{
+			DeviceClass: class,
+			Registered:  counts[class],
+			Planned:     planned[class],
+			Remaining:   max(deviceClassLimit-counts[class]-planned[class], 0),
+		})
+	}
+	return slots
+}
+
+// fullDeviceClasses returns the device classes whose planned registrations
+// exceed the slots left.
+func fullDeviceClasses(slots []deviceSlots) []string {
+	var full []string
+	for _, slot := range slots {
+		if slot.Planned > 0 && slot.Registered+slot.Planned > deviceClassLimit {
+			full = append(full, slot.DeviceClass)
+		}
+	}
+	return full
+}
+
+func renderDevicesImportSummary(summary *devicesImportSummary, markdown bool) error {
+	render := asc.RenderTable
+	if markdown {
+		render = asc.RenderMarkdown
+	}
+
+	render(
+		[]string{"Input File", "Format", "Dry Run", "Total", "Registered", "Skipped", "Invalid", "Failed"},
+		[][]string{{
+			summary.InputFile,
+			summary.Format,
+			fmt.Sprintf("%t", summary.DryRun),
+			fmt.Sprintf("%d", summary.Total),
+			fmt.Sprintf("%d", summary.Registered),
+			fmt.Sprintf("%d", summary.Skipped),
+			fmt.Sprintf("%d", summary.Invalid),
+			fmt.Sprintf("%d", summary.Failed),
+		}},
+	)
+
+	rows := make([][]string, 0, len(summary.Results))
+	for _, result := range summary.Results {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", result.Line),
+			result.UDID,
+			result.Name,
+			result.Platform,
+			result.Status,
+			result.Reason,
+		})
+	}
+	render([]string{"Line", "UDID", "Name", "Platform", "Status", "Reason"}, rows)
+
+	slotRows := make([][]string, 0, len(summary.Slots))
+	for _, slot := range summary.Slots {
+		slotRows = append(slotRows, []string{
+			slot.DeviceClass,
+			fmt.Sprintf("%d", slot.Registered),
+			fmt.Sprintf("%d", slot.Planned),
+			fmt.Sprintf("%d", slot.Remaining),
+		})
+	}
+	render([]string{"Device Class", "Registered", "Planned", "Remaining"}, slotRows)
+	return nil
+}
+
+// DevicesExportCommand returns the devices export subcommand.
+func DevicesExportCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+
+	filePath := fs.String("file", "", "Output devices file path (required)")
+	format := fs.String("format", deviceFileFormatAuto, "File format: auto (by extension), apple, csv")
+	platform := fs.String("platform", "", "Filter by platform(s), comma-separated: "+strings.Join(devicePlatformList(), ", "))
+	status := fs.String("status", "", "Filter by status: ENABLED, DISABLED")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "export",
+		ShortUsage: "asc devices export --file devices.txt [flags]",
+		ShortHelp:  "Export registered devices to a devices file.",
+		LongHelp: `Export registered devices to a devices file.
+
+Writes Apple's tab-separated device upload format (or CSV for .csv files or
+--format csv), which 'asc devices import' and the developer portal accept.
+
+Examples:
+  asc devices export --file "devices.txt"
+  asc devices export --file "devices.csv" --status ENABLED
+  asc devices export --file "macs.txt" --platform MAC_OS`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			outputValue := strings.TrimSpace(*filePath)
+			if outputValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --file is required")
+				return flag.ErrHelp
+			}
+			formatValue, err := resolveDeviceFileFormat(*format, outputValue)
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+			platformValues, err := normalizeDevicePlatforms(shared.SplitCSV(*platform))
+			if err != nil {
+				return fmt.Errorf("devices export: %w", err)
+			}
+			statusValue, err := normalizeDeviceStatus(*status)
+			if err != nil {
+				return fmt.Errorf("devices export: %w", err)
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("devices export: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			var opts []asc.DevicesOption
+			if len(platformValues) > 0 {
+				opts = append(opts, asc.WithDevicesPlatforms(platformValues))
+			}
+			if statusValue != "" {
+				opts = append(opts, asc.WithDevicesStatus(statusValue))
+			}
+			devices, err := fetchAllDevices(requestCtx, client, opts...)
+			if err != nil {
+				return fmt.Errorf("devices export: %w", err)
+			}
+			slices.SortStableFunc(devices, func(a, b asc.Resource[asc.DeviceAttributes]) int {
+				return strings.Compare(strings.ToLower(a.Attributes.Name), strings.ToLower(b.Attributes.Name))
+			})
+
+			data, err := formatDevicesFile(devices, formatValue)
+			if err != nil {
+				return fmt.Errorf("devices export: %w", err)
+			}
+			if _, err := shared.WriteFileNoSymlinkOverwrite(outputValue, bytes.NewReader(data), 0o644, ".asc-devices-*", ".asc-devices-backup-*"); err != nil {
+				return fmt.Errorf("devices export: %w", err)
+			}
+
+			summary := &devicesExportSummary{
+				OutputFile: filepath.Clean(outputValue),
+				Format:     formatValue,
+				Total:      len(devices),
+			}
+			headers := []string{"Output File", "Format", "Total"}
+			rows := [][]string{{summary.OutputFile, summary.Format, fmt.Sprintf("%d", summary.Total)}}
+			return shared.PrintOutputWithRenderers(
+				summary,
+				*output.Output,
+				*output.Pretty,
+				func() error {
+					asc.RenderTable(headers, rows)
+					return nil
+				},
+				func() error {
+					asc.RenderMarkdown(headers, rows)
+					return nil
+				},
+			)
+		},
+	}
+}
+
+func fetchAllDevices(ctx context.Context, client *asc.Client, opts ...asc.DevicesOption) ([]asc.Resource[asc.DeviceAttributes], error) {
+	firstPage, err := client.GetDevices(ctx, append(opts, asc.WithDevicesLimit(200))...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch devices: %w", err)
+	}
+	all, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetDevices(ctx, asc.WithDevicesNextURL(nextURL))
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, ok := all.(*asc.DevicesResponse)
+	if !ok || resp == nil {
+		return nil, fmt.Errorf("unexpected devices response type")
+	}
+	return resp.Data, nil
+}
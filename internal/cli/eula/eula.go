@@ -69,7 +69,11 @@ Examples:
 			idValue := strings.TrimSpace(*id)
 			appValue := ""
 			if idValue == "" {
-				appValue = shared.ResolveAppID(*appID)
+				var err error
+				appValue, err = shared.ResolveAppID(ctx, *appID)
+				if err != nil {
+					return fmt.Errorf("eula get: %w", err)
+				}
 			}
 			if idValue == "" && appValue == "" {
 				fmt.Fprintln(os.Stderr, "Error: --id or --app is required (or set ASC_APP_ID)")
@@ -121,7 +125,10 @@ Examples:
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
-			appValue := shared.ResolveAppID(*appID)
+			appValue, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("eula list: %w", err)
+			}
 			if appValue == "" {
 				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
 				return flag.ErrHelp
@@ -165,7 +172,10 @@ Examples:
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
-			appValue := shared.ResolveAppID(*appID)
+			appValue, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("eula create: %w", err)
+			}
 			if appValue == "" {
 				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
 				return flag.ErrHelp
@@ -34,11 +34,12 @@ func FeedbackCommand() *ffcli.Command {
 
 	return &ffcli.Command{
 		Name:       "feedback",
-		ShortUsage: "asc feedback [flags]",
+		ShortUsage: "asc feedback [flags] | asc feedback group [flags]",
 		ShortHelp:  "List TestFlight feedback from beta testers.",
 		LongHelp: `List TestFlight feedback from beta testers.
 
-This command fetches beta feedback screenshot submissions and comments.
+This command fetches beta feedback screenshot submissions and comments. Use
+'asc feedback group' to cluster near-identical comments.
 
 Examples:
   asc feedback --app "123456789"
@@ -46,9 +47,13 @@ Examples:
   asc feedback --app "123456789" --device-model "iPhone15,3" --os-version "17.2"
   asc feedback --app "123456789" --sort -createdDate --limit 5
   asc feedback --next "<links.next>"
-  asc feedback --app "123456789" --paginate`,
+  asc feedback --app "123456789" --paginate
+  asc feedback group --app "123456789" --top 10 --output table`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			FeedbackGroupCommand(),
+		},
 		Exec: func(ctx context.Context, args []string) error {
 			if *limit != 0 && (*limit < 1 || *limit > 200) {
 				return fmt.Errorf("feedback: --limit must be between 1 and 200")
@@ -60,7 +65,10 @@ Examples:
 				return fmt.Errorf("feedback: %w", err)
 			}
 
-			resolvedAppID := shared.ResolveAppID(*appID)
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("feedback: %w", err)
+			}
 			if resolvedAppID == "" && strings.TrimSpace(*next) == "" {
 				fmt.Fprintf(os.Stderr, "Error: --app is required (or set ASC_APP_ID)\n\n")
 				return flag.ErrHelp
@@ -0,0 +1,156 @@
+package feedback
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/crashgroup"
+)
+
+const defaultGroupMaxSubmissions = 500
+
+// groupClient is the subset of the App Store Connect client used by group.
+type groupClient interface {
+	GetFeedback(ctx context.Context, appID string, opts ...asc.FeedbackOption) (*asc.FeedbackResponse, error)
+}
+
+// groupReport is the output of feedback group.
+type groupReport struct {
+	Submissions int                `json:"submissions"`
+	Groups      []crashgroup.Group `json:"groups"`
+}
+
+// FeedbackGroupCommand returns the feedback group subcommand.
+func FeedbackGroupCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("feedback group", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID env)")
+	buildID := fs.String("build", "", "Filter by build ID(s), comma-separated")
+	buildPreRelease := fs.String("build-pre-release-version", "", "Filter by pre-release version ID(s), comma-separated")
+	deviceModel := fs.String("device-model", "", "Filter by device model(s), comma-separated")
+	osVersion := fs.String("os-version", "", "Filter by OS version(s), comma-separated")
+	maxSubmissions := fs.Int("max-submissions", defaultGroupMaxSubmissions, "Maximum number of newest submissions to group")
+	top := fs.Int("top", 0, "Only show the N largest groups (0 = all)")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "group",
+		ShortUsage: "asc feedback group --app APP_ID [flags]",
+		ShortHelp:  "Group feedback submissions with near-identical comments.",
+		LongHelp: `Group feedback submissions with near-identical comments.
+
+Comments are compared ignoring case, punctuation and numbers. Each group
+reports counts per build, OS version and device model, and when it was first
+and last seen. Groups are sorted by count.
+
+Examples:
+  asc feedback group --app "123456789"
+  asc feedback group --app "123456789" --build "BUILD_ID" --top 10 --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("feedback group: %w", err)
+			}
+			if resolvedAppID == "" {
+				fmt.Fprintf(os.Stderr, "Error: --app is required (or set ASC_APP_ID)\n\n")
+				return flag.ErrHelp
+			}
+			if *maxSubmissions < 1 {
+				return shared.UsageError("--max-submissions must be at least 1")
+			}
+			if *top < 0 {
+				return shared.UsageError("--top must not be negative")
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("feedback group: %w", err)
+			}
+
+			filters := []asc.FeedbackOption{
+				asc.WithFeedbackBuildIDs(shared.SplitCSV(*buildID)),
+				asc.WithFeedbackBuildPreReleaseVersionIDs(shared.SplitCSV(*buildPreRelease)),
+				asc.WithFeedbackDeviceModels(shared.SplitCSV(*deviceModel)),
+				asc.WithFeedbackOSVersions(shared.SplitCSV(*osVersion)),
+			}
+			report, err := groupFeedback(ctx, client, resolvedAppID, filters, *maxSubmissions, *top)
+			if err != nil {
+				return fmt.Errorf("feedback group: %w", err)
+			}
+
+			return shared.PrintOutputWithRenderers(
+				report,
+				*output.Output,
+				*output.Pretty,
+				func() error { asc.RenderTable(crashgroup.Headers, crashgroup.Rows(report.Groups)); return nil },
+				func() error { asc.RenderMarkdown(crashgroup.Headers, crashgroup.Rows(report.Groups)); return nil },
+			)
+		},
+	}
+}
+
+func groupFeedback(ctx context.Context, client groupClient, appID string, filters []asc.FeedbackOption, maxSubmissions, top int) (*groupReport, error) {
+	var submissions []crashgroup.Submission
+	builds := map[string]string{}
+
+	pageOpts := append([]asc.FeedbackOption{}, filters...)
+	pageOpts = append(pageOpts,
+		asc.WithFeedbackIncludeBuild(),
+		asc.WithFeedbackSort("-createdDate"),
+		asc.WithFeedbackLimit(min(maxSubmissions, 200)),
+	)
+	var feedback []asc.Resource[asc.FeedbackAttributes]
+	for len(feedback) < maxSubmissions {
+		requestCtx, cancel := shared.ContextWithTimeout(ctx)
+		page, err := client.GetFeedback(requestCtx, appID, pageOpts...)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch feedback: %w", err)
+		}
+		for id, version := range crashgroup.BuildLabels(page.Included) {
+			builds[id] = version
+		}
+		feedback = append(feedback, page.Data...)
+		next := strings.TrimSpace(page.Links.Next)
+		if next == "" {
+			break
+		}
+		pageOpts = []asc.FeedbackOption{asc.WithFeedbackNextURL(next)}
+	}
+	if len(feedback) > maxSubmissions {
+		feedback = feedback[:maxSubmissions]
+	}
+
+	for _, item := range feedback {
+		buildID := crashgroup.RelatedBuildID(item.Relationships)
+		build := builds[buildID]
+		if build == "" {
+			build = buildID
+		}
+		createdAt, _ := time.Parse(time.RFC3339, item.Attributes.CreatedDate)
+		submissions = append(submissions, crashgroup.Submission{
+			ID:          item.ID,
+			Signature:   crashgroup.FeedbackSignature(item.Attributes.Comment),
+			Build:       build,
+			OSVersion:   item.Attributes.OSVersion,
+			DeviceModel: item.Attributes.DeviceModel,
+			CreatedAt:   createdAt,
+		})
+	}
+
+	groups := crashgroup.GroupSubmissions(submissions)
+	if top > 0 && len(groups) > top {
+		groups = groups[:top]
+	}
+	return &groupReport{Submissions: len(submissions), Groups: groups}, nil
+}
@@ -0,0 +1,56 @@
+package feedback
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+type stubGroupClient struct {
+	data []asc.Resource[asc.FeedbackAttributes]
+}
+
+func (s *stubGroupClient) GetFeedback(ctx context.Context, appID string, opts ...asc.FeedbackOption) (*asc.FeedbackResponse, error) {
+	return &asc.FeedbackResponse{
+		Data:     s.data,
+		Included: json.RawMessage(`[{"type":"builds","id":"build-1","attributes":{"version":"42"}}]`),
+	}, nil
+}
+
+func feedbackResource(id, comment, created string) asc.Resource[asc.FeedbackAttributes] {
+	return asc.Resource[asc.FeedbackAttributes]{
+		ID:            id,
+		Attributes:    asc.FeedbackAttributes{Comment: comment, CreatedDate: created, OSVersion: "18.1"},
+		Relationships: json.RawMessage(`{"build":{"data":{"type":"builds","id":"build-1"}}}`),
+	}
+}
+
+func TestGroupFeedback(t *testing.T) {
+	client := &stubGroupClient{data: []asc.Resource[asc.FeedbackAttributes]{
+		feedbackResource("f1", "Login button does nothing!", "2026-03-02T10:00:00Z"),
+		feedbackResource("f2", "Dark mode colors look off", "2026-03-03T10:00:00Z"),
+		feedbackResource("f3", "login button does nothing", "2026-03-01T10:00:00Z"),
+	}}
+
+	report, err := groupFeedback(context.Background(), client, "app-1", nil, 10, 0)
+	if err != nil {
+		t.Fatalf("groupFeedback() error: %v", err)
+	}
+	if report.Submissions != 3 || len(report.Groups) != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	top := report.Groups[0]
+	if top.Count != 2 || top.Title != "Login button does nothing!" || top.Builds["42"] != 2 {
+		t.Fatalf("unexpected top group: %+v", top)
+	}
+
+	limited, err := groupFeedback(context.Background(), client, "app-1", nil, 2, 1)
+	if err != nil {
+		t.Fatalf("groupFeedback() error: %v", err)
+	}
+	if limited.Submissions != 2 || len(limited.Groups) != 1 {
+		t.Fatalf("unexpected limited report: %+v", limited)
+	}
+}
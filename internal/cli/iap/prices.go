@@ -91,7 +91,7 @@ Examples:
 		Exec: func(ctx context.Context, args []string) error {
 			requestedIAPID := strings.TrimSpace(*iapID)
 			requestedAppID := strings.TrimSpace(*appID)
-			if requestedIAPID == "" && shared.ResolveAppID(requestedAppID) == "" {
+			if requestedIAPID == "" && shared.ResolveAppIDValue(requestedAppID) == "" {
 				fmt.Fprintln(os.Stderr, "Error: --app or --iap-id is required")
 				return flag.ErrHelp
 			}
@@ -116,7 +116,10 @@ Examples:
 				}
 				iaps = []asc.Resource[asc.InAppPurchaseV2Attributes]{resp.Data}
 			} else {
-				resolvedAppID := shared.ResolveAppID(requestedAppID)
+				resolvedAppID, err := shared.ResolveAppID(ctx, requestedAppID)
+				if err != nil {
+					return fmt.Errorf("iap prices: %w", err)
+				}
 				firstPage, err := client.GetInAppPurchasesV2(requestCtx, resolvedAppID, asc.WithIAPLimit(200))
 				if err != nil {
 					return fmt.Errorf("iap prices: failed to fetch IAP list: %w", err)
@@ -0,0 +1,222 @@
+package ipa
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/ipa"
+)
+
+// IPAInspectCommand returns the ipa inspect subcommand.
+func IPAInspectCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("ipa inspect", flag.ExitOnError)
+
+	ipaPath := fs.String("ipa", "", "Path to an .ipa file or .app bundle (required)")
+	depth := fs.Int("depth", ipa.DefaultSizeDepth, "Directory levels below the app bundle in the size breakdown")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "inspect",
+		ShortUsage: "asc ipa inspect --ipa PATH [flags]",
+		ShortHelp:  "Report what is inside an IPA or app bundle.",
+		LongHelp: `Report what is inside an IPA or app bundle.
+
+Reports the app's bundle ID, version and build, the embedded provisioning
+profile (team, type, expiry, devices and entitlements), the code-signing
+entitlements, the Mach-O architectures with their platform and minimum OS,
+every app extension and framework, and a per-directory size breakdown.
+
+Use it before uploading to catch a wrong profile or a bloated bundle.
+
+Examples:
+  asc ipa inspect --ipa MyApp.ipa
+  asc ipa inspect --ipa MyApp.ipa --output table
+  asc ipa inspect --ipa build/Build/Products/Release-iphoneos/MyApp.app --depth 2 --output markdown`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			path := strings.TrimSpace(*ipaPath)
+			if path == "" {
+				fmt.Fprintln(os.Stderr, "Error: --ipa is required")
+				return flag.ErrHelp
+			}
+			if *depth < 1 {
+				return shared.UsageError("--depth must be at least 1")
+			}
+
+			archive, err := ipa.Open(path)
+			if err != nil {
+				return fmt.Errorf("ipa inspect: %w", err)
+			}
+			defer archive.Close()
+
+			report, err := ipa.Inspect(archive, ipa.Options{SizeDepth: *depth})
+			if err != nil {
+				return fmt.Errorf("ipa inspect: %w", err)
+			}
+
+			return shared.PrintOutputWithRenderers(
+				report,
+				*output.Output,
+				*output.Pretty,
+				func() error { renderInspectReport(report, asc.RenderTable); return nil },
+				func() error { renderInspectReport(report, asc.RenderMarkdown); return nil },
+			)
+		},
+	}
+}
+
+func renderInspectReport(report *ipa.Report, render func([]string, [][]string)) {
+	render([]string{"Field", "Value"}, summaryRows(report))
+
+	bundles := append([]ipa.Bundle{report.App}, report.Extensions...)
+	render([]string{"Bundle", "Bundle ID", "Architectures", "Min OS", "Extension Point", "Size"}, bundleRows(bundles))
+
+	if len(report.Frameworks) > 0 {
+		render([]string{"Framework", "Bundle ID", "Version", "Size"}, frameworkRows(report.Frameworks))
+	}
+	if len(report.App.Entitlements) > 0 {
+		render([]string{"Entitlement", "Value"}, entitlementRows(report.App.Entitlements))
+	}
+	render(sizeHeaders(report), sizeRows(report))
+}
+
+func summaryRows(report *ipa.Report) [][]string {
+	app := report.App
+	rows := [][]string{
+		{"Path", report.Path},
+		{"Name", app.Name},
+		{"Bundle ID", app.BundleID},
+		{"Version", app.Version},
+		{"Build", app.Build},
+		{"Minimum OS", app.MinimumOSVersion},
+		{"Size", sizeLabel(report.TotalSize, report.CompressedSize)},
+	}
+	if profile := app.Profile; profile != nil {
+		expiry := profile.ExpirationDate.Format(time.RFC3339)
+		if profile.Expired {
+			expiry += " (expired)"
+		}
+		devices := strconv.Itoa(len(profile.Devices))
+		if profile.ProvisionsAllDevices {
+			devices = "all"
+		}
+		rows = append(rows,
+			[]string{"Profile", fmt.Sprintf("%s (%s)", profile.Name, profile.UUID)},
+			[]string{"Profile Type", profile.Type},
+			[]string{"Team", strings.TrimSpace(profile.TeamName + " " + parenthesize(profile.TeamID))},
+			[]string{"Profile App ID", profile.ApplicationID},
+			[]string{"Profile Expires", expiry},
+			[]string{"Profile Devices", devices},
+		)
+	} else {
+		rows = append(rows, []string{"Profile", "none"})
+	}
+	for _, warning := range app.Warnings {
+		rows = append(rows, []string{"Warning", warning})
+	}
+	return rows
+}
+
+func bundleRows(bundles []ipa.Bundle) [][]string {
+	rows := make([][]string, 0, len(bundles))
+	for _, bundle := range bundles {
+		var archs, minOS []string
+		for _, arch := range bundle.Architectures {
+			archs = append(archs, arch.Name)
+			label := strings.TrimSpace(arch.Platform + " " + arch.MinOS)
+			if arch.MinOS != "" && !slices.Contains(minOS, label) {
+				minOS = append(minOS, label)
+			}
+		}
+		if len(minOS) == 0 && bundle.MinimumOSVersion != "" {
+			minOS = []string{bundle.MinimumOSVersion}
+		}
+		rows = append(rows, []string{
+			bundle.Path,
+			bundle.BundleID,
+			strings.Join(archs, ", "),
+			strings.Join(minOS, ", "),
+			bundle.ExtensionPoint,
+			ipa.FormatSize(bundle.Size),
+		})
+	}
+	return rows
+}
+
+func frameworkRows(frameworks []ipa.Bundle) [][]string {
+	rows := make([][]string, 0, len(frameworks))
+	for _, framework := range frameworks {
+		rows = append(rows, []string{framework.Path, framework.BundleID, framework.Version, ipa.FormatSize(framework.Size)})
+	}
+	return rows
+}
+
+func entitlementRows(entitlements map[string]any) [][]string {
+	keys := make([]string, 0, len(entitlements))
+	for key := range entitlements {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	rows := make([][]string, 0, len(keys))
+	for _, key := range keys {
+		rows = append(rows, []string{key, formatEntitlement(entitlements[key])})
+	}
+	return rows
+}
+
+func formatEntitlement(value any) string {
+	switch v := value.(type) {
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			parts = append(parts, formatEntitlement(item))
+		}
+		return strings.Join(parts, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func sizeHeaders(report *ipa.Report) []string {
+	if report.CompressedSize > 0 {
+		return []string{"Path", "Files", "Size", "Compressed"}
+	}
+	return []string{"Path", "Files", "Size"}
+}
+
+func sizeRows(report *ipa.Report) [][]string {
+	rows := make([][]string, 0, len(report.Sizes))
+	for _, entry := range report.Sizes {
+		row := []string{entry.Path, strconv.Itoa(entry.Files), ipa.FormatSize(entry.Size)}
+		if report.CompressedSize > 0 {
+			row = append(row, ipa.FormatSize(entry.CompressedSize))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func sizeLabel(size, compressed int64) string {
+	if compressed == 0 {
+		return ipa.FormatSize(size)
+	}
+	return fmt.Sprintf("%s (%s compressed)", ipa.FormatSize(size), ipa.FormatSize(compressed))
+}
+
+func parenthesize(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "(" + value + ")"
+}
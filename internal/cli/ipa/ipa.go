@@ -0,0 +1,36 @@
+package ipa
+
+import (
+	"context"
+	"flag"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// IPACommand returns the ipa command group.
+func IPACommand() *ffcli.Command {
+	return &ffcli.Command{
+		Name:       "ipa",
+		ShortUsage: "asc ipa <subcommand> [flags]",
+		ShortHelp:  "Inspect and lint IPA files and app bundles offline.",
+		LongHelp: `Inspect and lint IPA files and app bundles offline.
+
+These commands read the archive locally and never contact App Store Connect,
+so they run on Linux CI before an upload.
+
+Examples:
+  asc ipa inspect --ipa MyApp.ipa
+  asc ipa inspect --ipa MyApp.ipa --output table
+  asc ipa lint --ipa MyApp.ipa --bundle-id com.example.app`,
+		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			IPAInspectCommand(),
+			IPALintCommand(),
+		},
+		Exec: func(ctx context.Context, args []string) error {
+			return flag.ErrHelp
+		},
+	}
+}
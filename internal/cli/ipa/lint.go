@@ -0,0 +1,90 @@
+package ipa
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/ipa"
+)
+
+// IPALintCommand returns the ipa lint subcommand.
+func IPALintCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("ipa lint", flag.ExitOnError)
+
+	ipaPath := fs.String("ipa", "", "Path to an .ipa file or .app bundle (required)")
+	bundleID := fs.String("bundle-id", "", "Expected bundle ID (checked offline)")
+	appID := fs.String("app", "", "App Store Connect app ID whose bundle ID the IPA must match (requires auth)")
+	strict := fs.Bool("strict", false, "Treat warnings as errors (exit non-zero)")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "lint",
+		ShortUsage: "asc ipa lint --ipa PATH [flags]",
+		ShortHelp:  "Check an IPA for problems that fail App Store processing.",
+		LongHelp: `Check an IPA for problems that fail App Store processing.
+
+Checks run locally against the archive:
+  - bundle ID matches --bundle-id, or the app given by --app
+  - ITSAppUsesNonExemptEncryption is declared in Info.plist
+  - app icons have no alpha channel (loose PNGs only; the 1024px icon
+    compiled into Assets.car is not decoded and is reported as an info check)
+  - the embedded provisioning profile exists, is unexpired and matches the bundle ID
+  - required reason APIs used by each binary are declared in a privacy manifest
+
+The same checks run automatically before 'asc builds upload' and
+'asc publish'; pass --skip-lint there to bypass them.
+
+Examples:
+  asc ipa lint --ipa MyApp.ipa
+  asc ipa lint --ipa MyApp.ipa --bundle-id com.example.app --strict
+  asc ipa lint --ipa MyApp.ipa --app "123456789" --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			path := strings.TrimSpace(*ipaPath)
+			if path == "" {
+				fmt.Fprintln(os.Stderr, "Error: --ipa is required")
+				return flag.ErrHelp
+			}
+			expectedBundleID := strings.TrimSpace(*bundleID)
+			app := strings.TrimSpace(*appID)
+			if expectedBundleID != "" && app != "" {
+				return shared.UsageError("--bundle-id and --app are mutually exclusive")
+			}
+
+			if app != "" {
+				client, err := shared.GetASCClient()
+				if err != nil {
+					return fmt.Errorf("ipa lint: %w", err)
+				}
+				requestCtx, cancel := shared.ContextWithTimeout(ctx)
+				resp, err := client.GetApp(requestCtx, app)
+				cancel()
+				if err != nil {
+					return fmt.Errorf("ipa lint: failed to fetch app: %w", err)
+				}
+				expectedBundleID = strings.TrimSpace(resp.Data.Attributes.BundleID)
+			}
+
+			report, err := ipa.Lint(path, expectedBundleID, *strict)
+			if err != nil {
+				return fmt.Errorf("ipa lint: %w", err)
+			}
+
+			shared.RecordArtifactReportChecks(path, report.Checks)
+			if err := shared.PrintOutput(report, *output.Output, *output.Pretty); err != nil {
+				return err
+			}
+			if report.Summary.Blocking > 0 {
+				return shared.NewReportedError(fmt.Errorf("ipa lint: found %d blocking issue(s)", report.Summary.Blocking))
+			}
+			return nil
+		},
+	}
+}
@@ -136,7 +136,10 @@ Examples:
 				}
 				return shared.PrintOutput(resp, *output.Output, *output.Pretty)
 			case shared.LocalizationTypeAppInfo:
-				resolvedAppID := shared.ResolveAppID(*appID)
+				resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+				if err != nil {
+					return fmt.Errorf("localizations list: %w", err)
+				}
 				if resolvedAppID == "" {
 					fmt.Fprintln(os.Stderr, "Error: --app is required for app-info localizations")
 					return flag.ErrHelp
@@ -312,7 +315,10 @@ Examples:
 
 				return shared.PrintOutput(&result, *output.Output, *output.Pretty)
 			case shared.LocalizationTypeAppInfo:
-				resolvedAppID := shared.ResolveAppID(*appID)
+				resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+				if err != nil {
+					return fmt.Errorf("localizations download: %w", err)
+				}
 				if resolvedAppID == "" {
 					fmt.Fprintln(os.Stderr, "Error: --app is required for app-info localizations")
 					return flag.ErrHelp
@@ -473,7 +479,10 @@ Examples:
 
 				return shared.PrintOutput(&result, *output.Output, *output.Pretty)
 			case shared.LocalizationTypeAppInfo:
-				resolvedAppID := shared.ResolveAppID(*appID)
+				resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+				if err != nil {
+					return fmt.Errorf("localizations upload: %w", err)
+				}
 				if resolvedAppID == "" {
 					fmt.Fprintln(os.Stderr, "Error: --app is required for app-info localizations")
 					return flag.ErrHelp
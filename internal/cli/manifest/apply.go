@@ -0,0 +1,416 @@
+package manifest
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+type manifestApplySummary struct {
+	File      string           `json:"file"`
+	AppID     string           `json:"appId"`
+	AppInfoID string           `json:"appInfoId,omitempty"`
+	VersionID string           `json:"versionId,omitempty"`
+	DryRun    bool             `json:"dryRun"`
+	Planned   int              `json:"planned"`
+	Applied   int              `json:"applied"`
+	Changes   []manifestChange `json:"changes"`
+}
+
+// ApplyCommand returns the apply command.
+func ApplyCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("apply", flag.ExitOnError)
+
+	var file string
+	fs.StringVar(&file, "file", "", "Manifest YAML file path (required)")
+	fs.StringVar(&file, "f", "", "Shorthand for --file")
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID env; defaults to app.id in the file)")
+	appInfoID := fs.String("app-info", "", "App info ID (defaults to app.appInfoId in the file, then the editable app info)")
+	versionID := fs.String("version-id", "", "App Store version ID (defaults to version in the file)")
+	version := fs.String("version", "", "App Store version string (e.g., 1.2.0); resolved with --platform")
+	platform := fs.String("platform", "", "Platform for --version: IOS, MAC_OS, TV_OS, VISION_OS (default IOS)")
+	dryRun := fs.Bool("dry-run", false, "Print the plan without mutating network state")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "apply",
+		ShortUsage: "asc apply -f app.yaml [flags]",
+		ShortHelp:  "Apply a declarative metadata manifest to an app.",
+		LongHelp: `Apply a declarative metadata manifest to an app.
+
+Reads a YAML manifest, diffs every section in it against live App Store
+Connect state, prints the plan, and applies only the fields that differ.
+Sections that are omitted from the manifest are left untouched.
+
+Manifest sections:
+  app                   id and optional appInfoId
+  version               id, or version and platform (for version-scoped sections)
+  versionLocalizations  locale -> description, keywords, marketingUrl,
+                        promotionalText, supportUrl, whatsNew
+  appInfoLocalizations  locale -> name, subtitle, privacyPolicyUrl,
+                        privacyChoicesUrl, privacyPolicyText
+  categories            primary and secondary category IDs
+  ageRating             age rating declaration attributes (API names)
+  reviewDetails         contact and demo account fields, notes
+  availability          territories and availableInNewTerritories
+  pricing               baseTerritory, pricePoint, optional startDate
+
+Empty localization values are ignored. Changing the base price keeps the
+manual prices in other territories and scheduled base price changes. Use
+'asc export' to generate a manifest from a live app.
+
+Examples:
+  asc apply -f app.yaml --dry-run
+  asc apply -f app.yaml
+  asc apply --file app.yaml --app "APP_ID" --version "1.2.0" --platform IOS
+  asc apply -f app.yaml --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			fileValue := strings.TrimSpace(file)
+			if fileValue == "" {
+				fmt.Fprintf(os.Stderr, "Error: --file is required\n\n")
+				return flag.ErrHelp
+			}
+
+			manifest, err := readManifestYAML(fileValue)
+			if err != nil {
+				return fmt.Errorf("apply: %w", err)
+			}
+
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("apply: %w", err)
+			}
+			if resolvedAppID == "" {
+				resolvedAppID = strings.TrimSpace(manifest.App.ID)
+			}
+			if resolvedAppID == "" {
+				fmt.Fprintf(os.Stderr, "Error: --app is required (or set ASC_APP_ID or app.id in the file)\n\n")
+				return flag.ErrHelp
+			}
+
+			selector, err := resolveVersionSelector(manifest.Version, *versionID, *version, *platform)
+			if err != nil {
+				return err
+			}
+			if manifest.needsVersion() && selector == nil {
+				fmt.Fprintf(os.Stderr, "Error: --version-id or --version is required (or set version in the file)\n\n")
+				return flag.ErrHelp
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("apply: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			target := manifestTarget{AppID: resolvedAppID}
+			if manifest.needsVersion() {
+				target.VersionID, err = selector.resolve(requestCtx, client, resolvedAppID)
+				if err != nil {
+					return fmt.Errorf("apply: %w", err)
+				}
+			}
+			if manifest.needsAppInfo() {
+				target.AppInfoID, err = resolveManifestAppInfoID(requestCtx, client, resolvedAppID, firstNonEmpty(*appInfoID, manifest.App.AppInfoID))
+				if err != nil {
+					return fmt.Errorf("apply: %w", err)
+				}
+			}
+
+			now := time.Now()
+			changes, err := planManifest(requestCtx, client, target, manifest, now)
+			if err != nil {
+				return fmt.Errorf("apply: %w", err)
+			}
+
+			summary := &manifestApplySummary{
+				File:      filepath.Clean(fileValue),
+				AppID:     target.AppID,
+				AppInfoID: target.AppInfoID,
+				VersionID: target.VersionID,
+				DryRun:    *dryRun,
+				Planned:   len(changes),
+				Changes:   changes,
+			}
+
+			var applyErr error
+			if !*dryRun {
+				summary.Applied, applyErr = applyManifest(requestCtx, client, target, manifest, changes, now)
+			}
+
+			if err := shared.PrintOutputWithRenderers(
+				summary,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderManifestApplyTables(summary, false) },
+				func() error { return renderManifestApplyTables(summary, true) },
+			); err != nil {
+				return err
+			}
+
+			if applyErr != nil {
+				return shared.NewReportedError(fmt.Errorf("apply: %w", applyErr))
+			}
+			return nil
+		},
+	}
+}
+
+// ReadFile reads and validates a manifest YAML file.
+func ReadFile(path string) (*Manifest, error) {
+	return readManifestYAML(path)
+}
+
+// Apply applies a manifest to an app and one of its App Store versions the
+// way asc apply does, and returns how many changes were planned and
+// applied. versionID is required when the manifest has version-scoped
+// sections.
+func Apply(ctx context.Context, client *asc.Client, appID, versionID string, manifest *Manifest) (int, int, error) {
+	target := manifestTarget{AppID: appID, VersionID: versionID}
+	if manifest.needsVersion() && target.VersionID == "" {
+		return 0, 0, fmt.Errorf("an App Store version is required for version-scoped sections")
+	}
+	if manifest.needsAppInfo() {
+		appInfoID, err := resolveManifestAppInfoID(ctx, client, appID, manifest.App.AppInfoID)
+		if err != nil {
+			return 0, 0, err
+		}
+		target.AppInfoID = appInfoID
+	}
+
+	now := time.Now()
+	changes, err := planManifest(ctx, client, target, manifest, now)
+	if err != nil {
+		return 0, 0, err
+	}
+	applied, err := applyManifest(ctx, client, target, manifest, changes, now)
+	return len(changes), applied, err
+}
+
+// applyManifest executes changes in order and returns how many were applied
+// before the first failure.
+func applyManifest(ctx context.Context, client manifestClient, target manifestTarget, manifest *Manifest, changes []manifestChange, now time.Time) (int, error) {
+	for i := range changes {
+		change := &changes[i]
+		if err := applyManifestChange(ctx, client, target, manifest, change, now); err != nil {
+			return i, fmt.Errorf("%s %s: %w", change.Action, change.label(), err)
+		}
+	}
+	return len(changes), nil
+}
+
+func applyManifestChange(ctx context.Context, client manifestClient, target manifestTarget, manifest *Manifest, change *manifestChange, now time.Time) error {
+	switch change.Section {
+	case sectionVersionLocalizations:
+		values := changedLocalizationValues(manifest.VersionLocalizations[change.Target], change)
+		_, err := shared.UploadVersionLocalizations(ctx, client, target.VersionID, map[string]map[string]string{change.Target: values}, false)
+		return err
+	case sectionAppInfoLocalizations:
+		values := changedLocalizationValues(manifest.AppInfoLocalizations[change.Target], change)
+		_, err := shared.UploadAppInfoLocalizations(ctx, client, target.AppInfoID, map[string]map[string]string{change.Target: values}, false)
+		return err
+	case sectionCategories:
+		_, err := client.UpdateAppInfoCategories(ctx, change.id, strings.TrimSpace(manifest.Categories.Primary), strings.TrimSpace(manifest.Categories.Secondary))
+		return err
+	case sectionAgeRating:
+		values := make(map[string]any, len(change.Fields))
+		for _, field := range change.Fields {
+			values[field.Field] = manifest.AgeRating[field.Field]
+		}
+		attrs, err := ageRatingAttributes(values)
+		if err != nil {
+			return err
+		}
+		_, err = client.UpdateAgeRatingDeclaration(ctx, change.id, attrs)
+		return err
+	case sectionReviewDetails:
+		attrs := reviewDetailUpdateAttributes(*manifest.ReviewDetails, change)
+		if change.Action == manifestActionCreate {
+			created := asc.AppStoreReviewDetailCreateAttributes(attrs)
+			_, err := client.CreateAppStoreReviewDetail(ctx, target.VersionID, &created)
+			return err
+		}
+		_, err := client.UpdateAppStoreReviewDetail(ctx, change.id, attrs)
+		return err
+	case sectionAvailability:
+		_, err := client.CreateAppAvailabilityV2(ctx, target.AppID, availabilityAttributes(*manifest.Availability, change))
+		return err
+	case sectionPricing:
+		startDate := strings.TrimSpace(manifest.Pricing.StartDate)
+		if startDate == "" {
+			startDate = now.Format("2006-01-02")
+		}
+		baseTerritory := strings.ToUpper(strings.TrimSpace(manifest.Pricing.BaseTerritory))
+		_, err := client.CreateAppPriceSchedule(ctx, target.AppID, asc.AppPriceScheduleCreateAttributes{
+			PricePointID:     strings.TrimSpace(manifest.Pricing.PricePoint),
+			StartDate:        startDate,
+			BaseTerritoryID:  baseTerritory,
+			AdditionalPrices: additionalPrices(change.manualPrices, baseTerritory, startDate, now),
+		})
+		return err
+	default:
+		return fmt.Errorf("unknown section %q", change.Section)
+	}
+}
+
+// additionalPrices keeps the live manual prices around the new base price. A
+// base price starting on the same day as the new one is replaced by it.
+func additionalPrices(live []liveManualPrice, baseTerritory, startDate string, now time.Time) []asc.AppPriceScheduleManualPrice {
+	today := now.Format("2006-01-02")
+	prices := make([]asc.AppPriceScheduleManualPrice, 0, len(live))
+	for _, price := range live {
+		if price.territory == baseTerritory {
+			effective := price.startDate
+			if effective == "" {
+				effective = today
+			}
+			if effective == startDate {
+				continue
+			}
+		}
+		prices = append(prices, asc.AppPriceScheduleManualPrice{PricePointID: price.pricePoint, StartDate: price.startDate})
+	}
+	return prices
+}
+
+// changedLocalizationValues returns only the fields a change touches so that
+// updates never resend unchanged copy.
+func changedLocalizationValues(desired map[string]string, change *manifestChange) map[string]string {
+	values := make(map[string]string, len(change.Fields))
+	for _, field := range change.Fields {
+		values[field.Field] = desired[field.Field]
+	}
+	return values
+}
+
+func reviewDetailUpdateAttributes(desired ManifestReviewDetails, change *manifestChange) asc.AppStoreReviewDetailUpdateAttributes {
+	var attrs asc.AppStoreReviewDetailUpdateAttributes
+	for _, field := range change.Fields {
+		switch field.Field {
+		case "contactFirstName":
+			attrs.ContactFirstName = desired.ContactFirstName
+		case "contactLastName":
+			attrs.ContactLastName = desired.ContactLastName
+		case "contactPhone":
+			attrs.ContactPhone = desired.ContactPhone
+		case "contactEmail":
+			attrs.ContactEmail = desired.ContactEmail
+		case "demoAccountName":
+			attrs.DemoAccountName = desired.DemoAccountName
+		case "demoAccountPassword":
+			attrs.DemoAccountPassword = desired.DemoAccountPassword
+		case "demoAccountRequired":
+			attrs.DemoAccountRequired = desired.DemoAccountRequired
+		case "notes":
+			attrs.Notes = desired.Notes
+		}
+	}
+	return attrs
+}
+
+// availabilityAttributes marks every manifest territory available and every
+// territory that is live today but missing from the manifest unavailable.
+func availabilityAttributes(desired ManifestAvailability, change *manifestChange) asc.AppAvailabilityV2CreateAttributes {
+	want := normalizeTerritories(desired.Territories)
+	wanted := make(map[string]struct{}, len(want))
+	territories := make([]asc.TerritoryAvailabilityCreate, 0, len(want))
+	for _, territory := range want {
+		wanted[territory] = struct{}{}
+		territories = append(territories, asc.TerritoryAvailabilityCreate{TerritoryID: territory, Available: true})
+	}
+	for _, field := range change.Fields {
+		if field.Field != "territories" || field.Current == "" {
+			continue
+		}
+		for _, territory := range strings.Split(field.Current, ",") {
+			if _, ok := wanted[territory]; !ok {
+				territories = append(territories, asc.TerritoryAvailabilityCreate{TerritoryID: territory, Available: false})
+			}
+		}
+	}
+	return asc.AppAvailabilityV2CreateAttributes{
+		AvailableInNewTerritories: desired.AvailableInNewTerritories,
+		TerritoryAvailabilities:   territories,
+	}
+}
+
+func (c manifestChange) label() string {
+	if c.Target != "" {
+		return c.Section + " " + c.Target
+	}
+	return c.Section
+}
+
+func renderManifestApplyTables(summary *manifestApplySummary, markdown bool) error {
+	if summary == nil {
+		return fmt.Errorf("summary is nil")
+	}
+
+	render := asc.RenderTable
+	if markdown {
+		render = asc.RenderMarkdown
+	}
+
+	render(
+		[]string{"App ID", "Manifest", "Dry Run", "Planned", "Applied"},
+		[][]string{{
+			summary.AppID,
+			summary.File,
+			fmt.Sprintf("%t", summary.DryRun),
+			fmt.Sprintf("%d", summary.Planned),
+			fmt.Sprintf("%d", summary.Applied),
+		}},
+	)
+
+	if len(summary.Changes) > 0 {
+		rows := make([][]string, 0, len(summary.Changes))
+		for _, change := range summary.Changes {
+			for _, field := range change.Fields {
+				rows = append(rows, []string{
+					change.Action,
+					change.label(),
+					field.Field,
+					summarizeValue(field.Current),
+					summarizeValue(field.Desired),
+				})
+			}
+		}
+		render([]string{"Action", "Target", "Field", "Current", "Desired"}, rows)
+	}
+
+	return nil
+}
+
+// summarizeValue collapses whitespace and shortens long copy for table cells.
+func summarizeValue(value string) string {
+	const maxRunes = 60
+	value = strings.Join(strings.Fields(value), " ")
+	if utf8.RuneCountInString(value) <= maxRunes {
+		return value
+	}
+	runes := []rune(value)
+	return string(runes[:maxRunes-3]) + "..."
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			return trimmed
+		}
+	}
+	return ""
+}
@@ -0,0 +1,232 @@
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+type manifestExportSummary struct {
+	File                 string `json:"file"`
+	AppID                string `json:"appId"`
+	AppInfoID            string `json:"appInfoId"`
+	VersionID            string `json:"versionId,omitempty"`
+	VersionLocalizations int    `json:"versionLocalizations"`
+	AppInfoLocalizations int    `json:"appInfoLocalizations"`
+}
+
+// ExportCommand returns the export command.
+func ExportCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+
+	var file string
+	fs.StringVar(&file, "file", "", "Output manifest YAML path (default: stdout)")
+	fs.StringVar(&file, "f", "", "Shorthand for --file")
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID env)")
+	appInfoID := fs.String("app-info", "", "App info ID (defaults to the editable app info)")
+	versionID := fs.String("version-id", "", "App Store version ID to export version-scoped sections from")
+	version := fs.String("version", "", "App Store version string (e.g., 1.2.0); resolved with --platform")
+	platform := fs.String("platform", "", "Platform for --version: IOS, MAC_OS, TV_OS, VISION_OS (default IOS)")
+	pretty := shared.BindPrettyJSONFlag(fs)
+
+	return &ffcli.Command{
+		Name:       "export",
+		ShortUsage: "asc export --app APP_ID [--version VERSION] [-f app.yaml]",
+		ShortHelp:  "Export an app's metadata as an apply manifest.",
+		LongHelp: `Export an app's metadata as an apply manifest.
+
+Reads app info localizations, categories, age rating, availability and
+pricing from a live app. When a version is selected, version localizations
+and review details are exported too. The result can be edited and passed to
+'asc apply'.
+
+The demo account password is never exported.
+
+With --file, the manifest is written to that path and a JSON summary is
+printed. Without it, the manifest is printed to stdout.
+
+Examples:
+  asc export --app "APP_ID" --version "1.2.0" -f app.yaml
+  asc export --app "APP_ID" --version-id "VERSION_ID" --file app.yaml
+  asc export --app "APP_ID" > app.yaml`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("export: %w", err)
+			}
+			if resolvedAppID == "" {
+				fmt.Fprintf(os.Stderr, "Error: --app is required (or set ASC_APP_ID)\n\n")
+				return flag.ErrHelp
+			}
+
+			selector, err := resolveVersionSelector(nil, *versionID, *version, *platform)
+			if err != nil {
+				return err
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("export: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			target := manifestTarget{AppID: resolvedAppID}
+			if selector != nil {
+				target.VersionID, err = selector.resolve(requestCtx, client, resolvedAppID)
+				if err != nil {
+					return fmt.Errorf("export: %w", err)
+				}
+			}
+			target.AppInfoID, err = resolveManifestAppInfoID(requestCtx, client, resolvedAppID, *appInfoID)
+			if err != nil {
+				return fmt.Errorf("export: %w", err)
+			}
+
+			manifest, err := exportManifest(requestCtx, client, target, time.Now())
+			if err != nil {
+				return fmt.Errorf("export: %w", err)
+			}
+			if selector != nil {
+				manifest.Version = &ManifestVersion{ID: selector.id, Version: selector.version, Platform: selector.platform}
+			}
+
+			data, err := marshalManifestYAML(manifest)
+			if err != nil {
+				return fmt.Errorf("export: %w", err)
+			}
+
+			fileValue := strings.TrimSpace(file)
+			if fileValue == "" {
+				_, err := os.Stdout.Write(data)
+				return err
+			}
+			if _, err := shared.WriteFileNoSymlinkOverwrite(fileValue, bytes.NewReader(data), 0o644, ".asc-manifest-*", ".asc-manifest-backup-*"); err != nil {
+				return fmt.Errorf("export: %w", err)
+			}
+
+			return shared.PrintOutput(manifestExportSummary{
+				File:                 filepath.Clean(fileValue),
+				AppID:                target.AppID,
+				AppInfoID:            target.AppInfoID,
+				VersionID:            target.VersionID,
+				VersionLocalizations: len(manifest.VersionLocalizations),
+				AppInfoLocalizations: len(manifest.AppInfoLocalizations),
+			}, "json", *pretty)
+		},
+	}
+}
+
+// exportManifest reads live state for every manifest section. Version-scoped
+// sections are skipped when target has no version.
+func exportManifest(ctx context.Context, client manifestClient, target manifestTarget, now time.Time) (*Manifest, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client is required")
+	}
+
+	manifest := &Manifest{App: ManifestApp{ID: target.AppID}}
+
+	if target.VersionID != "" {
+		localizations, err := fetchVersionLocalizations(ctx, client, target.VersionID)
+		if err != nil {
+			return nil, err
+		}
+		manifest.VersionLocalizations = nonEmptyLocalizations(localizations)
+
+		reviewDetails, err := fetchReviewDetails(ctx, client, target.VersionID)
+		if err != nil {
+			return nil, err
+		}
+		if reviewDetails != nil {
+			manifest.ReviewDetails = exportReviewDetails(reviewDetails)
+		}
+	}
+
+	localizations, err := fetchAppInfoLocalizations(ctx, client, target.AppInfoID)
+	if err != nil {
+		return nil, err
+	}
+	manifest.AppInfoLocalizations = nonEmptyLocalizations(localizations)
+
+	categories, err := fetchCategories(ctx, client, target.AppInfoID)
+	if err != nil {
+		return nil, err
+	}
+	if categories.Primary != "" {
+		manifest.Categories = &categories
+	}
+
+	ageRating, err := fetchAgeRating(ctx, client, target.AppInfoID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ageRating.values) > 0 {
+		manifest.AgeRating = ageRating.values
+	}
+
+	availability, err := fetchAvailability(ctx, client, target.AppID)
+	if err != nil {
+		return nil, err
+	}
+	if availability != nil && len(availability.available) > 0 {
+		availableInNewTerritories := availability.availableInNewTerritories
+		manifest.Availability = &ManifestAvailability{
+			AvailableInNewTerritories: &availableInNewTerritories,
+			Territories:               availability.available,
+		}
+	}
+
+	pricing, err := fetchPricing(ctx, client, target.AppID, now)
+	if err != nil {
+		return nil, err
+	}
+	if pricing != nil && pricing.baseTerritory != "" && pricing.pricePoint != "" {
+		manifest.Pricing = &ManifestPricing{
+			BaseTerritory: pricing.baseTerritory,
+			PricePoint:    pricing.pricePoint,
+		}
+	}
+
+	return manifest, nil
+}
+
+func nonEmptyLocalizations(values map[string]map[string]string) map[string]map[string]string {
+	result := make(map[string]map[string]string, len(values))
+	for locale, fields := range values {
+		if len(fields) > 0 {
+			result[locale] = fields
+		}
+	}
+	return result
+}
+
+func exportReviewDetails(live *liveReviewDetails) *ManifestReviewDetails {
+	optional := func(value string) *string {
+		if strings.TrimSpace(value) == "" {
+			return nil
+		}
+		return &value
+	}
+	demoAccountRequired := live.attrs.DemoAccountRequired
+	return &ManifestReviewDetails{
+		ContactFirstName:    optional(live.attrs.ContactFirstName),
+		ContactLastName:     optional(live.attrs.ContactLastName),
+		ContactPhone:        optional(live.attrs.ContactPhone),
+		ContactEmail:        optional(live.attrs.ContactEmail),
+		DemoAccountName:     optional(live.attrs.DemoAccountName),
+		DemoAccountRequired: &demoAccountRequired,
+		Notes:               optional(live.attrs.Notes),
+	}
+}
@@ -0,0 +1,312 @@
+package manifest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// manifestClient is the subset of the App Store Connect client used to read
+// and apply a manifest.
+type manifestClient interface {
+	GetAppStoreVersionLocalizations(ctx context.Context, versionID string, opts ...asc.AppStoreVersionLocalizationsOption) (*asc.AppStoreVersionLocalizationsResponse, error)
+	CreateAppStoreVersionLocalization(ctx context.Context, versionID string, attributes asc.AppStoreVersionLocalizationAttributes) (*asc.AppStoreVersionLocalizationResponse, error)
+	UpdateAppStoreVersionLocalization(ctx context.Context, localizationID string, attributes asc.AppStoreVersionLocalizationAttributes) (*asc.AppStoreVersionLocalizationResponse, error)
+	GetAppInfoLocalizations(ctx context.Context, appInfoID string, opts ...asc.AppInfoLocalizationsOption) (*asc.AppInfoLocalizationsResponse, error)
+	CreateAppInfoLocalization(ctx context.Context, appInfoID string, attributes asc.AppInfoLocalizationAttributes) (*asc.AppInfoLocalizationResponse, error)
+	UpdateAppInfoLocalization(ctx context.Context, localizationID string, attributes asc.AppInfoLocalizationAttributes) (*asc.AppInfoLocalizationResponse, error)
+
+	GetAppInfoPrimaryCategoryRelationship(ctx context.Context, appInfoID string) (*asc.AppInfoPrimaryCategoryLinkageResponse, error)
+	GetAppInfoSecondaryCategoryRelationship(ctx context.Context, appInfoID string) (*asc.AppInfoSecondaryCategoryLinkageResponse, error)
+	UpdateAppInfoCategories(ctx context.Context, appInfoID string, primaryCategoryID, secondaryCategoryID string) (*asc.AppInfoResponse, error)
+
+	GetAgeRatingDeclarationForAppInfo(ctx context.Context, appInfoID string) (*asc.AgeRatingDeclarationResponse, error)
+	UpdateAgeRatingDeclaration(ctx context.Context, declarationID string, attributes asc.AgeRatingDeclarationAttributes) (*asc.AgeRatingDeclarationResponse, error)
+
+	GetAppStoreReviewDetailForVersion(ctx context.Context, versionID string) (*asc.AppStoreReviewDetailResponse, error)
+	CreateAppStoreReviewDetail(ctx context.Context, versionID string, attrs *asc.AppStoreReviewDetailCreateAttributes) (*asc.AppStoreReviewDetailResponse, error)
+	UpdateAppStoreReviewDetail(ctx context.Context, detailID string, attrs asc.AppStoreReviewDetailUpdateAttributes) (*asc.AppStoreReviewDetailResponse, error)
+
+	GetAppAvailabilityV2(ctx context.Context, appID string) (*asc.AppAvailabilityV2Response, error)
+	GetTerritoryAvailabilities(ctx context.Context, availabilityID string, opts ...asc.TerritoryAvailabilitiesOption) (*asc.TerritoryAvailabilitiesResponse, error)
+	CreateAppAvailabilityV2(ctx context.Context, appID string, attrs asc.AppAvailabilityV2CreateAttributes) (*asc.AppAvailabilityV2Response, error)
+
+	GetAppPriceSchedule(ctx context.Context, appID string) (*asc.AppPriceScheduleResponse, error)
+	GetAppPriceScheduleBaseTerritory(ctx context.Context, scheduleID string) (*asc.TerritoryResponse, error)
+	GetAppPriceScheduleManualPrices(ctx context.Context, scheduleID string, opts ...asc.AppPricesOption) (*asc.AppPricesResponse, error)
+	CreateAppPriceSchedule(ctx context.Context, appID string, attrs asc.AppPriceScheduleCreateAttributes) (*asc.AppPriceScheduleResponse, error)
+}
+
+// manifestTarget holds the resolved IDs a manifest is applied to.
+type manifestTarget struct {
+	AppID     string
+	AppInfoID string
+	VersionID string
+}
+
+type liveAgeRating struct {
+	id     string
+	values map[string]any
+}
+
+type liveReviewDetails struct {
+	id    string
+	attrs asc.AppStoreReviewDetailAttributes
+}
+
+type liveAvailability struct {
+	id                        string
+	availableInNewTerritories bool
+	available                 []string
+}
+
+type livePricing struct {
+	baseTerritory string
+	pricePoint    string
+	// manualPrices are the current and scheduled manual prices in every
+	// territory, which a new price schedule must carry over.
+	manualPrices []liveManualPrice
+}
+
+type liveManualPrice struct {
+	territory  string
+	pricePoint string
+	startDate  string // empty when already in effect
+}
+
+func fetchVersionLocalizations(ctx context.Context, client manifestClient, versionID string) (map[string]map[string]string, error) {
+	resp, err := client.GetAppStoreVersionLocalizations(ctx, versionID, asc.WithAppStoreVersionLocalizationsLimit(200))
+	if err != nil {
+		return nil, fmt.Errorf("fetch version localizations: %w", err)
+	}
+	values := make(map[string]map[string]string, len(resp.Data))
+	for _, item := range resp.Data {
+		locale := strings.TrimSpace(item.Attributes.Locale)
+		if locale == "" {
+			continue
+		}
+		values[locale] = shared.VersionLocalizationValues(item.Attributes)
+	}
+	return values, nil
+}
+
+func fetchAppInfoLocalizations(ctx context.Context, client manifestClient, appInfoID string) (map[string]map[string]string, error) {
+	resp, err := client.GetAppInfoLocalizations(ctx, appInfoID, asc.WithAppInfoLocalizationsLimit(200))
+	if err != nil {
+		return nil, fmt.Errorf("fetch app info localizations: %w", err)
+	}
+	values := make(map[string]map[string]string, len(resp.Data))
+	for _, item := range resp.Data {
+		locale := strings.TrimSpace(item.Attributes.Locale)
+		if locale == "" {
+			continue
+		}
+		values[locale] = shared.AppInfoLocalizationValues(item.Attributes)
+	}
+	return values, nil
+}
+
+func fetchCategories(ctx context.Context, client manifestClient, appInfoID string) (ManifestCategories, error) {
+	primary, err := client.GetAppInfoPrimaryCategoryRelationship(ctx, appInfoID)
+	if err != nil {
+		return ManifestCategories{}, fmt.Errorf("fetch primary category: %w", err)
+	}
+	secondary, err := client.GetAppInfoSecondaryCategoryRelationship(ctx, appInfoID)
+	if err != nil {
+		return ManifestCategories{}, fmt.Errorf("fetch secondary category: %w", err)
+	}
+	return ManifestCategories{
+		Primary:   strings.TrimSpace(primary.Data.ID),
+		Secondary: strings.TrimSpace(secondary.Data.ID),
+	}, nil
+}
+
+func fetchAgeRating(ctx context.Context, client manifestClient, appInfoID string) (*liveAgeRating, error) {
+	resp, err := client.GetAgeRatingDeclarationForAppInfo(ctx, appInfoID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch age rating declaration: %w", err)
+	}
+	id := strings.TrimSpace(resp.Data.ID)
+	if id == "" {
+		return nil, fmt.Errorf("fetch age rating declaration: declaration id is empty")
+	}
+	values, err := ageRatingValues(resp.Data.Attributes)
+	if err != nil {
+		return nil, fmt.Errorf("fetch age rating declaration: %w", err)
+	}
+	return &liveAgeRating{id: id, values: values}, nil
+}
+
+// fetchReviewDetails returns nil when the version has no review details yet.
+func fetchReviewDetails(ctx context.Context, client manifestClient, versionID string) (*liveReviewDetails, error) {
+	resp, err := client.GetAppStoreReviewDetailForVersion(ctx, versionID)
+	if err != nil {
+		if asc.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("fetch review details: %w", err)
+	}
+	if resp == nil || strings.TrimSpace(resp.Data.ID) == "" {
+		return nil, nil
+	}
+	return &liveReviewDetails{id: resp.Data.ID, attrs: resp.Data.Attributes}, nil
+}
+
+// fetchAvailability returns nil when the app has no availability yet.
+func fetchAvailability(ctx context.Context, client manifestClient, appID string) (*liveAvailability, error) {
+	resp, err := client.GetAppAvailabilityV2(ctx, appID)
+	if err != nil {
+		if shared.IsAppAvailabilityMissing(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("fetch app availability: %w", err)
+	}
+	availability := &liveAvailability{
+		id:                        strings.TrimSpace(resp.Data.ID),
+		availableInNewTerritories: resp.Data.Attributes.AvailableInNewTerritories,
+	}
+	if availability.id == "" {
+		return nil, nil
+	}
+
+	available := make([]string, 0)
+	page, err := client.GetTerritoryAvailabilities(ctx, availability.id, asc.WithTerritoryAvailabilitiesLimit(200))
+	for {
+		if err != nil {
+			return nil, fmt.Errorf("fetch territory availabilities: %w", err)
+		}
+		for _, item := range page.Data {
+			if !item.Attributes.Available {
+				continue
+			}
+			territory, relErr := relationshipID(item.Relationships, "territory")
+			if relErr != nil {
+				return nil, fmt.Errorf("fetch territory availabilities: %w", relErr)
+			}
+			if territory != "" {
+				available = append(available, territory)
+			}
+		}
+		next := strings.TrimSpace(page.Links.Next)
+		if next == "" {
+			break
+		}
+		page, err = client.GetTerritoryAvailabilities(ctx, availability.id, asc.WithTerritoryAvailabilitiesNextURL(next))
+	}
+	availability.available = normalizeTerritories(available)
+	return availability, nil
+}
+
+// fetchPricing returns nil when the app has no price schedule yet. The price
+// point is the manual base-territory price in effect today. All current and
+// scheduled manual prices are kept so that applying a new base price can
+// preserve them.
+func fetchPricing(ctx context.Context, client manifestClient, appID string, now time.Time) (*livePricing, error) {
+	schedule, err := client.GetAppPriceSchedule(ctx, appID)
+	if err != nil {
+		if asc.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("fetch app price schedule: %w", err)
+	}
+	scheduleID := strings.TrimSpace(schedule.Data.ID)
+	if scheduleID == "" {
+		return nil, nil
+	}
+
+	territoryResp, err := client.GetAppPriceScheduleBaseTerritory(ctx, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch base territory: %w", err)
+	}
+	pricing := &livePricing{baseTerritory: strings.ToUpper(strings.TrimSpace(territoryResp.Data.ID))}
+
+	firstPage, err := client.GetAppPriceScheduleManualPrices(ctx, scheduleID,
+		asc.WithAppPricesInclude([]string{"appPricePoint", "territory"}),
+		asc.WithAppPricesLimit(200),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fetch manual prices: %w", err)
+	}
+	allPages, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetAppPriceScheduleManualPrices(ctx, scheduleID, asc.WithAppPricesNextURL(nextURL))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch manual prices: %w", err)
+	}
+	prices := allPages.(*asc.AppPricesResponse)
+
+	today := now.Format("2006-01-02")
+	latestStart := ""
+	for _, price := range prices.Data {
+		start := strings.TrimSpace(price.Attributes.StartDate)
+		end := strings.TrimSpace(price.Attributes.EndDate)
+		if end != "" && end <= today {
+			continue
+		}
+		territory, err := relationshipID(price.Relationships, "territory")
+		if err != nil {
+			return nil, fmt.Errorf("fetch manual prices: %w", err)
+		}
+		pricePoint, err := relationshipID(price.Relationships, "appPricePoint")
+		if err != nil {
+			return nil, fmt.Errorf("fetch manual prices: %w", err)
+		}
+		if pricePoint == "" {
+			continue
+		}
+		territory = strings.ToUpper(territory)
+		if territory == "" {
+			territory = pricing.baseTerritory
+		}
+		if start > today {
+			pricing.manualPrices = append(pricing.manualPrices, liveManualPrice{territory: territory, pricePoint: pricePoint, startDate: start})
+			continue
+		}
+		if territory != pricing.baseTerritory {
+			pricing.manualPrices = append(pricing.manualPrices, liveManualPrice{territory: territory, pricePoint: pricePoint})
+			continue
+		}
+		if pricing.pricePoint != "" && start < latestStart {
+			continue
+		}
+		pricing.pricePoint = pricePoint
+		latestStart = start
+	}
+	if pricing.pricePoint != "" {
+		pricing.manualPrices = append(pricing.manualPrices, liveManualPrice{territory: pricing.baseTerritory, pricePoint: pricing.pricePoint})
+	}
+	return pricing, nil
+}
+
+// relationshipID extracts relationships.<name>.data.id from a resource.
+func relationshipID(raw json.RawMessage, name string) (string, error) {
+	if len(raw) == 0 {
+		return "", nil
+	}
+	var relationships map[string]struct {
+		Data *asc.ResourceData `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &relationships); err != nil {
+		return "", fmt.Errorf("parse %s relationship: %w", name, err)
+	}
+	relationship, ok := relationships[name]
+	if !ok || relationship.Data == nil {
+		return "", nil
+	}
+	return strings.TrimSpace(relationship.Data.ID), nil
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
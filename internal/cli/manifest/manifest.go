@@ -0,0 +1,210 @@
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// Manifest is the declarative App Store metadata file read by asc apply and
+// written by asc export. Sections that are omitted are left untouched.
+type Manifest struct {
+	App                  ManifestApp                  `yaml:"app"`
+	Version              *ManifestVersion             `yaml:"version,omitempty"`
+	VersionLocalizations map[string]map[string]string `yaml:"versionLocalizations,omitempty"`
+	AppInfoLocalizations map[string]map[string]string `yaml:"appInfoLocalizations,omitempty"`
+	Categories           *ManifestCategories          `yaml:"categories,omitempty"`
+	AgeRating            map[string]any               `yaml:"ageRating,omitempty"`
+	ReviewDetails        *ManifestReviewDetails       `yaml:"reviewDetails,omitempty"`
+	Availability         *ManifestAvailability        `yaml:"availability,omitempty"`
+	Pricing              *ManifestPricing             `yaml:"pricing,omitempty"`
+}
+
+// ManifestApp identifies the app and, optionally, the app info to edit.
+type ManifestApp struct {
+	ID        string `yaml:"id"`
+	AppInfoID string `yaml:"appInfoId,omitempty"`
+}
+
+// ManifestVersion identifies the App Store version for version-scoped sections.
+type ManifestVersion struct {
+	ID       string `yaml:"id,omitempty"`
+	Version  string `yaml:"version,omitempty"`
+	Platform string `yaml:"platform,omitempty"`
+}
+
+// ManifestCategories holds app category IDs (see asc categories list).
+type ManifestCategories struct {
+	Primary   string `yaml:"primary"`
+	Secondary string `yaml:"secondary,omitempty"`
+}
+
+// ManifestReviewDetails holds App Store review contact and demo account info.
+type ManifestReviewDetails struct {
+	ContactFirstName    *string `yaml:"contactFirstName,omitempty"`
+	ContactLastName     *string `yaml:"contactLastName,omitempty"`
+	ContactPhone        *string `yaml:"contactPhone,omitempty"`
+	ContactEmail        *string `yaml:"contactEmail,omitempty"`
+	DemoAccountName     *string `yaml:"demoAccountName,omitempty"`
+	DemoAccountPassword *string `yaml:"demoAccountPassword,omitempty"`
+	DemoAccountRequired *bool   `yaml:"demoAccountRequired,omitempty"`
+	Notes               *string `yaml:"notes,omitempty"`
+}
+
+// ManifestAvailability lists the territories where the app is available.
+type ManifestAvailability struct {
+	AvailableInNewTerritories *bool    `yaml:"availableInNewTerritories,omitempty"`
+	Territories               []string `yaml:"territories"`
+}
+
+// ManifestPricing describes the app's base price.
+type ManifestPricing struct {
+	BaseTerritory string `yaml:"baseTerritory"`
+	PricePoint    string `yaml:"pricePoint"`
+	StartDate     string `yaml:"startDate,omitempty"`
+}
+
+func readManifestYAML(path string) (*Manifest, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return decodeManifest(file)
+}
+
+func decodeManifest(r io.Reader) (*Manifest, error) {
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+
+	var manifest Manifest
+	if err := decoder.Decode(&manifest); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, shared.UsageError("YAML file is empty")
+		}
+		return nil, fmt.Errorf("parse YAML: %w", err)
+	}
+	if err := manifest.validate(); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+func marshalManifestYAML(manifest *Manifest) ([]byte, error) {
+	if manifest == nil {
+		return nil, fmt.Errorf("manifest is required")
+	}
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(manifest); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// validate checks the manifest before any network calls so that typos fail
+// fast instead of half-way through an apply.
+func (m *Manifest) validate() error {
+	if err := shared.ValidateLocalizationValues(shared.LocalizationTypeVersion, m.VersionLocalizations); err != nil {
+		return fmt.Errorf("versionLocalizations: %w", err)
+	}
+	if err := shared.ValidateLocalizationValues(shared.LocalizationTypeAppInfo, m.AppInfoLocalizations); err != nil {
+		return fmt.Errorf("appInfoLocalizations: %w", err)
+	}
+	if m.Categories != nil && strings.TrimSpace(m.Categories.Primary) == "" {
+		return fmt.Errorf("categories: primary is required")
+	}
+	if _, err := ageRatingAttributes(m.AgeRating); err != nil {
+		return err
+	}
+	if m.Availability != nil {
+		if len(normalizeTerritories(m.Availability.Territories)) == 0 {
+			return fmt.Errorf("availability: territories must include at least one territory")
+		}
+	}
+	if m.Pricing != nil {
+		if strings.TrimSpace(m.Pricing.BaseTerritory) == "" {
+			return fmt.Errorf("pricing: baseTerritory is required")
+		}
+		if strings.TrimSpace(m.Pricing.PricePoint) == "" {
+			return fmt.Errorf("pricing: pricePoint is required")
+		}
+	}
+	return nil
+}
+
+// needsVersion reports whether any section is scoped to an App Store version.
+func (m *Manifest) needsVersion() bool {
+	return len(m.VersionLocalizations) > 0 || m.ReviewDetails != nil
+}
+
+// needsAppInfo reports whether any section is scoped to an app info.
+func (m *Manifest) needsAppInfo() bool {
+	return len(m.AppInfoLocalizations) > 0 || m.Categories != nil || len(m.AgeRating) > 0
+}
+
+// ageRatingAttributes converts the ageRating section, keyed by API attribute
+// name, into declaration attributes. Unknown keys are rejected.
+func ageRatingAttributes(values map[string]any) (asc.AgeRatingDeclarationAttributes, error) {
+	var attrs asc.AgeRatingDeclarationAttributes
+	if len(values) == 0 {
+		return attrs, nil
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return attrs, fmt.Errorf("ageRating: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&attrs); err != nil {
+		return attrs, fmt.Errorf("ageRating: %w", err)
+	}
+	return attrs, nil
+}
+
+// ageRatingValues flattens declaration attributes into API attribute names.
+// Deprecated attributes are dropped so exports only contain settable fields.
+func ageRatingValues(attrs asc.AgeRatingDeclarationAttributes) (map[string]any, error) {
+	data, err := json.Marshal(attrs)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]any)
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	delete(values, "seventeenPlus")
+	return values, nil
+}
+
+func normalizeTerritories(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		territory := strings.ToUpper(strings.TrimSpace(value))
+		if territory == "" {
+			continue
+		}
+		if _, ok := seen[territory]; ok {
+			continue
+		}
+		seen[territory] = struct{}{}
+		result = append(result, territory)
+	}
+	sort.Strings(result)
+	return result
+}
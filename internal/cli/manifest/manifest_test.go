@@ -0,0 +1,505 @@
+package manifest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+type manifestStub struct {
+	manifestClient
+
+	versionLocalizations []asc.Resource[asc.AppStoreVersionLocalizationAttributes]
+	appInfoLocalizations []asc.Resource[asc.AppInfoLocalizationAttributes]
+	primaryCategory      string
+	secondaryCategory    string
+	ageRating            asc.AgeRatingDeclarationAttributes
+	reviewDetail         *asc.Resource[asc.AppStoreReviewDetailAttributes]
+	availableInNew       bool
+	territories          []string
+	noAvailability       bool
+	baseTerritory        string
+	manualPrices         []asc.Resource[asc.AppPriceAttributes]
+	manualPricesCalls    int
+
+	updatedVersionLocalizations map[string]asc.AppStoreVersionLocalizationAttributes
+	createdAppInfoLocalizations []asc.AppInfoLocalizationAttributes
+	updatedCategories           []string
+	updatedAgeRating            *asc.AgeRatingDeclarationAttributes
+	createdReviewDetail         *asc.AppStoreReviewDetailCreateAttributes
+	updatedReviewDetail         *asc.AppStoreReviewDetailUpdateAttributes
+	createdAvailability         *asc.AppAvailabilityV2CreateAttributes
+	createdPriceSchedule        *asc.AppPriceScheduleCreateAttributes
+}
+
+func (s *manifestStub) GetAppStoreVersionLocalizations(ctx context.Context, versionID string, opts ...asc.AppStoreVersionLocalizationsOption) (*asc.AppStoreVersionLocalizationsResponse, error) {
+	return &asc.AppStoreVersionLocalizationsResponse{Data: s.versionLocalizations}, nil
+}
+
+func (s *manifestStub) UpdateAppStoreVersionLocalization(ctx context.Context, localizationID string, attributes asc.AppStoreVersionLocalizationAttributes) (*asc.AppStoreVersionLocalizationResponse, error) {
+	if s.updatedVersionLocalizations == nil {
+		s.updatedVersionLocalizations = make(map[string]asc.AppStoreVersionLocalizationAttributes)
+	}
+	s.updatedVersionLocalizations[localizationID] = attributes
+	return &asc.AppStoreVersionLocalizationResponse{}, nil
+}
+
+func (s *manifestStub) GetAppInfoLocalizations(ctx context.Context, appInfoID string, opts ...asc.AppInfoLocalizationsOption) (*asc.AppInfoLocalizationsResponse, error) {
+	return &asc.AppInfoLocalizationsResponse{Data: s.appInfoLocalizations}, nil
+}
+
+func (s *manifestStub) CreateAppInfoLocalization(ctx context.Context, appInfoID string, attributes asc.AppInfoLocalizationAttributes) (*asc.AppInfoLocalizationResponse, error) {
+	s.createdAppInfoLocalizations = append(s.createdAppInfoLocalizations, attributes)
+	return &asc.AppInfoLocalizationResponse{}, nil
+}
+
+func (s *manifestStub) GetAppInfoPrimaryCategoryRelationship(ctx context.Context, appInfoID string) (*asc.AppInfoPrimaryCategoryLinkageResponse, error) {
+	return &asc.AppInfoPrimaryCategoryLinkageResponse{Data: asc.ResourceData{ID: s.primaryCategory}}, nil
+}
+
+func (s *manifestStub) GetAppInfoSecondaryCategoryRelationship(ctx context.Context, appInfoID string) (*asc.AppInfoSecondaryCategoryLinkageResponse, error) {
+	return &asc.AppInfoSecondaryCategoryLinkageResponse{Data: asc.ResourceData{ID: s.secondaryCategory}}, nil
+}
+
+func (s *manifestStub) UpdateAppInfoCategories(ctx context.Context, appInfoID string, primaryCategoryID, secondaryCategoryID string) (*asc.AppInfoResponse, error) {
+	s.updatedCategories = []string{appInfoID, primaryCategoryID, secondaryCategoryID}
+	return &asc.AppInfoResponse{}, nil
+}
+
+func (s *manifestStub) GetAgeRatingDeclarationForAppInfo(ctx context.Context, appInfoID string) (*asc.AgeRatingDeclarationResponse, error) {
+	return &asc.AgeRatingDeclarationResponse{Data: asc.Resource[asc.AgeRatingDeclarationAttributes]{ID: "age-1", Attributes: s.ageRating}}, nil
+}
+
+func (s *manifestStub) UpdateAgeRatingDeclaration(ctx context.Context, declarationID string, attributes asc.AgeRatingDeclarationAttributes) (*asc.AgeRatingDeclarationResponse, error) {
+	s.updatedAgeRating = &attributes
+	return &asc.AgeRatingDeclarationResponse{}, nil
+}
+
+func (s *manifestStub) GetAppStoreReviewDetailForVersion(ctx context.Context, versionID string) (*asc.AppStoreReviewDetailResponse, error) {
+	if s.reviewDetail == nil {
+		return nil, &asc.APIError{StatusCode: 404}
+	}
+	return &asc.AppStoreReviewDetailResponse{Data: *s.reviewDetail}, nil
+}
+
+func (s *manifestStub) CreateAppStoreReviewDetail(ctx context.Context, versionID string, attrs *asc.AppStoreReviewDetailCreateAttributes) (*asc.AppStoreReviewDetailResponse, error) {
+	s.createdReviewDetail = attrs
+	return &asc.AppStoreReviewDetailResponse{}, nil
+}
+
+func (s *manifestStub) UpdateAppStoreReviewDetail(ctx context.Context, detailID string, attrs asc.AppStoreReviewDetailUpdateAttributes) (*asc.AppStoreReviewDetailResponse, error) {
+	s.updatedReviewDetail = &attrs
+	return &asc.AppStoreReviewDetailResponse{}, nil
+}
+
+func (s *manifestStub) GetAppAvailabilityV2(ctx context.Context, appID string) (*asc.AppAvailabilityV2Response, error) {
+	if s.noAvailability {
+		return &asc.AppAvailabilityV2Response{}, nil
+	}
+	return &asc.AppAvailabilityV2Response{Data: asc.Resource[asc.AppAvailabilityV2Attributes]{
+		ID:         "availability-1",
+		Attributes: asc.AppAvailabilityV2Attributes{AvailableInNewTerritories: s.availableInNew},
+	}}, nil
+}
+
+func (s *manifestStub) GetTerritoryAvailabilities(ctx context.Context, availabilityID string, opts ...asc.TerritoryAvailabilitiesOption) (*asc.TerritoryAvailabilitiesResponse, error) {
+	data := make([]asc.Resource[asc.TerritoryAvailabilityAttributes], 0, len(s.territories))
+	for _, territory := range s.territories {
+		data = append(data, asc.Resource[asc.TerritoryAvailabilityAttributes]{
+			ID:            "ta-" + territory,
+			Attributes:    asc.TerritoryAvailabilityAttributes{Available: true},
+			Relationships: relationships("territory", territory),
+		})
+	}
+	return &asc.TerritoryAvailabilitiesResponse{Data: data}, nil
+}
+
+func (s *manifestStub) CreateAppAvailabilityV2(ctx context.Context, appID string, attrs asc.AppAvailabilityV2CreateAttributes) (*asc.AppAvailabilityV2Response, error) {
+	s.createdAvailability = &attrs
+	return &asc.AppAvailabilityV2Response{}, nil
+}
+
+func (s *manifestStub) GetAppPriceSchedule(ctx context.Context, appID string) (*asc.AppPriceScheduleResponse, error) {
+	if s.baseTerritory == "" {
+		return nil, &asc.APIError{StatusCode: 404}
+	}
+	return &asc.AppPriceScheduleResponse{Data: asc.Resource[asc.AppPriceScheduleAttributes]{ID: "schedule-1"}}, nil
+}
+
+func (s *manifestStub) GetAppPriceScheduleBaseTerritory(ctx context.Context, scheduleID string) (*asc.TerritoryResponse, error) {
+	return &asc.TerritoryResponse{Data: asc.Resource[asc.TerritoryAttributes]{ID: s.baseTerritory}}, nil
+}
+
+// GetAppPriceScheduleManualPrices serves the manual prices two per page.
+func (s *manifestStub) GetAppPriceScheduleManualPrices(ctx context.Context, scheduleID string, opts ...asc.AppPricesOption) (*asc.AppPricesResponse, error) {
+	const pageSize = 2
+	pages := (len(s.manualPrices) + pageSize - 1) / pageSize
+	if pages == 0 {
+		return &asc.AppPricesResponse{}, nil
+	}
+	page := s.manualPricesCalls % pages
+	s.manualPricesCalls++
+	resp := &asc.AppPricesResponse{Data: s.manualPrices[page*pageSize : min((page+1)*pageSize, len(s.manualPrices))]}
+	if page+1 < pages {
+		resp.Links.Next = fmt.Sprintf("https://api.appstoreconnect.apple.com/v1/appPriceSchedules/%s/manualPrices?cursor=%d", scheduleID, page+1)
+	}
+	return resp, nil
+}
+
+func (s *manifestStub) CreateAppPriceSchedule(ctx context.Context, appID string, attrs asc.AppPriceScheduleCreateAttributes) (*asc.AppPriceScheduleResponse, error) {
+	s.createdPriceSchedule = &attrs
+	return &asc.AppPriceScheduleResponse{}, nil
+}
+
+// relationships builds a relationships payload from name/id pairs.
+func relationships(pairs ...string) json.RawMessage {
+	rel := make(map[string]any, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		rel[pairs[i]] = map[string]any{"data": map[string]string{"id": pairs[i+1]}}
+	}
+	data, _ := json.Marshal(rel)
+	return data
+}
+
+func liveStub() *manifestStub {
+	none := "NONE"
+	no := false
+	return &manifestStub{
+		versionLocalizations: []asc.Resource[asc.AppStoreVersionLocalizationAttributes]{
+			{ID: "vl-en", Attributes: asc.AppStoreVersionLocalizationAttributes{Locale: "en-US", Description: "Old", Keywords: "a,b"}},
+		},
+		appInfoLocalizations: []asc.Resource[asc.AppInfoLocalizationAttributes]{
+			{ID: "il-en", Attributes: asc.AppInfoLocalizationAttributes{Locale: "en-US", Name: "My App"}},
+		},
+		primaryCategory: "GAMES",
+		ageRating:       asc.AgeRatingDeclarationAttributes{Gambling: &no, ViolenceCartoonOrFantasy: &none},
+		reviewDetail: &asc.Resource[asc.AppStoreReviewDetailAttributes]{
+			ID:         "review-1",
+			Attributes: asc.AppStoreReviewDetailAttributes{ContactEmail: "old@example.com", DemoAccountPassword: "secret"},
+		},
+		availableInNew: true,
+		territories:    []string{"USA", "GBR"},
+		baseTerritory:  "USA",
+		manualPrices: []asc.Resource[asc.AppPriceAttributes]{
+			{ID: "price-old", Attributes: asc.AppPriceAttributes{StartDate: "2025-01-01", EndDate: "2026-01-01"}, Relationships: relationships("territory", "USA", "appPricePoint", "pp-old")},
+			{ID: "price-now", Attributes: asc.AppPriceAttributes{StartDate: "2026-01-01"}, Relationships: relationships("territory", "USA", "appPricePoint", "pp-now")},
+			{ID: "price-gbr-old", Attributes: asc.AppPriceAttributes{StartDate: "2025-01-01", EndDate: "2026-01-01"}, Relationships: relationships("territory", "GBR", "appPricePoint", "pp-gbr-old")},
+			{ID: "price-gbr", Attributes: asc.AppPriceAttributes{StartDate: "2026-01-01"}, Relationships: relationships("territory", "GBR", "appPricePoint", "pp-gbr")},
+			{ID: "price-fra", Attributes: asc.AppPriceAttributes{StartDate: "2026-09-01"}, Relationships: relationships("territory", "FRA", "appPricePoint", "pp-fra")},
+			{ID: "price-usa-aug", Attributes: asc.AppPriceAttributes{StartDate: "2026-08-01"}, Relationships: relationships("territory", "USA", "appPricePoint", "pp-usa-aug")},
+		},
+	}
+}
+
+var testNow = time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+var testTarget = manifestTarget{AppID: "app-1", AppInfoID: "info-1", VersionID: "version-1"}
+
+func TestDecodeManifestRejectsUnknownFields(t *testing.T) {
+	_, err := decodeManifest(strings.NewReader("app:\n  id: \"1\"\nscreenshots: {}\n"))
+	if err == nil {
+		t.Fatal("expected error for unknown field")
+	}
+}
+
+func TestDecodeManifestValidatesSections(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{name: "unknown localization key", yaml: "versionLocalizations:\n  en-US:\n    title: x\n", want: "title"},
+		{name: "missing primary category", yaml: "categories:\n  secondary: GAMES\n", want: "categories: primary"},
+		{name: "unknown age rating key", yaml: "ageRating:\n  violence: NONE\n", want: "ageRating"},
+		{name: "empty availability", yaml: "availability:\n  availableInNewTerritories: true\n", want: "availability: territories"},
+		{name: "missing price point", yaml: "pricing:\n  baseTerritory: USA\n", want: "pricing: pricePoint"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := decodeManifest(strings.NewReader(test.yaml))
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("expected error containing %q, got %v", test.want, err)
+			}
+		})
+	}
+}
+
+func TestPlanManifestNoChanges(t *testing.T) {
+	client := liveStub()
+	manifest, err := exportManifest(context.Background(), client, testTarget, testNow)
+	if err != nil {
+		t.Fatalf("exportManifest() error: %v", err)
+	}
+	changes, err := planManifest(context.Background(), client, testTarget, manifest, testNow)
+	if err != nil {
+		t.Fatalf("planManifest() error: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("expected exported manifest to plan no changes, got %+v", changes)
+	}
+}
+
+func TestExportManifestOmitsPassword(t *testing.T) {
+	manifest, err := exportManifest(context.Background(), liveStub(), testTarget, testNow)
+	if err != nil {
+		t.Fatalf("exportManifest() error: %v", err)
+	}
+	if manifest.ReviewDetails == nil || manifest.ReviewDetails.DemoAccountPassword != nil {
+		t.Fatalf("expected review details without password, got %+v", manifest.ReviewDetails)
+	}
+	if manifest.Pricing == nil || manifest.Pricing.PricePoint != "pp-now" {
+		t.Fatalf("expected current base territory price point, got %+v", manifest.Pricing)
+	}
+	if want := []string{"GBR", "USA"}; manifest.Availability == nil || !reflect.DeepEqual(manifest.Availability.Territories, want) {
+		t.Fatalf("expected territories %v, got %+v", want, manifest.Availability)
+	}
+	data, err := marshalManifestYAML(manifest)
+	if err != nil {
+		t.Fatalf("marshalManifestYAML() error: %v", err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Fatalf("expected password to be omitted from export:\n%s", data)
+	}
+	if _, err := decodeManifest(strings.NewReader(string(data))); err != nil {
+		t.Fatalf("expected exported manifest to decode, got %v", err)
+	}
+}
+
+func TestPlanAndApplyManifest(t *testing.T) {
+	client := liveStub()
+	manifest, err := decodeManifest(strings.NewReader(`
+versionLocalizations:
+  en-US:
+    description: New
+    keywords: a,b
+appInfoLocalizations:
+  de-DE:
+    name: Meine App
+categories:
+  primary: PRODUCTIVITY
+ageRating:
+  gambling: true
+  violenceCartoonOrFantasy: NONE
+reviewDetails:
+  contactEmail: new@example.com
+  demoAccountPassword: hunter2
+availability:
+  availableInNewTerritories: true
+  territories: [usa, can]
+pricing:
+  baseTerritory: USA
+  pricePoint: pp-new
+`))
+	if err != nil {
+		t.Fatalf("decodeManifest() error: %v", err)
+	}
+
+	changes, err := planManifest(context.Background(), client, testTarget, manifest, testNow)
+	if err != nil {
+		t.Fatalf("planManifest() error: %v", err)
+	}
+	var got []string
+	for _, change := range changes {
+		got = append(got, change.Action+" "+change.label())
+	}
+	want := []string{
+		"update versionLocalizations en-US",
+		"create appInfoLocalizations de-DE",
+		"update categories",
+		"update ageRating age-1",
+		"update reviewDetails review-1",
+		"update availability availability-1",
+		"update pricing",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("planned changes = %v, want %v", got, want)
+	}
+	for _, field := range changes[4].Fields {
+		if field.Field == "demoAccountPassword" && (field.Current != redactedValue || field.Desired != redactedValue) {
+			t.Fatalf("expected password to be redacted in plan, got %+v", field)
+		}
+	}
+
+	applied, err := applyManifest(context.Background(), client, testTarget, manifest, changes, testNow)
+	if err != nil {
+		t.Fatalf("applyManifest() error: %v", err)
+	}
+	if applied != len(changes) {
+		t.Fatalf("applied = %d, want %d", applied, len(changes))
+	}
+
+	if update := client.updatedVersionLocalizations["vl-en"]; update.Description != "New" || update.Keywords != "" {
+		t.Fatalf("expected only description to be sent, got %+v", update)
+	}
+	if len(client.createdAppInfoLocalizations) != 1 || client.createdAppInfoLocalizations[0].Name != "Meine App" {
+		t.Fatalf("unexpected app info localization creates: %+v", client.createdAppInfoLocalizations)
+	}
+	if want := []string{"info-1", "PRODUCTIVITY", ""}; !reflect.DeepEqual(client.updatedCategories, want) {
+		t.Fatalf("updated categories = %v, want %v", client.updatedCategories, want)
+	}
+	if client.updatedAgeRating == nil || client.updatedAgeRating.Gambling == nil || !*client.updatedAgeRating.Gambling || client.updatedAgeRating.ViolenceCartoonOrFantasy != nil {
+		t.Fatalf("expected only gambling to be updated, got %+v", client.updatedAgeRating)
+	}
+	if client.updatedReviewDetail == nil || client.updatedReviewDetail.ContactEmail == nil || *client.updatedReviewDetail.ContactEmail != "new@example.com" {
+		t.Fatalf("unexpected review detail update: %+v", client.updatedReviewDetail)
+	}
+	wantTerritories := []asc.TerritoryAvailabilityCreate{
+		{TerritoryID: "CAN", Available: true},
+		{TerritoryID: "USA", Available: true},
+		{TerritoryID: "GBR", Available: false},
+	}
+	if client.createdAvailability == nil || !reflect.DeepEqual(client.createdAvailability.TerritoryAvailabilities, wantTerritories) {
+		t.Fatalf("unexpected availability payload: %+v", client.createdAvailability)
+	}
+	// Manual prices on every page and scheduled base prices survive the new
+	// schedule; only today's base price is replaced.
+	wantPrice := asc.AppPriceScheduleCreateAttributes{
+		PricePointID:    "pp-new",
+		StartDate:       "2026-06-01",
+		BaseTerritoryID: "USA",
+		AdditionalPrices: []asc.AppPriceScheduleManualPrice{
+			{PricePointID: "pp-gbr"},
+			{PricePointID: "pp-fra", StartDate: "2026-09-01"},
+			{PricePointID: "pp-usa-aug", StartDate: "2026-08-01"},
+		},
+	}
+	if client.createdPriceSchedule == nil || !reflect.DeepEqual(*client.createdPriceSchedule, wantPrice) {
+		t.Fatalf("unexpected price schedule: %+v", client.createdPriceSchedule)
+	}
+}
+
+func TestPlanPricingDropsTerritoryPriceForNewBaseTerritory(t *testing.T) {
+	client := liveStub()
+	manifest := &Manifest{Pricing: &ManifestPricing{BaseTerritory: "GBR", PricePoint: "pp-gbr-new"}}
+	changes, err := planManifest(context.Background(), client, testTarget, manifest, testNow)
+	if err != nil {
+		t.Fatalf("planManifest() error: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("expected one pricing change, got %+v", changes)
+	}
+	want := []liveManualPrice{{territory: "FRA", pricePoint: "pp-fra", startDate: "2026-09-01"}}
+	if !reflect.DeepEqual(changes[0].manualPrices, want) {
+		t.Fatalf("manual prices = %+v, want %+v", changes[0].manualPrices, want)
+	}
+}
+
+func TestApplyPricingWithLaterStartKeepsCurrentBasePrice(t *testing.T) {
+	client := liveStub()
+	manifest := &Manifest{Pricing: &ManifestPricing{BaseTerritory: "USA", PricePoint: "pp-new", StartDate: "2026-07-01"}}
+	changes, err := planManifest(context.Background(), client, testTarget, manifest, testNow)
+	if err != nil {
+		t.Fatalf("planManifest() error: %v", err)
+	}
+	if _, err := applyManifest(context.Background(), client, testTarget, manifest, changes, testNow); err != nil {
+		t.Fatalf("applyManifest() error: %v", err)
+	}
+	want := []asc.AppPriceScheduleManualPrice{
+		{PricePointID: "pp-gbr"},
+		{PricePointID: "pp-fra", StartDate: "2026-09-01"},
+		{PricePointID: "pp-usa-aug", StartDate: "2026-08-01"},
+		{PricePointID: "pp-now"},
+	}
+	if client.createdPriceSchedule == nil || !reflect.DeepEqual(client.createdPriceSchedule.AdditionalPrices, want) {
+		t.Fatalf("unexpected price schedule: %+v", client.createdPriceSchedule)
+	}
+}
+
+func TestPlanManifestCreatesMissingResources(t *testing.T) {
+	client := liveStub()
+	client.reviewDetail = nil
+	client.noAvailability = true
+	client.baseTerritory = ""
+	required := true
+	manifest := &Manifest{
+		ReviewDetails: &ManifestReviewDetails{DemoAccountRequired: &required},
+		Availability:  &ManifestAvailability{Territories: []string{"USA"}},
+		Pricing:       &ManifestPricing{BaseTerritory: "USA", PricePoint: "pp-1", StartDate: "2026-07-01"},
+	}
+	changes, err := planManifest(context.Background(), client, testTarget, manifest, testNow)
+	if err != nil {
+		t.Fatalf("planManifest() error: %v", err)
+	}
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 changes, got %+v", changes)
+	}
+	for _, change := range changes {
+		if change.Action != manifestActionCreate {
+			t.Fatalf("expected create action, got %+v", change)
+		}
+	}
+	if _, err := applyManifest(context.Background(), client, testTarget, manifest, changes, testNow); err != nil {
+		t.Fatalf("applyManifest() error: %v", err)
+	}
+	if client.createdReviewDetail == nil || client.createdReviewDetail.DemoAccountRequired == nil || !*client.createdReviewDetail.DemoAccountRequired {
+		t.Fatalf("unexpected review detail create: %+v", client.createdReviewDetail)
+	}
+	if client.createdPriceSchedule == nil || client.createdPriceSchedule.StartDate != "2026-07-01" {
+		t.Fatalf("unexpected price schedule: %+v", client.createdPriceSchedule)
+	}
+}
+
+func TestApplyManifestStopsAtFirstFailure(t *testing.T) {
+	client := &failingCategoriesStub{manifestStub: liveStub()}
+	manifest := &Manifest{
+		AppInfoLocalizations: map[string]map[string]string{"en-US": {"name": "Renamed"}},
+		Categories:           &ManifestCategories{Primary: "PRODUCTIVITY"},
+		Pricing:              &ManifestPricing{BaseTerritory: "USA", PricePoint: "pp-new"},
+	}
+	changes, err := planManifest(context.Background(), client, testTarget, manifest, testNow)
+	if err != nil {
+		t.Fatalf("planManifest() error: %v", err)
+	}
+	applied, err := applyManifest(context.Background(), client, testTarget, manifest, changes, testNow)
+	if err == nil || !strings.Contains(err.Error(), "update categories") {
+		t.Fatalf("expected categories failure, got %v", err)
+	}
+	if applied != 1 {
+		t.Fatalf("applied = %d, want 1", applied)
+	}
+	if client.createdPriceSchedule != nil {
+		t.Fatal("expected pricing not to be applied after failure")
+	}
+}
+
+type failingCategoriesStub struct {
+	*manifestStub
+}
+
+func (s *failingCategoriesStub) UpdateAppInfoCategories(ctx context.Context, appInfoID string, primaryCategoryID, secondaryCategoryID string) (*asc.AppInfoResponse, error) {
+	return nil, errors.New("boom")
+}
+
+func (s *manifestStub) UpdateAppInfoLocalization(ctx context.Context, localizationID string, attributes asc.AppInfoLocalizationAttributes) (*asc.AppInfoLocalizationResponse, error) {
+	return &asc.AppInfoLocalizationResponse{}, nil
+}
+
+func TestResolveVersionSelector(t *testing.T) {
+	selector, err := resolveVersionSelector(&ManifestVersion{Version: "1.2.0"}, "", "", "")
+	if err != nil {
+		t.Fatalf("resolveVersionSelector() error: %v", err)
+	}
+	if selector == nil || selector.version != "1.2.0" || selector.platform != "IOS" {
+		t.Fatalf("unexpected selector: %+v", selector)
+	}
+
+	selector, err = resolveVersionSelector(&ManifestVersion{Version: "1.2.0"}, "VERSION_ID", "", "")
+	if err != nil {
+		t.Fatalf("resolveVersionSelector() error: %v", err)
+	}
+	if selector.id != "VERSION_ID" || selector.version != "" {
+		t.Fatalf("expected flag to override file, got %+v", selector)
+	}
+
+	selector, err = resolveVersionSelector(nil, "", "", "")
+	if err != nil || selector != nil {
+		t.Fatalf("expected nil selector, got %+v, %v", selector, err)
+	}
+}
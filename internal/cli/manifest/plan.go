@@ -0,0 +1,358 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+const (
+	sectionVersionLocalizations = "versionLocalizations"
+	sectionAppInfoLocalizations = "appInfoLocalizations"
+	sectionCategories           = "categories"
+	sectionAgeRating            = "ageRating"
+	sectionReviewDetails        = "reviewDetails"
+	sectionAvailability         = "availability"
+	sectionPricing              = "pricing"
+
+	manifestActionCreate = "create"
+	manifestActionUpdate = "update"
+
+	redactedValue = "********"
+)
+
+// manifestChange is a single planned mutation produced by apply.
+type manifestChange struct {
+	Section string                `json:"section"`
+	Action  string                `json:"action"`
+	Target  string                `json:"target,omitempty"`
+	Fields  []manifestFieldChange `json:"fields"`
+
+	// id is the live resource ID updated by the change, if any.
+	id string
+	// manualPrices are the live manual prices a pricing change carries over.
+	manualPrices []liveManualPrice
+}
+
+// manifestFieldChange describes one attribute moving from its live value to
+// the value in the manifest.
+type manifestFieldChange struct {
+	Field   string `json:"field"`
+	Current string `json:"current"`
+	Desired string `json:"desired"`
+}
+
+// planManifest diffs every section present in manifest against live state
+// and returns the changes needed to converge, in apply order.
+func planManifest(ctx context.Context, client manifestClient, target manifestTarget, manifest *Manifest, now time.Time) ([]manifestChange, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client is required")
+	}
+	if manifest == nil {
+		return nil, fmt.Errorf("manifest is required")
+	}
+
+	changes := make([]manifestChange, 0)
+
+	if len(manifest.VersionLocalizations) > 0 {
+		live, err := fetchVersionLocalizations(ctx, client, target.VersionID)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, planLocalizations(sectionVersionLocalizations, manifest.VersionLocalizations, live)...)
+	}
+
+	if len(manifest.AppInfoLocalizations) > 0 {
+		live, err := fetchAppInfoLocalizations(ctx, client, target.AppInfoID)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, planLocalizations(sectionAppInfoLocalizations, manifest.AppInfoLocalizations, live)...)
+	}
+
+	if manifest.Categories != nil {
+		live, err := fetchCategories(ctx, client, target.AppInfoID)
+		if err != nil {
+			return nil, err
+		}
+		if change, ok := planCategories(*manifest.Categories, live); ok {
+			change.id = target.AppInfoID
+			changes = append(changes, change)
+		}
+	}
+
+	if len(manifest.AgeRating) > 0 {
+		live, err := fetchAgeRating(ctx, client, target.AppInfoID)
+		if err != nil {
+			return nil, err
+		}
+		change, ok, err := planAgeRating(manifest.AgeRating, live)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			changes = append(changes, change)
+		}
+	}
+
+	if manifest.ReviewDetails != nil {
+		live, err := fetchReviewDetails(ctx, client, target.VersionID)
+		if err != nil {
+			return nil, err
+		}
+		if change, ok := planReviewDetails(*manifest.ReviewDetails, live); ok {
+			changes = append(changes, change)
+		}
+	}
+
+	if manifest.Availability != nil {
+		live, err := fetchAvailability(ctx, client, target.AppID)
+		if err != nil {
+			return nil, err
+		}
+		if change, ok := planAvailability(*manifest.Availability, live); ok {
+			changes = append(changes, change)
+		}
+	}
+
+	if manifest.Pricing != nil {
+		live, err := fetchPricing(ctx, client, target.AppID, now)
+		if err != nil {
+			return nil, err
+		}
+		if change, ok := planPricing(*manifest.Pricing, live); ok {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes, nil
+}
+
+// planLocalizations compares localization values per locale. Only keys listed
+// in the manifest are managed; empty values are ignored because uploads
+// cannot clear a field.
+func planLocalizations(section string, desired, live map[string]map[string]string) []manifestChange {
+	changes := make([]manifestChange, 0)
+	for _, locale := range sortedKeys(desired) {
+		current, exists := live[locale]
+		fields := make([]manifestFieldChange, 0)
+		for _, key := range sortedKeys(desired[locale]) {
+			want := desired[locale][key]
+			if strings.TrimSpace(want) == "" {
+				continue
+			}
+			if have := current[key]; have != want {
+				fields = append(fields, manifestFieldChange{Field: key, Current: have, Desired: want})
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		action := manifestActionUpdate
+		if !exists {
+			action = manifestActionCreate
+		}
+		changes = append(changes, manifestChange{
+			Section: section,
+			Action:  action,
+			Target:  locale,
+			Fields:  fields,
+		})
+	}
+	return changes
+}
+
+func planCategories(desired, live ManifestCategories) (manifestChange, bool) {
+	fields := make([]manifestFieldChange, 0, 2)
+	primary := strings.TrimSpace(desired.Primary)
+	if primary != live.Primary {
+		fields = append(fields, manifestFieldChange{Field: "primary", Current: live.Primary, Desired: primary})
+	}
+	if secondary := strings.TrimSpace(desired.Secondary); secondary != "" && secondary != live.Secondary {
+		fields = append(fields, manifestFieldChange{Field: "secondary", Current: live.Secondary, Desired: secondary})
+	}
+	if len(fields) == 0 {
+		return manifestChange{}, false
+	}
+	return manifestChange{
+		Section: sectionCategories,
+		Action:  manifestActionUpdate,
+		Fields:  fields,
+	}, true
+}
+
+func planAgeRating(desired map[string]any, live *liveAgeRating) (manifestChange, bool, error) {
+	attrs, err := ageRatingAttributes(desired)
+	if err != nil {
+		return manifestChange{}, false, err
+	}
+	// Round-trip through the API attributes so desired values are formatted
+	// the same way as the live declaration.
+	normalized, err := ageRatingValues(attrs)
+	if err != nil {
+		return manifestChange{}, false, fmt.Errorf("ageRating: %w", err)
+	}
+
+	fields := make([]manifestFieldChange, 0)
+	for _, key := range sortedKeys(normalized) {
+		want := fmt.Sprint(normalized[key])
+		have := ""
+		if value, ok := live.values[key]; ok {
+			have = fmt.Sprint(value)
+		}
+		if have != want {
+			fields = append(fields, manifestFieldChange{Field: key, Current: have, Desired: want})
+		}
+	}
+	if len(fields) == 0 {
+		return manifestChange{}, false, nil
+	}
+	return manifestChange{
+		Section: sectionAgeRating,
+		Action:  manifestActionUpdate,
+		Target:  live.id,
+		Fields:  fields,
+		id:      live.id,
+	}, true, nil
+}
+
+func planReviewDetails(desired ManifestReviewDetails, live *liveReviewDetails) (manifestChange, bool) {
+	var current asc.AppStoreReviewDetailAttributes
+	if live != nil {
+		current = live.attrs
+	}
+
+	fields := make([]manifestFieldChange, 0)
+	compare := func(field string, want *string, have string, secret bool) {
+		if want == nil || *want == have {
+			return
+		}
+		change := manifestFieldChange{Field: field, Current: have, Desired: *want}
+		if secret {
+			change.Current = redact(change.Current)
+			change.Desired = redact(change.Desired)
+		}
+		fields = append(fields, change)
+	}
+	compare("contactFirstName", desired.ContactFirstName, current.ContactFirstName, false)
+	compare("contactLastName", desired.ContactLastName, current.ContactLastName, false)
+	compare("contactPhone", desired.ContactPhone, current.ContactPhone, false)
+	compare("contactEmail", desired.ContactEmail, current.ContactEmail, false)
+	compare("demoAccountName", desired.DemoAccountName, current.DemoAccountName, false)
+	compare("demoAccountPassword", desired.DemoAccountPassword, current.DemoAccountPassword, true)
+	if desired.DemoAccountRequired != nil && (*desired.DemoAccountRequired != current.DemoAccountRequired || live == nil) {
+		fields = append(fields, manifestFieldChange{
+			Field:   "demoAccountRequired",
+			Current: fmt.Sprint(current.DemoAccountRequired),
+			Desired: fmt.Sprint(*desired.DemoAccountRequired),
+		})
+	}
+	compare("notes", desired.Notes, current.Notes, false)
+
+	if len(fields) == 0 {
+		return manifestChange{}, false
+	}
+	change := manifestChange{
+		Section: sectionReviewDetails,
+		Action:  manifestActionCreate,
+		Fields:  fields,
+	}
+	if live != nil {
+		change.Action = manifestActionUpdate
+		change.Target = live.id
+		change.id = live.id
+	}
+	return change, true
+}
+
+func planAvailability(desired ManifestAvailability, live *liveAvailability) (manifestChange, bool) {
+	want := normalizeTerritories(desired.Territories)
+	var have []string
+	if live != nil {
+		have = live.available
+	}
+
+	fields := make([]manifestFieldChange, 0, 2)
+	if desired.AvailableInNewTerritories != nil && (live == nil || *desired.AvailableInNewTerritories != live.availableInNewTerritories) {
+		current := ""
+		if live != nil {
+			current = fmt.Sprint(live.availableInNewTerritories)
+		}
+		fields = append(fields, manifestFieldChange{
+			Field:   "availableInNewTerritories",
+			Current: current,
+			Desired: fmt.Sprint(*desired.AvailableInNewTerritories),
+		})
+	}
+	if strings.Join(want, ",") != strings.Join(have, ",") {
+		fields = append(fields, manifestFieldChange{
+			Field:   "territories",
+			Current: strings.Join(have, ","),
+			Desired: strings.Join(want, ","),
+		})
+	}
+	if len(fields) == 0 {
+		return manifestChange{}, false
+	}
+	change := manifestChange{
+		Section: sectionAvailability,
+		Action:  manifestActionCreate,
+		Fields:  fields,
+	}
+	if live != nil {
+		change.Action = manifestActionUpdate
+		change.Target = live.id
+		change.id = live.id
+	}
+	return change, true
+}
+
+func planPricing(desired ManifestPricing, live *livePricing) (manifestChange, bool) {
+	var current livePricing
+	if live != nil {
+		current = *live
+	}
+	baseTerritory := strings.ToUpper(strings.TrimSpace(desired.BaseTerritory))
+	pricePoint := strings.TrimSpace(desired.PricePoint)
+
+	fields := make([]manifestFieldChange, 0, 2)
+	if baseTerritory != current.baseTerritory {
+		fields = append(fields, manifestFieldChange{Field: "baseTerritory", Current: current.baseTerritory, Desired: baseTerritory})
+	}
+	if pricePoint != current.pricePoint {
+		fields = append(fields, manifestFieldChange{Field: "pricePoint", Current: current.pricePoint, Desired: pricePoint})
+	}
+	if len(fields) == 0 {
+		return manifestChange{}, false
+	}
+	action := manifestActionUpdate
+	if live == nil {
+		action = manifestActionCreate
+	}
+	// A new schedule replaces every manual price, so carry over the live
+	// ones. Moving the base territory drops the old base schedule and any
+	// manual price in the new base territory.
+	var manualPrices []liveManualPrice
+	for _, price := range current.manualPrices {
+		if baseTerritory != current.baseTerritory && (price.territory == baseTerritory || price.territory == current.baseTerritory) {
+			continue
+		}
+		manualPrices = append(manualPrices, price)
+	}
+	return manifestChange{
+		Section:      sectionPricing,
+		Action:       action,
+		Fields:       fields,
+		manualPrices: manualPrices,
+	}, true
+}
+
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return redactedValue
+}
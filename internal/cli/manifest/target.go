@@ -0,0 +1,84 @@
+package manifest
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// versionSelector identifies an App Store version by ID or by version string
+// and platform.
+type versionSelector struct {
+	id       string
+	version  string
+	platform string
+}
+
+// resolveVersionSelector merges version flags over the manifest's version
+// section. It returns nil when neither names a version.
+func resolveVersionSelector(fromFile *ManifestVersion, versionID, version, platform string) (*versionSelector, error) {
+	versionID = strings.TrimSpace(versionID)
+	version = strings.TrimSpace(version)
+	platform = strings.TrimSpace(platform)
+	if versionID != "" && version != "" {
+		fmt.Fprintf(os.Stderr, "Error: --version-id and --version are mutually exclusive\n\n")
+		return nil, flag.ErrHelp
+	}
+
+	selector := &versionSelector{}
+	switch {
+	case versionID != "":
+		selector.id = versionID
+	case version != "":
+		selector.version = version
+		selector.platform = platform
+	case fromFile != nil:
+		selector.id = strings.TrimSpace(fromFile.ID)
+		selector.version = strings.TrimSpace(fromFile.Version)
+		selector.platform = firstNonEmpty(platform, fromFile.Platform)
+	}
+	if selector.id == "" && selector.version == "" {
+		return nil, nil
+	}
+	if selector.id == "" {
+		if selector.platform == "" {
+			selector.platform = string(asc.PlatformIOS)
+		}
+		normalized, err := shared.NormalizeAppStoreVersionPlatform(selector.platform)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+			return nil, flag.ErrHelp
+		}
+		selector.platform = normalized
+	}
+	return selector, nil
+}
+
+func (s *versionSelector) resolve(ctx context.Context, client *asc.Client, appID string) (string, error) {
+	if s.id != "" {
+		return s.id, nil
+	}
+	return shared.ResolveAppStoreVersionID(ctx, client, appID, s.version, s.platform)
+}
+
+// resolveManifestAppInfoID uses override when set, otherwise the app info
+// that is currently editable.
+func resolveManifestAppInfoID(ctx context.Context, client *asc.Client, appID, override string) (string, error) {
+	if override = strings.TrimSpace(override); override != "" {
+		return override, nil
+	}
+	appInfos, err := client.GetAppInfos(ctx, appID)
+	if err != nil {
+		return "", fmt.Errorf("fetch app infos: %w", err)
+	}
+	appInfoID := shared.SelectBestAppInfoID(appInfos)
+	if appInfoID == "" {
+		return "", fmt.Errorf("no app info found for app %q", appID)
+	}
+	return appInfoID, nil
+}
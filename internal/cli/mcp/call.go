@@ -0,0 +1,255 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/mcpserver"
+)
+
+// commandHandler serves the leaf commands of the asc command tree as tools.
+// Every call runs against a fresh command tree so flag values never leak
+// between calls.
+type commandHandler struct {
+	newCommands func() []*ffcli.Command
+	tools       []commandTool
+	byName      map[string]commandTool
+}
+
+func newCommandHandler(newCommands func() []*ffcli.Command, roots []string) *commandHandler {
+	tools := collectTools(newCommands(), roots)
+	byName := make(map[string]commandTool, len(tools))
+	for _, tool := range tools {
+		byName[tool.tool.Name] = tool
+	}
+	return &commandHandler{newCommands: newCommands, tools: tools, byName: byName}
+}
+
+func (h *commandHandler) Tools() []mcpserver.Tool {
+	tools := make([]mcpserver.Tool, 0, len(h.tools))
+	for _, tool := range h.tools {
+		tools = append(tools, tool.tool)
+	}
+	return tools
+}
+
+func (h *commandHandler) CallTool(ctx context.Context, name string, arguments map[string]any) (*mcpserver.ToolResult, error) {
+	tool, ok := h.byName[name]
+	if !ok {
+		return nil, mcpserver.ErrUnknownTool
+	}
+	args, err := buildArgs(tool, arguments)
+	if err != nil {
+		return mcpserver.TextResult(err.Error(), true), nil
+	}
+
+	root := &ffcli.Command{
+		Name:        "asc",
+		FlagSet:     flag.NewFlagSet("asc", flag.ContinueOnError),
+		Subcommands: h.newCommands(),
+	}
+	continueOnError(root)
+
+	stdout, stderr, runErr := captureOutput(func() error {
+		return root.ParseAndRun(ctx, append(slices.Clone(tool.path), args...))
+	})
+	return toolResult(stdout, stderr, runErr), nil
+}
+
+// continueOnError switches every flag set in the tree to ContinueOnError so
+// a bad argument fails the call instead of exiting the server. Usage text is
+// discarded; the parse error is returned to the client.
+func continueOnError(cmd *ffcli.Command) {
+	if cmd.FlagSet == nil {
+		cmd.FlagSet = flag.NewFlagSet(cmd.Name, flag.ContinueOnError)
+	}
+	cmd.FlagSet.Init(cmd.FlagSet.Name(), flag.ContinueOnError)
+	cmd.FlagSet.SetOutput(io.Discard)
+	for _, sub := range cmd.Subcommands {
+		if sub != nil {
+			continueOnError(sub)
+		}
+	}
+}
+
+// buildArgs converts tool arguments to command-line flags, in sorted order,
+// followed by any positional arguments. Commands with an output format flag
+// default to JSON so results are structured.
+func buildArgs(tool commandTool, arguments map[string]any) ([]string, error) {
+	keys := make([]string, 0, len(arguments))
+	for key := range arguments {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var args, positional []string
+	for _, key := range keys {
+		value := arguments[key]
+		if key == argsProperty && tool.args {
+			items, err := stringList(key, value)
+			if err != nil {
+				return nil, err
+			}
+			positional = items
+			continue
+		}
+		kind, ok := tool.flags[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown argument %q for %s", key, tool.tool.Name)
+		}
+		if value == nil {
+			continue
+		}
+		if kind == "array" {
+			items, err := stringList(key, value)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range items {
+				args = append(args, "--"+key+"="+item)
+			}
+			continue
+		}
+		text, err := scalarString(key, value)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "--"+key+"="+text)
+	}
+
+	if tool.outputFlag != "" {
+		if _, set := arguments[tool.outputFlag]; !set {
+			args = append(args, "--"+tool.outputFlag+"=json")
+		}
+	}
+	if len(positional) > 0 {
+		args = append(args, "--")
+		args = append(args, positional...)
+	}
+	return args, nil
+}
+
+func stringList(key string, value any) ([]string, error) {
+	items, ok := value.([]any)
+	if !ok {
+		text, err := scalarString(key, value)
+		if err != nil {
+			return nil, err
+		}
+		return []string{text}, nil
+	}
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		text, err := scalarString(key, item)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, text)
+	}
+	return out, nil
+}
+
+func scalarString(key string, value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return strconv.FormatInt(int64(v), 10), nil
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("argument %q must be a string, number or boolean", key)
+	}
+}
+
+// captureOutput runs fn with stdout and stderr redirected to buffers and
+// stdin empty, so commands cannot write to or read from the protocol
+// stream.
+func captureOutput(fn func() error) (string, string, error) {
+	origStdin, origStdout, origStderr := os.Stdin, os.Stdout, os.Stderr
+
+	stdin, err := os.Open(os.DevNull)
+	if err != nil {
+		return "", "", err
+	}
+	defer stdin.Close()
+	stdoutR, stdoutW, err := os.Pipe()
+	if err != nil {
+		return "", "", err
+	}
+	stderrR, stderrW, err := os.Pipe()
+	if err != nil {
+		_ = stdoutR.Close()
+		_ = stdoutW.Close()
+		return "", "", err
+	}
+
+	var stdout, stderr bytes.Buffer
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(&stdout, stdoutR)
+	}()
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(&stderr, stderrR)
+	}()
+
+	os.Stdin, os.Stdout, os.Stderr = stdin, stdoutW, stderrW
+	runErr := fn()
+	os.Stdin, os.Stdout, os.Stderr = origStdin, origStdout, origStderr
+
+	_ = stdoutW.Close()
+	_ = stderrW.Close()
+	wg.Wait()
+	_ = stdoutR.Close()
+	_ = stderrR.Close()
+	return stdout.String(), stderr.String(), runErr
+}
+
+// toolResult returns the command output as text, and as structured content
+// when it is JSON. JSON objects are returned as is; other values are wrapped
+// in {"result": ...}.
+func toolResult(stdout, stderr string, runErr error) *mcpserver.ToolResult {
+	stdout = strings.TrimSpace(stdout)
+	stderr = strings.TrimSpace(stderr)
+	if runErr != nil {
+		message := runErr.Error()
+		if errors.Is(runErr, flag.ErrHelp) {
+			message = "invalid arguments"
+		}
+		if stderr != "" {
+			message += "\n" + stderr
+		}
+		return mcpserver.TextResult(message, true)
+	}
+
+	result := mcpserver.TextResult(stdout, false)
+	var value any
+	if stdout != "" && json.Unmarshal([]byte(stdout), &value) == nil {
+		if _, isObject := value.(map[string]any); isObject {
+			result.StructuredContent = value
+		} else {
+			result.StructuredContent = map[string]any{"result": value}
+		}
+	}
+	return result
+}
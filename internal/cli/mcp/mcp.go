@@ -0,0 +1,102 @@
+package mcp
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/mcpserver"
+)
+
+// MCPCommand returns the mcp command group. newCommands builds a fresh set
+// of root subcommands; it is called once to list tools and once per call.
+func MCPCommand(version string, newCommands func() []*ffcli.Command) *ffcli.Command {
+	fs := flag.NewFlagSet("mcp", flag.ExitOnError)
+
+	return &ffcli.Command{
+		Name:       "mcp",
+		ShortUsage: "asc mcp <subcommand> [flags]",
+		ShortHelp:  "Serve asc commands as Model Context Protocol tools.",
+		LongHelp: `Serve asc commands as Model Context Protocol tools.
+
+Examples:
+  asc mcp serve
+  asc mcp serve --commands apps,builds,testflight`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			MCPServeCommand(version, newCommands),
+		},
+		Exec: func(ctx context.Context, args []string) error {
+			return flag.ErrHelp
+		},
+	}
+}
+
+// MCPServeCommand returns the mcp serve subcommand.
+func MCPServeCommand(version string, newCommands func() []*ffcli.Command) *ffcli.Command {
+	fs := flag.NewFlagSet("mcp serve", flag.ExitOnError)
+
+	commands := fs.String("commands", "", "Comma-separated root commands to expose (default: all)")
+
+	return &ffcli.Command{
+		Name:       "serve",
+		ShortUsage: "asc mcp serve [flags]",
+		ShortHelp:  "Run an MCP server over stdio.",
+		LongHelp: `Run an MCP server over stdio.
+
+Every leaf command becomes a tool named after its path ("apps list" is
+apps_list; names over 64 characters are shortened with a hash). Use
+--commands to expose only some root commands. The input schema is derived from the command's flags: flag
+names are the property names, booleans and integers keep their types and
+repeatable flags take arrays. Commands that take positional arguments
+accept them as "args".
+
+Commands run in-process with the server's credentials and root flags
+(--profile, --strict-auth, ...). Their output defaults to JSON and is
+returned as structured content. Only list, get, view, info, status and
+similar read-only commands are annotated read-only. Commands with
+--confirm, delete, remove, revoke, expire, cancel, clear and deactivate
+commands, commands that replace remote state from a file (apply, testflight
+sync push) and api (any --method) are annotated as destructive so clients
+can ask before calling them; --confirm must still be passed explicitly. Long-running servers
+(mock serve, webhooks serve) are not exposed.
+
+Example client configuration:
+  {"mcpServers": {"asc": {"command": "asc", "args": ["mcp", "serve"]}}}
+
+Examples:
+  asc mcp serve
+  asc mcp serve --commands apps,builds,testflight
+  asc --profile "ci" mcp serve`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) > 0 {
+				return shared.UsageErrorf("unexpected arguments: %s", strings.Join(args, " "))
+			}
+
+			roots := shared.SplitCSV(*commands)
+			handler := newCommandHandler(newCommands, roots)
+			if len(handler.tools) == 0 {
+				return shared.UsageErrorf("--commands %s matched no commands", strings.Join(roots, ","))
+			}
+			server := mcpserver.New("asc", version, handler)
+
+			serveCtx, stop := signal.NotifyContext(ctx, os.Interrupt)
+			defer stop()
+
+			fmt.Fprintf(os.Stderr, "Serving %d asc tools over stdio\n", len(handler.tools))
+			if err := server.Serve(serveCtx, os.Stdin, os.Stdout); err != nil {
+				return fmt.Errorf("mcp serve: %w", err)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,319 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+type listFlag []string
+
+func (l *listFlag) String() string { return strings.Join(*l, ",") }
+
+func (l *listFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// testCommands returns a small command tree; calls records the arguments
+// each leaf ran with.
+func testCommands(calls *[]string) func() []*ffcli.Command {
+	return func() []*ffcli.Command {
+		listFS := flag.NewFlagSet("widgets list", flag.ExitOnError)
+		limit := listFS.Int("limit", 0, "Maximum results per page (1-200)")
+		paginate := listFS.Bool("paginate", false, "Fetch all pages")
+		output := listFS.String("output", "json", "Output format: json (default), table")
+		var tags listFlag
+		listFS.Var(&tags, "tag", "Tag filter (repeatable)")
+
+		deleteFS := flag.NewFlagSet("widgets delete", flag.ExitOnError)
+		id := deleteFS.String("id", "", "Widget ID")
+		deleteFS.StringVar(id, "i", "", "Shorthand for --id")
+		confirm := deleteFS.Bool("confirm", false, "Confirm deletion")
+
+		echoFS := flag.NewFlagSet("echo", flag.ExitOnError)
+
+		return []*ffcli.Command{
+			{
+				Name:       "widgets",
+				ShortUsage: "asc widgets <subcommand> [flags]",
+				Exec: func(ctx context.Context, args []string) error {
+					return flag.ErrHelp
+				},
+				Subcommands: []*ffcli.Command{
+					{
+						Name:       "list",
+						ShortUsage: "asc widgets list [flags]",
+						ShortHelp:  "List widgets.",
+						FlagSet:    listFS,
+						Exec: func(ctx context.Context, args []string) error {
+							*calls = append(*calls, fmt.Sprintf("list limit=%d paginate=%t output=%s tags=%v", *limit, *paginate, *output, []string(tags)))
+							fmt.Println(`{"data":[{"type":"widgets","id":"w1"}]}`)
+							return nil
+						},
+					},
+					{
+						Name:       "delete",
+						ShortUsage: "asc widgets delete --id ID --confirm",
+						ShortHelp:  "Delete a widget.",
+						FlagSet:    deleteFS,
+						Exec: func(ctx context.Context, args []string) error {
+							if !*confirm {
+								fmt.Fprintln(os.Stderr, "Error: --confirm is required")
+								return flag.ErrHelp
+							}
+							*calls = append(*calls, "delete "+*id)
+							return nil
+						},
+					},
+				},
+			},
+			{
+				Name:       "echo",
+				ShortUsage: "asc echo <words...>",
+				ShortHelp:  "Print the arguments.",
+				FlagSet:    echoFS,
+				Exec: func(ctx context.Context, args []string) error {
+					fmt.Println(strings.Join(args, " "))
+					return nil
+				},
+			},
+			{Name: "version", Exec: func(ctx context.Context, args []string) error { return nil }},
+		}
+	}
+}
+
+func findTool(t *testing.T, tools []commandTool, name string) commandTool {
+	t.Helper()
+	for _, tool := range tools {
+		if tool.tool.Name == name {
+			return tool
+		}
+	}
+	t.Fatalf("tool %q not found", name)
+	return commandTool{}
+}
+
+func TestCollectToolsBuildsSchemasFromFlags(t *testing.T) {
+	tools := collectTools(testCommands(new([]string))(), nil)
+
+	var names []string
+	for _, tool := range tools {
+		names = append(names, tool.tool.Name)
+	}
+	if want := []string{"echo", "widgets_delete", "widgets_list"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("tool names = %v, want %v", names, want)
+	}
+
+	list := findTool(t, tools, "widgets_list")
+	properties := list.tool.InputSchema["properties"].(map[string]any)
+	wantTypes := map[string]string{"limit": "integer", "paginate": "boolean", "output": "string", "tag": "array"}
+	for name, want := range wantTypes {
+		schema := properties[name].(map[string]any)
+		if schema["type"] != want {
+			t.Fatalf("%s type = %v, want %s", name, schema["type"], want)
+		}
+	}
+	if !list.tool.Annotations.ReadOnlyHint || list.tool.Annotations.DestructiveHint {
+		t.Fatalf("expected list to be read-only, got %+v", list.tool.Annotations)
+	}
+	if list.outputFlag != "output" {
+		t.Fatalf("expected --output to be detected as the output format flag, got %q", list.outputFlag)
+	}
+
+	del := findTool(t, tools, "widgets_delete")
+	deleteProperties := del.tool.InputSchema["properties"].(map[string]any)
+	if _, ok := deleteProperties["i"]; ok {
+		t.Fatal("expected shorthand flags to be omitted")
+	}
+	if !del.tool.Annotations.DestructiveHint || del.tool.Annotations.ReadOnlyHint {
+		t.Fatalf("expected delete to be destructive, got %+v", del.tool.Annotations)
+	}
+
+	echo := findTool(t, tools, "echo")
+	if _, ok := echo.tool.InputSchema["properties"].(map[string]any)[argsProperty]; !ok {
+		t.Fatal("expected positional args property for echo")
+	}
+
+	if filtered := collectTools(testCommands(new([]string))(), []string{"echo"}); len(filtered) != 1 {
+		t.Fatalf("expected --commands to filter root commands, got %d tools", len(filtered))
+	}
+}
+
+func TestToolNameShortensLongPaths(t *testing.T) {
+	path := strings.Split(strings.Repeat("segment-", 12)+"leaf", "-")
+	name := toolName(path)
+	if len(name) != maxToolNameLength {
+		t.Fatalf("expected %d character name, got %d (%s)", maxToolNameLength, len(name), name)
+	}
+	other := toolName(append(path[:len(path)-1:len(path)-1], "other"))
+	if name == other {
+		t.Fatalf("expected distinct names for distinct paths, got %s", name)
+	}
+	if got := toolName([]string{"apps", "list"}); got != "apps_list" {
+		t.Fatalf("toolName = %s", got)
+	}
+}
+
+func TestBuildArgs(t *testing.T) {
+	tools := collectTools(testCommands(new([]string))(), nil)
+
+	args, err := buildArgs(findTool(t, tools, "widgets_list"), map[string]any{
+		"limit":    float64(5),
+		"paginate": true,
+		"tag":      []any{"a", "b"},
+	})
+	if err != nil {
+		t.Fatalf("buildArgs() error: %v", err)
+	}
+	want := []string{"--limit=5", "--paginate=true", "--tag=a", "--tag=b", "--output=json"}
+	if !reflect.DeepEqual(args, want) {
+		t.Fatalf("args = %v, want %v", args, want)
+	}
+
+	args, err = buildArgs(findTool(t, tools, "echo"), map[string]any{"args": []any{"-n", "hi"}})
+	if err != nil {
+		t.Fatalf("buildArgs() error: %v", err)
+	}
+	if want := []string{"--", "-n", "hi"}; !reflect.DeepEqual(args, want) {
+		t.Fatalf("args = %v, want %v", args, want)
+	}
+
+	if _, err := buildArgs(findTool(t, tools, "widgets_list"), map[string]any{"bogus": "x"}); err == nil {
+		t.Fatal("expected unknown argument error")
+	}
+	if _, err := buildArgs(findTool(t, tools, "widgets_list"), map[string]any{"limit": map[string]any{}}); err == nil {
+		t.Fatal("expected type error for object argument")
+	}
+}
+
+func TestCallToolRunsCommandAndReturnsStructuredJSON(t *testing.T) {
+	var calls []string
+	handler := newCommandHandler(testCommands(&calls), nil)
+
+	result, err := handler.CallTool(context.Background(), "widgets_list", map[string]any{"limit": float64(2), "tag": "beta"})
+	if err != nil {
+		t.Fatalf("CallTool() error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %+v", result)
+	}
+	structured, err := json.Marshal(result.StructuredContent)
+	if err != nil {
+		t.Fatalf("marshal error: %v", err)
+	}
+	if string(structured) != `{"data":[{"id":"w1","type":"widgets"}]}` {
+		t.Fatalf("structuredContent = %s", structured)
+	}
+
+	// A second call gets a fresh command tree, so flags from the first call
+	// do not leak.
+	if _, err := handler.CallTool(context.Background(), "widgets_list", nil); err != nil {
+		t.Fatalf("CallTool() error: %v", err)
+	}
+	want := []string{
+		"list limit=2 paginate=false output=json tags=[beta]",
+		"list limit=0 paginate=false output=json tags=[]",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Fatalf("calls = %q, want %q", calls, want)
+	}
+
+	echo, err := handler.CallTool(context.Background(), "echo", map[string]any{"args": []any{"hello", "world"}})
+	if err != nil {
+		t.Fatalf("CallTool(echo) error: %v", err)
+	}
+	if echo.Content[0].Text != "hello world" || echo.StructuredContent != nil {
+		t.Fatalf("unexpected echo result %+v", echo)
+	}
+}
+
+func TestCallToolReportsCommandErrors(t *testing.T) {
+	var calls []string
+	handler := newCommandHandler(testCommands(&calls), nil)
+
+	result, err := handler.CallTool(context.Background(), "widgets_delete", map[string]any{"id": "w1"})
+	if err != nil {
+		t.Fatalf("CallTool() error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "--confirm is required") {
+		t.Fatalf("expected confirm error, got %+v", result)
+	}
+
+	result, err = handler.CallTool(context.Background(), "widgets_list", map[string]any{"limit": "many"})
+	if err != nil {
+		t.Fatalf("CallTool() error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "invalid value") {
+		t.Fatalf("expected flag parse error without exiting, got %+v", result)
+	}
+	if len(calls) != 0 {
+		t.Fatalf("expected no successful calls, got %v", calls)
+	}
+}
+
+func TestCollectToolsAnnotationsAndExclusions(t *testing.T) {
+	noop := func(ctx context.Context, args []string) error { return nil }
+	frameFS := flag.NewFlagSet("screenshots frame", flag.ExitOnError)
+	frameFS.String("config", "", "Config file")
+	frameFS.Bool("watch", false, "Watch for changes")
+
+	commands := []*ffcli.Command{
+		{Name: "mock", Subcommands: []*ffcli.Command{{Name: "serve", Exec: noop}}},
+		{Name: "webhooks", Subcommands: []*ffcli.Command{{Name: "serve", Exec: noop}, {Name: "list", Exec: noop}}},
+		{Name: "offer-codes", Subcommands: []*ffcli.Command{{Name: "generate", Exec: noop}}},
+		{Name: "publish", Subcommands: []*ffcli.Command{{Name: "testflight", Exec: noop}}},
+		{Name: "screenshots", Subcommands: []*ffcli.Command{{Name: "frame", FlagSet: frameFS, Exec: noop}}},
+	}
+	tools := collectTools(commands, nil)
+
+	var names []string
+	for _, tool := range tools {
+		names = append(names, tool.tool.Name)
+	}
+	if want := []string{"offer-codes_generate", "publish_testflight", "screenshots_frame", "webhooks_list"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("tool names = %v, want %v", names, want)
+	}
+	for _, name := range []string{"offer-codes_generate", "publish_testflight"} {
+		if findTool(t, tools, name).tool.Annotations.ReadOnlyHint {
+			t.Fatalf("expected %s not to be read-only", name)
+		}
+	}
+	if !findTool(t, tools, "webhooks_list").tool.Annotations.ReadOnlyHint {
+		t.Fatal("expected webhooks list to be read-only")
+	}
+	frame := findTool(t, tools, "screenshots_frame")
+	if _, ok := frame.flags["watch"]; ok {
+		t.Fatal("expected --watch to be left out of screenshots frame")
+	}
+	if _, ok := frame.flags["config"]; !ok {
+		t.Fatal("expected --config to be kept")
+	}
+}
+
+func TestCollectToolsMarksReplacingCommandsDestructive(t *testing.T) {
+	noop := func(ctx context.Context, args []string) error { return nil }
+	apiFS := flag.NewFlagSet("api", flag.ExitOnError)
+	apiFS.String("method", "", "HTTP method")
+
+	commands := []*ffcli.Command{
+		{Name: "api", FlagSet: apiFS, Exec: noop},
+		{Name: "apply", Exec: noop},
+		{Name: "testflight", Subcommands: []*ffcli.Command{{Name: "sync", Subcommands: []*ffcli.Command{{Name: "push", Exec: noop}}}}},
+	}
+	tools := collectTools(commands, nil)
+
+	for _, name := range []string{"api", "apply", "testflight_sync_push"} {
+		annotations := findTool(t, tools, name).tool.Annotations
+		if !annotations.DestructiveHint || annotations.ReadOnlyHint {
+			t.Fatalf("expected %s to be destructive, got %+v", name, annotations)
+		}
+	}
+}
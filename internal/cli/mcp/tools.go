@@ -0,0 +1,205 @@
+package mcp
+
+import (
+	"flag"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/mcpserver"
+)
+
+// argsProperty is the input property holding positional arguments for
+// commands whose usage takes them (e.g. "asc api [METHOD] <path>").
+const argsProperty = "args"
+
+// maxToolNameLength is the longest tool name clients are required to accept.
+const maxToolNameLength = 64
+
+// excludedCommands are command paths that make no sense as tools, including
+// long-running servers that would block the stdio server forever.
+var excludedCommands = map[string]bool{
+	"mcp":            true,
+	"completion":     true,
+	"version":        true,
+	"mock serve":     true,
+	"webhooks serve": true,
+}
+
+// excludedFlags are flags left out of a tool's input schema because they
+// turn the command into a long-running process.
+var excludedFlags = map[string][]string{
+	"screenshots frame": {"watch", "watch-debounce", "watch-review-dir", "watch-raw-dir"},
+}
+
+// commandTool is a leaf command exposed as a tool.
+type commandTool struct {
+	tool mcpserver.Tool
+	path []string
+	// flags maps flag names to their schema kind.
+	flags map[string]string
+	args  bool
+	// outputFlag is the command's output format flag, set to json unless
+	// the caller chooses a format.
+	outputFlag string
+}
+
+// collectTools returns one tool per runnable leaf command, sorted by name.
+// When roots is non-empty only those root commands are included.
+func collectTools(commands []*ffcli.Command, roots []string) []commandTool {
+	var tools []commandTool
+	for _, cmd := range commands {
+		if cmd == nil {
+			continue
+		}
+		if len(roots) > 0 && !slices.Contains(roots, cmd.Name) {
+			continue
+		}
+		tools = appendLeafTools(tools, nil, cmd)
+	}
+	sort.Slice(tools, func(i, j int) bool {
+		return tools[i].tool.Name < tools[j].tool.Name
+	})
+	return tools
+}
+
+func appendLeafTools(tools []commandTool, parent []string, cmd *ffcli.Command) []commandTool {
+	path := append(slices.Clone(parent), cmd.Name)
+	if excludedCommands[strings.Join(path, " ")] {
+		return tools
+	}
+	if len(cmd.Subcommands) > 0 {
+		for _, sub := range cmd.Subcommands {
+			if sub != nil {
+				tools = appendLeafTools(tools, path, sub)
+			}
+		}
+		return tools
+	}
+	if cmd.Exec == nil {
+		return tools
+	}
+	return append(tools, buildTool(path, cmd))
+}
+
+func buildTool(path []string, cmd *ffcli.Command) commandTool {
+	properties := map[string]any{}
+	flags := map[string]string{}
+	skipFlags := excludedFlags[strings.Join(path, " ")]
+	if cmd.FlagSet != nil {
+		cmd.FlagSet.VisitAll(func(f *flag.Flag) {
+			if _, ok := shared.ShorthandTarget(f); ok {
+				return
+			}
+			if slices.Contains(skipFlags, f.Name) {
+				return
+			}
+			kind, schema := flagSchema(f)
+			properties[f.Name] = schema
+			flags[f.Name] = kind
+		})
+	}
+
+	takesArgs := strings.Contains(cmd.ShortUsage, "<")
+	if takesArgs {
+		if _, exists := properties[argsProperty]; !exists {
+			properties[argsProperty] = map[string]any{
+				"type":        "array",
+				"items":       map[string]any{"type": "string"},
+				"description": "Positional arguments: " + cmd.ShortUsage,
+			}
+		}
+	}
+
+	var outputFlag string
+	if output, ok := shared.CommandOutputFormat(cmd); ok && slices.Contains(output.Formats, "json") {
+		outputFlag = output.Name
+	}
+	effects := shared.InferCommandEffects(cmd)
+
+	description := strings.TrimSpace(cmd.ShortHelp)
+	if cmd.ShortUsage != "" {
+		description += "\n\nUsage: " + cmd.ShortUsage
+	}
+	if effects.Destructive {
+		description += "\n\nDestructive: confirm with the user before calling."
+	}
+
+	return commandTool{
+		tool: mcpserver.Tool{
+			Name:        toolName(path),
+			Title:       "asc " + strings.Join(path, " "),
+			Description: description,
+			InputSchema: map[string]any{
+				"type":                 "object",
+				"properties":           properties,
+				"additionalProperties": false,
+			},
+			Annotations: &mcpserver.ToolAnnotations{
+				ReadOnlyHint:    effects.ReadOnly,
+				DestructiveHint: effects.Destructive,
+			},
+		},
+		path:       path,
+		flags:      flags,
+		args:       takesArgs,
+		outputFlag: outputFlag,
+	}
+}
+
+// toolName joins path with underscores. Names over maxToolNameLength are
+// truncated and suffixed with a hash of the full path so they stay unique.
+func toolName(path []string) string {
+	name := strings.Join(path, "_")
+	if len(name) <= maxToolNameLength {
+		return name
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(name))
+	suffix := fmt.Sprintf("_%08x", hash.Sum32())
+	return name[:maxToolNameLength-len(suffix)] + suffix
+}
+
+// flagSchema returns the kind and JSON schema for a flag. Repeatable flags
+// take string arrays and durations are strings such as "30s".
+func flagSchema(f *flag.Flag) (string, map[string]any) {
+	kind := shared.FlagType(f)
+	if kind == "duration" {
+		kind = "string"
+	}
+
+	schema := map[string]any{"description": f.Usage}
+	if kind == "array" {
+		schema["type"] = "array"
+		schema["items"] = map[string]any{"type": "string"}
+	} else {
+		schema["type"] = kind
+	}
+	if f.DefValue != "" && f.DefValue != "false" && f.DefValue != "0" && f.DefValue != "[]" {
+		schema["default"] = defaultValue(kind, f.DefValue)
+	}
+	return kind, schema
+}
+
+func defaultValue(kind, value string) any {
+	switch kind {
+	case "integer":
+		var n int64
+		if _, err := fmt.Sscan(value, &n); err == nil {
+			return n
+		}
+	case "number":
+		var n float64
+		if _, err := fmt.Sscan(value, &n); err == nil {
+			return n
+		}
+	case "boolean":
+		return value == "true"
+	}
+	return value
+}
@@ -37,7 +37,11 @@ func resolveAppID(ctx context.Context, client *asc.Client, appFlag string, confi
 		}
 		return resp.Data[0].ID, nil
 	}
-	if appID := shared.ResolveAppID(""); appID != "" {
+	appID, err := shared.ResolveAppID(ctx, "")
+	if err != nil {
+		return "", err
+	}
+	if appID != "" {
 		return appID, nil
 	}
 	return "", fmt.Errorf("--app is required (or set ASC_APP_ID or provide Deliverfile app_identifier)")
@@ -0,0 +1,137 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/mockserver"
+)
+
+const mockShutdownTimeout = 5 * time.Second
+
+// MockCommand returns the mock command group.
+func MockCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("mock", flag.ExitOnError)
+
+	return &ffcli.Command{
+		Name:       "mock",
+		ShortUsage: "asc mock <subcommand> [flags]",
+		ShortHelp:  "Run an offline App Store Connect API stand-in.",
+		LongHelp: `Run an offline App Store Connect API stand-in.
+
+Examples:
+  asc mock serve --dir "./fixtures"
+  ASC_BASE_URL="http://127.0.0.1:8787" asc apps list`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			MockServeCommand(),
+		},
+		Exec: func(ctx context.Context, args []string) error {
+			return flag.ErrHelp
+		},
+	}
+}
+
+// MockServeCommand returns the mock serve subcommand.
+func MockServeCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("mock serve", flag.ExitOnError)
+
+	dir := fs.String("dir", "", "Fixture directory (required)")
+	addr := fs.String("addr", "127.0.0.1:8787", "Listen address (host:port)")
+
+	return &ffcli.Command{
+		Name:       "serve",
+		ShortUsage: "asc mock serve --dir \"./fixtures\" [flags]",
+		ShortHelp:  "Serve recorded JSON:API fixtures from a directory.",
+		LongHelp: `Serve recorded JSON:API fixtures from a directory.
+
+Fixtures are looked up by method and path:
+  <dir>/GET/v1/apps.json                    GET /v1/apps
+  <dir>/GET/v1/apps.cursor=abc&limit=200.json GET /v1/apps?limit=200&cursor=abc
+  <dir>/PATCH/v1/betaGroups/GROUP_ID.json     PATCH /v1/betaGroups/GROUP_ID
+
+Query-specific fixtures use the query encoded with sorted keys and win over
+the plain fixture. Requests with a pagination cursor require an exact match.
+Links to https://api.appstoreconnect.apple.com in fixtures are rewritten to
+this server. JSON:API error documents are served with their error status.
+
+Point asc at the server with ASC_BASE_URL (or base_url in config.json).
+Credentials are still required to sign requests, but any valid .p8 key works.
+
+Examples:
+  asc mock serve --dir "./fixtures"
+  asc mock serve --dir "./fixtures" --addr "127.0.0.1:9000"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			dirValue := strings.TrimSpace(*dir)
+			if dirValue == "" {
+				fmt.Fprintf(os.Stderr, "Error: --dir is required\n\n")
+				return flag.ErrHelp
+			}
+			addrValue := strings.TrimSpace(*addr)
+			if addrValue == "" {
+				fmt.Fprintf(os.Stderr, "Error: --addr is required\n\n")
+				return flag.ErrHelp
+			}
+
+			listener, err := net.Listen("tcp", addrValue)
+			if err != nil {
+				return fmt.Errorf("mock serve: %w", err)
+			}
+			baseURL := "http://" + listener.Addr().String()
+
+			handler, err := mockserver.New(dirValue, baseURL, os.Stderr)
+			if err != nil {
+				_ = listener.Close()
+				return fmt.Errorf("mock serve: %w", err)
+			}
+
+			serveCtx, stop := signal.NotifyContext(ctx, os.Interrupt)
+			defer stop()
+
+			return serve(serveCtx, listener, handler, baseURL)
+		},
+	}
+}
+
+func serve(ctx context.Context, listener net.Listener, handler http.Handler, baseURL string) error {
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	fmt.Fprintf(os.Stderr, "Serving fixtures on %s (set ASC_BASE_URL=%s)\n", baseURL, baseURL)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("mock serve: %w", err)
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), mockShutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("mock serve: %w", err)
+		}
+		return nil
+	}
+}
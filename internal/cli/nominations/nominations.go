@@ -330,7 +330,11 @@ Examples:
 				visited[f.Name] = true
 			})
 
-			relatedApps := shared.SplitCSV(shared.ResolveAppID(*appID))
+			appValue, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("nominations create: %w", err)
+			}
+			relatedApps := shared.SplitCSV(appValue)
 			if len(relatedApps) == 0 {
 				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
 				return flag.ErrHelp
@@ -0,0 +1,114 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	discordWebhookEnvVar = "ASC_DISCORD_WEBHOOK"
+	// discordMaxContentLength is Discord's limit for a message's content.
+	discordMaxContentLength = 2000
+)
+
+var discordWebhookRule = webhookRule{
+	name:       "Discord",
+	envVar:     discordWebhookEnvVar,
+	example:    "https://discord.com/api/webhooks/...",
+	hosts:      "discord.com,discordapp.com,ptb.discord.com,canary.discord.com",
+	pathPrefix: "/api/webhooks/",
+}
+
+func DiscordCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("notify discord", flag.ExitOnError)
+
+	webhook := fs.String("webhook", "", "Discord webhook URL (or set "+discordWebhookEnvVar+" env var)")
+	title := fs.String("title", "", "Bold first line of the message")
+	message := fs.String("message", "", "Message to send to Discord")
+	username := fs.String("username", "", "Override the webhook's display name")
+
+	return &ffcli.Command{
+		Name:       "discord",
+		ShortUsage: "asc notify discord --webhook URL --message TEXT [--title TEXT]",
+		ShortHelp:  "Send a message to Discord via webhook.",
+		LongHelp: `Send a message to Discord via webhook.
+
+The webhook URL can be provided via --webhook flag or ASC_DISCORD_WEBHOOK env var.
+Discord limits messages to 2000 characters, including the title.
+
+Examples:
+  asc notify discord --webhook "https://discord.com/api/webhooks/..." --message "Build uploaded"
+  ASC_DISCORD_WEBHOOK=$WEBHOOK asc notify discord --title "Release v2.1" --message "Live on the App Store"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			webhookURL := resolveURL(*webhook, discordWebhookEnvVar)
+			if webhookURL == "" {
+				fmt.Fprintf(os.Stderr, "Error: --webhook is required or set %s env var\n", discordWebhookEnvVar)
+				return flag.ErrHelp
+			}
+			if err := validateWebhookURL("--webhook", webhookURL, discordWebhookRule); err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", err.Error())
+				return flag.ErrHelp
+			}
+
+			msg := strings.TrimSpace(*message)
+			if msg == "" {
+				fmt.Fprintln(os.Stderr, "Error: --message is required")
+				return flag.ErrHelp
+			}
+			notification := Message{Title: strings.TrimSpace(*title), Text: msg}
+			if length := len([]rune(discordContent(notification))); length > discordMaxContentLength {
+				fmt.Fprintf(os.Stderr, "Error: message is %d characters; Discord allows at most %d\n", length, discordMaxContentLength)
+				return flag.ErrHelp
+			}
+
+			notifier := &discordNotifier{webhookURL: webhookURL, username: strings.TrimSpace(*username)}
+			if err := notifier.Send(ctx, notification); err != nil {
+				return fmt.Errorf("notify discord: %w", err)
+			}
+
+			fmt.Fprintln(os.Stderr, "Message sent to Discord successfully")
+			return nil
+		},
+	}
+}
+
+type discordNotifier struct {
+	webhookURL string
+	username   string
+}
+
+type discordPayload struct {
+	Content  string `json:"content"`
+	Username string `json:"username,omitempty"`
+}
+
+// Send posts message to the Discord webhook. Content longer than Discord
+// accepts is truncated rather than rejected by the API.
+func (n *discordNotifier) Send(ctx context.Context, message Message) error {
+	content := discordContent(message)
+	if runes := []rune(content); len(runes) > discordMaxContentLength {
+		content = string(runes[:discordMaxContentLength-1]) + "…"
+	}
+	body, err := json.Marshal(discordPayload{Content: content, Username: n.username})
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	return postWebhook(ctx, n.webhookURL, body, nil)
+}
+
+func discordContent(message Message) string {
+	if title := strings.TrimSpace(message.Title); title != "" {
+		return "**" + title + "**\n" + message.Text
+	}
+	return message.Text
+}
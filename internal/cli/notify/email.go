@@ -0,0 +1,268 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	smtpHostEnvVar     = "ASC_SMTP_HOST"
+	smtpPortEnvVar     = "ASC_SMTP_PORT"
+	smtpUsernameEnvVar = "ASC_SMTP_USERNAME"
+	smtpPasswordEnvVar = "ASC_SMTP_PASSWORD"
+	smtpFromEnvVar     = "ASC_SMTP_FROM"
+	smtpDefaultPort    = 587
+	// smtpImplicitTLSPort uses TLS from the first byte instead of STARTTLS.
+	smtpImplicitTLSPort = 465
+)
+
+// deliverMail sends a prepared message. Tests replace it to avoid a network
+// round trip.
+var deliverMail = sendSMTP
+
+func EmailCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("notify email", flag.ExitOnError)
+
+	host := fs.String("smtp-host", "", "SMTP server host (or set "+smtpHostEnvVar+" env var)")
+	port := fs.Int("smtp-port", 0, "SMTP server port (or set "+smtpPortEnvVar+" env var; default 587)")
+	username := fs.String("smtp-username", "", "SMTP username (or set "+smtpUsernameEnvVar+" env var)")
+	from := fs.String("from", "", "Sender address (or set "+smtpFromEnvVar+" env var)")
+	to := fs.String("to", "", "Recipient addresses, comma-separated")
+	subject := fs.String("subject", "", "Email subject")
+	message := fs.String("message", "", "Email body")
+
+	return &ffcli.Command{
+		Name:       "email",
+		ShortUsage: "asc notify email --to ADDRESSES --subject TEXT --message TEXT [flags]",
+		ShortHelp:  "Send a plain-text email via SMTP.",
+		LongHelp: `Send a plain-text email via SMTP.
+
+The password is read only from the ASC_SMTP_PASSWORD env var so it never
+appears in shell history or process listings. Port 465 uses implicit TLS;
+other ports upgrade with STARTTLS when the server offers it, and credentials
+are only sent over TLS (or to localhost).
+
+Examples:
+  ASC_SMTP_HOST=smtp.example.com ASC_SMTP_USERNAME=ci ASC_SMTP_PASSWORD=$PASS \
+    asc notify email --from ci@example.com --to team@example.com --subject "Release" --message "v2.1 is live"
+  asc notify email --smtp-host smtp.example.com --smtp-port 465 --from ci@example.com --to "a@example.com,b@example.com" --subject "Build 42" --message "Uploaded"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			hostValue := resolveURL(*host, smtpHostEnvVar)
+			if hostValue == "" {
+				fmt.Fprintf(os.Stderr, "Error: --smtp-host is required or set %s env var\n", smtpHostEnvVar)
+				return flag.ErrHelp
+			}
+			portValue, err := resolveSMTPPort(*port)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", err.Error())
+				return flag.ErrHelp
+			}
+
+			fromValue := resolveURL(*from, smtpFromEnvVar)
+			if fromValue == "" {
+				fmt.Fprintf(os.Stderr, "Error: --from is required or set %s env var\n", smtpFromEnvVar)
+				return flag.ErrHelp
+			}
+			sender, err := mail.ParseAddress(fromValue)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error: --from must be a valid email address")
+				return flag.ErrHelp
+			}
+			if strings.TrimSpace(*to) == "" {
+				fmt.Fprintln(os.Stderr, "Error: --to is required")
+				return flag.ErrHelp
+			}
+			recipients, err := mail.ParseAddressList(*to)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error: --to must be a comma-separated list of email addresses")
+				return flag.ErrHelp
+			}
+
+			subjectValue := strings.TrimSpace(*subject)
+			if strings.ContainsAny(subjectValue, "\r\n") {
+				fmt.Fprintln(os.Stderr, "Error: --subject must be a single line")
+				return flag.ErrHelp
+			}
+			msg := strings.TrimSpace(*message)
+			if msg == "" {
+				fmt.Fprintln(os.Stderr, "Error: --message is required")
+				return flag.ErrHelp
+			}
+
+			notifier := &emailNotifier{
+				host:     hostValue,
+				port:     portValue,
+				username: resolveURL(*username, smtpUsernameEnvVar),
+				password: os.Getenv(smtpPasswordEnvVar),
+				from:     sender,
+				to:       recipients,
+			}
+			if err := notifier.Send(ctx, Message{Title: subjectValue, Text: msg}); err != nil {
+				return fmt.Errorf("notify email: %w", err)
+			}
+
+			fmt.Fprintln(os.Stderr, "Message sent by email successfully")
+			return nil
+		},
+	}
+}
+
+func resolveSMTPPort(flagValue int) (int, error) {
+	port := flagValue
+	if port == 0 {
+		if env := strings.TrimSpace(os.Getenv(smtpPortEnvVar)); env != "" {
+			parsed, err := strconv.Atoi(env)
+			if err != nil {
+				return 0, fmt.Errorf("%s must be a port number", smtpPortEnvVar)
+			}
+			port = parsed
+		}
+	}
+	if port == 0 {
+		port = smtpDefaultPort
+	}
+	if port < 1 || port > 65535 {
+		return 0, fmt.Errorf("--smtp-port must be between 1 and 65535")
+	}
+	return port, nil
+}
+
+type emailNotifier struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     *mail.Address
+	to       []*mail.Address
+}
+
+// smtpEnvelope is a message ready for delivery.
+type smtpEnvelope struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+	Data     []byte
+}
+
+// Send delivers message as a plain-text email. The title becomes the subject.
+func (n *emailNotifier) Send(ctx context.Context, message Message) error {
+	subject := strings.TrimSpace(message.Title)
+	if subject == "" {
+		subject = "App Store Connect notification"
+	}
+	if strings.ContainsAny(subject, "\r\n") {
+		return fmt.Errorf("subject must be a single line")
+	}
+
+	to := make([]string, 0, len(n.to))
+	headerTo := make([]string, 0, len(n.to))
+	for _, address := range n.to {
+		to = append(to, address.Address)
+		headerTo = append(headerTo, address.String())
+	}
+
+	var data bytes.Buffer
+	fmt.Fprintf(&data, "From: %s\r\n", n.from.String())
+	fmt.Fprintf(&data, "To: %s\r\n", strings.Join(headerTo, ", "))
+	fmt.Fprintf(&data, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&data, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	data.WriteString("MIME-Version: 1.0\r\n")
+	data.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	data.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	data.WriteString(strings.ReplaceAll(strings.ReplaceAll(message.Text, "\r\n", "\n"), "\n", "\r\n"))
+	data.WriteString("\r\n")
+
+	return deliverMail(ctx, smtpEnvelope{
+		Host:     n.host,
+		Port:     n.port,
+		Username: n.username,
+		Password: n.password,
+		From:     n.from.Address,
+		To:       to,
+		Data:     data.Bytes(),
+	})
+}
+
+func sendSMTP(ctx context.Context, envelope smtpEnvelope) error {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	address := net.JoinHostPort(envelope.Host, strconv.Itoa(envelope.Port))
+	tlsConfig := &tls.Config{ServerName: envelope.Host, MinVersion: tls.VersionTLS12}
+
+	var conn net.Conn
+	var err error
+	if envelope.Port == smtpImplicitTLSPort {
+		conn, err = (&tls.Dialer{Config: tlsConfig}).DialContext(requestCtx, "tcp", address)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(requestCtx, "tcp", address)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	if deadline, ok := requestCtx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, envelope.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer client.Close()
+
+	if envelope.Port != smtpImplicitTLSPort {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return fmt.Errorf("failed to start TLS: %w", err)
+			}
+		}
+	}
+	if envelope.Username != "" {
+		// smtp.PlainAuth refuses to send credentials without TLS except to
+		// localhost.
+		if err := client.Auth(smtp.PlainAuth("", envelope.Username, envelope.Password, envelope.Host)); err != nil {
+			return fmt.Errorf("authentication failed: %w", err)
+		}
+	}
+	if err := client.Mail(envelope.From); err != nil {
+		return fmt.Errorf("sender rejected: %w", err)
+	}
+	for _, recipient := range envelope.To {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("recipient %s rejected: %w", recipient, err)
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to send: %w", err)
+	}
+	if _, err := writer.Write(envelope.Data); err != nil {
+		writer.Close()
+		return fmt.Errorf("failed to send: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to send: %w", err)
+	}
+	return client.Quit()
+}
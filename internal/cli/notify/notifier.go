@@ -0,0 +1,228 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	notifyAllowLocalEnv        = "ASC_NOTIFY_ALLOW_LOCALHOST"
+	notifyMaxResponseBodyBytes = 4096
+	redactedWebhookURL         = "[webhook URL redacted]"
+)
+
+// Provider names accepted by NewURLNotifier.
+const (
+	ProviderSlack   = "slack"
+	ProviderTeams   = "teams"
+	ProviderDiscord = "discord"
+	ProviderWebhook = "webhook"
+)
+
+// URLProviders lists the providers that only need a webhook URL.
+var URLProviders = []string{ProviderSlack, ProviderTeams, ProviderDiscord, ProviderWebhook}
+
+// Message is a provider-neutral notification.
+type Message struct {
+	Title string
+	Text  string
+}
+
+// Notifier delivers messages to one destination.
+type Notifier interface {
+	Send(ctx context.Context, message Message) error
+}
+
+var notifyHTTPClient = func() *http.Client {
+	return &http.Client{Timeout: asc.ResolveTimeout()}
+}
+
+// NewURLNotifier returns the notifier for a provider that only needs a
+// webhook URL. An empty rawURL falls back to the provider's environment
+// variable.
+func NewURLNotifier(provider, rawURL string) (Notifier, error) {
+	var rule webhookRule
+	var newNotifier func(webhookURL string) Notifier
+	switch strings.ToLower(strings.TrimSpace(provider)) {
+	case ProviderSlack:
+		rule = slackWebhookRule
+		newNotifier = func(webhookURL string) Notifier { return &slackNotifier{webhookURL: webhookURL} }
+	case ProviderTeams:
+		rule = teamsWebhookRule
+		newNotifier = func(webhookURL string) Notifier { return &teamsNotifier{webhookURL: webhookURL} }
+	case ProviderDiscord:
+		rule = discordWebhookRule
+		newNotifier = func(webhookURL string) Notifier { return &discordNotifier{webhookURL: webhookURL} }
+	case ProviderWebhook:
+		rule = genericWebhookRule
+		newNotifier = func(webhookURL string) Notifier { return &webhookNotifier{webhookURL: webhookURL} }
+	default:
+		return nil, fmt.Errorf("unsupported provider %q (allowed: %s)", provider, strings.Join(URLProviders, ", "))
+	}
+
+	webhookURL := resolveURL(rawURL, rule.envVar)
+	if webhookURL == "" {
+		return nil, fmt.Errorf("a %s webhook URL is required (or set %s)", rule.name, rule.envVar)
+	}
+	if err := validateWebhookURL("webhook URL", webhookURL, rule); err != nil {
+		return nil, err
+	}
+	return newNotifier(webhookURL), nil
+}
+
+// webhookRule describes which URLs a provider accepts.
+type webhookRule struct {
+	// name is used in error messages, e.g. "Slack".
+	name string
+	// envVar holds the webhook URL when no flag is given.
+	envVar string
+	// example is shown when the URL cannot be parsed.
+	example string
+	// hosts lists accepted hosts, comma-separated; entries starting with
+	// "." match any subdomain. Empty accepts any host.
+	hosts string
+	// pathPrefix is required at the start of the URL path when set.
+	pathPrefix string
+	// allowLocalEnv names an extra environment variable that permits
+	// localhost URLs, in addition to ASC_NOTIFY_ALLOW_LOCALHOST.
+	allowLocalEnv string
+}
+
+// validateWebhookURL checks rawURL against rule. Webhook URLs carry their
+// credentials, so only https to the provider's own hosts is accepted; local
+// http endpoints are allowed for testing when ASC_NOTIFY_ALLOW_LOCALHOST is
+// set.
+func validateWebhookURL(label, rawURL string, rule webhookRule) error {
+	rawURL = strings.TrimSpace(rawURL)
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" || parsed.User != nil {
+		return fmt.Errorf("%s must be a valid %s webhook URL (%s)", label, rule.name, rule.example)
+	}
+	host := strings.ToLower(parsed.Hostname())
+	if allowLocalWebhook(rule.allowLocalEnv) && isLocalhost(host) {
+		if parsed.Scheme != "http" && parsed.Scheme != "https" {
+			return fmt.Errorf("%s must use http or https", label)
+		}
+		return nil
+	}
+	if parsed.Scheme != "https" {
+		return fmt.Errorf("%s must use https", label)
+	}
+	if rule.hosts != "" {
+		if ip := net.ParseIP(host); ip != nil || !hostAllowed(host, rule.hosts) {
+			return fmt.Errorf("%s must target %s", label, strings.ReplaceAll(rule.hosts, ",", ", "))
+		}
+	}
+	if rule.pathPrefix != "" && !strings.HasPrefix(parsed.Path, rule.pathPrefix) {
+		return fmt.Errorf("%s must start with %s", label, rule.pathPrefix)
+	}
+	return nil
+}
+
+func hostAllowed(host, hosts string) bool {
+	for allowed := range strings.SplitSeq(hosts, ",") {
+		if strings.HasPrefix(allowed, ".") {
+			if strings.HasSuffix(host, allowed) {
+				return true
+			}
+			continue
+		}
+		if host == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func allowLocalWebhook(extraEnv string) bool {
+	for _, name := range []string{notifyAllowLocalEnv, extraEnv} {
+		if name == "" {
+			continue
+		}
+		value := strings.TrimSpace(os.Getenv(name))
+		if value == "1" || strings.EqualFold(value, "true") {
+			return true
+		}
+	}
+	return false
+}
+
+func resolveURL(flagValue, envVar string) string {
+	if v := strings.TrimSpace(flagValue); v != "" {
+		return v
+	}
+	return strings.TrimSpace(os.Getenv(envVar))
+}
+
+// postWebhook sends body to webhookURL and treats any 2xx status as success.
+// Error responses are read up to notifyMaxResponseBodyBytes, and the webhook
+// URL, which usually embeds a secret token, is never included in errors.
+func postWebhook(ctx context.Context, webhookURL string, body []byte, header http.Header) error {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(requestCtx, "POST", webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", redactURLError(err))
+	}
+	for name, values := range header {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := notifyHTTPClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send: %w", redactURLError(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		limited := io.LimitReader(resp.Body, notifyMaxResponseBodyBytes)
+		respBody, readErr := io.ReadAll(limited)
+		if readErr != nil {
+			return fmt.Errorf("failed to read response: %w", redactURLError(readErr))
+		}
+		message := strings.TrimSpace(strings.ReplaceAll(string(respBody), webhookURL, redactedWebhookURL))
+		if message == "" {
+			return fmt.Errorf("unexpected response %d", resp.StatusCode)
+		}
+		return fmt.Errorf("unexpected response %d: %s", resp.StatusCode, message)
+	}
+	return nil
+}
+
+// redactURLError drops the request URL that net/http adds to transport
+// errors.
+func redactURLError(err error) error {
+	if urlErr, ok := errors.AsType[*url.Error](err); ok {
+		return fmt.Errorf("%s %s: %w", urlErr.Op, redactedWebhookURL, urlErr.Err)
+	}
+	return err
+}
+
+func isLocalhost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	return ip.IsLoopback()
+}
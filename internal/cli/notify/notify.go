@@ -1,34 +1,30 @@
 package notify
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
-	"io"
-	"net"
-	"net/http"
-	"net/url"
 	"os"
 	"strings"
 
 	"github.com/peterbourgon/ff/v3/ffcli"
 
-	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
 )
 
 const (
-	slackWebhookEnvVar               = "ASC_SLACK_WEBHOOK"
-	slackWebhookAllowLocalEnv        = "ASC_SLACK_WEBHOOK_ALLOW_LOCALHOST"
-	slackWebhookHost                 = "hooks.slack.com"
-	slackWebhookPathPrefix           = "/services/"
-	slackWebhookMaxResponseBodyBytes = 4096
+	slackWebhookEnvVar        = "ASC_SLACK_WEBHOOK"
+	slackWebhookAllowLocalEnv = "ASC_SLACK_WEBHOOK_ALLOW_LOCALHOST"
 )
 
-var slackHTTPClient = func() *http.Client {
-	return &http.Client{Timeout: asc.ResolveTimeout()}
+var slackWebhookRule = webhookRule{
+	name:          "Slack",
+	envVar:        slackWebhookEnvVar,
+	example:       "https://hooks.slack.com/...",
+	hosts:         "hooks.slack.com",
+	pathPrefix:    "/services/",
+	allowLocalEnv: slackWebhookAllowLocalEnv,
 }
 
 func slackFlags(fs *flag.FlagSet) (webhook *string, channel *string, message *string, blocksJSON *string, blocksFile *string) {
@@ -49,13 +45,24 @@ func NotifyCommand() *ffcli.Command {
 		ShortHelp:  "Send notifications to external services.",
 		LongHelp: `Send notifications to external services.
 
+Every provider limits how much of an error response is read and never
+includes the webhook URL or credentials in error output.
+
 Examples:
   asc notify slack --webhook $WEBHOOK --message "Build uploaded"
-  ASC_SLACK_WEBHOOK=$WEBHOOK asc notify slack --message "Done"`,
+  ASC_SLACK_WEBHOOK=$WEBHOOK asc notify slack --message "Done"
+  asc notify teams --webhook $TEAMS_WEBHOOK --title "Release" --message "v2.1 is live"
+  asc notify discord --webhook $DISCORD_WEBHOOK --message "Build 42 uploaded"
+  asc notify webhook --url https://ci.example.com/hooks/asc --message "Done"
+  asc notify email --to team@example.com --subject "Release" --message "v2.1 is live"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
 			SlackCommand(),
+			TeamsCommand(),
+			DiscordCommand(),
+			WebhookCommand(),
+			EmailCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			return flag.ErrHelp
@@ -92,7 +99,7 @@ Examples:
 				fmt.Fprintf(os.Stderr, "Error: --webhook is required or set %s env var\n", slackWebhookEnvVar)
 				return flag.ErrHelp
 			}
-			if err := validateSlackWebhookURL(webhookURL); err != nil {
+			if err := validateWebhookURL("--webhook", webhookURL, slackWebhookRule); err != nil {
 				fmt.Fprintln(os.Stderr, "Error:", err.Error())
 				return flag.ErrHelp
 			}
@@ -109,48 +116,9 @@ Examples:
 				return flag.ErrHelp
 			}
 
-			payload := map[string]any{}
-			payload["text"] = msg
-
-			if ch := strings.TrimSpace(*channel); ch != "" {
-				payload["channel"] = ch
-			}
-			if blocks != nil {
-				payload["blocks"] = blocks
-			}
-
-			body, err := json.Marshal(payload)
-			if err != nil {
-				return fmt.Errorf("notify slack: failed to marshal payload: %w", err)
-			}
-
-			requestCtx, cancel := shared.ContextWithTimeout(ctx)
-			defer cancel()
-
-			req, err := http.NewRequestWithContext(requestCtx, "POST", webhookURL, bytes.NewReader(body))
-			if err != nil {
-				return fmt.Errorf("notify slack: failed to create request: %w", err)
-			}
-			req.Header.Set("Content-Type", "application/json")
-
-			client := slackHTTPClient()
-			resp, err := client.Do(req)
-			if err != nil {
-				return fmt.Errorf("notify slack: failed to send: %w", err)
-			}
-			defer resp.Body.Close()
-
-			if resp.StatusCode != http.StatusOK {
-				limited := io.LimitReader(resp.Body, slackWebhookMaxResponseBodyBytes)
-				respBody, readErr := io.ReadAll(limited)
-				if readErr != nil {
-					return fmt.Errorf("notify slack: failed to read response: %w", readErr)
-				}
-				message := strings.TrimSpace(string(respBody))
-				if message == "" {
-					return fmt.Errorf("notify slack: unexpected response %d", resp.StatusCode)
-				}
-				return fmt.Errorf("notify slack: unexpected response %d: %s", resp.StatusCode, message)
+			notifier := &slackNotifier{webhookURL: webhookURL, channel: strings.TrimSpace(*channel), blocks: blocks}
+			if err := notifier.Send(ctx, Message{Text: msg}); err != nil {
+				return fmt.Errorf("notify slack: %w", err)
 			}
 
 			fmt.Fprintln(os.Stderr, "Message sent to Slack successfully")
@@ -159,14 +127,34 @@ Examples:
 	}
 }
 
-func resolveWebhook(flagValue string) string {
-	if v := strings.TrimSpace(flagValue); v != "" {
-		return v
+type slackNotifier struct {
+	webhookURL string
+	channel    string
+	blocks     []json.RawMessage
+}
+
+type slackPayload struct {
+	Text    string            `json:"text"`
+	Channel string            `json:"channel,omitempty"`
+	Blocks  []json.RawMessage `json:"blocks,omitempty"`
+}
+
+// Send posts message to the Slack incoming webhook. A title is rendered as a
+// bold first line.
+func (n *slackNotifier) Send(ctx context.Context, message Message) error {
+	text := message.Text
+	if title := strings.TrimSpace(message.Title); title != "" {
+		text = "*" + title + "*\n" + text
 	}
-	if v := strings.TrimSpace(os.Getenv(slackWebhookEnvVar)); v != "" {
-		return v
+	body, err := json.Marshal(slackPayload{Text: text, Channel: n.channel, Blocks: n.blocks})
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
 	}
-	return ""
+	return postWebhook(ctx, n.webhookURL, body, nil)
+}
+
+func resolveWebhook(flagValue string) string {
+	return resolveURL(flagValue, slackWebhookEnvVar)
 }
 
 func parseSlackBlocks(blocksJSON string, blocksFile string) ([]json.RawMessage, error) {
@@ -200,47 +188,3 @@ func parseSlackBlocks(blocksJSON string, blocksFile string) ([]json.RawMessage,
 
 	return blocks, nil
 }
-
-func validateSlackWebhookURL(rawURL string) error {
-	rawURL = strings.TrimSpace(rawURL)
-	parsed, err := url.Parse(rawURL)
-	if err != nil || parsed.Scheme == "" || parsed.Host == "" || parsed.User != nil {
-		return fmt.Errorf("--webhook must be a valid Slack webhook URL (https://hooks.slack.com/...)")
-	}
-	host := strings.ToLower(parsed.Hostname())
-	if allowLocalSlackWebhook() && isLocalhost(host) {
-		if parsed.Scheme != "http" && parsed.Scheme != "https" {
-			return fmt.Errorf("--webhook must use http or https")
-		}
-		return nil
-	}
-	if parsed.Scheme != "https" {
-		return fmt.Errorf("--webhook must use https")
-	}
-	if ip := net.ParseIP(host); ip != nil {
-		return fmt.Errorf("--webhook must target hooks.slack.com")
-	}
-	if host != slackWebhookHost {
-		return fmt.Errorf("--webhook must target hooks.slack.com")
-	}
-	if !strings.HasPrefix(parsed.Path, slackWebhookPathPrefix) {
-		return fmt.Errorf("--webhook must start with %s", slackWebhookPathPrefix)
-	}
-	return nil
-}
-
-func allowLocalSlackWebhook() bool {
-	value := strings.TrimSpace(os.Getenv(slackWebhookAllowLocalEnv))
-	return value == "1" || strings.EqualFold(value, "true")
-}
-
-func isLocalhost(host string) bool {
-	if host == "localhost" {
-		return true
-	}
-	ip := net.ParseIP(host)
-	if ip == nil {
-		return false
-	}
-	return ip.IsLoopback()
-}
@@ -0,0 +1,350 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+func runNotifyCommand(t *testing.T, cmd *ffcli.Command, args []string) error {
+	t.Helper()
+	if err := cmd.Parse(args); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	return cmd.Run(context.Background())
+}
+
+func newRecordingServer(t *testing.T, status int, received *[]byte, header *http.Header) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*received = body
+		if header != nil {
+			*header = r.Header.Clone()
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv(notifyAllowLocalEnv, "1")
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	return server
+}
+
+func TestNotifyTeamsSendsAdaptiveCard(t *testing.T) {
+	var body []byte
+	server := newRecordingServer(t, http.StatusAccepted, &body, nil)
+	t.Setenv(teamsWebhookEnvVar, server.URL)
+
+	cmd := TeamsCommand()
+	cmd.FlagSet.SetOutput(io.Discard)
+	if err := runNotifyCommand(t, cmd, []string{"--title", "Release", "--message", "v2.1 is live"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var payload teamsPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("unmarshal payload: %v", err)
+	}
+	if len(payload.Attachments) != 1 || payload.Attachments[0].ContentType != "application/vnd.microsoft.card.adaptive" {
+		t.Fatalf("unexpected attachments: %s", body)
+	}
+	card := payload.Attachments[0].Content
+	if card.Type != "AdaptiveCard" || len(card.Body) != 2 {
+		t.Fatalf("unexpected card: %s", body)
+	}
+	if card.Body[0].Text != "Release" || card.Body[0].Weight != "Bolder" || card.Body[1].Text != "v2.1 is live" {
+		t.Fatalf("unexpected card body: %s", body)
+	}
+}
+
+func TestNotifyDiscordSendsContent(t *testing.T) {
+	var body []byte
+	server := newRecordingServer(t, http.StatusNoContent, &body, nil)
+	t.Setenv(discordWebhookEnvVar, server.URL)
+
+	cmd := DiscordCommand()
+	cmd.FlagSet.SetOutput(io.Discard)
+	if err := runNotifyCommand(t, cmd, []string{"--title", "Build 42", "--message", "Uploaded", "--username", "asc"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var payload discordPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("unmarshal payload: %v", err)
+	}
+	if payload.Content != "**Build 42**\nUploaded" || payload.Username != "asc" {
+		t.Fatalf("unexpected payload: %s", body)
+	}
+}
+
+func TestDiscordNotifierTruncatesLongContent(t *testing.T) {
+	var body []byte
+	server := newRecordingServer(t, http.StatusNoContent, &body, nil)
+
+	notifier := &discordNotifier{webhookURL: server.URL}
+	if err := notifier.Send(context.Background(), Message{Text: strings.Repeat("é", discordMaxContentLength+10)}); err != nil {
+		t.Fatalf("Send() error: %v", err)
+	}
+	var payload discordPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("unmarshal payload: %v", err)
+	}
+	if got := len([]rune(payload.Content)); got != discordMaxContentLength {
+		t.Fatalf("expected %d characters, got %d", discordMaxContentLength, got)
+	}
+}
+
+func TestNotifyWebhookRendersTemplateAndHeaders(t *testing.T) {
+	var body []byte
+	var header http.Header
+	server := newRecordingServer(t, http.StatusOK, &body, &header)
+
+	cmd := WebhookCommand()
+	cmd.FlagSet.SetOutput(io.Discard)
+	err := runNotifyCommand(t, cmd, []string{
+		"--url", server.URL,
+		"--title", "Release",
+		"--message", `Say "hi"`,
+		"--header", "Authorization: Bearer token",
+		"--body-template", `{"event":"release","summary":{{json .Title}},"text":{{json .Message}}}`,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(body) != `{"event":"release","summary":"Release","text":"Say \"hi\""}` {
+		t.Fatalf("unexpected body: %s", body)
+	}
+	if header.Get("Authorization") != "Bearer token" || header.Get("Content-Type") != "application/json" {
+		t.Fatalf("unexpected headers: %v", header)
+	}
+}
+
+func TestWebhookNotifierDefaultBody(t *testing.T) {
+	var body []byte
+	server := newRecordingServer(t, http.StatusOK, &body, nil)
+
+	notifier, err := NewURLNotifier(ProviderWebhook, server.URL)
+	if err != nil {
+		t.Fatalf("NewURLNotifier() error: %v", err)
+	}
+	if err := notifier.Send(context.Background(), Message{Title: "T", Text: "M"}); err != nil {
+		t.Fatalf("Send() error: %v", err)
+	}
+	if string(body) != `{"title":"T","message":"M"}` {
+		t.Fatalf("unexpected body: %s", body)
+	}
+}
+
+func TestWebhookNotifierRejectsInvalidJSONTemplate(t *testing.T) {
+	tmpl, err := parseBodyTemplate(`{"text": {{.Message}}}`, "")
+	if err != nil {
+		t.Fatalf("parseBodyTemplate() error: %v", err)
+	}
+	notifier := &webhookNotifier{webhookURL: "https://example.com/hook", template: tmpl}
+	if err := notifier.Send(context.Background(), Message{Text: "not quoted"}); err == nil || !strings.Contains(err.Error(), "valid JSON") {
+		t.Fatalf("expected invalid JSON error, got %v", err)
+	}
+
+	notifier.header = http.Header{"Content-Type": []string{"text/plain"}}
+	body, err := notifier.render(Message{Text: "plain"})
+	if err != nil || string(body) != `{"text": plain}` {
+		t.Fatalf("expected non-JSON content type to skip validation, got %q, %v", body, err)
+	}
+}
+
+func TestNotifyProvidersRejectWrongHosts(t *testing.T) {
+	t.Setenv(notifyAllowLocalEnv, "")
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	tests := []struct {
+		name    string
+		build   func() *ffcli.Command
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "teams other host",
+			build:   func() *ffcli.Command { return TeamsCommand() },
+			args:    []string{"--webhook", "https://example.com/hook", "--message", "hi"},
+			wantErr: "--webhook must target .webhook.office.com",
+		},
+		{
+			name:    "teams lookalike host",
+			build:   func() *ffcli.Command { return TeamsCommand() },
+			args:    []string{"--webhook", "https://evilwebhook.office.com/hook", "--message", "hi"},
+			wantErr: "--webhook must target",
+		},
+		{
+			name:    "discord wrong path",
+			build:   func() *ffcli.Command { return DiscordCommand() },
+			args:    []string{"--webhook", "https://discord.com/channels/1", "--message", "hi"},
+			wantErr: "--webhook must start with /api/webhooks/",
+		},
+		{
+			name:    "discord http",
+			build:   func() *ffcli.Command { return DiscordCommand() },
+			args:    []string{"--webhook", "http://discord.com/api/webhooks/1/x", "--message", "hi"},
+			wantErr: "--webhook must use https",
+		},
+		{
+			name:    "webhook localhost without opt-in",
+			build:   func() *ffcli.Command { return WebhookCommand() },
+			args:    []string{"--url", "http://127.0.0.1:8080/hook", "--message", "hi"},
+			wantErr: "--url must use https",
+		},
+		{
+			name:    "webhook both templates",
+			build:   func() *ffcli.Command { return WebhookCommand() },
+			args:    []string{"--url", "https://example.com/hook", "--message", "hi", "--body-template", "{}", "--body-template-file", "x"},
+			wantErr: "only one of --body-template or --body-template-file may be set",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := test.build()
+			cmd.FlagSet.SetOutput(io.Discard)
+			stderr := captureOutput(t, func() {
+				if err := runNotifyCommand(t, cmd, test.args); !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected flag.ErrHelp, got %v", err)
+				}
+			})
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected error %q, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
+
+func TestPostWebhookRedactsURL(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("bad hook " + server.URL + "/secret-token " + strings.Repeat("x", 2*notifyMaxResponseBodyBytes)))
+	}))
+	defer server.Close()
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	webhookURL := server.URL + "/secret-token"
+	err := postWebhook(context.Background(), webhookURL, []byte(`{}`), nil)
+	if err == nil || !strings.Contains(err.Error(), "unexpected response 400") {
+		t.Fatalf("expected status error, got %v", err)
+	}
+	if strings.Contains(err.Error(), "secret-token") {
+		t.Fatalf("expected webhook URL to be redacted, got %v", err)
+	}
+	if len(err.Error()) > notifyMaxResponseBodyBytes+100 {
+		t.Fatalf("expected response body to be limited, got %d bytes", len(err.Error()))
+	}
+
+	server.Close()
+	err = postWebhook(context.Background(), webhookURL, []byte(`{}`), nil)
+	if err == nil || strings.Contains(err.Error(), "secret-token") {
+		t.Fatalf("expected redacted transport error, got %v", err)
+	}
+}
+
+func TestNewURLNotifier(t *testing.T) {
+	t.Setenv(slackWebhookEnvVar, "")
+	t.Setenv(discordWebhookEnvVar, "https://discord.com/api/webhooks/1/token")
+
+	if _, err := NewURLNotifier("pager", "https://example.com"); err == nil || !strings.Contains(err.Error(), "unsupported provider") {
+		t.Fatalf("expected unsupported provider error, got %v", err)
+	}
+	if _, err := NewURLNotifier(ProviderSlack, ""); err == nil || !strings.Contains(err.Error(), slackWebhookEnvVar) {
+		t.Fatalf("expected missing URL error, got %v", err)
+	}
+	if _, err := NewURLNotifier(ProviderSlack, "https://example.com/services/x"); err == nil || !strings.Contains(err.Error(), "webhook URL must target hooks.slack.com") {
+		t.Fatalf("expected host error, got %v", err)
+	}
+	notifier, err := NewURLNotifier(ProviderDiscord, "")
+	if err != nil {
+		t.Fatalf("NewURLNotifier() error: %v", err)
+	}
+	if _, ok := notifier.(*discordNotifier); !ok {
+		t.Fatalf("expected discord notifier, got %T", notifier)
+	}
+}
+
+func TestNotifyEmailBuildsMessage(t *testing.T) {
+	t.Setenv(smtpHostEnvVar, "smtp.example.com")
+	t.Setenv(smtpPortEnvVar, "465")
+	t.Setenv(smtpUsernameEnvVar, "ci")
+	t.Setenv(smtpPasswordEnvVar, "hunter2")
+	t.Setenv(smtpFromEnvVar, "")
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	var sent smtpEnvelope
+	original := deliverMail
+	deliverMail = func(ctx context.Context, envelope smtpEnvelope) error {
+		sent = envelope
+		return nil
+	}
+	t.Cleanup(func() { deliverMail = original })
+
+	cmd := EmailCommand()
+	cmd.FlagSet.SetOutput(io.Discard)
+	err := runNotifyCommand(t, cmd, []string{
+		"--from", "CI <ci@example.com>",
+		"--to", "a@example.com, Team <b@example.com>",
+		"--subject", "Release v2.1",
+		"--message", "Line one\nLine two",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if sent.Host != "smtp.example.com" || sent.Port != 465 || sent.Username != "ci" || sent.Password != "hunter2" {
+		t.Fatalf("unexpected connection settings: %+v", sent)
+	}
+	if sent.From != "ci@example.com" || strings.Join(sent.To, ",") != "a@example.com,b@example.com" {
+		t.Fatalf("unexpected envelope: from %q to %v", sent.From, sent.To)
+	}
+	data := string(sent.Data)
+	for _, want := range []string{"Subject: Release v2.1\r\n", "To: <a@example.com>, \"Team\" <b@example.com>\r\n", "\r\n\r\nLine one\r\nLine two\r\n"} {
+		if !strings.Contains(data, want) {
+			t.Fatalf("expected message to contain %q, got %q", want, data)
+		}
+	}
+}
+
+func TestNotifyEmailValidationErrors(t *testing.T) {
+	t.Setenv(smtpHostEnvVar, "smtp.example.com")
+	t.Setenv(smtpPortEnvVar, "")
+	t.Setenv(smtpFromEnvVar, "ci@example.com")
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "missing to", args: []string{"--message", "hi"}, wantErr: "--to is required"},
+		{name: "invalid to", args: []string{"--to", "not-an-address", "--message", "hi"}, wantErr: "--to must be a comma-separated list"},
+		{name: "header injection", args: []string{"--to", "a@example.com", "--subject", "hi\r\nBcc: x@example.com", "--message", "hi"}, wantErr: "--subject must be a single line"},
+		{name: "invalid port", args: []string{"--to", "a@example.com", "--smtp-port", "70000", "--message", "hi"}, wantErr: "--smtp-port must be between 1 and 65535"},
+		{name: "missing message", args: []string{"--to", "a@example.com"}, wantErr: "--message is required"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := EmailCommand()
+			cmd.FlagSet.SetOutput(io.Discard)
+			stderr := captureOutput(t, func() {
+				if err := runNotifyCommand(t, cmd, test.args); !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected flag.ErrHelp, got %v", err)
+				}
+			})
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected error %q, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
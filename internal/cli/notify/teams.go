@@ -0,0 +1,133 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const teamsWebhookEnvVar = "ASC_TEAMS_WEBHOOK"
+
+// teamsWebhookRule accepts Teams workflow (Power Automate) webhooks and the
+// legacy Office 365 connector URLs.
+var teamsWebhookRule = webhookRule{
+	name:    "Microsoft Teams",
+	envVar:  teamsWebhookEnvVar,
+	example: "https://<tenant>.webhook.office.com/...",
+	hosts:   ".webhook.office.com,.logic.azure.com,.powerplatform.com,outlook.office.com",
+}
+
+func TeamsCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("notify teams", flag.ExitOnError)
+
+	webhook := fs.String("webhook", "", "Microsoft Teams webhook URL (or set "+teamsWebhookEnvVar+" env var)")
+	title := fs.String("title", "", "Card title")
+	message := fs.String("message", "", "Message to send to Microsoft Teams")
+
+	return &ffcli.Command{
+		Name:       "teams",
+		ShortUsage: "asc notify teams --webhook URL --message TEXT [--title TEXT]",
+		ShortHelp:  "Send an Adaptive Card to Microsoft Teams via webhook.",
+		LongHelp: `Send an Adaptive Card to Microsoft Teams via webhook.
+
+Posts a message card to a Teams channel through a Workflows (Power Automate)
+webhook or a legacy incoming webhook connector. The card shows --title in bold
+above --message.
+
+The webhook URL can be provided via --webhook flag or ASC_TEAMS_WEBHOOK env var.
+
+Examples:
+  asc notify teams --webhook "https://contoso.webhook.office.com/..." --message "Build uploaded"
+  ASC_TEAMS_WEBHOOK=$WEBHOOK asc notify teams --title "Release v2.1" --message "Submitted for review"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			webhookURL := resolveURL(*webhook, teamsWebhookEnvVar)
+			if webhookURL == "" {
+				fmt.Fprintf(os.Stderr, "Error: --webhook is required or set %s env var\n", teamsWebhookEnvVar)
+				return flag.ErrHelp
+			}
+			if err := validateWebhookURL("--webhook", webhookURL, teamsWebhookRule); err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", err.Error())
+				return flag.ErrHelp
+			}
+
+			msg := strings.TrimSpace(*message)
+			if msg == "" {
+				fmt.Fprintln(os.Stderr, "Error: --message is required")
+				return flag.ErrHelp
+			}
+
+			notifier := &teamsNotifier{webhookURL: webhookURL}
+			if err := notifier.Send(ctx, Message{Title: strings.TrimSpace(*title), Text: msg}); err != nil {
+				return fmt.Errorf("notify teams: %w", err)
+			}
+
+			fmt.Fprintln(os.Stderr, "Message sent to Microsoft Teams successfully")
+			return nil
+		},
+	}
+}
+
+type teamsNotifier struct {
+	webhookURL string
+}
+
+type teamsPayload struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	Content     adaptiveCard `json:"content"`
+}
+
+type adaptiveCard struct {
+	Schema  string              `json:"$schema"`
+	Type    string              `json:"type"`
+	Version string              `json:"version"`
+	Body    []adaptiveTextBlock `json:"body"`
+}
+
+type adaptiveTextBlock struct {
+	Type   string `json:"type"`
+	Text   string `json:"text"`
+	Weight string `json:"weight,omitempty"`
+	Size   string `json:"size,omitempty"`
+	Wrap   bool   `json:"wrap"`
+}
+
+// Send posts message as an Adaptive Card. Workflows webhooks answer 202, so
+// any 2xx status counts as delivered.
+func (n *teamsNotifier) Send(ctx context.Context, message Message) error {
+	var body []adaptiveTextBlock
+	if title := strings.TrimSpace(message.Title); title != "" {
+		body = append(body, adaptiveTextBlock{Type: "TextBlock", Text: title, Weight: "Bolder", Size: "Medium", Wrap: true})
+	}
+	body = append(body, adaptiveTextBlock{Type: "TextBlock", Text: message.Text, Wrap: true})
+
+	payload, err := json.Marshal(teamsPayload{
+		Type: "message",
+		Attachments: []teamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content: adaptiveCard{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: "1.4",
+				Body:    body,
+			},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	return postWebhook(ctx, n.webhookURL, payload, nil)
+}
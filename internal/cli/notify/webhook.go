@@ -0,0 +1,218 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"maps"
+	"mime"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const genericWebhookEnvVar = "ASC_NOTIFY_WEBHOOK_URL"
+
+// genericWebhookRule accepts any https endpoint.
+var genericWebhookRule = webhookRule{
+	name:    "HTTPS",
+	envVar:  genericWebhookEnvVar,
+	example: "https://example.com/hooks/asc",
+}
+
+func WebhookCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("notify webhook", flag.ExitOnError)
+
+	webhook := fs.String("url", "", "Webhook URL (or set "+genericWebhookEnvVar+" env var)")
+	title := fs.String("title", "", "Title available to the body template")
+	message := fs.String("message", "", "Message to send")
+	bodyTemplate := fs.String("body-template", "", "Go text/template for the request body")
+	bodyTemplateFile := fs.String("body-template-file", "", "Path to a Go text/template file for the request body")
+	var headers headerFlag
+	fs.Var(&headers, "header", "Request header as 'Name: value' (repeatable)")
+
+	return &ffcli.Command{
+		Name:       "webhook",
+		ShortUsage: "asc notify webhook --url URL --message TEXT [--body-template TEMPLATE] [--header 'Name: value']",
+		ShortHelp:  "POST a JSON message to any webhook.",
+		LongHelp: `POST a JSON message to any webhook.
+
+Without a template the request body is {"title": ..., "message": ...}.
+--body-template and --body-template-file take a Go text/template rendered with
+.Title and .Message; use the json function to embed values as JSON strings.
+The rendered body must be valid JSON unless --header sets a non-JSON
+Content-Type.
+
+The URL can be provided via --url flag or ASC_NOTIFY_WEBHOOK_URL env var and
+must use https. Header values are sent as-is and never printed.
+
+Examples:
+  asc notify webhook --url "https://ci.example.com/hooks/asc" --message "Build uploaded"
+  asc notify webhook --url "$URL" --message "Done" --header "Authorization: Bearer $TOKEN"
+  asc notify webhook --url "$URL" --title "Release" --message "v2.1 live" --body-template '{"event":"release","text":{{json .Message}}}'
+  asc notify webhook --url "$URL" --message "Done" --body-template-file ./payload.tmpl`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			webhookURL := resolveURL(*webhook, genericWebhookEnvVar)
+			if webhookURL == "" {
+				fmt.Fprintf(os.Stderr, "Error: --url is required or set %s env var\n", genericWebhookEnvVar)
+				return flag.ErrHelp
+			}
+			if err := validateWebhookURL("--url", webhookURL, genericWebhookRule); err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", err.Error())
+				return flag.ErrHelp
+			}
+
+			msg := strings.TrimSpace(*message)
+			if msg == "" {
+				fmt.Fprintln(os.Stderr, "Error: --message is required")
+				return flag.ErrHelp
+			}
+
+			tmpl, err := parseBodyTemplate(*bodyTemplate, *bodyTemplateFile)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", err.Error())
+				return flag.ErrHelp
+			}
+
+			notifier := &webhookNotifier{webhookURL: webhookURL, template: tmpl, header: headers.header}
+			if err := notifier.Send(ctx, Message{Title: strings.TrimSpace(*title), Text: msg}); err != nil {
+				return fmt.Errorf("notify webhook: %w", err)
+			}
+
+			fmt.Fprintln(os.Stderr, "Message sent to webhook successfully")
+			return nil
+		},
+	}
+}
+
+type webhookNotifier struct {
+	webhookURL string
+	template   *template.Template
+	header     http.Header
+}
+
+// webhookTemplateData is the data passed to --body-template.
+type webhookTemplateData struct {
+	Title   string
+	Message string
+}
+
+// Send renders the request body and posts it to the webhook.
+func (n *webhookNotifier) Send(ctx context.Context, message Message) error {
+	body, err := n.render(message)
+	if err != nil {
+		return err
+	}
+	return postWebhook(ctx, n.webhookURL, body, n.header)
+}
+
+func (n *webhookNotifier) render(message Message) ([]byte, error) {
+	data := webhookTemplateData{Title: message.Title, Message: message.Text}
+	if n.template == nil {
+		body, err := json.Marshal(struct {
+			Title   string `json:"title,omitempty"`
+			Message string `json:"message"`
+		}{Title: data.Title, Message: data.Message})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal payload: %w", err)
+		}
+		return body, nil
+	}
+
+	var buf bytes.Buffer
+	if err := n.template.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render body template: %w", err)
+	}
+	if n.sendsJSON() && !json.Valid(buf.Bytes()) {
+		return nil, fmt.Errorf("body template did not render valid JSON")
+	}
+	return buf.Bytes(), nil
+}
+
+func (n *webhookNotifier) sendsJSON() bool {
+	contentType := n.header.Get("Content-Type")
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func parseBodyTemplate(text, path string) (*template.Template, error) {
+	text = strings.TrimSpace(text)
+	path = strings.TrimSpace(path)
+
+	if text != "" && path != "" {
+		return nil, fmt.Errorf("only one of --body-template or --body-template-file may be set")
+	}
+	if text == "" && path == "" {
+		return nil, nil
+	}
+
+	source := "--body-template"
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("--body-template-file must be readable: %w", err)
+		}
+		text = string(data)
+		source = "--body-template-file"
+	}
+
+	tmpl, err := template.New("body").Option("missingkey=error").Funcs(template.FuncMap{
+		"json": templateJSON,
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid template: %w", source, err)
+	}
+	return tmpl, nil
+}
+
+func templateJSON(value any) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// headerFlag collects repeatable --header "Name: value" flags.
+type headerFlag struct {
+	header http.Header
+}
+
+func (h *headerFlag) Set(value string) error {
+	name, val, ok := strings.Cut(value, ":")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return fmt.Errorf("must be in the form 'Name: value'")
+	}
+	if strings.ContainsAny(name, " \t\r\n") || strings.ContainsAny(val, "\r\n") {
+		return fmt.Errorf("must not contain line breaks or spaces in the name")
+	}
+	if h.header == nil {
+		h.header = http.Header{}
+	}
+	h.header.Add(name, strings.TrimSpace(val))
+	return nil
+}
+
+func (h *headerFlag) String() string {
+	if h == nil || len(h.header) == 0 {
+		return ""
+	}
+	return strings.Join(slices.Sorted(maps.Keys(h.header)), ",")
+}
@@ -59,7 +59,11 @@ Examples:
 				selectionCount++
 			}
 			if selectionCount == 0 {
-				appFlag = shared.ResolveAppID(*appID)
+				var err error
+				appFlag, err = shared.ResolveAppID(ctx, *appID)
+				if err != nil {
+					return fmt.Errorf("performance download: %w", err)
+				}
 				if appFlag == "" {
 					fmt.Fprintln(os.Stderr, "Error: --app, --build, or --diagnostic-id is required")
 					return flag.ErrHelp
@@ -0,0 +1,113 @@
+package plugins
+
+import (
+	"context"
+	"flag"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// PluginsCommand returns the plugins command group.
+func PluginsCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("plugins", flag.ExitOnError)
+
+	return &ffcli.Command{
+		Name:       "plugins",
+		ShortUsage: "asc plugins <subcommand> [flags]",
+		ShortHelp:  "Manage external asc-<name> plugin commands.",
+		LongHelp: `Manage external asc-<name> plugin commands.
+
+Any executable named asc-<name> on PATH runs as "asc <name>", like git
+plugins. Arguments after the name are passed through unchanged, and the
+plugin's exit status becomes asc's. Built-in commands always take
+precedence over plugins with the same name.
+
+Plugins run with these environment variables:
+  ASC_BIN               Path of the asc executable
+  ASC_PROFILE           Resolved authentication profile (when set)
+  ASC_BASE_URL          App Store Connect API base URL
+  ASC_TOKEN             Short-lived API token (when credentials resolve)
+  ASC_TOKEN_EXPIRES_AT  Token expiry time (RFC 3339)
+  ASC_KEY_ID            Key ID the token was signed with
+  ASC_ISSUER_ID         Issuer ID the token was signed with
+
+The private key is never passed to plugins.
+
+Examples:
+  asc plugins list
+  asc --profile "ci" my-plugin --flag value`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			PluginsListCommand(),
+		},
+		Exec: func(ctx context.Context, args []string) error {
+			return flag.ErrHelp
+		},
+	}
+}
+
+// PluginsListCommand returns the plugins list subcommand.
+func PluginsListCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("plugins list", flag.ExitOnError)
+
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "list",
+		ShortUsage: "asc plugins list [flags]",
+		ShortHelp:  "List asc-<name> plugins found on PATH.",
+		LongHelp: `List asc-<name> plugins found on PATH.
+
+When several PATH directories provide the same plugin, only the first is
+listed; it is the one "asc <name>" runs.
+
+Examples:
+  asc plugins list
+  asc plugins list --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) > 0 {
+				return shared.UsageErrorf("unexpected arguments: %s", strings.Join(args, " "))
+			}
+
+			plugins := List()
+			if plugins == nil {
+				plugins = []Plugin{}
+			}
+			return shared.PrintOutputWithRenderers(
+				plugins,
+				*output.Output,
+				*output.Pretty,
+				func() error { return printTable(plugins) },
+				func() error { return printMarkdown(plugins) },
+			)
+		},
+	}
+}
+
+func printTable(plugins []Plugin) error {
+	headers, rows := pluginRows(plugins)
+	asc.RenderTable(headers, rows)
+	return nil
+}
+
+func printMarkdown(plugins []Plugin) error {
+	headers, rows := pluginRows(plugins)
+	asc.RenderMarkdown(headers, rows)
+	return nil
+}
+
+func pluginRows(plugins []Plugin) ([]string, [][]string) {
+	headers := []string{"Name", "Path"}
+	rows := make([][]string, 0, len(plugins))
+	for _, plugin := range plugins {
+		rows = append(rows, []string{plugin.Name, plugin.Path})
+	}
+	return headers, rows
+}
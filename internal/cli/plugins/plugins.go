@@ -0,0 +1,198 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// Prefix is the executable name prefix of plugins: "asc foo" runs asc-foo.
+const Prefix = "asc-"
+
+// Environment variables set for plugins.
+const (
+	// BinEnvVar is the path of the asc executable, for plugins that call
+	// back into asc.
+	BinEnvVar = "ASC_BIN"
+	// ProfileEnvVar is the resolved authentication profile.
+	ProfileEnvVar = "ASC_PROFILE"
+	// TokenEnvVar is a short-lived App Store Connect API token.
+	TokenEnvVar = "ASC_TOKEN"
+	// TokenExpiresAtEnvVar is the RFC 3339 expiry time of ASC_TOKEN.
+	TokenExpiresAtEnvVar = "ASC_TOKEN_EXPIRES_AT"
+	// KeyIDEnvVar and IssuerIDEnvVar identify the key ASC_TOKEN was signed
+	// with.
+	KeyIDEnvVar    = "ASC_KEY_ID"
+	IssuerIDEnvVar = "ASC_ISSUER_ID"
+	// BaseURLEnvVar is the App Store Connect API base URL.
+	BaseURLEnvVar = "ASC_BASE_URL"
+)
+
+// Plugin is an asc-<name> executable on PATH.
+type Plugin struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// ExitError reports that a plugin exited with a non-zero status. asc exits
+// with the same status.
+type ExitError struct {
+	Name string
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("plugin %s exited with status %d", e.Name, e.Code)
+}
+
+// issueToken is replaced in tests.
+var issueToken = shared.IssueShortLivedToken
+
+// validName reports whether name can be a plugin name. Flags and paths are
+// never looked up.
+func validName(name string) bool {
+	return name != "" &&
+		!strings.HasPrefix(name, "-") &&
+		!strings.ContainsAny(name, `/\`) &&
+		name != "." && name != ".."
+}
+
+// Find looks up the asc-<name> executable on PATH.
+func Find(name string) (Plugin, bool) {
+	if !validName(name) {
+		return Plugin{}, false
+	}
+	path, err := exec.LookPath(Prefix + name)
+	if err != nil {
+		return Plugin{}, false
+	}
+	return Plugin{Name: name, Path: path}, true
+}
+
+// List returns the plugins on PATH, sorted by name. When several
+// directories provide the same plugin, the first one on PATH wins, as it
+// does when the plugin is run.
+func List() []Plugin {
+	seen := map[string]bool{}
+	var plugins []Plugin
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			dir = "."
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := pluginName(entry.Name())
+			if !ok || seen[name] {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			seen[name] = true
+			plugins = append(plugins, Plugin{Name: name, Path: path})
+		}
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins
+}
+
+func pluginName(file string) (string, bool) {
+	name, ok := strings.CutPrefix(file, Prefix)
+	if !ok {
+		return "", false
+	}
+	if runtime.GOOS == "windows" {
+		ext := filepath.Ext(name)
+		if !isWindowsExecutableExt(ext) {
+			return "", false
+		}
+		name = strings.TrimSuffix(name, ext)
+	}
+	return name, validName(name)
+}
+
+func isWindowsExecutableExt(ext string) bool {
+	pathExt := os.Getenv("PATHEXT")
+	if pathExt == "" {
+		pathExt = ".com;.exe;.bat;.cmd"
+	}
+	for _, candidate := range filepath.SplitList(pathExt) {
+		if strings.EqualFold(candidate, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	return info.Mode()&0o111 != 0
+}
+
+// Run runs a plugin with args, connected to the terminal, and returns an
+// *ExitError when it exits with a non-zero status.
+func Run(ctx context.Context, plugin Plugin, args []string) error {
+	cmd := exec.CommandContext(ctx, plugin.Path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), Environment()...)
+
+	err := cmd.Run()
+	if exitErr, ok := errors.AsType[*exec.ExitError](err); ok {
+		return &ExitError{Name: plugin.Name, Code: exitErr.ExitCode()}
+	}
+	if err != nil {
+		return fmt.Errorf("plugin %s: %w", plugin.Name, err)
+	}
+	return nil
+}
+
+// Environment returns the variables set for plugins: the asc executable,
+// profile and API base URL, and a short-lived token signed with the resolved
+// credentials. The private key itself is never passed. Plugins that do not
+// call the API still run without credentials; the token is then omitted.
+func Environment() []string {
+	var env []string
+	if exe, err := os.Executable(); err == nil {
+		env = append(env, BinEnvVar+"="+exe)
+	}
+	if profile := shared.ResolveProfileName(); profile != "" {
+		env = append(env, ProfileEnvVar+"="+profile)
+	}
+	env = append(env, BaseURLEnvVar+"="+asc.ResolveBaseURL())
+
+	token, err := issueToken()
+	if err != nil {
+		if !errors.Is(err, shared.ErrMissingAuth) {
+			fmt.Fprintf(os.Stderr, "Warning: running plugin without credentials: %v\n", err)
+		}
+		return env
+	}
+	return append(env,
+		KeyIDEnvVar+"="+token.KeyID,
+		IssuerIDEnvVar+"="+token.IssuerID,
+		TokenEnvVar+"="+token.Token,
+		TokenExpiresAtEnvVar+"="+token.ExpiresAt.Format(time.RFC3339),
+	)
+}
@@ -0,0 +1,94 @@
+package plugins
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+func writeFile(t *testing.T, dir, name string, mode os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"), mode); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestListUsesFirstPluginOnPath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("executable bits are not used on Windows")
+	}
+	first, second := t.TempDir(), t.TempDir()
+	hello := writeFile(t, first, "asc-hello", 0o755)
+	writeFile(t, second, "asc-hello", 0o755)
+	world := writeFile(t, second, "asc-world", 0o755)
+	writeFile(t, first, "asc-notexec", 0o644)
+	writeFile(t, first, "other-tool", 0o755)
+	if err := os.Mkdir(filepath.Join(first, "asc-dir"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", strings.Join([]string{first, second}, string(os.PathListSeparator)))
+
+	got := List()
+	want := []Plugin{{Name: "hello", Path: hello}, {Name: "world", Path: world}}
+	if !slices.Equal(got, want) {
+		t.Fatalf("List() = %v, want %v", got, want)
+	}
+
+	plugin, ok := Find("hello")
+	if !ok || plugin.Path != hello {
+		t.Fatalf("Find(hello) = %v, %t; want %s", plugin, ok, hello)
+	}
+	for _, name := range []string{"notexec", "missing", "-h", "../hello", ""} {
+		if _, ok := Find(name); ok {
+			t.Fatalf("expected Find(%q) to fail", name)
+		}
+	}
+}
+
+func TestEnvironmentPassesTokenNotKey(t *testing.T) {
+	t.Setenv("ASC_PROFILE", "ci")
+	expires := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	issueToken = func() (shared.ShortLivedToken, error) {
+		return shared.ShortLivedToken{KeyID: "KEY", IssuerID: "ISSUER", Token: "jwt", ExpiresAt: expires}, nil
+	}
+	t.Cleanup(func() { issueToken = shared.IssueShortLivedToken })
+
+	env := Environment()
+	for _, want := range []string{
+		"ASC_PROFILE=ci",
+		"ASC_KEY_ID=KEY",
+		"ASC_ISSUER_ID=ISSUER",
+		"ASC_TOKEN=jwt",
+		"ASC_TOKEN_EXPIRES_AT=2026-01-02T03:04:05Z",
+	} {
+		if !slices.Contains(env, want) {
+			t.Fatalf("expected %s in %v", want, env)
+		}
+	}
+	for _, entry := range env {
+		if strings.HasPrefix(entry, "ASC_PRIVATE_KEY") {
+			t.Fatalf("unexpected private key variable %s", entry)
+		}
+	}
+}
+
+func TestEnvironmentWithoutCredentials(t *testing.T) {
+	issueToken = func() (shared.ShortLivedToken, error) {
+		return shared.ShortLivedToken{}, shared.ErrMissingAuth
+	}
+	t.Cleanup(func() { issueToken = shared.IssueShortLivedToken })
+
+	for _, entry := range Environment() {
+		if strings.HasPrefix(entry, "ASC_TOKEN=") {
+			t.Fatalf("expected no token without credentials, got %s", entry)
+		}
+	}
+}
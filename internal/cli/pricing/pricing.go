@@ -174,7 +174,10 @@ Examples:
 				return fmt.Errorf("pricing price-points: %w", err)
 			}
 
-			resolvedAppID := shared.ResolveAppID(*appID)
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("pricing price-points: %w", err)
+			}
 			if resolvedAppID == "" && strings.TrimSpace(*next) == "" {
 				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
 				return flag.ErrHelp
@@ -355,7 +358,11 @@ Examples:
 			idValue := strings.TrimSpace(*id)
 			appValue := ""
 			if idValue == "" {
-				appValue = shared.ResolveAppID(*appID)
+				var err error
+				appValue, err = shared.ResolveAppID(ctx, *appID)
+				if err != nil {
+					return fmt.Errorf("pricing schedule get: %w", err)
+				}
 			}
 			if idValue == "" && appValue == "" {
 				fmt.Fprintln(os.Stderr, "Error: --app or --id is required (or set ASC_APP_ID)")
@@ -538,7 +545,11 @@ Examples:
 			idValue := strings.TrimSpace(*id)
 			appValue := ""
 			if idValue == "" {
-				appValue = shared.ResolveAppID(*appID)
+				var err error
+				appValue, err = shared.ResolveAppID(ctx, *appID)
+				if err != nil {
+					return fmt.Errorf("pricing availability get: %w", err)
+				}
 			}
 			if idValue == "" && appValue == "" {
 				fmt.Fprintln(os.Stderr, "Error: --app or --id is required (or set ASC_APP_ID)")
@@ -86,7 +86,10 @@ Examples:
 			}
 
 			if *v2 {
-				resolvedAppID := shared.ResolveAppID(*appID)
+				resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+				if err != nil {
+					return fmt.Errorf("experiments list: %w", err)
+				}
 				if resolvedAppID == "" {
 					fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
 					return flag.ErrHelp
@@ -267,7 +270,10 @@ Examples:
 			}
 
 			if *v2 {
-				resolvedAppID := shared.ResolveAppID(*appID)
+				resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+				if err != nil {
+					return fmt.Errorf("experiments create: %w", err)
+				}
 				if resolvedAppID == "" {
 					fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
 					return flag.ErrHelp
@@ -58,6 +58,7 @@ func PublishTestFlightCommand() *ffcli.Command {
 	timeout := fs.Duration("timeout", 0, "Override upload + processing timeout (e.g., 30m)")
 	testNotes := fs.String("test-notes", "", "What to Test notes for the build")
 	locale := fs.String("locale", "", "Locale for --test-notes (e.g., en-US)")
+	skipLint := fs.Bool("skip-lint", false, "Skip the offline IPA checks run before uploading")
 	output := shared.BindOutputFlags(fs)
 
 	return &ffcli.Command{
@@ -67,10 +68,11 @@ func PublishTestFlightCommand() *ffcli.Command {
 		LongHelp: `Upload IPA and distribute to TestFlight beta groups.
 
 Steps:
-1. Upload IPA to App Store Connect
-2. Wait for processing (if --wait)
-3. Add build to specified beta groups
-4. Optionally notify testers
+1. Check the IPA locally (see 'asc ipa lint'; skip with --skip-lint)
+2. Upload IPA to App Store Connect
+3. Wait for processing (if --wait)
+4. Add build to specified beta groups
+5. Optionally notify testers
 
 Examples:
   asc publish testflight --app "123" --ipa app.ipa --group "GROUP_ID"
@@ -80,7 +82,10 @@ Examples:
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
-			resolvedAppID := shared.ResolveAppID(*appID)
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("publish testflight: %w", err)
+			}
 			if resolvedAppID == "" {
 				fmt.Fprintf(os.Stderr, "Error: --app is required (or set ASC_APP_ID)\n\n")
 				return flag.ErrHelp
@@ -139,6 +144,12 @@ Examples:
 				return fmt.Errorf("publish testflight: %w", err)
 			}
 
+			if !*skipLint {
+				if err := shared.LintIPABeforeUpload(ctx, client, resolvedAppID, *ipaPath); err != nil {
+					return fmt.Errorf("publish testflight: %w", err)
+				}
+			}
+
 			timeoutValue := resolvePublishTimeout(*timeout)
 			requestCtx, cancel := shared.ContextWithTimeoutDuration(ctx, timeoutValue)
 			defer cancel()
@@ -202,6 +213,7 @@ func PublishAppStoreCommand() *ffcli.Command {
 	wait := fs.Bool("wait", false, "Wait for build processing")
 	pollInterval := fs.Duration("poll-interval", shared.PublishDefaultPollInterval, "Polling interval for --wait and build discovery")
 	timeout := fs.Duration("timeout", 0, "Override upload + processing timeout (e.g., 30m)")
+	skipLint := fs.Bool("skip-lint", false, "Skip the offline IPA checks run before uploading")
 	output := shared.BindOutputFlags(fs)
 
 	return &ffcli.Command{
@@ -211,11 +223,12 @@ func PublishAppStoreCommand() *ffcli.Command {
 		LongHelp: `Upload IPA, attach to version, and optionally submit for review.
 
 Steps:
-1. Upload IPA to App Store Connect
-2. Wait for processing (if --wait)
-3. Find or create App Store version
-4. Attach build to version
-5. Submit for review (if --submit --confirm)
+1. Check the IPA locally (see 'asc ipa lint'; skip with --skip-lint)
+2. Upload IPA to App Store Connect
+3. Wait for processing (if --wait)
+4. Find or create App Store version
+5. Attach build to version
+6. Submit for review (if --submit --confirm)
 
 Examples:
   asc publish appstore --app "123" --ipa app.ipa --version 1.2.3
@@ -228,7 +241,10 @@ Examples:
 				return flag.ErrHelp
 			}
 
-			resolvedAppID := shared.ResolveAppID(*appID)
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("publish appstore: %w", err)
+			}
 			if resolvedAppID == "" {
 				fmt.Fprintf(os.Stderr, "Error: --app is required (or set ASC_APP_ID)\n\n")
 				return flag.ErrHelp
@@ -264,6 +280,12 @@ Examples:
 				return fmt.Errorf("publish appstore: %w", err)
 			}
 
+			if !*skipLint {
+				if err := shared.LintIPABeforeUpload(ctx, client, resolvedAppID, *ipaPath); err != nil {
+					return fmt.Errorf("publish appstore: %w", err)
+				}
+			}
+
 			timeoutValue := resolvePublishTimeout(*timeout)
 			requestCtx, cancel := shared.ContextWithTimeoutDuration(ctx, timeoutValue)
 			defer cancel()
@@ -324,6 +346,27 @@ Examples:
 	}
 }
 
+// ResolveBundleInfo returns the version and build number to upload an IPA
+// as, reading whichever is empty from the IPA's Info.plist.
+func ResolveBundleInfo(ipaPath, version, buildNumber string) (string, string, error) {
+	return resolveBundleInfoForIPA(ipaPath, version, buildNumber)
+}
+
+// UploadBuild uploads an IPA as version/buildNumber and waits until the
+// build appears in App Store Connect. A zero timeout uses the default
+// upload timeout.
+func UploadBuild(ctx context.Context, client *asc.Client, appID, ipaPath, version, buildNumber string, platform asc.Platform, pollInterval, timeout time.Duration) (*asc.BuildResponse, error) {
+	fileInfo, err := validateIPAPath(ipaPath)
+	if err != nil {
+		return nil, err
+	}
+	result, err := uploadBuildAndWaitForID(ctx, client, appID, ipaPath, fileInfo, version, buildNumber, platform, pollInterval, resolvePublishTimeout(timeout), timeout > 0)
+	if err != nil {
+		return nil, err
+	}
+	return result.Build, nil
+}
+
 type publishUploadResult struct {
 	Build       *asc.BuildResponse
 	Version     string
@@ -331,7 +374,7 @@ type publishUploadResult struct {
 }
 
 func uploadBuildAndWaitForID(ctx context.Context, client *asc.Client, appID, ipaPath string, fileInfo os.FileInfo, version, buildNumber string, platform asc.Platform, pollInterval time.Duration, uploadTimeout time.Duration, overrideUploadTimeout bool) (*publishUploadResult, error) {
-	_, fileResp, err := prepareBuildUpload(ctx, client, appID, fileInfo, version, buildNumber, platform)
+	uploadResp, fileResp, err := prepareBuildUpload(ctx, client, appID, fileInfo, version, buildNumber, platform)
 	if err != nil {
 		return nil, err
 	}
@@ -341,10 +384,10 @@ func uploadBuildAndWaitForID(ctx context.Context, client *asc.Client, appID, ipa
 	}
 
 	uploadCtx, uploadCancel := contextWithPublishUploadTimeout(ctx, uploadTimeout, overrideUploadTimeout)
-	err = asc.ExecuteUploadOperations(uploadCtx, ipaPath, fileResp.Data.Attributes.UploadOperations)
+	err = asc.ExecuteUploadOperations(uploadCtx, ipaPath, fileResp.Data.Attributes.UploadOperations, asc.WithUploadCheckpoint(uploadResp.Data.ID))
 	uploadCancel()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w (resume with: asc builds uploads resume --id %q --ipa %q)", err, uploadResp.Data.ID, ipaPath)
 	}
 
 	commitCtx, commitCancel := contextWithPublishUploadTimeout(ctx, uploadTimeout, overrideUploadTimeout)
@@ -13,6 +13,7 @@ import (
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/alternativedistribution"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/analytics"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/androidiosmapping"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/api"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/app_events"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/appclips"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/apps"
@@ -38,16 +39,21 @@ import (
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/iap"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/initcmd"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/install"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/ipa"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/localizations"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/manifest"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/marketplace"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/mcp"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/merchantids"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/migrate"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/mock"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/nominations"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/notarization"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/notify"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/offercodes"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/passtypeids"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/performance"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/plugins"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/preorders"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/prerelease"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/pricing"
@@ -55,7 +61,9 @@ import (
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/profiles"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/promotedpurchases"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/publish"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/release"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/releasenotes"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/reports"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/reviews"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/routingcoverage"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/sandbox"
@@ -103,6 +111,7 @@ func Subcommands(version string) []*ffcli.Command {
 		analytics.AnalyticsCommand(),
 		performance.PerformanceCommand(),
 		finance.FinanceCommand(),
+		reports.ReportsCommand(),
 		apps.AppsCommand(),
 		appclips.AppClipsCommand(),
 		androidiosmapping.AndroidIosMappingCommand(),
@@ -125,7 +134,9 @@ func Subcommands(version string) []*ffcli.Command {
 		testflight.TestFlightCommand(),
 		builds.BuildsCommand(),
 		buildbundles.BuildBundlesCommand(),
+		ipa.IPACommand(),
 		publish.PublishCommand(),
+		release.ReleaseCommand(),
 		versions.VersionsCommand(),
 		productpages.ProductPagesCommand(),
 		routingcoverage.RoutingCoverageCommand(),
@@ -158,7 +169,15 @@ func Subcommands(version string) []*ffcli.Command {
 		encryption.EncryptionCommand(),
 		promotedpurchases.PromotedPurchasesCommand(),
 		migrate.MigrateCommand(),
+		manifest.ApplyCommand(),
+		manifest.ExportCommand(),
 		notify.NotifyCommand(),
+		mock.MockCommand(),
+		api.APICommand(),
+		mcp.MCPCommand(version, func() []*ffcli.Command {
+			return Subcommands(version)
+		}),
+		plugins.PluginsCommand(),
 		gamecenter.GameCenterCommand(),
 		VersionCommand(version),
 	}
@@ -0,0 +1,254 @@
+package release
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/assets"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/manifest"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// Release modes: what happens once the version is approved.
+const (
+	// releaseManual releases the version as soon as review approves it.
+	releaseManual = "manual"
+	// releasePhased releases the version as a 7-day phased release.
+	releasePhased = "phased"
+	// releaseAfterApproval lets App Store Connect release the version
+	// automatically after approval.
+	releaseAfterApproval = "after-approval"
+	// releaseNone leaves the approved version for a later manual release.
+	releaseNone = "none"
+)
+
+const (
+	defaultReviewTimeout      = time.Hour
+	defaultReviewPollInterval = time.Minute
+)
+
+// Plan is the release plan file read by asc release run. Relative paths are
+// resolved against the plan file's directory.
+type Plan struct {
+	App           string                          `yaml:"app"`
+	Version       string                          `yaml:"version"`
+	Platform      string                          `yaml:"platform,omitempty"`
+	Build         *PlanBuild                      `yaml:"build,omitempty"`
+	Metadata      string                          `yaml:"metadata,omitempty"`
+	Screenshots   []PlanScreenshots               `yaml:"screenshots,omitempty"`
+	ReviewDetails *manifest.ManifestReviewDetails `yaml:"reviewDetails,omitempty"`
+	Validate      PlanValidate                    `yaml:"validate,omitempty"`
+	Submit        *bool                           `yaml:"submit,omitempty"`
+	Review        PlanReview                      `yaml:"review,omitempty"`
+	Release       string                          `yaml:"release,omitempty"`
+}
+
+// PlanBuild selects the build to attach: an IPA to upload, or an existing
+// build by ID or build number.
+type PlanBuild struct {
+	IPA      string `yaml:"ipa,omitempty"`
+	ID       string `yaml:"id,omitempty"`
+	Number   string `yaml:"number,omitempty"`
+	SkipLint bool   `yaml:"skipLint,omitempty"`
+}
+
+// PlanScreenshots uploads a screenshot file or directory to one
+// localization and display type.
+type PlanScreenshots struct {
+	Locale      string `yaml:"locale"`
+	DisplayType string `yaml:"displayType"`
+	Path        string `yaml:"path"`
+}
+
+// PlanValidate configures the asc validate step.
+type PlanValidate struct {
+	Skip   bool `yaml:"skip,omitempty"`
+	Strict bool `yaml:"strict,omitempty"`
+}
+
+// PlanReview configures waiting for App Review.
+type PlanReview struct {
+	Wait         *bool  `yaml:"wait,omitempty"`
+	Timeout      string `yaml:"timeout,omitempty"`
+	PollInterval string `yaml:"pollInterval,omitempty"`
+}
+
+// loadedPlan is a validated plan with normalized values and absolute paths.
+type loadedPlan struct {
+	Plan
+	path               string
+	hash               string
+	platform           string
+	release            string
+	reviewTimeout      time.Duration
+	reviewPollInterval time.Duration
+	metadata           *manifest.Manifest
+}
+
+func (p *loadedPlan) submits() bool {
+	return p.Submit == nil || *p.Submit
+}
+
+func (p *loadedPlan) waitsForReview() bool {
+	return p.Review.Wait == nil || *p.Review.Wait
+}
+
+// releaseType is the App Store version release type the plan needs.
+func (p *loadedPlan) releaseType() string {
+	if p.release == releaseAfterApproval {
+		return "AFTER_APPROVAL"
+	}
+	return "MANUAL"
+}
+
+func readPlan(path string) (*loadedPlan, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	return parsePlan(path, data)
+}
+
+func parsePlan(path string, data []byte) (*loadedPlan, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var plan Plan
+	if err := decoder.Decode(&plan); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, shared.UsageError("plan file is empty")
+		}
+		return nil, fmt.Errorf("parse YAML: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	loaded := &loadedPlan{
+		Plan: plan,
+		path: filepath.Clean(path),
+		hash: hex.EncodeToString(sum[:]),
+	}
+	if err := loaded.normalize(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	return loaded, nil
+}
+
+// normalize validates the plan before any network calls so that typos fail
+// fast instead of half-way through a release.
+func (p *loadedPlan) normalize(dir string) error {
+	p.App = strings.TrimSpace(p.App)
+	p.Version = strings.TrimSpace(p.Version)
+	if p.Version == "" {
+		return fmt.Errorf("version is required")
+	}
+
+	platform := strings.TrimSpace(p.Platform)
+	if platform == "" {
+		platform = string(asc.PlatformIOS)
+	}
+	normalized, err := shared.NormalizeAppStoreVersionPlatform(platform)
+	if err != nil {
+		return fmt.Errorf("platform: %w", err)
+	}
+	p.platform = normalized
+
+	if p.Build != nil {
+		sources := 0
+		for _, value := range []string{p.Build.IPA, p.Build.ID, p.Build.Number} {
+			if strings.TrimSpace(value) != "" {
+				sources++
+			}
+		}
+		if sources != 1 {
+			return fmt.Errorf("build: set exactly one of ipa, id or number")
+		}
+		if strings.TrimSpace(p.Build.IPA) != "" {
+			p.Build.IPA = resolvePath(dir, p.Build.IPA)
+		}
+	}
+
+	if strings.TrimSpace(p.Metadata) != "" {
+		p.Metadata = resolvePath(dir, p.Metadata)
+		p.metadata, err = manifest.ReadFile(p.Metadata)
+		if err != nil {
+			return fmt.Errorf("metadata: %w", err)
+		}
+	}
+
+	for i := range p.Screenshots {
+		shots := &p.Screenshots[i]
+		shots.Locale = strings.TrimSpace(shots.Locale)
+		if shots.Locale == "" {
+			return fmt.Errorf("screenshots[%d]: locale is required", i)
+		}
+		if strings.TrimSpace(shots.Path) == "" {
+			return fmt.Errorf("screenshots[%d]: path is required", i)
+		}
+		shots.Path = resolvePath(dir, shots.Path)
+		shots.DisplayType, err = assets.NormalizeScreenshotDisplayType(shots.DisplayType)
+		if err != nil {
+			return fmt.Errorf("screenshots[%d]: %w", i, err)
+		}
+	}
+
+	p.release = strings.ToLower(strings.TrimSpace(p.Release))
+	switch p.release {
+	case "":
+		p.release = releaseManual
+	case releaseManual, releasePhased, releaseAfterApproval, releaseNone:
+	default:
+		return fmt.Errorf("release must be one of: %s, %s, %s, %s", releaseManual, releasePhased, releaseAfterApproval, releaseNone)
+	}
+
+	p.reviewTimeout, err = parseDuration("review.timeout", p.Review.Timeout, defaultReviewTimeout)
+	if err != nil {
+		return err
+	}
+	p.reviewPollInterval, err = parseDuration("review.pollInterval", p.Review.PollInterval, defaultReviewPollInterval)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func parseDuration(field, value string, fallback time.Duration) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return fallback, nil
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed <= 0 {
+		return 0, fmt.Errorf("%s must be a positive duration (e.g. 30m)", field)
+	}
+	return parsed, nil
+}
+
+func resolvePath(dir, path string) string {
+	path = strings.TrimSpace(path)
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(dir, path)
+}
+
+// defaultStatePath keeps the state next to the plan: release.yaml records
+// its progress in release.state.json.
+func defaultStatePath(planPath string) string {
+	return strings.TrimSuffix(planPath, filepath.Ext(planPath)) + ".state.json"
+}
@@ -0,0 +1,327 @@
+package release
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// ReleaseCommand returns the release command with subcommands.
+func ReleaseCommand() *ffcli.Command {
+	return &ffcli.Command{
+		Name:       "release",
+		ShortUsage: "asc release <subcommand> [flags]",
+		ShortHelp:  "Run an App Store release from a plan file.",
+		LongHelp: `Run an App Store release from a plan file.
+
+A release plan describes the whole App Store pipeline: version, build,
+metadata, screenshots, review details, validation, submission, waiting for
+review, and the manual or phased release. Progress is saved after every step
+so a failed run can be resumed without redoing uploads.
+
+Examples:
+  asc release run --plan release.yaml --dry-run
+  asc release run --plan release.yaml --confirm
+  asc release status --plan release.yaml`,
+		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			ReleaseRunCommand(),
+			ReleaseStatusCommand(),
+		},
+		Exec: func(ctx context.Context, args []string) error {
+			return flag.ErrHelp
+		},
+	}
+}
+
+// ReleaseRunCommand runs (or resumes) a release plan.
+func ReleaseRunCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("release run", flag.ExitOnError)
+
+	planPath := fs.String("plan", "", "Release plan YAML file (required)")
+	statePath := fs.String("state", "", "State file (default: <plan>.state.json next to the plan)")
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID env; defaults to app in the plan)")
+	from := fs.String("from", "", "Rerun from this step, even if it completed: "+strings.Join(stepNames(releaseSteps()), ", "))
+	restart := fs.Bool("restart", false, "Ignore saved state and start from the first step")
+	dryRun := fs.Bool("dry-run", false, "Validate the plan and print the steps that would run")
+	confirm := fs.Bool("confirm", false, "Confirm the release (required unless --dry-run)")
+	pollInterval := fs.Duration("poll-interval", shared.PublishDefaultPollInterval, "Polling interval for build upload and processing")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "run",
+		ShortUsage: "asc release run --plan release.yaml [flags]",
+		ShortHelp:  "Run or resume a release plan.",
+		LongHelp: `Run or resume a release plan.
+
+Steps run in order; each reports completed, skipped or failed:
+  version          find or create the App Store version, set its release type
+  build            upload build.ipa (or select build.id / build.number),
+                   wait for processing and attach it
+  metadata         apply the asc apply manifest in metadata
+  screenshots      upload screenshots per locale and display type, replacing
+                   ones an earlier run left undelivered
+  review-details   set App Review contact and demo account details
+  phased-release   enable phased release (release: phased)
+  validate         run asc validate; blocking issues stop the release
+  submit           submit the version for App Review, reusing the review
+                   submission from an earlier run
+  wait-for-review  poll until App Review approves the version
+  release          release the approved version (release: manual or phased)
+
+Progress is saved to the state file after every step. Rerunning the same
+plan continues with the first step that has not completed, so a failure
+while waiting for review does not redo the uploads. Use --from to rerun
+from a step, or --restart to start over. Changing the plan file requires
+one of them.
+
+Plan file:
+  app: "APP_ID"
+  version: "1.2.0"
+  platform: IOS
+  build:
+    ipa: ./build/App.ipa
+  metadata: ./app.yaml
+  screenshots:
+    - locale: en-US
+      displayType: APP_IPHONE_67
+      path: ./screenshots/en-US
+  reviewDetails:
+    contactEmail: review@example.com
+  validate:
+    strict: false
+  submit: true
+  review:
+    wait: true
+    timeout: 48h
+    pollInterval: 5m
+  release: phased   # manual (default), phased, after-approval, none
+
+Examples:
+  asc release run --plan release.yaml --dry-run
+  asc release run --plan release.yaml --confirm
+  asc release run --plan release.yaml --from wait-for-review --confirm
+  asc release run --plan release.yaml --restart --confirm --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			planValue := strings.TrimSpace(*planPath)
+			if planValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --plan is required")
+				return flag.ErrHelp
+			}
+			if !*dryRun && !*confirm {
+				fmt.Fprintln(os.Stderr, "Error: --confirm is required (or use --dry-run)")
+				return flag.ErrHelp
+			}
+			if *restart && strings.TrimSpace(*from) != "" {
+				return shared.UsageError("--restart and --from are mutually exclusive")
+			}
+			if *pollInterval <= 0 {
+				return shared.UsageError("--poll-interval must be greater than 0")
+			}
+
+			plan, err := readPlan(planValue)
+			if err != nil {
+				return fmt.Errorf("release run: %w", err)
+			}
+
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("release run: %w", err)
+			}
+			if resolvedAppID == "" {
+				resolvedAppID = plan.App
+			}
+			if resolvedAppID == "" {
+				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID or app in the plan)")
+				return flag.ErrHelp
+			}
+
+			stateValue := strings.TrimSpace(*statePath)
+			if stateValue == "" {
+				stateValue = defaultStatePath(plan.path)
+			}
+
+			steps := releaseSteps()
+			state, err := loadRunState(stateValue, plan, resolvedAppID, steps, *restart, strings.TrimSpace(*from))
+			if err != nil {
+				return err
+			}
+
+			r := &runner{
+				plan:         plan,
+				state:        state,
+				statePath:    stateValue,
+				pollInterval: *pollInterval,
+				now:          time.Now,
+				progress:     os.Stderr,
+			}
+
+			var runErr error
+			if *dryRun {
+				r.dryRun(steps)
+			} else {
+				client, err := shared.GetASCClient()
+				if err != nil {
+					return fmt.Errorf("release run: %w", err)
+				}
+				r.client = client
+				runErr = r.run(ctx, steps)
+			}
+
+			if err := printState(state, *output.Output, *output.Pretty); err != nil {
+				return err
+			}
+			if runErr != nil {
+				return shared.NewReportedError(fmt.Errorf("release run: %w", runErr))
+			}
+			return nil
+		},
+	}
+}
+
+// loadRunState returns the state to run with: the saved one when resuming,
+// or a fresh one.
+func loadRunState(path string, plan *loadedPlan, appID string, steps []step, restart bool, from string) (*State, error) {
+	if from != "" && !containsStep(steps, from) {
+		return nil, shared.UsageErrorf("--from must be one of: %s", strings.Join(stepNames(steps), ", "))
+	}
+	if restart {
+		return newState(plan, appID, steps), nil
+	}
+
+	saved, err := readState(path)
+	if err != nil {
+		return nil, fmt.Errorf("release run: %w", err)
+	}
+	if saved == nil {
+		if from != "" {
+			return nil, shared.UsageErrorf("--from needs a saved state; no state at %s", path)
+		}
+		return newState(plan, appID, steps), nil
+	}
+
+	if saved.AppID != appID || saved.Version != plan.Version || saved.Platform != plan.platform {
+		return nil, shared.UsageErrorf("state file %s is for app %s version %s (%s); use --restart or a different --state", path, saved.AppID, saved.Version, saved.Platform)
+	}
+	if saved.PlanHash != plan.hash && from == "" {
+		return nil, shared.UsageErrorf("plan %s changed since the last run; use --from STEP to continue from a step or --restart to start over", plan.path)
+	}
+
+	// Keep the saved steps in pipeline order and add any that are missing.
+	state := newState(plan, appID, steps)
+	state.VersionID = saved.VersionID
+	state.BuildID = saved.BuildID
+	state.SubmissionID = saved.SubmissionID
+	for i := range state.Steps {
+		if previous := saved.step(state.Steps[i].Name); previous != nil {
+			state.Steps[i] = *previous
+		}
+	}
+	if from != "" {
+		if err := state.resetFrom(from); err != nil {
+			return nil, err
+		}
+	}
+	return state, nil
+}
+
+func containsStep(steps []step, name string) bool {
+	for _, s := range steps {
+		if s.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ReleaseStatusCommand prints the saved state of a release plan.
+func ReleaseStatusCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("release status", flag.ExitOnError)
+
+	planPath := fs.String("plan", "", "Release plan YAML file (used to find the default state file)")
+	statePath := fs.String("state", "", "State file (default: <plan>.state.json next to the plan)")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "status",
+		ShortUsage: "asc release status --plan release.yaml [flags]",
+		ShortHelp:  "Show the saved progress of a release plan.",
+		LongHelp: `Show the saved progress of a release plan.
+
+Prints the state written by asc release run without calling App Store
+Connect.
+
+Examples:
+  asc release status --plan release.yaml
+  asc release status --state release.state.json --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			stateValue := strings.TrimSpace(*statePath)
+			planValue := strings.TrimSpace(*planPath)
+			if stateValue == "" && planValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --plan or --state is required")
+				return flag.ErrHelp
+			}
+			if stateValue == "" {
+				stateValue = defaultStatePath(planValue)
+			}
+
+			state, err := readState(stateValue)
+			if err != nil {
+				return fmt.Errorf("release status: %w", err)
+			}
+			if state == nil {
+				return fmt.Errorf("release status: no saved state at %s", stateValue)
+			}
+			return printState(state, *output.Output, *output.Pretty)
+		},
+	}
+}
+
+func printState(state *State, format string, pretty bool) error {
+	return shared.PrintOutputWithRenderers(
+		state,
+		format,
+		pretty,
+		func() error { return renderStateTables(state, false) },
+		func() error { return renderStateTables(state, true) },
+	)
+}
+
+func renderStateTables(state *State, markdown bool) error {
+	if state == nil {
+		return fmt.Errorf("state is nil")
+	}
+
+	render := asc.RenderTable
+	if markdown {
+		render = asc.RenderMarkdown
+	}
+
+	render(
+		[]string{"App ID", "Version", "Platform", "Version ID", "Build ID", "Status"},
+		[][]string{{state.AppID, state.Version, state.Platform, state.VersionID, state.BuildID, state.Status}},
+	)
+
+	rows := make([][]string, 0, len(state.Steps))
+	for _, s := range state.Steps {
+		message := s.Message
+		if s.Error != "" {
+			message = s.Error
+		}
+		rows = append(rows, []string{s.Name, s.Status, s.StartedAt, s.FinishedAt, message})
+	}
+	render([]string{"Step", "Status", "Started", "Finished", "Message"}, rows)
+	return nil
+}
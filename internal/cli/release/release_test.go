@@ -0,0 +1,267 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePlanDefaults(t *testing.T) {
+	dir := t.TempDir()
+	plan, err := parsePlan(filepath.Join(dir, "release.yaml"), []byte(`app: app-1
+version: " 1.2.0 "
+build:
+  ipa: build/App.ipa
+screenshots:
+  - locale: en-US
+    displayType: iphone_67
+    path: shots
+`))
+	if err != nil {
+		t.Fatalf("parsePlan() error: %v", err)
+	}
+	if plan.Version != "1.2.0" || plan.platform != "IOS" || plan.release != releaseManual {
+		t.Fatalf("unexpected plan: version=%q platform=%q release=%q", plan.Version, plan.platform, plan.release)
+	}
+	if plan.Build.IPA != filepath.Join(dir, "build", "App.ipa") {
+		t.Fatalf("expected ipa relative to plan, got %q", plan.Build.IPA)
+	}
+	if plan.Screenshots[0].DisplayType != "APP_IPHONE_67" || plan.Screenshots[0].Path != filepath.Join(dir, "shots") {
+		t.Fatalf("unexpected screenshots: %+v", plan.Screenshots[0])
+	}
+	if !plan.submits() || !plan.waitsForReview() || plan.releaseType() != "MANUAL" {
+		t.Fatalf("expected submit, wait and manual release by default")
+	}
+	if plan.reviewTimeout != defaultReviewTimeout || plan.reviewPollInterval != defaultReviewPollInterval {
+		t.Fatalf("unexpected review durations: %s %s", plan.reviewTimeout, plan.reviewPollInterval)
+	}
+	if len(plan.hash) != 64 {
+		t.Fatalf("expected sha256 hash, got %q", plan.hash)
+	}
+}
+
+func TestParsePlanErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "missing version", yaml: "app: app-1\n", wantErr: "version is required"},
+		{name: "unknown field", yaml: "version: 1.0\nbiuld: {}\n", wantErr: "biuld"},
+		{name: "two build sources", yaml: "version: 1.0\nbuild:\n  id: b\n  number: \"42\"\n", wantErr: "exactly one of ipa, id or number"},
+		{name: "bad platform", yaml: "version: 1.0\nplatform: WATCH\n", wantErr: "platform"},
+		{name: "bad release", yaml: "version: 1.0\nrelease: soon\n", wantErr: "release must be one of"},
+		{name: "bad timeout", yaml: "version: 1.0\nreview:\n  timeout: -1h\n", wantErr: "review.timeout"},
+		{name: "screenshot without locale", yaml: "version: 1.0\nscreenshots:\n  - path: shots\n    displayType: APP_IPHONE_67\n", wantErr: "screenshots[0]: locale is required"},
+		{name: "missing metadata file", yaml: "version: 1.0\nmetadata: missing.yaml\n", wantErr: "metadata"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parsePlan(filepath.Join(t.TempDir(), "release.yaml"), []byte(test.yaml))
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDefaultStatePath(t *testing.T) {
+	if got := defaultStatePath(filepath.Join("ci", "release.yaml")); got != filepath.Join("ci", "release.state.json") {
+		t.Fatalf("defaultStatePath() = %q", got)
+	}
+	if got := defaultStatePath("plan"); got != "plan.state.json" {
+		t.Fatalf("defaultStatePath() = %q", got)
+	}
+}
+
+func testPlan(t *testing.T, yaml string) *loadedPlan {
+	t.Helper()
+	plan, err := parsePlan(filepath.Join(t.TempDir(), "release.yaml"), []byte(yaml))
+	if err != nil {
+		t.Fatalf("parsePlan() error: %v", err)
+	}
+	return plan
+}
+
+func testRunner(t *testing.T, plan *loadedPlan, steps []step) *runner {
+	t.Helper()
+	return &runner{
+		plan:      plan,
+		state:     newState(plan, "app-1", steps),
+		statePath: filepath.Join(t.TempDir(), "release.state.json"),
+		now:       func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) },
+	}
+}
+
+func TestRunnerResumesAfterFailure(t *testing.T) {
+	plan := testPlan(t, "version: 1.0\n")
+	calls := map[string]int{}
+	fail := true
+	steps := []step{
+		{name: "upload", run: func(ctx context.Context, r *runner) (string, error) {
+			calls["upload"]++
+			return "uploaded", nil
+		}},
+		{name: "optional", skip: func(p *loadedPlan) string { return "not needed" }, run: func(ctx context.Context, r *runner) (string, error) {
+			calls["optional"]++
+			return "", nil
+		}},
+		{name: "wait", run: func(ctx context.Context, r *runner) (string, error) {
+			calls["wait"]++
+			if fail {
+				return "", errors.New("still WAITING_FOR_REVIEW")
+			}
+			return "approved", nil
+		}},
+	}
+
+	r := testRunner(t, plan, steps)
+	err := r.run(context.Background(), steps)
+	if err == nil || err.Error() != "wait: still WAITING_FOR_REVIEW" {
+		t.Fatalf("expected wait failure, got %v", err)
+	}
+
+	saved, err := readState(r.statePath)
+	if err != nil || saved == nil {
+		t.Fatalf("readState() = %v, %v", saved, err)
+	}
+	if saved.Status != statusFailed {
+		t.Fatalf("expected failed state, got %q", saved.Status)
+	}
+	wantStatuses := []string{statusCompleted, statusSkipped, statusFailed}
+	for i, want := range wantStatuses {
+		if saved.Steps[i].Status != want {
+			t.Fatalf("step %s: expected %s, got %s", saved.Steps[i].Name, want, saved.Steps[i].Status)
+		}
+	}
+	if saved.Steps[2].Error != "still WAITING_FOR_REVIEW" || saved.Steps[0].Message != "uploaded" {
+		t.Fatalf("unexpected step details: %+v", saved.Steps)
+	}
+
+	fail = false
+	r.state = saved
+	if err := r.run(context.Background(), steps); err != nil {
+		t.Fatalf("resume error: %v", err)
+	}
+	if calls["upload"] != 1 || calls["optional"] != 0 || calls["wait"] != 2 {
+		t.Fatalf("unexpected calls: %v", calls)
+	}
+	if r.state.Status != statusCompleted || r.state.Steps[2].Status != statusCompleted || r.state.Steps[2].Error != "" {
+		t.Fatalf("unexpected state after resume: %+v", r.state)
+	}
+}
+
+func TestLoadRunState(t *testing.T) {
+	plan := testPlan(t, "version: 1.0\n")
+	steps := releaseSteps()
+	statePath := filepath.Join(t.TempDir(), "release.state.json")
+
+	state, err := loadRunState(statePath, plan, "app-1", steps, false, "")
+	if err != nil {
+		t.Fatalf("loadRunState() error: %v", err)
+	}
+	if len(state.Steps) != len(steps) || state.Steps[0].Status != statusPending {
+		t.Fatalf("expected fresh state, got %+v", state.Steps)
+	}
+
+	if _, err := loadRunState(statePath, plan, "app-1", steps, false, "submit"); !errors.Is(err, flag.ErrHelp) {
+		t.Fatalf("expected --from without state to be a usage error, got %v", err)
+	}
+
+	for i := range state.Steps {
+		state.Steps[i].Status = statusCompleted
+	}
+	state.VersionID = "version-1"
+	if err := writeState(statePath, state, time.Now()); err != nil {
+		t.Fatalf("writeState() error: %v", err)
+	}
+
+	resumed, err := loadRunState(statePath, plan, "app-1", steps, false, "")
+	if err != nil {
+		t.Fatalf("loadRunState() error: %v", err)
+	}
+	if resumed.VersionID != "version-1" || resumed.Steps[len(steps)-1].Status != statusCompleted {
+		t.Fatalf("expected saved state, got %+v", resumed)
+	}
+
+	if _, err := loadRunState(statePath, plan, "app-2", steps, false, ""); !errors.Is(err, flag.ErrHelp) {
+		t.Fatalf("expected other app to be a usage error, got %v", err)
+	}
+
+	changed := testPlan(t, "version: 1.0\nrelease: phased\n")
+	if _, err := loadRunState(statePath, changed, "app-1", steps, false, ""); !errors.Is(err, flag.ErrHelp) {
+		t.Fatalf("expected changed plan to be a usage error, got %v", err)
+	}
+	fromState, err := loadRunState(statePath, changed, "app-1", steps, false, "submit")
+	if err != nil {
+		t.Fatalf("loadRunState(--from) error: %v", err)
+	}
+	if fromState.PlanHash != changed.hash {
+		t.Fatalf("expected plan hash to be updated")
+	}
+	for _, s := range fromState.Steps {
+		wantPending := s.Name == "submit" || s.Name == "wait-for-review" || s.Name == "release"
+		if wantPending != (s.Status == statusPending) {
+			t.Fatalf("step %s: unexpected status %s", s.Name, s.Status)
+		}
+	}
+
+	restarted, err := loadRunState(statePath, plan, "app-1", steps, true, "")
+	if err != nil {
+		t.Fatalf("loadRunState(--restart) error: %v", err)
+	}
+	if restarted.VersionID != "" || restarted.Steps[0].Status != statusPending {
+		t.Fatalf("expected fresh state on restart, got %+v", restarted)
+	}
+}
+
+func TestReleaseStepSkips(t *testing.T) {
+	plan := testPlan(t, "version: 1.0\nsubmit: false\nvalidate:\n  skip: true\n")
+	skipped := map[string]bool{}
+	for _, s := range releaseSteps() {
+		if s.skip != nil && s.skip(plan) != "" {
+			skipped[s.name] = true
+		}
+	}
+	for _, name := range []string{"build", "metadata", "screenshots", "review-details", "phased-release", "validate", "submit", "wait-for-review", "release"} {
+		if !skipped[name] {
+			t.Fatalf("expected %s to be skipped", name)
+		}
+	}
+	if skipped["version"] {
+		t.Fatalf("version always runs")
+	}
+
+	phased := testPlan(t, "version: 1.0\nrelease: phased\n")
+	if reason := skipPhasedRelease(phased); reason != "" {
+		t.Fatalf("expected phased-release to run, got %q", reason)
+	}
+	if reason := skipRelease(phased); reason != "" {
+		t.Fatalf("expected release to run, got %q", reason)
+	}
+	afterApproval := testPlan(t, "version: 1.0\nrelease: after-approval\n")
+	if skipRelease(afterApproval) == "" || afterApproval.releaseType() != "AFTER_APPROVAL" {
+		t.Fatalf("expected after-approval to leave the release to App Store Connect")
+	}
+}
+
+func TestWriteStateIsPrivate(t *testing.T) {
+	plan := testPlan(t, "version: 1.0\n")
+	path := filepath.Join(t.TempDir(), "release.state.json")
+	if err := writeState(path, newState(plan, "app-1", releaseSteps()), time.Now()); err != nil {
+		t.Fatalf("writeState() error: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		t.Fatalf("expected private state file, got %v", info.Mode().Perm())
+	}
+}
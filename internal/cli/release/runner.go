@@ -0,0 +1,126 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+// step is one stage of the release pipeline. skip reports why the plan does
+// not need the step, or "" when it should run.
+type step struct {
+	name string
+	skip func(p *loadedPlan) string
+	run  func(ctx context.Context, r *runner) (string, error)
+}
+
+type runner struct {
+	plan         *loadedPlan
+	state        *State
+	statePath    string
+	client       *asc.Client
+	pollInterval time.Duration
+	now          func() time.Time
+	progress     io.Writer
+}
+
+// run executes every step that has not completed yet, saving the state after
+// each one so a failed run can be resumed.
+func (r *runner) run(ctx context.Context, steps []step) error {
+	r.state.Status = statusRunning
+	if err := r.save(); err != nil {
+		return err
+	}
+
+	for i, s := range steps {
+		current := r.state.step(s.name)
+		if current == nil {
+			return fmt.Errorf("state has no step %q", s.name)
+		}
+		if current.Status == statusCompleted || current.Status == statusSkipped {
+			r.progressf("[%d/%d] %s: %s", i+1, len(steps), s.name, current.Status)
+			continue
+		}
+
+		if s.skip != nil {
+			if reason := s.skip(r.plan); reason != "" {
+				*current = StepState{Name: s.name, Status: statusSkipped, Message: reason}
+				r.progressf("[%d/%d] %s: %s (%s)", i+1, len(steps), s.name, statusSkipped, reason)
+				if err := r.save(); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		*current = StepState{Name: s.name, Status: statusRunning, StartedAt: r.timestamp()}
+		r.progressf("[%d/%d] %s: %s", i+1, len(steps), s.name, statusRunning)
+		if err := r.save(); err != nil {
+			return err
+		}
+
+		message, err := s.run(ctx, r)
+		// Re-fetch: the step may have saved the state itself.
+		current = r.state.step(s.name)
+		current.FinishedAt = r.timestamp()
+		if err != nil {
+			current.Status = statusFailed
+			current.Error = err.Error()
+			r.state.Status = statusFailed
+			r.progressf("[%d/%d] %s: %s: %v", i+1, len(steps), s.name, statusFailed, err)
+			if saveErr := r.save(); saveErr != nil {
+				return fmt.Errorf("%s: %w (saving state: %v)", s.name, err, saveErr)
+			}
+			return fmt.Errorf("%s: %w", s.name, err)
+		}
+		current.Status = statusCompleted
+		current.Message = message
+		r.progressf("[%d/%d] %s: %s", i+1, len(steps), s.name, statusCompleted)
+		if err := r.save(); err != nil {
+			return err
+		}
+	}
+
+	r.state.Status = statusCompleted
+	return r.save()
+}
+
+// dryRun fills in the step statuses a run would produce without running
+// anything.
+func (r *runner) dryRun(steps []step) {
+	r.state.DryRun = true
+	for _, s := range steps {
+		current := r.state.step(s.name)
+		if current == nil || current.Status == statusCompleted || current.Status == statusSkipped {
+			continue
+		}
+		if s.skip != nil {
+			if reason := s.skip(r.plan); reason != "" {
+				*current = StepState{Name: s.name, Status: statusSkipped, Message: reason}
+				continue
+			}
+		}
+		*current = StepState{Name: s.name, Status: statusPending}
+	}
+}
+
+func (r *runner) save() error {
+	if err := writeState(r.statePath, r.state, r.now()); err != nil {
+		return fmt.Errorf("save state: %w", err)
+	}
+	return nil
+}
+
+func (r *runner) timestamp() string {
+	return r.now().UTC().Format(time.RFC3339)
+}
+
+func (r *runner) progressf(format string, args ...any) {
+	if r.progress == nil {
+		return
+	}
+	fmt.Fprintf(r.progress, format+"\n", args...)
+}
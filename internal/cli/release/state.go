@@ -0,0 +1,126 @@
+package release
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// Step statuses.
+const (
+	statusPending   = "pending"
+	statusRunning   = "running"
+	statusCompleted = "completed"
+	statusSkipped   = "skipped"
+	statusFailed    = "failed"
+)
+
+// State records the progress of a release run. It is saved after every step
+// so a rerun continues with the first step that has not completed.
+type State struct {
+	Plan         string      `json:"plan"`
+	PlanHash     string      `json:"planHash"`
+	AppID        string      `json:"appId"`
+	Version      string      `json:"version"`
+	Platform     string      `json:"platform"`
+	VersionID    string      `json:"versionId,omitempty"`
+	BuildID      string      `json:"buildId,omitempty"`
+	SubmissionID string      `json:"submissionId,omitempty"`
+	Status       string      `json:"status"`
+	DryRun       bool        `json:"dryRun,omitempty"`
+	UpdatedAt    string      `json:"updatedAt,omitempty"`
+	Steps        []StepState `json:"steps"`
+}
+
+// StepState is the status of one step.
+type StepState struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	StartedAt  string `json:"startedAt,omitempty"`
+	FinishedAt string `json:"finishedAt,omitempty"`
+	Message    string `json:"message,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// newState returns a state with every step pending.
+func newState(plan *loadedPlan, appID string, steps []step) *State {
+	state := &State{
+		Plan:     plan.path,
+		PlanHash: plan.hash,
+		AppID:    appID,
+		Version:  plan.Version,
+		Platform: plan.platform,
+		Status:   statusPending,
+		Steps:    make([]StepState, 0, len(steps)),
+	}
+	for _, s := range steps {
+		state.Steps = append(state.Steps, StepState{Name: s.name, Status: statusPending})
+	}
+	return state
+}
+
+func (s *State) step(name string) *StepState {
+	for i := range s.Steps {
+		if s.Steps[i].Name == name {
+			return &s.Steps[i]
+		}
+	}
+	return nil
+}
+
+// resetFrom marks the named step and every later step pending again.
+func (s *State) resetFrom(name string) error {
+	index := -1
+	for i := range s.Steps {
+		if s.Steps[i].Name == name {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return fmt.Errorf("unknown step %q", name)
+	}
+	for i := index; i < len(s.Steps); i++ {
+		s.Steps[i] = StepState{Name: s.Steps[i].Name, Status: statusPending}
+	}
+	return nil
+}
+
+// readState loads a saved state. It returns nil when there is none.
+func readState(path string) (*State, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parse state %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+func writeState(path string, state *State, now time.Time) error {
+	state.UpdatedAt = now.UTC().Format(time.RFC3339)
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	_, err = shared.WriteFileNoSymlinkOverwrite(path, bytes.NewReader(data), 0o600, ".asc-release-state-*", ".asc-release-state-backup-*")
+	return err
+}
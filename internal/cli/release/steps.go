@@ -0,0 +1,526 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/assets"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/manifest"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/publish"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/validate"
+)
+
+// buildTimeout bounds build upload discovery and processing, as in asc
+// publish.
+const buildTimeout = 30 * time.Minute
+
+// submittableStates are version states that can be submitted for review.
+// Any other state means an earlier run already submitted the version.
+var submittableStates = []string{
+	"PREPARE_FOR_SUBMISSION",
+	"READY_FOR_REVIEW",
+	"DEVELOPER_REJECTED",
+	"REJECTED",
+	"METADATA_REJECTED",
+}
+
+// approvedStates are version states reached once App Review approves.
+var approvedStates = []string{
+	"ACCEPTED",
+	"PENDING_APPLE_RELEASE",
+	"PENDING_DEVELOPER_RELEASE",
+	"PROCESSING_FOR_APP_STORE",
+	"PROCESSING_FOR_DISTRIBUTION",
+	"READY_FOR_DISTRIBUTION",
+	"READY_FOR_SALE",
+	"PREORDER_READY_FOR_SALE",
+}
+
+// rejectedStates end a review without approval.
+var rejectedStates = []string{
+	"REJECTED",
+	"METADATA_REJECTED",
+	"INVALID_BINARY",
+	"DEVELOPER_REJECTED",
+}
+
+// releasedStates are version states after the version has been released.
+var releasedStates = []string{
+	"PROCESSING_FOR_APP_STORE",
+	"PROCESSING_FOR_DISTRIBUTION",
+	"READY_FOR_DISTRIBUTION",
+	"READY_FOR_SALE",
+	"PREORDER_READY_FOR_SALE",
+}
+
+// releaseSteps returns the release pipeline in order. Step names are stored
+// in the state file, so they must stay stable.
+func releaseSteps() []step {
+	return []step{
+		{name: "version", run: runVersion},
+		{name: "build", skip: skipBuild, run: runBuild},
+		{name: "metadata", skip: skipMetadata, run: runMetadata},
+		{name: "screenshots", skip: skipScreenshots, run: runScreenshots},
+		{name: "review-details", skip: skipReviewDetails, run: runReviewDetails},
+		{name: "phased-release", skip: skipPhasedRelease, run: runPhasedRelease},
+		{name: "validate", skip: skipValidate, run: runValidate},
+		{name: "submit", skip: skipSubmit, run: runSubmit},
+		{name: "wait-for-review", skip: skipWaitForReview, run: runWaitForReview},
+		{name: "release", skip: skipRelease, run: runRelease},
+	}
+}
+
+func stepNames(steps []step) []string {
+	names := make([]string, 0, len(steps))
+	for _, s := range steps {
+		names = append(names, s.name)
+	}
+	return names
+}
+
+func skipBuild(p *loadedPlan) string {
+	if p.Build == nil {
+		return "no build in plan"
+	}
+	return ""
+}
+
+func skipMetadata(p *loadedPlan) string {
+	if p.metadata == nil {
+		return "no metadata in plan"
+	}
+	return ""
+}
+
+func skipScreenshots(p *loadedPlan) string {
+	if len(p.Screenshots) == 0 {
+		return "no screenshots in plan"
+	}
+	return ""
+}
+
+func skipReviewDetails(p *loadedPlan) string {
+	if p.ReviewDetails == nil {
+		return "no reviewDetails in plan"
+	}
+	return ""
+}
+
+func skipPhasedRelease(p *loadedPlan) string {
+	if p.release != releasePhased {
+		return "release is " + p.release
+	}
+	return ""
+}
+
+func skipValidate(p *loadedPlan) string {
+	if p.Validate.Skip {
+		return "validate.skip is set"
+	}
+	return ""
+}
+
+func skipSubmit(p *loadedPlan) string {
+	if !p.submits() {
+		return "submit is false"
+	}
+	return ""
+}
+
+func skipWaitForReview(p *loadedPlan) string {
+	if !p.submits() {
+		return "submit is false"
+	}
+	if !p.waitsForReview() {
+		return "review.wait is false"
+	}
+	return ""
+}
+
+func skipRelease(p *loadedPlan) string {
+	if !p.submits() {
+		return "submit is false"
+	}
+	if p.release == releaseAfterApproval || p.release == releaseNone {
+		return "release is " + p.release
+	}
+	return ""
+}
+
+func runVersion(ctx context.Context, r *runner) (string, error) {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	version, err := r.client.FindOrCreateAppStoreVersion(requestCtx, r.state.AppID, r.plan.Version, asc.Platform(r.plan.platform))
+	if err != nil {
+		return "", err
+	}
+	r.state.VersionID = version.Data.ID
+
+	releaseType := r.plan.releaseType()
+	if _, err := r.client.UpdateAppStoreVersion(requestCtx, version.Data.ID, asc.AppStoreVersionUpdateAttributes{ReleaseType: &releaseType}); err != nil {
+		return "", fmt.Errorf("set release type: %w", err)
+	}
+	return fmt.Sprintf("version %s (%s), release type %s", r.plan.Version, version.Data.ID, releaseType), nil
+}
+
+func runBuild(ctx context.Context, r *runner) (string, error) {
+	requestCtx, cancel := shared.ContextWithTimeoutDuration(ctx, asc.ResolveTimeoutWithDefault(buildTimeout))
+	defer cancel()
+
+	build := r.plan.Build
+	var (
+		buildID string
+		message string
+	)
+	switch {
+	case strings.TrimSpace(build.ID) != "":
+		buildID = strings.TrimSpace(build.ID)
+		message = "build " + buildID
+	case strings.TrimSpace(build.Number) != "":
+		number := strings.TrimSpace(build.Number)
+		found, err := shared.FindBuildByNumber(requestCtx, r.client, r.state.AppID, r.plan.Version, number, r.plan.platform)
+		if err != nil {
+			return "", err
+		}
+		if found == nil {
+			return "", fmt.Errorf("no build %s found for version %s", number, r.plan.Version)
+		}
+		buildID = found.Data.ID
+		message = fmt.Sprintf("build %s (%s)", number, buildID)
+	default:
+		version, number, err := publish.ResolveBundleInfo(build.IPA, r.plan.Version, "")
+		if err != nil {
+			return "", err
+		}
+		// A rerun after a failure past the upload must not upload the same
+		// build number again.
+		found, err := shared.FindBuildByNumber(requestCtx, r.client, r.state.AppID, version, number, r.plan.platform)
+		if err != nil {
+			return "", err
+		}
+		if found != nil {
+			buildID = found.Data.ID
+			message = fmt.Sprintf("build %s already uploaded (%s)", number, buildID)
+			break
+		}
+		if !build.SkipLint {
+			if err := shared.LintIPABeforeUpload(requestCtx, r.client, r.state.AppID, build.IPA); err != nil {
+				return "", err
+			}
+		}
+		uploaded, err := publish.UploadBuild(requestCtx, r.client, r.state.AppID, build.IPA, version, number, asc.Platform(r.plan.platform), r.pollInterval, 0)
+		if err != nil {
+			return "", err
+		}
+		buildID = uploaded.Data.ID
+		message = fmt.Sprintf("uploaded build %s (%s)", number, buildID)
+	}
+	r.state.BuildID = buildID
+	if err := r.save(); err != nil {
+		return "", err
+	}
+
+	if _, err := r.client.WaitForBuildProcessing(requestCtx, buildID, r.pollInterval); err != nil {
+		return "", err
+	}
+	if err := r.client.AttachBuildToVersion(requestCtx, r.state.VersionID, buildID); err != nil {
+		return "", fmt.Errorf("attach build: %w", err)
+	}
+	return message + ", attached", nil
+}
+
+func runMetadata(ctx context.Context, r *runner) (string, error) {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	planned, applied, err := manifest.Apply(requestCtx, r.client, r.state.AppID, r.state.VersionID, r.plan.metadata)
+	if err != nil {
+		return "", fmt.Errorf("%s: applied %d of %d changes: %w", filepath.Base(r.plan.Metadata), applied, planned, err)
+	}
+	return fmt.Sprintf("applied %d change(s) from %s", applied, filepath.Base(r.plan.Metadata)), nil
+}
+
+func runScreenshots(ctx context.Context, r *runner) (string, error) {
+	files := make([][]string, len(r.plan.Screenshots))
+	for i, shots := range r.plan.Screenshots {
+		collected, err := assets.CollectAssetFiles(shots.Path)
+		if err != nil {
+			return "", err
+		}
+		for _, file := range collected {
+			if err := asc.ValidateScreenshotDimensions(file, shots.DisplayType); err != nil {
+				return "", err
+			}
+		}
+		files[i] = collected
+	}
+
+	uploadCtx, cancel := assets.ContextWithAssetUploadTimeout(ctx)
+	defer cancel()
+
+	localizations, err := r.client.GetAppStoreVersionLocalizations(uploadCtx, r.state.VersionID, asc.WithAppStoreVersionLocalizationsLimit(200))
+	if err != nil {
+		return "", fmt.Errorf("fetch localizations: %w", err)
+	}
+	localeToID := make(map[string]string, len(localizations.Data))
+	for _, loc := range localizations.Data {
+		localeToID[loc.Attributes.Locale] = loc.ID
+	}
+
+	uploaded, skipped := 0, 0
+	for i, shots := range r.plan.Screenshots {
+		localizationID := localeToID[shots.Locale]
+		if localizationID == "" {
+			created, err := r.client.CreateAppStoreVersionLocalization(uploadCtx, r.state.VersionID, asc.AppStoreVersionLocalizationAttributes{Locale: shots.Locale})
+			if err != nil {
+				return "", fmt.Errorf("create %s localization: %w", shots.Locale, err)
+			}
+			localizationID = created.Data.ID
+			localeToID[shots.Locale] = localizationID
+		}
+
+		setID, existing, err := screenshotSet(uploadCtx, r.client, localizationID, shots.DisplayType)
+		if err != nil {
+			return "", fmt.Errorf("%s %s: %w", shots.Locale, shots.DisplayType, err)
+		}
+		// Screenshots already delivered under the same file name were uploaded
+		// by an earlier run. Ones that never finished delivering are replaced.
+		for _, file := range files[i] {
+			name := filepath.Base(file)
+			for _, id := range existing[name].incomplete {
+				if err := r.client.DeleteAppScreenshot(uploadCtx, id); err != nil && !asc.IsNotFound(err) {
+					return "", fmt.Errorf("delete incomplete %s: %w", name, err)
+				}
+			}
+			if existing[name].complete {
+				skipped++
+				continue
+			}
+			if _, err := assets.UploadScreenshotAsset(uploadCtx, r.client, setID, file); err != nil {
+				return "", fmt.Errorf("upload %s: %w", file, err)
+			}
+			uploaded++
+		}
+	}
+	return fmt.Sprintf("uploaded %d screenshot(s), %d already present", uploaded, skipped), nil
+}
+
+// existingScreenshots records the screenshots in a set sharing a file name.
+type existingScreenshots struct {
+	complete   bool
+	incomplete []string
+}
+
+// screenshotSet finds or creates the screenshot set for a display type and
+// returns the screenshots already in it by file name.
+func screenshotSet(ctx context.Context, client *asc.Client, localizationID, displayType string) (string, map[string]existingScreenshots, error) {
+	sets, err := client.GetAppScreenshotSets(ctx, localizationID)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, set := range sets.Data {
+		if !strings.EqualFold(set.Attributes.ScreenshotDisplayType, displayType) {
+			continue
+		}
+		screenshots, err := client.GetAppScreenshots(ctx, set.ID)
+		if err != nil {
+			return "", nil, err
+		}
+		existing := make(map[string]existingScreenshots, len(screenshots.Data))
+		for _, shot := range screenshots.Data {
+			name := strings.TrimSpace(shot.Attributes.FileName)
+			if name == "" {
+				continue
+			}
+			entry := existing[name]
+			if state := shot.Attributes.AssetDeliveryState; state != nil && strings.EqualFold(state.State, "COMPLETE") {
+				entry.complete = true
+			} else {
+				entry.incomplete = append(entry.incomplete, shot.ID)
+			}
+			existing[name] = entry
+		}
+		return set.ID, existing, nil
+	}
+	created, err := client.CreateAppScreenshotSet(ctx, localizationID, displayType)
+	if err != nil {
+		return "", nil, err
+	}
+	return created.Data.ID, map[string]existingScreenshots{}, nil
+}
+
+func runReviewDetails(ctx context.Context, r *runner) (string, error) {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	details := &manifest.Manifest{ReviewDetails: r.plan.ReviewDetails}
+	_, applied, err := manifest.Apply(requestCtx, r.client, r.state.AppID, r.state.VersionID, details)
+	if err != nil {
+		return "", err
+	}
+	if applied == 0 {
+		return "review details up to date", nil
+	}
+	return fmt.Sprintf("updated %d review detail change(s)", applied), nil
+}
+
+func runPhasedRelease(ctx context.Context, r *runner) (string, error) {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	existing, err := r.client.GetAppStoreVersionPhasedRelease(requestCtx, r.state.VersionID)
+	if err != nil && !asc.IsNotFound(err) {
+		return "", err
+	}
+	if err == nil && existing.Data.ID != "" {
+		return fmt.Sprintf("phased release %s already configured", existing.Data.ID), nil
+	}
+
+	// An inactive phased release starts when the version is released.
+	created, err := r.client.CreateAppStoreVersionPhasedRelease(requestCtx, r.state.VersionID, asc.PhasedReleaseStateInactive)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("phased release %s configured", created.Data.ID), nil
+}
+
+func runValidate(ctx context.Context, r *runner) (string, error) {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	report, err := validate.CheckVersion(requestCtx, r.client, r.state.AppID, r.state.VersionID, r.plan.platform, r.plan.Validate.Strict)
+	if err != nil {
+		return "", err
+	}
+	summary := fmt.Sprintf("%d error(s), %d warning(s)", report.Summary.Errors, report.Summary.Warnings)
+	if report.Summary.Blocking > 0 {
+		return "", fmt.Errorf("%d blocking issue(s) (%s); see asc validate --app %q --version-id %q", report.Summary.Blocking, summary, r.state.AppID, r.state.VersionID)
+	}
+	return summary, nil
+}
+
+func runSubmit(ctx context.Context, r *runner) (string, error) {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	state, err := versionState(requestCtx, r.client, r.state.VersionID)
+	if err != nil {
+		return "", err
+	}
+	if !slices.Contains(submittableStates, state) {
+		return fmt.Sprintf("already submitted (version is %s)", state), nil
+	}
+
+	// Reuse the submission from an earlier run so a failed add or submit
+	// doesn't leave a second draft submission behind.
+	submissionID := r.state.SubmissionID
+	added := false
+	if submissionID != "" {
+		added, err = submissionHasVersion(requestCtx, r.client, submissionID, r.state.VersionID)
+		if err != nil {
+			return "", fmt.Errorf("list submission items: %w", err)
+		}
+	} else {
+		submission, err := r.client.CreateReviewSubmission(requestCtx, r.state.AppID, asc.Platform(r.plan.platform))
+		if err != nil {
+			return "", fmt.Errorf("create review submission: %w", err)
+		}
+		submissionID = submission.Data.ID
+		r.state.SubmissionID = submissionID
+		if err := r.save(); err != nil {
+			return "", err
+		}
+	}
+
+	if !added {
+		if _, err := r.client.AddReviewSubmissionItem(requestCtx, submissionID, r.state.VersionID); err != nil {
+			return "", fmt.Errorf("add version to submission: %w", err)
+		}
+	}
+	if _, err := r.client.SubmitReviewSubmission(requestCtx, submissionID); err != nil {
+		return "", fmt.Errorf("submit for review: %w", err)
+	}
+	return fmt.Sprintf("submitted for review (%s)", submissionID), nil
+}
+
+func submissionHasVersion(ctx context.Context, client *asc.Client, submissionID, versionID string) (bool, error) {
+	items, err := client.GetReviewSubmissionItems(ctx, submissionID, asc.WithReviewSubmissionItemsLimit(200))
+	if err != nil {
+		return false, err
+	}
+	for _, item := range items.Data {
+		if item.Relationships != nil && item.Relationships.AppStoreVersion != nil &&
+			item.Relationships.AppStoreVersion.Data.ID == versionID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func runWaitForReview(ctx context.Context, r *runner) (string, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, r.plan.reviewTimeout)
+	defer cancel()
+
+	last := ""
+	state, err := asc.PollUntil(waitCtx, r.plan.reviewPollInterval, func(ctx context.Context) (string, bool, error) {
+		state, err := versionState(ctx, r.client, r.state.VersionID)
+		if err != nil {
+			return "", false, err
+		}
+		if state != last {
+			r.progressf("wait-for-review: version is %s", state)
+			last = state
+		}
+		if slices.Contains(rejectedStates, state) {
+			return "", false, fmt.Errorf("review ended with version %s", state)
+		}
+		return state, slices.Contains(approvedStates, state), nil
+	})
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			return "", fmt.Errorf("version still %s after %s; rerun to keep waiting", last, r.plan.reviewTimeout)
+		}
+		return "", err
+	}
+	return "approved (version is " + state + ")", nil
+}
+
+func runRelease(ctx context.Context, r *runner) (string, error) {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	state, err := versionState(requestCtx, r.client, r.state.VersionID)
+	if err != nil {
+		return "", err
+	}
+	if slices.Contains(releasedStates, state) {
+		return "already released (version is " + state + ")", nil
+	}
+	if state != "PENDING_DEVELOPER_RELEASE" {
+		return "", fmt.Errorf("version is %s; it can be released once review approves it", state)
+	}
+
+	if _, err := r.client.CreateAppStoreVersionReleaseRequest(requestCtx, r.state.VersionID); err != nil {
+		return "", err
+	}
+	if r.plan.release == releasePhased {
+		return "phased release started", nil
+	}
+	return "released", nil
+}
+
+func versionState(ctx context.Context, client *asc.Client, versionID string) (string, error) {
+	version, err := client.GetAppStoreVersion(ctx, versionID)
+	if err != nil {
+		return "", fmt.Errorf("fetch version: %w", err)
+	}
+	return shared.ResolveAppStoreVersionState(version.Data.Attributes), nil
+}
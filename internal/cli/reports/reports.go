@@ -0,0 +1,34 @@
+package reports
+
+import (
+	"context"
+	"flag"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// ReportsCommand returns the reports command group.
+func ReportsCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("reports", flag.ExitOnError)
+
+	return &ffcli.Command{
+		Name:       "reports",
+		ShortUsage: "asc reports <subcommand> [flags]",
+		ShortHelp:  "Keep a local SQLite warehouse of analytics, sales and finance reports.",
+		LongHelp: `Keep a local SQLite warehouse of analytics, sales and finance reports.
+
+Examples:
+  asc reports sync --db reports.sqlite --vendor "12345678" --sales
+  asc reports sync --db reports.sqlite --analytics-request "REQUEST_ID" --since 2024-01-01`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			ReportsSyncCommand(),
+		},
+		Exec: func(ctx context.Context, args []string) error {
+			return flag.ErrHelp
+		},
+	}
+}
@@ -0,0 +1,476 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/analytics"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/reportstore"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/salesreports"
+)
+
+const defaultSyncDays = 30
+
+// syncClient is the subset of the App Store Connect client used by sync.
+type syncClient interface {
+	analytics.ReportClient
+	DownloadAnalyticsReport(ctx context.Context, downloadURL string) (*asc.ReportDownload, error)
+	GetSalesReport(ctx context.Context, params asc.SalesReportParams) (*asc.ReportDownload, error)
+	DownloadFinanceReport(ctx context.Context, params asc.FinanceReportParams) (*asc.ReportDownload, error)
+}
+
+type syncOptions struct {
+	VendorNumber      string
+	AnalyticsRequests []string
+	Sales             bool
+	FinanceRegions    []string
+	Since             time.Time
+	Now               time.Time
+}
+
+type syncResult struct {
+	Database string         `json:"database"`
+	Since    string         `json:"since"`
+	Sources  []sourceResult `json:"sources"`
+}
+
+type sourceResult struct {
+	Source      string `json:"source"`
+	Synced      int    `json:"synced"`
+	Skipped     int    `json:"skipped"`
+	Unavailable int    `json:"unavailable"`
+	Rows        int    `json:"rows"`
+}
+
+// ReportsSyncCommand downloads new reports into a SQLite database.
+func ReportsSyncCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("sync", flag.ExitOnError)
+
+	dbPath := fs.String("db", "", "SQLite database path (created if missing)")
+	vendor := fs.String("vendor", "", "Vendor number for sales and finance reports (or ASC_VENDOR_NUMBER env)")
+	analyticsRequests := fs.String("analytics-request", "", "Analytics report request ID(s), comma-separated")
+	sales := fs.Bool("sales", false, "Sync daily SALES SUMMARY reports")
+	financeRegions := fs.String("finance-region", "", "Finance region code(s), comma-separated, or \"all\" (see 'asc finance regions')")
+	since := fs.String("since", "", "Earliest report date to sync (YYYY-MM-DD, default: 30 days ago)")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "sync",
+		ShortUsage: "asc reports sync --db PATH [flags]",
+		ShortHelp:  "Download new analytics, sales and finance reports into SQLite.",
+		LongHelp: `Download new analytics, sales and finance reports into SQLite.
+
+Each report is stored in one transaction and recorded in the sync_log table,
+keyed by analytics instance ID, or by vendor number and sales report date or
+finance report month and region. Reports already in the database are skipped, so sync can run on a
+schedule and only downloads what is new. Reports that are not available yet
+(404) are reported as unavailable and retried on the next sync.
+
+Sources:
+  --analytics-request  Every instance of every report in the request with a
+                       report date on or after --since. Rows are stored in
+                       analytics_rows as JSON keyed by column name.
+  --sales              Daily SALES SUMMARY reports from --since to yesterday,
+                       stored in sales_report_rows.
+  --finance-region     Monthly finance reports from the month of --since to
+                       last month, stored in finance_report_rows with their
+                       exchange rates in exchange_rates. Z1 downloads
+                       FINANCE_DETAIL; other regions download FINANCIAL.
+
+Examples:
+  asc reports sync --db reports.sqlite --vendor "12345678" --sales
+  asc reports sync --db reports.sqlite --vendor "12345678" --finance-region ZZ --since 2024-01-01
+  asc reports sync --db reports.sqlite --analytics-request "REQUEST_ID" --output table
+  sqlite3 reports.sqlite "SELECT sku, SUM(units) FROM sales_report_rows GROUP BY sku"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if strings.TrimSpace(*dbPath) == "" {
+				fmt.Fprintln(os.Stderr, "Error: --db is required")
+				return flag.ErrHelp
+			}
+			requestIDs := shared.SplitCSV(*analyticsRequests)
+			regions, err := normalizeFinanceRegions(shared.SplitCSV(*financeRegions))
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+			if len(requestIDs) == 0 && !*sales && len(regions) == 0 {
+				return shared.UsageError("at least one of --analytics-request, --sales or --finance-region is required")
+			}
+			vendorNumber := ""
+			if *sales || len(regions) > 0 {
+				vendorNumber = shared.ResolveVendorNumber(*vendor)
+				if vendorNumber == "" {
+					return shared.UsageError("--vendor is required for --sales and --finance-region (or set ASC_VENDOR_NUMBER)")
+				}
+			}
+
+			now := time.Now().UTC()
+			sinceDate := truncateDay(now).AddDate(0, 0, -defaultSyncDays)
+			if strings.TrimSpace(*since) != "" {
+				normalized, err := shared.NormalizeDate(*since, "--since")
+				if err != nil {
+					return shared.UsageError(err.Error())
+				}
+				if sinceDate, err = time.Parse("2006-01-02", normalized); err != nil {
+					return shared.UsageError(err.Error())
+				}
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("reports sync: %w", err)
+			}
+
+			store, err := reportstore.Open(ctx, *dbPath)
+			if err != nil {
+				return fmt.Errorf("reports sync: %w", err)
+			}
+			defer store.Close()
+
+			result, err := syncReports(ctx, client, store, syncOptions{
+				VendorNumber:      vendorNumber,
+				AnalyticsRequests: requestIDs,
+				Sales:             *sales,
+				FinanceRegions:    regions,
+				Since:             sinceDate,
+				Now:               now,
+			})
+			if err != nil {
+				return fmt.Errorf("reports sync: %w", err)
+			}
+			result.Database = strings.TrimSpace(*dbPath)
+
+			return shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderSyncResult(result, asc.RenderTable) },
+				func() error { return renderSyncResult(result, asc.RenderMarkdown) },
+			)
+		},
+	}
+}
+
+func normalizeFinanceRegions(values []string) ([]string, error) {
+	known := asc.FinanceRegions()
+	allowed := make(map[string]struct{}, len(known))
+	for _, region := range known {
+		allowed[region.RegionCode] = struct{}{}
+	}
+
+	regions := make([]string, 0, len(values))
+	seen := make(map[string]struct{}, len(values))
+	add := func(code string) {
+		if _, ok := seen[code]; ok {
+			return
+		}
+		seen[code] = struct{}{}
+		regions = append(regions, code)
+	}
+	for _, value := range values {
+		code := strings.ToUpper(strings.TrimSpace(value))
+		if code == "ALL" {
+			for _, region := range known {
+				add(region.RegionCode)
+			}
+			continue
+		}
+		if _, ok := allowed[code]; !ok {
+			return nil, fmt.Errorf("--finance-region %q is not a known region code (see 'asc finance regions')", value)
+		}
+		add(code)
+	}
+	return regions, nil
+}
+
+func syncReports(ctx context.Context, client syncClient, store *reportstore.Store, opts syncOptions) (*syncResult, error) {
+	result := &syncResult{Since: opts.Since.Format("2006-01-02"), Sources: make([]sourceResult, 0, 3)}
+
+	if len(opts.AnalyticsRequests) > 0 {
+		source, err := syncAnalytics(ctx, client, store, opts)
+		if err != nil {
+			return nil, err
+		}
+		result.Sources = append(result.Sources, source)
+	}
+	if opts.Sales {
+		source, err := syncSales(ctx, client, store, opts)
+		if err != nil {
+			return nil, err
+		}
+		result.Sources = append(result.Sources, source)
+	}
+	if len(opts.FinanceRegions) > 0 {
+		source, err := syncFinance(ctx, client, store, opts)
+		if err != nil {
+			return nil, err
+		}
+		result.Sources = append(result.Sources, source)
+	}
+	return result, nil
+}
+
+func syncAnalytics(ctx context.Context, client syncClient, store *reportstore.Store, opts syncOptions) (sourceResult, error) {
+	result := sourceResult{Source: reportstore.SourceAnalytics}
+	since := opts.Since.Format("2006-01-02")
+
+	for _, requestID := range opts.AnalyticsRequests {
+		requestCtx, cancel := shared.ContextWithTimeout(ctx)
+		reports, err := analytics.FetchReports(requestCtx, client, requestID)
+		cancel()
+		if err != nil {
+			return result, fmt.Errorf("fetch analytics reports for %s: %w", requestID, err)
+		}
+
+		for _, report := range reports {
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			instances, err := analytics.FetchReportInstances(requestCtx, client, report.ID)
+			cancel()
+			if err != nil {
+				return result, fmt.Errorf("fetch instances for analytics report %s: %w", report.ID, err)
+			}
+
+			for _, instance := range instances {
+				reportDate := strings.TrimSpace(instance.Attributes.ReportDate)
+				if reportDate != "" && reportDate < since {
+					continue
+				}
+				synced, err := store.Synced(ctx, reportstore.SourceAnalytics, "", instance.ID)
+				if err != nil {
+					return result, err
+				}
+				if synced {
+					result.Skipped++
+					continue
+				}
+
+				stored := reportstore.AnalyticsInstance{
+					RequestID:      requestID,
+					ReportID:       report.ID,
+					ReportName:     report.Attributes.Name,
+					Category:       report.Attributes.Category,
+					InstanceID:     instance.ID,
+					ReportDate:     reportDate,
+					ProcessingDate: instance.Attributes.ProcessingDate,
+					Granularity:    instance.Attributes.Granularity,
+				}
+				segments, err := downloadAnalyticsSegments(ctx, client, instance.ID)
+				if err != nil {
+					return result, err
+				}
+				if len(segments) == 0 {
+					result.Unavailable++
+					continue
+				}
+				stored.Segments = segments
+				if err := store.SaveAnalyticsInstance(ctx, stored); err != nil {
+					return result, err
+				}
+				result.Synced++
+				for _, segment := range segments {
+					result.Rows += len(segment.Records)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+func downloadAnalyticsSegments(ctx context.Context, client syncClient, instanceID string) ([]reportstore.AnalyticsSegment, error) {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	segments, err := analytics.FetchReportSegments(requestCtx, client, instanceID)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("fetch segments for analytics instance %s: %w", instanceID, err)
+	}
+
+	parsed := make([]reportstore.AnalyticsSegment, 0, len(segments))
+	for _, segment := range segments {
+		downloadURL := strings.TrimSpace(segment.Attributes.URL)
+		if downloadURL == "" {
+			return nil, fmt.Errorf("analytics segment %s has no download URL", segment.ID)
+		}
+		// Each segment gets its own timeout; large instances can have many.
+		requestCtx, cancel := shared.ContextWithTimeout(ctx)
+		download, err := client.DownloadAnalyticsReport(requestCtx, downloadURL)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("download analytics segment %s: %w", segment.ID, err)
+		}
+		stored, err := readReport(download, reportstore.ParseSegment)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("analytics segment %s: %w", segment.ID, err)
+		}
+		stored.SegmentID = segment.ID
+		stored.Checksum = segment.Attributes.Checksum
+		parsed = append(parsed, stored)
+	}
+	return parsed, nil
+}
+
+func syncSales(ctx context.Context, client syncClient, store *reportstore.Store, opts syncOptions) (sourceResult, error) {
+	result := sourceResult{Source: reportstore.SourceSales}
+
+	last := truncateDay(opts.Now).AddDate(0, 0, -1)
+	for day := truncateDay(opts.Since); !day.After(last); day = day.AddDate(0, 0, 1) {
+		reportDate := day.Format("2006-01-02")
+		report := reportstore.SalesReport{
+			Key:           strings.Join([]string{opts.VendorNumber, string(asc.SalesReportTypeSales), string(asc.SalesReportSubTypeSummary), string(asc.SalesReportFrequencyDaily), reportDate}, "/"),
+			VendorNumber:  opts.VendorNumber,
+			ReportType:    string(asc.SalesReportTypeSales),
+			ReportSubType: string(asc.SalesReportSubTypeSummary),
+			Frequency:     string(asc.SalesReportFrequencyDaily),
+			ReportDate:    reportDate,
+		}
+		synced, err := store.Synced(ctx, reportstore.SourceSales, report.VendorNumber, report.Key)
+		if err != nil {
+			return result, err
+		}
+		if synced {
+			result.Skipped++
+			continue
+		}
+
+		requestCtx, cancel := shared.ContextWithTimeout(ctx)
+		download, err := client.GetSalesReport(requestCtx, asc.SalesReportParams{
+			VendorNumber:  opts.VendorNumber,
+			ReportType:    asc.SalesReportTypeSales,
+			ReportSubType: asc.SalesReportSubTypeSummary,
+			Frequency:     asc.SalesReportFrequencyDaily,
+			ReportDate:    reportDate,
+			Version:       asc.SalesReportVersion1_0,
+		})
+		if err != nil {
+			cancel()
+			if asc.IsNotFound(err) {
+				result.Unavailable++
+				continue
+			}
+			return result, fmt.Errorf("download sales report %s: %w", reportDate, err)
+		}
+		report.Rows, err = readReport(download, salesreports.ParseSales)
+		cancel()
+		if err != nil {
+			return result, fmt.Errorf("sales report %s: %w", reportDate, err)
+		}
+		if err := store.SaveSalesReport(ctx, report); err != nil {
+			return result, err
+		}
+		result.Synced++
+		result.Rows += len(report.Rows)
+	}
+	return result, nil
+}
+
+func syncFinance(ctx context.Context, client syncClient, store *reportstore.Store, opts syncOptions) (sourceResult, error) {
+	result := sourceResult{Source: reportstore.SourceFinance}
+
+	first := time.Date(opts.Since.Year(), opts.Since.Month(), 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(opts.Now.Year(), opts.Now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+	for month := first; !month.After(last); month = month.AddDate(0, 1, 0) {
+		reportDate := month.Format("2006-01")
+		for _, region := range opts.FinanceRegions {
+			reportType := asc.FinanceReportTypeFinancial
+			if region == "Z1" {
+				reportType = asc.FinanceReportTypeFinanceDetail
+			}
+			report := reportstore.FinanceReport{
+				Key:          strings.Join([]string{opts.VendorNumber, string(reportType), region, reportDate}, "/"),
+				VendorNumber: opts.VendorNumber,
+				ReportType:   string(reportType),
+				RegionCode:   region,
+				ReportDate:   reportDate,
+			}
+			synced, err := store.Synced(ctx, reportstore.SourceFinance, report.VendorNumber, report.Key)
+			if err != nil {
+				return result, err
+			}
+			if synced {
+				result.Skipped++
+				continue
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			download, err := client.DownloadFinanceReport(requestCtx, asc.FinanceReportParams{
+				VendorNumber: opts.VendorNumber,
+				ReportType:   reportType,
+				RegionCode:   region,
+				ReportDate:   reportDate,
+			})
+			if err != nil {
+				cancel()
+				if asc.IsNotFound(err) {
+					result.Unavailable++
+					continue
+				}
+				return result, fmt.Errorf("download finance report %s: %w", report.Key, err)
+			}
+			content, err := readReport(download, io.ReadAll)
+			cancel()
+			if err != nil {
+				return result, fmt.Errorf("finance report %s: %w", report.Key, err)
+			}
+			if report.Rows, err = salesreports.ParseFinance(bytes.NewReader(content)); err != nil {
+				return result, fmt.Errorf("finance report %s: %w", report.Key, err)
+			}
+			// Single-region reports carry no exchange rate block.
+			if rates, err := salesreports.ParseExchangeRates(bytes.NewReader(content)); err == nil {
+				report.Rates = rates
+			}
+			if err := store.SaveFinanceReport(ctx, report); err != nil {
+				return result, err
+			}
+			result.Synced++
+			result.Rows += len(report.Rows)
+		}
+	}
+	return result, nil
+}
+
+// readReport inflates a downloaded report and parses it, closing the body.
+func readReport[T any](download *asc.ReportDownload, parse func(io.Reader) (T, error)) (T, error) {
+	defer download.Body.Close()
+	var zero T
+	reader, err := salesreports.NewReader(download.Body)
+	if err != nil {
+		return zero, err
+	}
+	defer reader.Close()
+	return parse(reader)
+}
+
+func truncateDay(value time.Time) time.Time {
+	return time.Date(value.Year(), value.Month(), value.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func renderSyncResult(result *syncResult, render func([]string, [][]string)) error {
+	if result == nil {
+		return fmt.Errorf("sync result is nil")
+	}
+	headers := []string{"Source", "Synced", "Skipped", "Unavailable", "Rows"}
+	rows := make([][]string, 0, len(result.Sources))
+	for _, source := range result.Sources {
+		rows = append(rows, []string{
+			source.Source,
+			strconv.Itoa(source.Synced),
+			strconv.Itoa(source.Skipped),
+			strconv.Itoa(source.Unavailable),
+			strconv.Itoa(source.Rows),
+		})
+	}
+	render(headers, rows)
+	return nil
+}
@@ -0,0 +1,158 @@
+package reports
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/reportstore"
+)
+
+const (
+	testSalesReport = "Provider\tSKU\tTitle\tUnits\tDeveloper Proceeds\tBegin Date\tEnd Date\tCustomer Currency\tCountry Code\tCurrency of Proceeds\tCustomer Price\n" +
+		"APPLE\tcom.example.app\tExample App\t3\t0.70\t01/20/2024\t01/20/2024\tUSD\tUS\tUSD\t0.99\n"
+	testFinanceReport = "Start Date\tEnd Date\tVendor Identifier\tQuantity\tPartner Share\tPartner Share Currency\tCountry Of Sale\n" +
+		"12/31/2023\t02/03/2024\tcom.example.app\t4\t0.70\tEUR\tDE\n" +
+		"Total_Rows\t1\n" +
+		"\n" +
+		"Country Of Sale\tPartner Share Currency\tExchange Rate\tBank Account Currency\n" +
+		"Euro-Zone\tEUR\t1.085000\tUSD\n"
+	testSegment = "Date\tApp Name\tCounts\n2024-01-20\tExample\t12\n2024-01-21\tExample\t7\n"
+)
+
+type stubSyncClient struct {
+	syncClient
+	downloads int
+}
+
+func (s *stubSyncClient) GetAnalyticsReports(ctx context.Context, requestID string, opts ...asc.AnalyticsReportsOption) (*asc.AnalyticsReportsResponse, error) {
+	return &asc.AnalyticsReportsResponse{Data: []asc.Resource[asc.AnalyticsReportAttributes]{
+		{ID: "report-1", Attributes: asc.AnalyticsReportAttributes{Name: "App Downloads Standard", Category: "COMMERCE"}},
+	}}, nil
+}
+
+func (s *stubSyncClient) GetAnalyticsReportInstances(ctx context.Context, reportID string, opts ...asc.AnalyticsReportInstancesOption) (*asc.AnalyticsReportInstancesResponse, error) {
+	return &asc.AnalyticsReportInstancesResponse{Data: []asc.Resource[asc.AnalyticsReportInstanceAttributes]{
+		{ID: "instance-old", Attributes: asc.AnalyticsReportInstanceAttributes{ReportDate: "2023-12-01", Granularity: "DAILY"}},
+		{ID: "instance-new", Attributes: asc.AnalyticsReportInstanceAttributes{ReportDate: "2024-01-20", Granularity: "DAILY"}},
+	}}, nil
+}
+
+func (s *stubSyncClient) GetAnalyticsReportSegments(ctx context.Context, instanceID string, opts ...asc.AnalyticsReportSegmentsOption) (*asc.AnalyticsReportSegmentsResponse, error) {
+	return &asc.AnalyticsReportSegmentsResponse{Data: []asc.Resource[asc.AnalyticsReportSegmentAttributes]{
+		{ID: "segment-" + instanceID, Attributes: asc.AnalyticsReportSegmentAttributes{URL: "https://example.com/" + instanceID}},
+	}}, nil
+}
+
+func (s *stubSyncClient) DownloadAnalyticsReport(ctx context.Context, downloadURL string) (*asc.ReportDownload, error) {
+	s.downloads++
+	return gzipDownload(testSegment), nil
+}
+
+func (s *stubSyncClient) GetSalesReport(ctx context.Context, params asc.SalesReportParams) (*asc.ReportDownload, error) {
+	s.downloads++
+	if params.ReportDate != "2024-01-20" {
+		return nil, &asc.APIError{Code: "NOT_FOUND", StatusCode: 404}
+	}
+	return gzipDownload(testSalesReport), nil
+}
+
+func (s *stubSyncClient) DownloadFinanceReport(ctx context.Context, params asc.FinanceReportParams) (*asc.ReportDownload, error) {
+	s.downloads++
+	return gzipDownload(testFinanceReport), nil
+}
+
+func gzipDownload(content string) *asc.ReportDownload {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, _ = gz.Write([]byte(content))
+	_ = gz.Close()
+	return &asc.ReportDownload{Body: io.NopCloser(&buf), ContentLength: int64(buf.Len())}
+}
+
+func TestSyncReportsIsIncremental(t *testing.T) {
+	ctx := context.Background()
+	store, err := reportstore.Open(ctx, filepath.Join(t.TempDir(), "reports.sqlite"))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer store.Close()
+
+	client := &stubSyncClient{}
+	opts := syncOptions{
+		VendorNumber:      "12345678",
+		AnalyticsRequests: []string{"request-1"},
+		Sales:             true,
+		FinanceRegions:    []string{"EU"},
+		Since:             time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+		Now:               time.Date(2024, 1, 22, 12, 0, 0, 0, time.UTC),
+	}
+
+	result, err := syncReports(ctx, client, store, opts)
+	if err != nil {
+		t.Fatalf("syncReports() error: %v", err)
+	}
+	want := []sourceResult{
+		{Source: "analytics", Synced: 1, Rows: 2},
+		{Source: "sales", Synced: 1, Unavailable: 21, Rows: 1},
+		{Source: "finance", Synced: 1, Rows: 1},
+	}
+	if len(result.Sources) != len(want) {
+		t.Fatalf("unexpected sources: %+v", result.Sources)
+	}
+	for i := range want {
+		if result.Sources[i] != want[i] {
+			t.Fatalf("source %d = %+v, want %+v", i, result.Sources[i], want[i])
+		}
+	}
+
+	var rate float64
+	if err := store.DB().QueryRowContext(ctx, `SELECT rate FROM exchange_rates WHERE vendor_number = '12345678' AND report_key = '12345678/FINANCIAL/EU/2023-12' AND currency = 'EUR'`).Scan(&rate); err != nil {
+		t.Fatalf("query exchange rate: %v", err)
+	}
+	if rate != 1.085 {
+		t.Fatalf("rate = %v, want 1.085", rate)
+	}
+
+	client.downloads = 0
+	result, err = syncReports(ctx, client, store, opts)
+	if err != nil {
+		t.Fatalf("second syncReports() error: %v", err)
+	}
+	if client.downloads != 21 {
+		t.Fatalf("expected only unavailable sales reports to be retried, got %d downloads", client.downloads)
+	}
+	for _, source := range result.Sources {
+		if source.Synced != 0 || source.Rows != 0 {
+			t.Fatalf("expected nothing new on second sync, got %+v", source)
+		}
+	}
+	if result.Sources[0].Skipped != 1 || result.Sources[1].Skipped != 1 || result.Sources[2].Skipped != 1 {
+		t.Fatalf("unexpected skipped counts: %+v", result.Sources)
+	}
+}
+
+func TestNormalizeFinanceRegions(t *testing.T) {
+	regions, err := normalizeFinanceRegions([]string{"us", "ZZ", "US"})
+	if err != nil {
+		t.Fatalf("normalizeFinanceRegions() error: %v", err)
+	}
+	if len(regions) != 2 || regions[0] != "US" || regions[1] != "ZZ" {
+		t.Fatalf("unexpected regions: %v", regions)
+	}
+	all, err := normalizeFinanceRegions([]string{"all"})
+	if err != nil {
+		t.Fatalf("normalizeFinanceRegions(all) error: %v", err)
+	}
+	if len(all) != len(asc.FinanceRegions()) {
+		t.Fatalf("expected every region, got %v", all)
+	}
+	if _, err := normalizeFinanceRegions([]string{"XX"}); err == nil {
+		t.Fatal("expected error for unknown region")
+	}
+}
@@ -0,0 +1,279 @@
+package schema
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// Document describes the asc command tree.
+type Document struct {
+	Name        string    `json:"name"`
+	Usage       string    `json:"usage"`
+	GlobalFlags []Flag    `json:"globalFlags"`
+	Commands    []Command `json:"commands"`
+}
+
+// Command describes one command. Commands are listed depth-first; group
+// commands name their subcommands and only leaf commands are Runnable.
+type Command struct {
+	Name          string   `json:"name"`
+	Path          []string `json:"path"`
+	ShortUsage    string   `json:"shortUsage,omitempty"`
+	ShortHelp     string   `json:"shortHelp,omitempty"`
+	Description   string   `json:"description,omitempty"`
+	Examples      []string `json:"examples,omitempty"`
+	Subcommands   []string `json:"subcommands,omitempty"`
+	Runnable      bool     `json:"runnable"`
+	Mutates       bool     `json:"mutates"`
+	Destructive   bool     `json:"destructive"`
+	OutputFlag    string   `json:"outputFlag,omitempty"`
+	OutputFormats []string `json:"outputFormats,omitempty"`
+	Flags         []Flag   `json:"flags"`
+}
+
+// Flag describes one flag. Shorthands such as -X are listed as aliases of
+// the flag they stand for.
+type Flag struct {
+	Name        string   `json:"name"`
+	Aliases     []string `json:"aliases,omitempty"`
+	Type        string   `json:"type"`
+	Default     string   `json:"default,omitempty"`
+	Required    bool     `json:"required"`
+	Description string   `json:"description"`
+}
+
+// SchemaCommand returns the schema command. root is the asc root command;
+// its tree is walked when the command runs.
+func SchemaCommand(root *ffcli.Command) *ffcli.Command {
+	fs := flag.NewFlagSet("schema", flag.ExitOnError)
+
+	command := fs.String("command", "", "Only describe this command and its subcommands (e.g. \"apps list\")")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "schema",
+		ShortUsage: "asc schema [flags]",
+		ShortHelp:  "Print a machine-readable description of every command.",
+		LongHelp: `Print a machine-readable description of every command.
+
+The schema lists the global flags and every command with its path, usage,
+help text, examples, subcommands and flags. Each flag has a type (string,
+boolean, integer, number, duration or array for repeatable flags), its
+default and whether it is always required. Commands report whether they
+mutate state or are destructive, and which output formats they support.
+
+Only commands on a read-only allowlist (list, get, view, info, status,
+download, ...) report mutates: false; every other command is treated as
+mutating. Commands with --confirm, delete/remove/revoke style commands,
+apply, testflight sync push and api (any --method) report destructive: true.
+Required-ness is inferred from "(required)" flag descriptions and the flags
+a command's usage line lists outside [brackets].
+
+Examples:
+  asc schema --pretty
+  asc schema --command "apps list" --pretty
+  asc --query '.commands[] | select(.mutates) | .name' schema
+  asc schema --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) > 0 {
+				return shared.UsageErrorf("unexpected arguments: %s", strings.Join(args, " "))
+			}
+
+			doc, err := Build(root, strings.Fields(*command))
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+
+			return shared.PrintOutputWithRenderers(
+				doc,
+				*output.Output,
+				*output.Pretty,
+				func() error { return printTable(doc) },
+				func() error { return printMarkdown(doc) },
+			)
+		},
+	}
+}
+
+// Build describes root. When path is non-empty only that command and its
+// subcommands are included.
+func Build(root *ffcli.Command, path []string) (*Document, error) {
+	doc := &Document{
+		Name:        root.Name,
+		Usage:       root.ShortUsage,
+		GlobalFlags: describeFlags(root),
+		Commands:    []Command{},
+	}
+
+	start := root.Subcommands
+	var parent []string
+	if len(path) > 0 {
+		cmd, err := findCommand(root, path)
+		if err != nil {
+			return nil, err
+		}
+		start = []*ffcli.Command{cmd}
+		parent = path[:len(path)-1]
+	}
+	for _, cmd := range start {
+		doc.Commands = appendCommand(doc.Commands, parent, cmd)
+	}
+	return doc, nil
+}
+
+func findCommand(root *ffcli.Command, path []string) (*ffcli.Command, error) {
+	current := root
+	for i, name := range path {
+		var next *ffcli.Command
+		for _, sub := range current.Subcommands {
+			if sub != nil && sub.Name == name {
+				next = sub
+				break
+			}
+		}
+		if next == nil {
+			return nil, fmt.Errorf("--command: unknown command %q", strings.Join(path[:i+1], " "))
+		}
+		current = next
+	}
+	return current, nil
+}
+
+func appendCommand(commands []Command, parent []string, cmd *ffcli.Command) []Command {
+	if cmd == nil {
+		return commands
+	}
+	path := append(append([]string{}, parent...), cmd.Name)
+	description, examples := splitLongHelp(cmd.ShortHelp, cmd.LongHelp)
+
+	entry := Command{
+		Name:        strings.Join(path, " "),
+		Path:        path,
+		ShortUsage:  strings.TrimSpace(cmd.ShortUsage),
+		ShortHelp:   strings.TrimSpace(cmd.ShortHelp),
+		Description: description,
+		Examples:    examples,
+		Runnable:    len(cmd.Subcommands) == 0 && cmd.Exec != nil,
+		Flags:       describeFlags(cmd),
+	}
+	for _, sub := range cmd.Subcommands {
+		if sub != nil {
+			entry.Subcommands = append(entry.Subcommands, sub.Name)
+		}
+	}
+	if entry.Runnable {
+		effects := shared.InferCommandEffects(cmd)
+		entry.Mutates = effects.Mutates
+		entry.Destructive = effects.Destructive
+		if output, ok := shared.CommandOutputFormat(cmd); ok {
+			entry.OutputFlag = output.Name
+			entry.OutputFormats = output.Formats
+		}
+	}
+
+	commands = append(commands, entry)
+	for _, sub := range cmd.Subcommands {
+		commands = appendCommand(commands, path, sub)
+	}
+	return commands
+}
+
+// splitLongHelp returns the long help without the repeated short help and
+// the trailing "Examples:" block, and the example command lines.
+func splitLongHelp(shortHelp, longHelp string) (string, []string) {
+	shortHelp = strings.TrimSpace(shortHelp)
+	longHelp = strings.TrimSpace(longHelp)
+	if shortHelp != "" {
+		longHelp = strings.TrimSpace(strings.TrimPrefix(longHelp, shortHelp))
+	}
+
+	var examples []string
+	if before, after, found := strings.Cut(longHelp, "Examples:"); found && (before == "" || strings.HasSuffix(before, "\n")) {
+		longHelp = strings.TrimSpace(before)
+		for _, line := range strings.Split(after, "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, "#") {
+				examples = append(examples, line)
+			}
+		}
+	}
+	return longHelp, examples
+}
+
+func describeFlags(cmd *ffcli.Command) []Flag {
+	flags := []Flag{}
+	if cmd.FlagSet == nil {
+		return flags
+	}
+	aliases := map[string][]string{}
+	cmd.FlagSet.VisitAll(func(f *flag.Flag) {
+		if target, ok := shared.ShorthandTarget(f); ok && cmd.FlagSet.Lookup(target) != nil {
+			aliases[target] = append(aliases[target], f.Name)
+		}
+	})
+	cmd.FlagSet.VisitAll(func(f *flag.Flag) {
+		if target, ok := shared.ShorthandTarget(f); ok && cmd.FlagSet.Lookup(target) != nil {
+			return
+		}
+		flags = append(flags, Flag{
+			Name:        f.Name,
+			Aliases:     aliases[f.Name],
+			Type:        shared.FlagType(f),
+			Default:     flagDefault(f),
+			Required:    shared.FlagRequired(cmd, f),
+			Description: f.Usage,
+		})
+	})
+	return flags
+}
+
+// flagDefault omits zero defaults so only meaningful ones are listed.
+func flagDefault(f *flag.Flag) string {
+	switch f.DefValue {
+	case "", "false", "0", "0s":
+		return ""
+	default:
+		return f.DefValue
+	}
+}
+
+func printTable(doc *Document) error {
+	headers, rows := commandRows(doc)
+	asc.RenderTable(headers, rows)
+	return nil
+}
+
+func printMarkdown(doc *Document) error {
+	headers, rows := commandRows(doc)
+	asc.RenderMarkdown(headers, rows)
+	return nil
+}
+
+func commandRows(doc *Document) ([]string, [][]string) {
+	headers := []string{"Command", "Mutates", "Destructive", "Flags", "Output Formats", "Summary"}
+	rows := make([][]string, 0, len(doc.Commands))
+	for _, cmd := range doc.Commands {
+		if !cmd.Runnable {
+			continue
+		}
+		rows = append(rows, []string{
+			cmd.Name,
+			strconv.FormatBool(cmd.Mutates),
+			strconv.FormatBool(cmd.Destructive),
+			strconv.Itoa(len(cmd.Flags)),
+			strings.Join(cmd.OutputFormats, ","),
+			cmd.ShortHelp,
+		})
+	}
+	return headers, rows
+}
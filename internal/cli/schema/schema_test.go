@@ -0,0 +1,115 @@
+package schema
+
+import (
+	"context"
+	"flag"
+	"reflect"
+	"testing"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+func testRoot() *ffcli.Command {
+	rootFS := flag.NewFlagSet("asc", flag.ContinueOnError)
+	rootFS.String("profile", "", "Use named authentication profile")
+
+	deleteFS := flag.NewFlagSet("widgets delete", flag.ContinueOnError)
+	id := deleteFS.String("id", "", "Widget ID")
+	deleteFS.StringVar(id, "i", "", "Shorthand for --id")
+	deleteFS.Bool("confirm", false, "Confirm deletion")
+	deleteFS.Int("retries", 3, "Retry count")
+	deleteFS.String("output", "json", "Output format: json (default), table")
+
+	exec := func(ctx context.Context, args []string) error { return nil }
+	return &ffcli.Command{
+		Name:       "asc",
+		ShortUsage: "asc <subcommand> [flags]",
+		FlagSet:    rootFS,
+		Subcommands: []*ffcli.Command{
+			{
+				Name:       "widgets",
+				ShortUsage: "asc widgets <subcommand> [flags]",
+				ShortHelp:  "Manage widgets.",
+				Exec:       exec,
+				Subcommands: []*ffcli.Command{
+					{
+						Name:       "delete",
+						ShortUsage: "asc widgets delete --id ID --confirm",
+						ShortHelp:  "Delete a widget.",
+						LongHelp: `Delete a widget.
+
+Deleted widgets cannot be restored.
+
+Examples:
+  asc widgets delete --id "W1" --confirm
+  # the shorthand works too
+  asc widgets delete -i "W1" --confirm`,
+						FlagSet: deleteFS,
+						Exec:    exec,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildDescribesCommands(t *testing.T) {
+	doc, err := Build(testRoot(), nil)
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	if len(doc.GlobalFlags) != 1 || doc.GlobalFlags[0].Name != "profile" {
+		t.Fatalf("unexpected global flags %+v", doc.GlobalFlags)
+	}
+	if len(doc.Commands) != 2 {
+		t.Fatalf("expected 2 commands, got %d", len(doc.Commands))
+	}
+
+	group := doc.Commands[0]
+	if group.Name != "widgets" || group.Runnable || !reflect.DeepEqual(group.Subcommands, []string{"delete"}) {
+		t.Fatalf("unexpected group %+v", group)
+	}
+
+	leaf := doc.Commands[1]
+	if leaf.Name != "widgets delete" || !reflect.DeepEqual(leaf.Path, []string{"widgets", "delete"}) || !leaf.Runnable {
+		t.Fatalf("unexpected leaf %+v", leaf)
+	}
+	if leaf.Description != "Deleted widgets cannot be restored." {
+		t.Fatalf("description = %q", leaf.Description)
+	}
+	wantExamples := []string{`asc widgets delete --id "W1" --confirm`, `asc widgets delete -i "W1" --confirm`}
+	if !reflect.DeepEqual(leaf.Examples, wantExamples) {
+		t.Fatalf("examples = %q, want %q", leaf.Examples, wantExamples)
+	}
+	if !leaf.Mutates || !leaf.Destructive {
+		t.Fatalf("expected delete to mutate and be destructive, got %+v", leaf)
+	}
+	if leaf.OutputFlag != "output" || !reflect.DeepEqual(leaf.OutputFormats, []string{"json", "table"}) {
+		t.Fatalf("unexpected output formats %q %v", leaf.OutputFlag, leaf.OutputFormats)
+	}
+
+	wantFlags := []Flag{
+		{Name: "confirm", Type: "boolean", Required: true, Description: "Confirm deletion"},
+		{Name: "id", Aliases: []string{"i"}, Type: "string", Required: true, Description: "Widget ID"},
+		{Name: "output", Type: "string", Default: "json", Description: "Output format: json (default), table"},
+		{Name: "retries", Type: "integer", Default: "3", Description: "Retry count"},
+	}
+	if !reflect.DeepEqual(leaf.Flags, wantFlags) {
+		t.Fatalf("flags = %+v\nwant %+v", leaf.Flags, wantFlags)
+	}
+}
+
+func TestBuildLimitsToCommand(t *testing.T) {
+	doc, err := Build(testRoot(), []string{"widgets", "delete"})
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+	if len(doc.Commands) != 1 || doc.Commands[0].Name != "widgets delete" {
+		t.Fatalf("expected only widgets delete, got %+v", doc.Commands)
+	}
+
+	if _, err := Build(testRoot(), []string{"widgets", "nope"}); err == nil {
+		t.Fatal("expected unknown command error")
+	}
+}
@@ -0,0 +1,198 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/config"
+)
+
+// appLookupCacheTTL bounds how long a resolved bundle ID or app name is
+// trusted before the apps endpoint is queried again.
+const appLookupCacheTTL = 7 * 24 * time.Hour
+
+const appLookupCacheFileName = "app_ids.json"
+
+// ErrAppNotFound is returned when an --app bundle ID or name matches no app.
+var ErrAppNotFound = errors.New("no app found")
+
+// ErrAppAmbiguous is returned when an --app name matches more than one app.
+var ErrAppAmbiguous = errors.New("ambiguous app")
+
+// errAppLookupUnavailable marks lookups that could not build a client.
+var errAppLookupUnavailable = errors.New("app lookup unavailable")
+
+// appLookupFn queries the apps endpoint; overridden in tests.
+var appLookupFn = lookupAppID
+
+type appLookupCacheEntry struct {
+	AppID      string    `json:"app_id"`
+	ResolvedAt time.Time `json:"resolved_at"`
+}
+
+// appIdentifierNeedsLookup reports whether value looks like a bundle ID
+// (contains a dot) or an app name (contains whitespace, or is a single word
+// of letters and digits, such as "Todo2") rather than an app ID. Numeric
+// IDs, placeholder IDs with underscores or dashes, and comma-separated lists
+// are used as given.
+func appIdentifierNeedsLookup(value string) bool {
+	if value == "" || strings.Contains(value, ",") {
+		return false
+	}
+	if strings.Contains(value, ".") || strings.ContainsFunc(value, unicode.IsSpace) {
+		return true
+	}
+	if !strings.ContainsFunc(value, unicode.IsLetter) {
+		return false
+	}
+	return !strings.ContainsFunc(value, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+}
+
+// resolveAppIdentifier maps a bundle ID or app name to its app ID. When no
+// client can be built (for example, credentials are missing) the value is
+// returned as-is so the command reports the underlying problem instead.
+// Bundle IDs and names that match no app or several apps, and failed
+// lookups, are errors.
+func resolveAppIdentifier(ctx context.Context, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if !appIdentifierNeedsLookup(value) {
+		return value, nil
+	}
+
+	cacheKey := appLookupCacheKey(value)
+	cachePath, cachePathErr := appLookupCachePath()
+	if cachePathErr == nil {
+		if id, ok := readAppLookupCache(cachePath, cacheKey, time.Now()); ok {
+			return id, nil
+		}
+	}
+
+	id, err := appLookupFn(ctx, value)
+	if err != nil {
+		if errors.Is(err, errAppLookupUnavailable) {
+			return value, nil
+		}
+		return "", fmt.Errorf("--app %q: %w", value, err)
+	}
+	if cachePathErr == nil {
+		_ = writeAppLookupCache(cachePath, cacheKey, id, time.Now())
+	}
+	return id, nil
+}
+
+// lookupAppID resolves value as an exact bundle ID first, then as an app
+// name (case-insensitive).
+func lookupAppID(ctx context.Context, value string) (string, error) {
+	client, err := getASCClient()
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", errAppLookupUnavailable, err)
+	}
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+
+	byBundleID, err := client.GetApps(ctx, asc.WithAppsBundleIDs([]string{value}), asc.WithAppsLimit(10))
+	if err != nil {
+		return "", err
+	}
+	for _, app := range byBundleID.Data {
+		if app.Attributes.BundleID == value {
+			return app.ID, nil
+		}
+	}
+
+	byName, err := client.GetApps(ctx, asc.WithAppsNames([]string{value}), asc.WithAppsLimit(10))
+	if err != nil {
+		return "", err
+	}
+	return matchAppByName(value, byName.Data)
+}
+
+// matchAppByName picks the single app whose name equals value, ignoring case.
+// The name filter also returns partial matches; those are listed in the
+// ambiguity error so the caller can pick one.
+func matchAppByName(value string, apps []asc.Resource[asc.AppAttributes]) (string, error) {
+	var exact []asc.Resource[asc.AppAttributes]
+	for _, app := range apps {
+		if strings.EqualFold(strings.TrimSpace(app.Attributes.Name), value) {
+			exact = append(exact, app)
+		}
+	}
+	switch {
+	case len(exact) == 1:
+		return exact[0].ID, nil
+	case len(exact) > 1:
+		return "", appAmbiguityError(exact)
+	case len(apps) > 0:
+		return "", appAmbiguityError(apps)
+	default:
+		return "", fmt.Errorf("%w with that bundle ID or name", ErrAppNotFound)
+	}
+}
+
+func appAmbiguityError(apps []asc.Resource[asc.AppAttributes]) error {
+	candidates := make([]string, 0, len(apps))
+	for _, app := range apps {
+		candidates = append(candidates, fmt.Sprintf("%s (%s, %s)", app.ID, app.Attributes.Name, app.Attributes.BundleID))
+	}
+	return fmt.Errorf("%w: matches %s; pass the numeric app ID or bundle ID instead", ErrAppAmbiguous, strings.Join(candidates, ", "))
+}
+
+// appLookupCacheKey scopes cached IDs to the active profile, since the same
+// name can belong to different apps in different teams.
+func appLookupCacheKey(value string) string {
+	return resolveProfileName() + "|" + strings.ToLower(value)
+}
+
+func appLookupCachePath() (string, error) {
+	path, err := config.GlobalPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "cache", appLookupCacheFileName), nil
+}
+
+func readAppLookupCache(path, key string, now time.Time) (string, bool) {
+	cache := loadAppLookupCache(path)
+	entry, ok := cache[key]
+	if !ok || entry.AppID == "" || now.Sub(entry.ResolvedAt) > appLookupCacheTTL {
+		return "", false
+	}
+	return entry.AppID, true
+}
+
+func writeAppLookupCache(path, key, appID string, now time.Time) error {
+	cache := loadAppLookupCache(path)
+	for existing, entry := range cache {
+		if now.Sub(entry.ResolvedAt) > appLookupCacheTTL {
+			delete(cache, existing)
+		}
+	}
+	cache[key] = appLookupCacheEntry{AppID: appID, ResolvedAt: now.UTC()}
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = WriteFileNoSymlinkOverwrite(path, bytes.NewReader(data), 0o600, ".app-ids-*", ".app-ids-backup-*")
+	return err
+}
+
+func loadAppLookupCache(path string) map[string]appLookupCacheEntry {
+	cache := map[string]appLookupCacheEntry{}
+	data, err := os.ReadFile(path)
+	if err != nil || len(data) == 0 {
+		return cache
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return map[string]appLookupCacheEntry{}
+	}
+	return cache
+}
@@ -0,0 +1,92 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppIdentifierNeedsLookup(t *testing.T) {
+	tests := map[string]bool{
+		"":                false,
+		"1234567890":      false,
+		"APP_ID":          false,
+		"app-1":           false,
+		"app-1,app-2":     false,
+		"com.example.app": true,
+		"My App":          true,
+		"Tally":           true,
+		"Todo2":           true,
+		"APP_1":           false,
+	}
+	for value, want := range tests {
+		if got := appIdentifierNeedsLookup(value); got != want {
+			t.Errorf("appIdentifierNeedsLookup(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+type appLookupTestKey struct{}
+
+func TestResolveAppIdentifierCachesAndReportsErrors(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "config.json"))
+	t.Setenv("ASC_PROFILE", "")
+
+	calls := 0
+	original := appLookupFn
+	t.Cleanup(func() { appLookupFn = original })
+	errServer := errors.New("500 internal server error")
+	appLookupFn = func(ctx context.Context, value string) (string, error) {
+		calls++
+		if ctx.Value(appLookupTestKey{}) != "request" {
+			t.Fatalf("expected the caller's context, got %v", ctx)
+		}
+		switch value {
+		case "Missing":
+			return "", ErrAppNotFound
+		case "NoAuth":
+			return "", fmt.Errorf("%w: missing authentication", errAppLookupUnavailable)
+		case "Flaky":
+			return "", errServer
+		}
+		return "42", nil
+	}
+	ctx := context.WithValue(context.Background(), appLookupTestKey{}, "request")
+
+	for range 2 {
+		if got, err := resolveAppIdentifier(ctx, "com.example.app"); err != nil || got != "42" {
+			t.Fatalf("resolveAppIdentifier() = %q, %v, want 42", got, err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected one lookup with a warm cache, got %d", calls)
+	}
+
+	if got, err := resolveAppIdentifier(ctx, "Missing"); !errors.Is(err, ErrAppNotFound) || got != "" {
+		t.Fatalf("expected not-found error, got %q, %v", got, err)
+	}
+	if got, err := resolveAppIdentifier(ctx, "Flaky"); !errors.Is(err, errServer) || got != "" {
+		t.Fatalf("expected failed lookups to return the error, got %q, %v", got, err)
+	}
+	if got, err := resolveAppIdentifier(ctx, "NoAuth"); err != nil || got != "NoAuth" {
+		t.Fatalf("expected lookups without a client to pass the value through, got %q, %v", got, err)
+	}
+	if got, err := resolveAppIdentifier(ctx, "123"); err != nil || got != "123" {
+		t.Fatalf("expected app IDs to be used as given, got %q, %v", got, err)
+	}
+}
+
+func TestReadAppLookupCacheExpires(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app_ids.json")
+	now := time.Now()
+	if err := writeAppLookupCache(path, "|tally", "42", now.Add(-appLookupCacheTTL-time.Hour)); err != nil {
+		t.Fatalf("writeAppLookupCache() error: %v", err)
+	}
+	if _, ok := readAppLookupCache(path, "|tally", now); ok {
+		t.Fatal("expected expired cache entry to be ignored")
+	}
+}
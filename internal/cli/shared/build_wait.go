@@ -42,6 +42,12 @@ func WaitForBuildByNumber(ctx context.Context, client *asc.Client, appID, versio
 	})
 }
 
+// FindBuildByNumber returns the build matching version/build number, or nil
+// when it has not been uploaded yet.
+func FindBuildByNumber(ctx context.Context, client *asc.Client, appID, version, buildNumber, platform string) (*asc.BuildResponse, error) {
+	return findBuildByNumber(ctx, client, appID, version, buildNumber, platform)
+}
+
 func findBuildByNumber(ctx context.Context, client *asc.Client, appID, version, buildNumber, platform string) (*asc.BuildResponse, error) {
 	preReleaseResp, err := client.GetPreReleaseVersions(ctx, appID,
 		asc.WithPreReleaseVersionsVersion(version),
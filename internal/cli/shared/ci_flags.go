@@ -3,31 +3,62 @@ package shared
 import (
 	"flag"
 	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/validation"
 )
 
 // CI report format types
 const (
-	ReportFormatJUnit = "junit"
+	ReportFormatJUnit    = "junit"
+	ReportFormatSARIF    = "sarif"
+	ReportFormatGitHub   = "github"
+	ReportFormatMarkdown = "markdown"
 )
 
+// githubStepSummaryEnvVar names the file GitHub Actions renders as the job summary.
+const githubStepSummaryEnvVar = "GITHUB_STEP_SUMMARY"
+
+var reportFormats = []string{
+	ReportFormatJUnit,
+	ReportFormatSARIF,
+	ReportFormatGitHub,
+	ReportFormatMarkdown,
+}
+
 var (
 	reportFormat string
 	reportFile   string
+
+	reportChecksMu sync.Mutex
+	reportChecks   []ReportCheck
 )
 
+// ReportCheck is a recorded validation result and the local file, such as an
+// IPA, it was found in. Artifact is empty for App Store Connect resources.
+type ReportCheck struct {
+	validation.CheckResult
+	Artifact string
+}
+
 // BindCIFlags registers CI-related flags for report output.
 // These are separate from BindRootFlags to keep CI concerns isolated.
 func BindCIFlags(fs *flag.FlagSet) {
-	fs.StringVar(&reportFormat, "report", "", "Report format for CI output (e.g., junit)")
+	fs.StringVar(&reportFormat, "report", "", "Report format for CI output: "+strings.Join(reportFormats, ", "))
 	fs.StringVar(&reportFile, "report-file", "", "Path to write CI report file")
 }
 
 // ValidateReportFlags validates the CI report flags and returns an error if invalid.
+// GitHub annotations default to stderr and the Markdown summary defaults to
+// $GITHUB_STEP_SUMMARY, so those formats only need --report-file outside Actions.
 func ValidateReportFlags() error {
-	if reportFormat != "" && reportFormat != ReportFormatJUnit {
-		return fmt.Errorf("--report must be %q if specified, got %q", ReportFormatJUnit, reportFormat)
+	if reportFormat != "" && !isReportFormat(reportFormat) {
+		return fmt.Errorf("--report must be one of %s if specified, got %q", strings.Join(reportFormats, ", "), reportFormat)
 	}
-	if reportFormat == ReportFormatJUnit && reportFile == "" {
+	if reportFormat != "" && reportFile == "" && !reportFormatHasDefaultFile(reportFormat) {
 		return fmt.Errorf("--report-file is required when --report is specified")
 	}
 	if reportFile != "" && reportFormat == "" {
@@ -36,6 +67,67 @@ func ValidateReportFlags() error {
 	return nil
 }
 
+func isReportFormat(format string) bool {
+	for _, candidate := range reportFormats {
+		if candidate == format {
+			return true
+		}
+	}
+	return false
+}
+
+func reportFormatHasDefaultFile(format string) bool {
+	switch format {
+	case ReportFormatGitHub:
+		return true
+	case ReportFormatMarkdown:
+		return strings.TrimSpace(os.Getenv(githubStepSummaryEnvVar)) != ""
+	default:
+		return false
+	}
+}
+
+// RecordReportChecks adds validation results to the CI report for this run.
+// Commands call it before printing so the report mirrors their output.
+func RecordReportChecks(checks []validation.CheckResult) {
+	RecordArtifactReportChecks("", checks)
+}
+
+// RecordArtifactReportChecks adds validation results found in a local file,
+// such as an IPA, so reports can point at it.
+func RecordArtifactReportChecks(artifact string, checks []validation.CheckResult) {
+	reportChecksMu.Lock()
+	defer reportChecksMu.Unlock()
+	for _, check := range checks {
+		reportChecks = append(reportChecks, ReportCheck{CheckResult: check, Artifact: artifact})
+	}
+}
+
+// ReportChecks returns the validation results recorded for this run.
+func ReportChecks() []ReportCheck {
+	reportChecksMu.Lock()
+	defer reportChecksMu.Unlock()
+	return slices.Clone(reportChecks)
+}
+
+// ReportCheckResults returns the recorded validation results without the
+// files they were found in.
+func ReportCheckResults() []validation.CheckResult {
+	checks := ReportChecks()
+	results := make([]validation.CheckResult, 0, len(checks))
+	for _, check := range checks {
+		results = append(results, check.CheckResult)
+	}
+	return results
+}
+
+// ResetReportChecks clears recorded validation results (for testing).
+func ResetReportChecks() {
+	reportChecksMu.Lock()
+	defer reportChecksMu.Unlock()
+	reportChecks = nil
+}
+
 // ReportFormat returns the configured report format.
 func ReportFormat() string {
 	return reportFormat
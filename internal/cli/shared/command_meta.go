@@ -0,0 +1,187 @@
+package shared
+
+import (
+	"flag"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+// Commands carry no explicit metadata, so tooling that describes them (asc
+// schema, asc mcp serve) infers it from names, flags and usage strings
+// using the helpers below.
+
+// readOnlyWords are command name words for commands that only read data.
+// A leaf matches when any word of its name is one of these, so
+// "attachments-list" and "cache-status" count.
+var readOnlyWords = []string{"list", "get", "view", "info", "status", "latest", "download"}
+
+// readOnlyNames are whole leaf command names that only read App Store
+// Connect data or inspect local files.
+var readOnlyNames = []string{"export", "inspect", "lint", "validate", "doctor", "sizes", "version", "completion", "schema"}
+
+// destructiveVerbs are command name words that delete or revoke resources.
+var destructiveVerbs = []string{"cancel", "clear", "deactivate", "delete", "expire", "remove", "revoke"}
+
+// destructiveNames are whole leaf command names that replace remote state
+// with a local file, overwriting or removing whatever the file omits.
+var destructiveNames = []string{"apply", "push"}
+
+// CommandEffects describes what running a command does.
+type CommandEffects struct {
+	// ReadOnly is set only for commands on the read-only allowlist.
+	ReadOnly bool
+	// Mutates is set for every command that is not read-only: commands
+	// change state unless they are known not to.
+	Mutates bool
+	// Destructive is set when the command deletes, revokes or replaces
+	// something, requires --confirm, or sends arbitrary HTTP methods.
+	Destructive bool
+}
+
+// InferCommandEffects classifies a command. Only allowlisted names
+// (readOnlyWords, readOnlyNames) are read-only; every other command, and any
+// command with --confirm or an HTTP --method (asc api), mutates. Destructive
+// commands are recognized by name words (so "submissions-cancel" counts),
+// destructiveNames, --confirm, or --method, since any method may be DELETE.
+func InferCommandEffects(cmd *ffcli.Command) CommandEffects {
+	effects := CommandEffects{Destructive: slices.Contains(destructiveNames, cmd.Name)}
+	words := strings.Split(cmd.Name, "-")
+	for _, word := range words {
+		if slices.Contains(destructiveVerbs, word) {
+			effects.Destructive = true
+		}
+	}
+	if cmd.FlagSet != nil && cmd.FlagSet.Lookup("confirm") != nil {
+		effects.Destructive = true
+	}
+
+	readOnly := slices.Contains(readOnlyNames, cmd.Name)
+	for _, word := range words {
+		if slices.Contains(readOnlyWords, word) {
+			readOnly = true
+		}
+	}
+	if cmd.FlagSet != nil && cmd.FlagSet.Lookup("method") != nil {
+		effects.Destructive = true
+	}
+	effects.ReadOnly = readOnly && !effects.Destructive
+	effects.Mutates = !effects.ReadOnly
+	return effects
+}
+
+// ShorthandTarget returns the flag f is a shorthand for, as declared with
+// fs.StringVar(&file, "f", "", "Shorthand for --file").
+func ShorthandTarget(f *flag.Flag) (string, bool) {
+	rest, ok := strings.CutPrefix(f.Usage, "Shorthand for --")
+	if !ok {
+		return "", false
+	}
+	name, _, _ := strings.Cut(rest, " ")
+	return name, name != ""
+}
+
+// FlagType returns the JSON type of a flag value: boolean, integer, number,
+// duration or string, or array for repeatable flags.
+func FlagType(f *flag.Flag) string {
+	if _, ok := f.Value.(*OptionalBool); ok {
+		return "boolean"
+	}
+	if boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && boolFlag.IsBoolFlag() {
+		return "boolean"
+	}
+	if getter, ok := f.Value.(flag.Getter); ok {
+		switch getter.Get().(type) {
+		case int, int64, uint, uint64:
+			return "integer"
+		case float64:
+			return "number"
+		case time.Duration:
+			return "duration"
+		}
+		return "string"
+	}
+	if strings.Contains(strings.ToLower(f.Usage), "repeatable") {
+		return "array"
+	}
+	return "string"
+}
+
+var requiredUsagePattern = regexp.MustCompile(`\(required[),]|, required\)`)
+
+// FlagRequired reports whether a flag is always required: its usage says
+// "(required)", or the command's ShortUsage lists it outside [optional]
+// brackets. Conditional requirements such as "(required with --id)" are not
+// counted.
+func FlagRequired(cmd *ffcli.Command, f *flag.Flag) bool {
+	if requiredUsagePattern.MatchString(f.Usage) {
+		return true
+	}
+	depth := 0
+	var b strings.Builder
+	for _, r := range cmd.ShortUsage {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			if depth > 0 {
+				depth--
+			}
+		default:
+			if depth == 0 {
+				b.WriteRune(r)
+			}
+		}
+	}
+	for _, field := range strings.Fields(b.String()) {
+		name, _, _ := strings.Cut(strings.TrimPrefix(field, "--"), "=")
+		if strings.HasPrefix(field, "--") && name == f.Name {
+			return true
+		}
+	}
+	return false
+}
+
+var outputFormatUsagePattern = regexp.MustCompile(`^(?:Summary )?[Oo]utput format(?: for [^:]+)?:(.*)$`)
+
+// OutputFormatFlag describes a command's output format flag.
+type OutputFormatFlag struct {
+	Name    string
+	Default string
+	Formats []string
+}
+
+// CommandOutputFormat returns the output format flag of a command (usually
+// --output, sometimes --format or --output-format), with the formats listed
+// in its "Output format: json (default), table, ..." usage.
+func CommandOutputFormat(cmd *ffcli.Command) (OutputFormatFlag, bool) {
+	if cmd.FlagSet == nil {
+		return OutputFormatFlag{}, false
+	}
+	var (
+		result OutputFormatFlag
+		found  bool
+	)
+	cmd.FlagSet.VisitAll(func(f *flag.Flag) {
+		if found {
+			return
+		}
+		match := outputFormatUsagePattern.FindStringSubmatch(f.Usage)
+		if match == nil {
+			return
+		}
+		found = true
+		result = OutputFormatFlag{Name: f.Name, Default: f.DefValue}
+		for _, part := range strings.Split(match[1], ",") {
+			format, _, _ := strings.Cut(strings.TrimSpace(part), " ")
+			format = strings.ToLower(format)
+			if format != "" && !slices.Contains(result.Formats, format) {
+				result.Formats = append(result.Formats, format)
+			}
+		}
+	})
+	return result, found
+}
@@ -0,0 +1,125 @@
+package shared
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+func TestInferCommandEffects(t *testing.T) {
+	withConfirm := flag.NewFlagSet("expire", flag.ContinueOnError)
+	withConfirm.Bool("confirm", false, "Confirm")
+	withMethod := flag.NewFlagSet("api", flag.ContinueOnError)
+	withMethod.String("method", "", "HTTP method")
+
+	tests := []struct {
+		cmd  *ffcli.Command
+		want CommandEffects
+	}{
+		{&ffcli.Command{Name: "list"}, CommandEffects{ReadOnly: true}},
+		{&ffcli.Command{Name: "attachments-list"}, CommandEffects{ReadOnly: true}},
+		{&ffcli.Command{Name: "export"}, CommandEffects{ReadOnly: true}},
+		{&ffcli.Command{Name: "create"}, CommandEffects{Mutates: true}},
+		{&ffcli.Command{Name: "add-groups"}, CommandEffects{Mutates: true}},
+		{&ffcli.Command{Name: "generate"}, CommandEffects{Mutates: true}},
+		{&ffcli.Command{Name: "serve"}, CommandEffects{Mutates: true}},
+		{&ffcli.Command{Name: "slack"}, CommandEffects{Mutates: true}},
+		{&ffcli.Command{Name: "submissions-cancel"}, CommandEffects{Mutates: true, Destructive: true}},
+		{&ffcli.Command{Name: "deactivate"}, CommandEffects{Mutates: true, Destructive: true}},
+		{&ffcli.Command{Name: "delete"}, CommandEffects{Mutates: true, Destructive: true}},
+		{&ffcli.Command{Name: "builds", FlagSet: withConfirm}, CommandEffects{Mutates: true, Destructive: true}},
+		{&ffcli.Command{Name: "get", FlagSet: withConfirm}, CommandEffects{Mutates: true, Destructive: true}},
+		{&ffcli.Command{Name: "get", FlagSet: withMethod}, CommandEffects{Mutates: true, Destructive: true}},
+		{&ffcli.Command{Name: "apply"}, CommandEffects{Mutates: true, Destructive: true}},
+		{&ffcli.Command{Name: "push"}, CommandEffects{Mutates: true, Destructive: true}},
+	}
+	for _, test := range tests {
+		if got := InferCommandEffects(test.cmd); got != test.want {
+			t.Fatalf("InferCommandEffects(%s) = %+v, want %+v", test.cmd.Name, got, test.want)
+		}
+	}
+}
+
+func TestFlagTypeAndShorthand(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Bool("paginate", false, "Fetch all pages")
+	fs.Int("limit", 0, "Limit")
+	fs.Float64("ratio", 0, "Ratio")
+	fs.Duration("timeout", time.Second, "Timeout")
+	file := fs.String("file", "", "File")
+	fs.StringVar(file, "f", "", "Shorthand for --file")
+	fs.Var(&OptionalBool{}, "enabled", "Enable")
+	var filters stringListFlag
+	fs.Var(&filters, "filter", "Filter as key=value (repeatable)")
+
+	want := map[string]string{
+		"paginate": "boolean",
+		"limit":    "integer",
+		"ratio":    "number",
+		"timeout":  "duration",
+		"file":     "string",
+		"enabled":  "boolean",
+		"filter":   "array",
+	}
+	for name, wantType := range want {
+		if got := FlagType(fs.Lookup(name)); got != wantType {
+			t.Fatalf("FlagType(%s) = %s, want %s", name, got, wantType)
+		}
+	}
+	if target, ok := ShorthandTarget(fs.Lookup("f")); !ok || target != "file" {
+		t.Fatalf("ShorthandTarget(f) = %q, %t", target, ok)
+	}
+	if _, ok := ShorthandTarget(fs.Lookup("file")); ok {
+		t.Fatal("expected --file not to be a shorthand")
+	}
+}
+
+type stringListFlag []string
+
+func (s *stringListFlag) String() string { return "" }
+
+func (s *stringListFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+func TestFlagRequired(t *testing.T) {
+	fs := flag.NewFlagSet("update", flag.ContinueOnError)
+	fs.String("id", "", "App ID")
+	fs.String("bundle-id", "", "Bundle ID")
+	fs.String("file", "", "Input file (required)")
+	fs.String("app", "", "App ID (required, or ASC_APP_ID env)")
+	fs.String("output-path", "", "Output file path (required with --id)")
+	cmd := &ffcli.Command{
+		Name:       "update",
+		ShortUsage: "asc apps update --id APP_ID [--bundle-id BUNDLE_ID]",
+		FlagSet:    fs,
+	}
+
+	want := map[string]bool{"id": true, "bundle-id": false, "file": true, "app": true, "output-path": false}
+	for name, wantRequired := range want {
+		if got := FlagRequired(cmd, fs.Lookup(name)); got != wantRequired {
+			t.Fatalf("FlagRequired(%s) = %t, want %t", name, got, wantRequired)
+		}
+	}
+}
+
+func TestCommandOutputFormat(t *testing.T) {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.String("output-path", "", "Output file path")
+	BindMetadataOutputFlags(fs)
+	got, ok := CommandOutputFormat(&ffcli.Command{Name: "list", FlagSet: fs})
+	want := OutputFormatFlag{Name: "output-format", Default: "json", Formats: []string{"json", "table", "markdown"}}
+	if !ok || !reflect.DeepEqual(got, want) {
+		t.Fatalf("CommandOutputFormat() = %+v, %t; want %+v", got, ok, want)
+	}
+
+	none := flag.NewFlagSet("download", flag.ContinueOnError)
+	none.String("output", "", "Output file path")
+	if _, ok := CommandOutputFormat(&ffcli.Command{Name: "download", FlagSet: none}); ok {
+		t.Fatal("expected a file path --output not to be an output format flag")
+	}
+}
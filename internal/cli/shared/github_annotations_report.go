@@ -0,0 +1,114 @@
+package shared
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/validation"
+)
+
+// GitHubAnnotationsReport renders validation results as GitHub Actions
+// workflow commands (::error, ::warning, ::notice).
+type GitHubAnnotationsReport struct {
+	Command string                   // Command path (e.g., asc validate)
+	Checks  []validation.CheckResult // Validation findings
+	Failure string                   // Command error when no checks explain it
+}
+
+// Write writes the annotations to the specified file path.
+func (r *GitHubAnnotationsReport) Write(path string) error {
+	if path == "" {
+		return fmt.Errorf("report file path is empty")
+	}
+	if _, err := WriteStreamToFile(path, bytes.NewReader(r.Marshal())); err != nil {
+		return fmt.Errorf("failed to write report file: %w", err)
+	}
+	return nil
+}
+
+// WriteTo writes the annotations to w. The runner parses workflow commands
+// from both stdout and stderr.
+func (r *GitHubAnnotationsReport) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(r.Marshal())
+	if err != nil {
+		return int64(n), fmt.Errorf("failed to write report: %w", err)
+	}
+	return int64(n), nil
+}
+
+// Marshal renders one workflow command per line.
+func (r *GitHubAnnotationsReport) Marshal() []byte {
+	var b strings.Builder
+	for _, check := range r.Checks {
+		title := check.ID
+		if check.Locale != "" {
+			title += " (" + check.Locale + ")"
+		}
+		message := check.Message
+		if location := checkLocation(check); location != "" {
+			message += "\n" + location
+		}
+		if check.Remediation != "" {
+			message += "\nRemediation: " + check.Remediation
+		}
+		writeWorkflowCommand(&b, githubAnnotationLevel(check.Severity), title, message)
+	}
+	if len(r.Checks) == 0 && r.Failure != "" {
+		title := r.Command
+		if title == "" {
+			title = "asc"
+		}
+		writeWorkflowCommand(&b, "error", title, r.Failure)
+	}
+	return []byte(b.String())
+}
+
+func githubAnnotationLevel(severity validation.Severity) string {
+	switch severity {
+	case validation.SeverityError:
+		return "error"
+	case validation.SeverityWarning:
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+func writeWorkflowCommand(b *strings.Builder, command, title, message string) {
+	b.WriteString("::")
+	b.WriteString(command)
+	if title != "" {
+		b.WriteString(" title=")
+		b.WriteString(escapeWorkflowProperty(title))
+	}
+	b.WriteString("::")
+	b.WriteString(escapeWorkflowData(message))
+	b.WriteString("\n")
+}
+
+// escapeWorkflowData escapes a workflow command message.
+func escapeWorkflowData(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(value)
+}
+
+// escapeWorkflowProperty escapes a workflow command property value.
+func escapeWorkflowProperty(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(value)
+}
+
+// checkLocation describes where a check applies, e.g. "appStoreVersionLocalizations LOC_ID field description".
+func checkLocation(check validation.CheckResult) string {
+	parts := make([]string, 0, 3)
+	if check.ResourceType != "" {
+		parts = append(parts, check.ResourceType)
+	}
+	if check.ResourceID != "" {
+		parts = append(parts, check.ResourceID)
+	}
+	if check.Field != "" {
+		parts = append(parts, "field "+check.Field)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,44 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/validation"
+)
+
+func TestGitHubAnnotationsReport_Marshal(t *testing.T) {
+	report := GitHubAnnotationsReport{
+		Command: "asc validate",
+		Checks: []validation.CheckResult{
+			{
+				ID:           "metadata.required.name",
+				Severity:     validation.SeverityError,
+				Message:      "Name is missing: 100% required",
+				Remediation:  "Set the app name",
+				Locale:       "en-US",
+				Field:        "name",
+				ResourceType: "appInfoLocalizations",
+				ResourceID:   "INFO_ID",
+			},
+			{ID: "build.expiring", Severity: validation.SeverityWarning, Message: "Build expires soon"},
+			{ID: "a,b:c", Severity: validation.SeverityInfo, Message: "FYI"},
+		},
+		Failure: "ignored when checks exist",
+	}
+
+	want := "::error title=metadata.required.name (en-US)::Name is missing: 100%25 required%0AappInfoLocalizations INFO_ID field name%0ARemediation: Set the app name\n" +
+		"::warning title=build.expiring::Build expires soon\n" +
+		"::notice title=a%2Cb%3Ac::FYI\n"
+	if got := string(report.Marshal()); got != want {
+		t.Fatalf("Marshal() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGitHubAnnotationsReport_CommandFailure(t *testing.T) {
+	report := GitHubAnnotationsReport{Command: "asc builds list", Failure: "request failed\nretry later"}
+
+	want := "::error title=asc builds list::request failed%0Aretry later\n"
+	if got := string(report.Marshal()); got != want {
+		t.Fatalf("Marshal() = %q, want %q", got, want)
+	}
+}
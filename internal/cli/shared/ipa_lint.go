@@ -0,0 +1,52 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/ipa"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/validation"
+)
+
+// appGetter fetches an app so its bundle ID can be compared with the IPA.
+type appGetter interface {
+	GetApp(ctx context.Context, appID string) (*asc.AppResponse, error)
+}
+
+// LintIPABeforeUpload runs the offline IPA checks (see 'asc ipa lint') that
+// predict App Store Connect processing failures. Findings are printed to
+// stderr and recorded for --report; errors abort the upload.
+func LintIPABeforeUpload(ctx context.Context, client appGetter, appID, ipaPath string) error {
+	expectedBundleID := ""
+	requestCtx, cancel := ContextWithTimeout(ctx)
+	app, err := client.GetApp(requestCtx, appID)
+	cancel()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: skipping bundle ID check, failed to fetch app %s: %v\n", appID, err)
+	} else {
+		expectedBundleID = strings.TrimSpace(app.Data.Attributes.BundleID)
+	}
+
+	report, err := ipa.Lint(ipaPath, expectedBundleID, false)
+	if err != nil {
+		return fmt.Errorf("IPA lint failed: %w (use --skip-lint to upload anyway)", err)
+	}
+	RecordArtifactReportChecks(ipaPath, report.Checks)
+	for _, check := range report.Checks {
+		label := "Warning"
+		if check.Severity == validation.SeverityError {
+			label = "Error"
+		}
+		fmt.Fprintf(os.Stderr, "%s: %s [%s]\n", label, check.Message, check.ID)
+		if check.Remediation != "" {
+			fmt.Fprintf(os.Stderr, "  %s\n", check.Remediation)
+		}
+	}
+	if report.Summary.Errors > 0 {
+		return fmt.Errorf("IPA lint found %d error(s); fix them or use --skip-lint to upload anyway", report.Summary.Errors)
+	}
+	return nil
+}
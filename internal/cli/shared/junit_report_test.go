@@ -174,6 +174,8 @@ func TestCIReportFlags(t *testing.T) {
 }
 
 func TestValidateReportFlags(t *testing.T) {
+	t.Setenv("GITHUB_STEP_SUMMARY", "")
+
 	tests := []struct {
 		name      string
 		format    string
@@ -187,6 +189,10 @@ func TestValidateReportFlags(t *testing.T) {
 		{"invalid format returns error", "nope", "", true},
 		{"invalid format with file is still error", "nope", "/tmp/report.xml", true},
 		{"another invalid format", "xml", "", true},
+		{"sarif without file is error", "sarif", "", true},
+		{"sarif with file is valid", "sarif", "/tmp/report.sarif", false},
+		{"github without file defaults to stderr", "github", "", false},
+		{"markdown without file or step summary is error", "markdown", "", true},
 	}
 
 	for _, tt := range tests {
@@ -204,6 +210,17 @@ func TestValidateReportFlags(t *testing.T) {
 	}
 }
 
+func TestValidateReportFlags_MarkdownDefaultsToStepSummary(t *testing.T) {
+	t.Setenv("GITHUB_STEP_SUMMARY", filepath.Join(t.TempDir(), "summary.md"))
+	SetReportFormat(ReportFormatMarkdown)
+	SetReportFile("")
+	t.Cleanup(func() { SetReportFormat("") })
+
+	if err := ValidateReportFlags(); err != nil {
+		t.Fatalf("ValidateReportFlags() = %v, want nil", err)
+	}
+}
+
 func TestJUnitReport_WriteCreatesRestrictedPermissions(t *testing.T) {
 	report := JUnitReport{
 		Tests: []JUnitTestCase{
@@ -164,6 +164,40 @@ func resolveLocalizationOutputPaths(outputPath string, locales []string) (map[st
 	return result, nil
 }
 
+// VersionLocalizationValues returns the non-empty upload keys for a version localization.
+func VersionLocalizationValues(attrs asc.AppStoreVersionLocalizationAttributes) map[string]string {
+	return mapVersionLocalizationStrings(attrs)
+}
+
+// AppInfoLocalizationValues returns the non-empty upload keys for an app info localization.
+func AppInfoLocalizationValues(attrs asc.AppInfoLocalizationAttributes) map[string]string {
+	return mapAppInfoLocalizationStrings(attrs)
+}
+
+// ValidateLocalizationValues checks that every key can be uploaded for the localization type.
+func ValidateLocalizationValues(localizationType string, valuesByLocale map[string]map[string]string) error {
+	var allowed map[string]bool
+	switch localizationType {
+	case LocalizationTypeVersion:
+		allowed = buildAllowedKeys(versionLocalizationKeys)
+	case LocalizationTypeAppInfo:
+		allowed = buildAllowedKeys(appInfoLocalizationKeys)
+	default:
+		return fmt.Errorf("unsupported localization type %q", localizationType)
+	}
+	locales := make([]string, 0, len(valuesByLocale))
+	for locale := range valuesByLocale {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	for _, locale := range locales {
+		if err := validateLocalizationKeys(locale, valuesByLocale[locale], allowed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func mapVersionLocalizationStrings(attrs asc.AppStoreVersionLocalizationAttributes) map[string]string {
 	values := make(map[string]string)
 	setIfNotEmpty(values, "description", attrs.Description)
@@ -0,0 +1,128 @@
+package shared
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/validation"
+)
+
+// MarkdownSummaryReport renders a Markdown job summary for GitHub Actions
+// ($GITHUB_STEP_SUMMARY) or any Markdown viewer.
+type MarkdownSummaryReport struct {
+	Command string                   // Command path (e.g., asc validate)
+	Time    time.Duration            // Command duration
+	Checks  []validation.CheckResult // Validation findings
+	Failure string                   // Command error (empty if passed)
+}
+
+// Write writes the summary to a new file at path.
+func (r *MarkdownSummaryReport) Write(path string) error {
+	if path == "" {
+		return fmt.Errorf("report file path is empty")
+	}
+	if _, err := WriteStreamToFile(path, bytes.NewReader(r.Marshal())); err != nil {
+		return fmt.Errorf("failed to write report file: %w", err)
+	}
+	return nil
+}
+
+// Append appends the summary to path, creating it if needed. Job summary
+// files are shared by every command in a step, so they are never truncated.
+func (r *MarkdownSummaryReport) Append(path string) error {
+	if path == "" {
+		return fmt.Errorf("report file path is empty")
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open report file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(r.Marshal()); err != nil {
+		return fmt.Errorf("failed to write report file: %w", err)
+	}
+	return nil
+}
+
+// Marshal renders the summary as GitHub-flavored Markdown.
+func (r *MarkdownSummaryReport) Marshal() []byte {
+	command := r.Command
+	if command == "" {
+		command = "asc"
+	}
+
+	var errors, warnings, infos int
+	for _, check := range r.Checks {
+		switch check.Severity {
+		case validation.SeverityError:
+			errors++
+		case validation.SeverityWarning:
+			warnings++
+		default:
+			infos++
+		}
+	}
+
+	status := "Passed"
+	if r.Failure != "" {
+		status = "Failed"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "### `%s`\n\n", escapeMarkdownCode(command))
+	fmt.Fprintf(&b, "**%s** in %.1fs", status, r.Time.Seconds())
+	if len(r.Checks) > 0 {
+		fmt.Fprintf(&b, " (%d error(s), %d warning(s), %d info)", errors, warnings, infos)
+	}
+	b.WriteString("\n\n")
+
+	if r.Failure != "" && len(r.Checks) == 0 {
+		fmt.Fprintf(&b, "> %s\n\n", escapeMarkdownCell(r.Failure))
+	}
+
+	if len(r.Checks) > 0 {
+		b.WriteString("| Severity | Check | Location | Message | Remediation |\n")
+		b.WriteString("| --- | --- | --- | --- | --- |\n")
+		for _, check := range r.Checks {
+			location := checkLocation(check)
+			if check.Locale != "" {
+				location = strings.TrimSpace(check.Locale + " " + location)
+			}
+			fmt.Fprintf(&b, "| %s | `%s` | %s | %s | %s |\n",
+				markdownSeverity(check.Severity),
+				escapeMarkdownCode(check.ID),
+				escapeMarkdownCell(location),
+				escapeMarkdownCell(check.Message),
+				escapeMarkdownCell(check.Remediation),
+			)
+		}
+		b.WriteString("\n")
+	}
+
+	return []byte(b.String())
+}
+
+func markdownSeverity(severity validation.Severity) string {
+	switch severity {
+	case validation.SeverityError:
+		return "**error**"
+	case validation.SeverityWarning:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+func escapeMarkdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	value = strings.ReplaceAll(value, "\r\n", "<br>")
+	return strings.ReplaceAll(value, "\n", "<br>")
+}
+
+func escapeMarkdownCode(value string) string {
+	return strings.ReplaceAll(escapeMarkdownCell(value), "`", "'")
+}
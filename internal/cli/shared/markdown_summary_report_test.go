@@ -0,0 +1,67 @@
+package shared
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/validation"
+)
+
+func TestMarkdownSummaryReport_Marshal(t *testing.T) {
+	report := MarkdownSummaryReport{
+		Command: "asc validate testflight",
+		Time:    1500 * time.Millisecond,
+		Checks: []validation.CheckResult{
+			{
+				ID:           "testflight.whatToTest",
+				Severity:     validation.SeverityWarning,
+				Message:      "What to Test | missing",
+				Remediation:  "Add notes\nfor testers",
+				Locale:       "en-US",
+				ResourceType: "betaBuildLocalizations",
+			},
+			{ID: "testflight.build", Severity: validation.SeverityError, Message: "Build is expired"},
+		},
+		Failure: "validate testflight: found 1 blocking issue(s)",
+	}
+
+	got := string(report.Marshal())
+	for _, want := range []string{
+		"### `asc validate testflight`\n",
+		"**Failed** in 1.5s (1 error(s), 1 warning(s), 0 info)",
+		"| Severity | Check | Location | Message | Remediation |",
+		"| warning | `testflight.whatToTest` | en-US betaBuildLocalizations | What to Test \\| missing | Add notes<br>for testers |",
+		"| **error** | `testflight.build` |  | Build is expired |  |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in summary:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "> validate testflight") {
+		t.Fatalf("expected checks to replace the failure quote:\n%s", got)
+	}
+}
+
+func TestMarkdownSummaryReport_AppendKeepsExistingSummary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.md")
+	if err := os.WriteFile(path, []byte("# Earlier step\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	report := MarkdownSummaryReport{Command: "asc builds list", Failure: "boom"}
+	if err := report.Append(path); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	got := string(data)
+	if !strings.HasPrefix(got, "# Earlier step\n### `asc builds list`") || !strings.Contains(got, "> boom") {
+		t.Fatalf("unexpected appended summary:\n%s", got)
+	}
+}
@@ -0,0 +1,121 @@
+package shared
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+var (
+	// requestedOutputFormat is the output format passed on the command line,
+	// so pagination can decide to stream before the command prints.
+	requestedOutputFormat string
+	// streamedOutput is set once paginated results were written as NDJSON;
+	// the next print call then has nothing left to write.
+	streamedOutput bool
+)
+
+// outputFormatFlag is a string flag that records the chosen output format.
+type outputFormatFlag struct {
+	value *string
+}
+
+func (f outputFormatFlag) String() string {
+	if f.value == nil {
+		return ""
+	}
+	return *f.value
+}
+
+func (f outputFormatFlag) Set(value string) error {
+	*f.value = value
+	requestedOutputFormat = value
+	return nil
+}
+
+// streamNDJSONRequested reports whether paginated results should be written
+// as NDJSON while pages arrive.
+func streamNDJSONRequested() bool {
+	format := requestedOutputFormat
+	if format == "" {
+		format = DefaultOutputFormat()
+	}
+	return NormalizeOutputFormat(format) == "ndjson"
+}
+
+func consumeStreamedOutput() bool {
+	if !streamedOutput {
+		return false
+	}
+	streamedOutput = false
+	return true
+}
+
+// streamPagesNDJSON writes each resource of every page as its own line and
+// returns an empty response of the first page's type.
+func streamPagesNDJSON(ctx context.Context, fetch FetchFunc, next asc.PaginateFunc) (asc.PaginatedResponse, error) {
+	selection, err := resolveOutputSelection()
+	if err != nil {
+		return nil, err
+	}
+	if len(splitCSV(outputColumns)) > 0 {
+		return nil, fmt.Errorf("--columns applies to table, markdown, CSV and TSV output; use --fields or --query with --output ndjson")
+	}
+
+	firstPage, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result, err := asc.StreamAll(ctx, firstPage, next, func(page asc.PaginatedResponse) error {
+		value, err := toJSONValue(page.GetData())
+		if err != nil {
+			return err
+		}
+		return writeNDJSON(value, selection)
+	})
+	if err != nil {
+		return result, err
+	}
+	streamedOutput = true
+	return result, nil
+}
+
+// writeNDJSON writes one JSON line per resource. For JSON:API documents the
+// resources are the data member; --fields and --query apply to each resource.
+func writeNDJSON(value any, selection outputSelection) error {
+	for _, item := range ndjsonItems(value) {
+		results, err := selection.apply(item, projectFields)
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			line, err := json.Marshal(result)
+			if err != nil {
+				return err
+			}
+			line = append(line, '\n')
+			if _, err := os.Stdout.Write(line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func ndjsonItems(value any) []any {
+	if document, ok := value.(map[string]any); ok {
+		if data, hasData := document["data"]; hasData {
+			value = data
+		}
+	}
+	if items, ok := value.([]any); ok {
+		return items
+	}
+	if value == nil {
+		return nil
+	}
+	return []any{value}
+}
@@ -0,0 +1,151 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+func TestPrintOutputDelimitedUsesRegistryRows(t *testing.T) {
+	app := testAppsResponse()
+	app.Data[0].Attributes.Name = "Tally, Pro"
+
+	stdout, _ := captureOutput(t, func() {
+		if err := PrintOutput(app, "csv", false); err != nil {
+			t.Fatalf("PrintOutput(csv) error: %v", err)
+		}
+	})
+	want := "ID,Name,Bundle ID,SKU\n1,\"Tally, Pro\",com.example.tally,TALLY\n2,Notes,com.example.notes,NOTES\n"
+	if stdout != want {
+		t.Fatalf("csv = %q, want %q", stdout, want)
+	}
+
+	setOutputSelection(t, "", "", "sku,id")
+	stdout, _ = captureOutput(t, func() {
+		if err := PrintOutput(app, "tsv", false); err != nil {
+			t.Fatalf("PrintOutput(tsv) error: %v", err)
+		}
+	})
+	if want := "SKU\tID\nTALLY\t1\nNOTES\t2\n"; stdout != want {
+		t.Fatalf("tsv = %q, want %q", stdout, want)
+	}
+}
+
+func TestPrintOutputWithRenderersDelimited(t *testing.T) {
+	stdout, _ := captureOutput(t, func() {
+		err := PrintOutputWithRenderers(map[string]string{"status": "ok"}, "csv", false,
+			func() error {
+				asc.RenderTable([]string{"Status"}, [][]string{{"ok"}})
+				return nil
+			},
+			nil,
+		)
+		if err != nil {
+			t.Fatalf("PrintOutputWithRenderers(csv) error: %v", err)
+		}
+	})
+	if stdout != "Status\nok\n" {
+		t.Fatalf("csv = %q", stdout)
+	}
+}
+
+func TestPrintOutputDelimitedRejectsUnregisteredTypes(t *testing.T) {
+	var err error
+	stdout, _ := captureOutput(t, func() {
+		err = PrintOutput(map[string]string{"status": "ok"}, "csv", false)
+	})
+	if err == nil || !strings.Contains(err.Error(), "csv output is not supported") {
+		t.Fatalf("expected unsupported error, got %v", err)
+	}
+	if stdout != "" {
+		t.Fatalf("expected no output, got %q", stdout)
+	}
+}
+
+func TestPrintOutputYAMLKeepsFieldOrder(t *testing.T) {
+	stdout, _ := captureOutput(t, func() {
+		data := struct {
+			Version string `json:"version"`
+			Build   string `json:"build"`
+			Enabled bool   `json:"enabled"`
+		}{Version: "1.0", Build: "42", Enabled: true}
+		if err := PrintOutput(data, "yaml", false); err != nil {
+			t.Fatalf("PrintOutput(yaml) error: %v", err)
+		}
+	})
+	want := "version: \"1.0\"\nbuild: \"42\"\nenabled: true\n"
+	if stdout != want {
+		t.Fatalf("yaml = %q, want %q", stdout, want)
+	}
+}
+
+func TestPrintOutputNDJSONWritesOneResourcePerLine(t *testing.T) {
+	setOutputSelection(t, "", "id,name", "")
+	stdout, _ := captureOutput(t, func() {
+		if err := PrintOutput(testAppsResponse(), "ndjson", false); err != nil {
+			t.Fatalf("PrintOutput(ndjson) error: %v", err)
+		}
+	})
+	want := "{\"id\":\"1\",\"name\":\"Tally\"}\n{\"id\":\"2\",\"name\":\"Notes\"}\n"
+	if stdout != want {
+		t.Fatalf("ndjson = %q, want %q", stdout, want)
+	}
+}
+
+func TestPaginateWithSpinnerStreamsNDJSON(t *testing.T) {
+	requestedOutputFormat = "ndjson"
+	t.Cleanup(func() {
+		requestedOutputFormat = ""
+		streamedOutput = false
+	})
+
+	page := func(id, next string) *asc.AppsResponse {
+		return &asc.AppsResponse{
+			Data:  []asc.Resource[asc.AppAttributes]{{Type: "apps", ID: id}},
+			Links: asc.Links{Next: next},
+		}
+	}
+	var lines []string
+	stdout, _ := captureOutput(t, func() {
+		result, err := PaginateWithSpinner(context.Background(),
+			func(context.Context) (asc.PaginatedResponse, error) {
+				return page("1", "https://api.appstoreconnect.apple.com/v1/apps?cursor=2"), nil
+			},
+			func(context.Context, string) (asc.PaginatedResponse, error) {
+				return page("2", ""), nil
+			},
+		)
+		if err != nil {
+			t.Fatalf("PaginateWithSpinner() error: %v", err)
+		}
+		if apps, ok := result.(*asc.AppsResponse); !ok || len(apps.Data) != 0 {
+			t.Fatalf("expected an empty aggregated response, got %#v", result)
+		}
+		if err := PrintOutput(result, "ndjson", false); err != nil {
+			t.Fatalf("PrintOutput() error: %v", err)
+		}
+	})
+	lines = strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"id":"1"`) || !strings.Contains(lines[1], `"id":"2"`) {
+		t.Fatalf("expected one line per resource, got %q", stdout)
+	}
+}
+
+func TestPrintOutputWithRenderersDelimitedRejectsFreeFormTables(t *testing.T) {
+	var err error
+	captureOutput(t, func() {
+		err = PrintOutputWithRenderers(map[string]string{"status": "ok"}, "tsv", false,
+			func() error {
+				fmt.Println("Status: ok")
+				return nil
+			},
+			nil,
+		)
+	})
+	if err == nil || !strings.Contains(err.Error(), "tsv output is not supported") {
+		t.Fatalf("expected unsupported error, got %v", err)
+	}
+}
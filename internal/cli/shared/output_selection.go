@@ -0,0 +1,206 @@
+package shared
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/jsonquery"
+)
+
+// Root-level output selection flags, applied by PrintOutput for every
+// command.
+var (
+	outputQuery   string
+	outputFields  string
+	outputColumns string
+
+	compiledQuery *jsonquery.Query
+)
+
+// BindOutputSelectionFlags registers --query, --fields and --columns.
+func BindOutputSelectionFlags(fs *flag.FlagSet) {
+	fs.StringVar(&outputQuery, "query", "", "Filter JSON output with a jq-style expression (e.g. '.data[].id')")
+	fs.StringVar(&outputFields, "fields", "", "Comma-separated fields to keep from each resource in JSON, YAML and NDJSON output (e.g. id,name,attributes.bundleId)")
+	fs.StringVar(&outputColumns, "columns", "", "Comma-separated columns to show in table, markdown, CSV and TSV output")
+}
+
+// ValidateOutputSelectionFlags rejects an invalid --query expression before
+// any request is made.
+func ValidateOutputSelectionFlags() error {
+	_, err := resolveOutputQuery()
+	return err
+}
+
+func resolveOutputQuery() (*jsonquery.Query, error) {
+	expr := strings.TrimSpace(outputQuery)
+	if expr == "" {
+		return nil, nil
+	}
+	if compiledQuery != nil && compiledQuery.String() == expr {
+		return compiledQuery, nil
+	}
+	query, err := jsonquery.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("--query: %w", err)
+	}
+	compiledQuery = query
+	return query, nil
+}
+
+// outputSelection holds the parsed --fields and --query flags.
+type outputSelection struct {
+	fields []string
+	query  *jsonquery.Query
+}
+
+func resolveOutputSelection() (outputSelection, error) {
+	query, err := resolveOutputQuery()
+	if err != nil {
+		return outputSelection{}, err
+	}
+	return outputSelection{fields: splitCSV(outputFields), query: query}, nil
+}
+
+func (s outputSelection) empty() bool {
+	return len(s.fields) == 0 && s.query == nil
+}
+
+// apply projects value onto --fields and runs --query, returning the values
+// to print.
+func (s outputSelection) apply(value any, project func(any, []string) any) ([]any, error) {
+	if len(s.fields) > 0 {
+		value = project(value, s.fields)
+	}
+	if s.query == nil {
+		return []any{value}, nil
+	}
+	results, err := s.query.Run(value)
+	if err != nil {
+		return nil, fmt.Errorf("--query: %w", err)
+	}
+	return results, nil
+}
+
+// printSelectedOutput applies --query, --fields and --columns. render is nil
+// for JSON, YAML and NDJSON output. It reports false when no selection flag
+// is set so callers print normally.
+func printSelectedOutput(data any, format string, pretty bool, render func() error) (bool, error) {
+	selection, err := resolveOutputSelection()
+	if err != nil {
+		return true, err
+	}
+	columns := splitCSV(outputColumns)
+	if selection.empty() && len(columns) == 0 {
+		return false, nil
+	}
+
+	if render != nil {
+		if !selection.empty() {
+			return true, fmt.Errorf("--query and --fields apply to JSON, YAML and NDJSON output; use --columns with --output %s", format)
+		}
+		return true, asc.RenderWithColumns(columns, render)
+	}
+	if len(columns) > 0 {
+		return true, fmt.Errorf("--columns applies to table, markdown, CSV and TSV output; use --fields or --query with --output %s", format)
+	}
+
+	value, err := toJSONValue(data)
+	if err != nil {
+		return true, err
+	}
+	switch format {
+	case "ndjson":
+		return true, writeNDJSON(value, selection)
+	case "yaml":
+		results, err := selection.apply(value, selectFields)
+		if err != nil {
+			return true, err
+		}
+		if len(results) == 1 {
+			return true, asc.PrintYAML(results[0])
+		}
+		return true, asc.PrintYAML(results)
+	default:
+		results, err := selection.apply(value, selectFields)
+		if err != nil {
+			return true, err
+		}
+		if selection.query == nil {
+			return true, printJSONOutput(results[0], pretty)
+		}
+		return true, jsonquery.WriteResults(os.Stdout, results, pretty)
+	}
+}
+
+func toJSONValue(data any) (any, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// selectFields projects each resource onto fields. For JSON:API documents
+// the resources are the data member, and links, meta and included are
+// dropped.
+func selectFields(value any, fields []string) any {
+	if document, ok := value.(map[string]any); ok {
+		if data, hasData := document["data"]; hasData {
+			value = data
+		}
+	}
+	switch v := value.(type) {
+	case []any:
+		out := make([]any, 0, len(v))
+		for _, item := range v {
+			out = append(out, projectFields(item, fields))
+		}
+		return out
+	default:
+		return projectFields(v, fields)
+	}
+}
+
+// projectFields keeps the dotted field paths of resource. A path that is
+// not found at the top level is looked up in attributes, so "name" and
+// "attributes.name" both work.
+func projectFields(resource any, fields []string) any {
+	object, ok := resource.(map[string]any)
+	if !ok {
+		return resource
+	}
+	out := make(map[string]any, len(fields))
+	for _, field := range fields {
+		value, found := lookupFieldPath(object, field)
+		if !found {
+			if attributes, ok := object["attributes"].(map[string]any); ok {
+				value, _ = lookupFieldPath(attributes, field)
+			}
+		}
+		out[field] = value
+	}
+	return out
+}
+
+func lookupFieldPath(object map[string]any, path string) (any, bool) {
+	var current any = object
+	for _, part := range strings.Split(path, ".") {
+		next, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current, ok = next[part]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
@@ -0,0 +1,94 @@
+package shared
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+func setOutputSelection(t *testing.T, query, fields, columns string) {
+	t.Helper()
+	outputQuery, outputFields, outputColumns = query, fields, columns
+	t.Cleanup(func() {
+		outputQuery, outputFields, outputColumns = "", "", ""
+	})
+}
+
+func testAppsResponse() *asc.AppsResponse {
+	return &asc.AppsResponse{
+		Data: []asc.Resource[asc.AppAttributes]{
+			{Type: "apps", ID: "1", Attributes: asc.AppAttributes{Name: "Tally", BundleID: "com.example.tally", SKU: "TALLY"}},
+			{Type: "apps", ID: "2", Attributes: asc.AppAttributes{Name: "Notes", BundleID: "com.example.notes", SKU: "NOTES"}},
+		},
+	}
+}
+
+func TestPrintOutputSelectsFields(t *testing.T) {
+	setOutputSelection(t, "", "id,name,attributes.bundleId,missing", "")
+	stdout, _ := captureOutput(t, func() {
+		if err := PrintOutput(testAppsResponse(), "json", false); err != nil {
+			t.Fatalf("PrintOutput() error: %v", err)
+		}
+	})
+	want := `[{"attributes.bundleId":"com.example.tally","id":"1","missing":null,"name":"Tally"},{"attributes.bundleId":"com.example.notes","id":"2","missing":null,"name":"Notes"}]`
+	if strings.TrimSpace(stdout) != want {
+		t.Fatalf("stdout = %s, want %s", stdout, want)
+	}
+}
+
+func TestPrintOutputRunsQueryAfterFields(t *testing.T) {
+	setOutputSelection(t, `.[] | select(.name == "Notes") | .id`, "id,name", "")
+	stdout, _ := captureOutput(t, func() {
+		if err := PrintOutput(testAppsResponse(), "json", false); err != nil {
+			t.Fatalf("PrintOutput() error: %v", err)
+		}
+	})
+	if stdout != "2\n" {
+		t.Fatalf("stdout = %q, want %q", stdout, "2\n")
+	}
+}
+
+func TestPrintOutputSelectsColumns(t *testing.T) {
+	setOutputSelection(t, "", "", "bundle-id,ID")
+	stdout, _ := captureOutput(t, func() {
+		if err := PrintOutput(testAppsResponse(), "markdown", false); err != nil {
+			t.Fatalf("PrintOutput() error: %v", err)
+		}
+	})
+	header, _, _ := strings.Cut(stdout, "\n")
+	if got := strings.Join(strings.Fields(header), " "); got != "| Bundle ID | ID |" {
+		t.Fatalf("expected Bundle ID and ID columns in order, got %q", stdout)
+	}
+	if !strings.Contains(stdout, "com.example.notes") || strings.Contains(stdout, "Tally") {
+		t.Fatalf("unexpected rows: %q", stdout)
+	}
+}
+
+func TestPrintOutputSelectionErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		fields  string
+		columns string
+		format  string
+		wantErr string
+	}{
+		{"unknown column", "", "", "Version", "table", "--columns Version not found (available: ID, Name, Bundle ID, SKU)"},
+		{"columns with json", "", "", "ID", "json", "--columns applies to table, markdown, CSV and TSV output"},
+		{"query with table", ".data", "", "", "table", "--query and --fields apply to JSON, YAML and NDJSON output"},
+		{"invalid query", ".data[", "", "", "json", "--query:"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setOutputSelection(t, test.query, test.fields, test.columns)
+			var err error
+			_, _ = captureOutput(t, func() {
+				err = PrintOutput(testAppsResponse(), test.format, false)
+			})
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package shared
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/auth"
+)
+
+// ShortLivedToken is a signed App Store Connect API token for the resolved
+// credentials, handed to code that should not see the private key.
+type ShortLivedToken struct {
+	KeyID     string
+	IssuerID  string
+	Token     string
+	ExpiresAt time.Time
+}
+
+// IssueShortLivedToken resolves credentials the same way API commands do
+// (profile, keychain, config, environment) and signs a token that expires
+// after asc.TokenLifetime.
+func IssueShortLivedToken() (ShortLivedToken, error) {
+	resolved, err := resolveCredentials()
+	if err != nil {
+		return ShortLivedToken{}, err
+	}
+	if err := auth.ValidateKeyFile(resolved.keyPath); err != nil {
+		return ShortLivedToken{}, fmt.Errorf("invalid private key: %w", err)
+	}
+	key, err := auth.LoadPrivateKey(resolved.keyPath)
+	if err != nil {
+		return ShortLivedToken{}, fmt.Errorf("failed to load private key: %w", err)
+	}
+
+	issuedAt := time.Now()
+	token, err := asc.GenerateJWT(resolved.keyID, resolved.issuerID, key)
+	if err != nil {
+		return ShortLivedToken{}, err
+	}
+	return ShortLivedToken{
+		KeyID:     resolved.keyID,
+		IssuerID:  resolved.issuerID,
+		Token:     token,
+		ExpiresAt: issuedAt.Add(asc.TokenLifetime).UTC(),
+	}, nil
+}
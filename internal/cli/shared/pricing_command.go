@@ -45,7 +45,10 @@ func NewPricingSetCommand(config PricingSetCommandConfig) *ffcli.Command {
 		FlagSet:    fs,
 		UsageFunc:  DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
-			resolvedAppID := resolveAppID(*appID)
+			resolvedAppID, err := resolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("%s: %w", config.ErrorPrefix, err)
+			}
 			if resolvedAppID == "" {
 				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
 				return flag.ErrHelp
@@ -0,0 +1,314 @@
+package shared
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/validation"
+)
+
+const (
+	sarifSchemaURI      = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion        = "2.1.0"
+	sarifInformationURI = "https://github.com/rudrankriyam/App-Store-Connect-CLI"
+
+	// CommandErrorRuleID identifies a command failure that produced no checks.
+	CommandErrorRuleID = "asc.command.error"
+
+	// sarifAppStoreConnectURI is the artifact for results about App Store
+	// Connect resources rather than a local file. Code scanning drops results
+	// without a physical location.
+	sarifAppStoreConnectURI = "app-store-connect"
+)
+
+// SARIFReport is a SARIF 2.1.0 log for code scanning uploads.
+type SARIFReport struct {
+	Command     string        // Command path (e.g., asc validate)
+	ToolVersion string        // asc version
+	Checks      []ReportCheck // Validation findings and the files they were found in
+	Failure     string        // Command error when no checks explain it
+}
+
+// Write writes the SARIF report to the specified file path.
+func (r *SARIFReport) Write(path string) error {
+	if path == "" {
+		return fmt.Errorf("report file path is empty")
+	}
+
+	data, err := r.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal SARIF report: %w", err)
+	}
+
+	if _, err := WriteStreamToFile(path, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("failed to write report file: %w", err)
+	}
+	return nil
+}
+
+// Marshal marshals the report to indented SARIF JSON.
+func (r *SARIFReport) Marshal() ([]byte, error) {
+	rules := make([]sarifRule, 0)
+	ruleIndex := make(map[string]int)
+	results := make([]sarifResult, 0, len(r.Checks)+1)
+
+	for _, check := range r.Checks {
+		ruleID := strings.TrimSpace(check.ID)
+		if ruleID == "" {
+			ruleID = "asc.validation"
+		}
+		level := sarifLevel(check.Severity)
+		index, ok := ruleIndex[ruleID]
+		if !ok {
+			index = len(rules)
+			ruleIndex[ruleID] = index
+			rule := sarifRule{
+				ID:                   ruleID,
+				ShortDescription:     sarifMessage{Text: sarifRuleDescription(ruleID)},
+				DefaultConfiguration: sarifRuleConfiguration{Level: level},
+			}
+			if check.Remediation != "" {
+				rule.Help = &sarifMessage{Text: check.Remediation}
+			}
+			rules = append(rules, rule)
+		}
+
+		result := sarifResult{
+			RuleID:    ruleID,
+			RuleIndex: index,
+			Level:     level,
+			Message:   sarifMessage{Text: check.Message},
+			PartialFingerprints: map[string]string{
+				"ascCheck/v1": sarifFingerprint(ruleID, check.ResourceType, check.ResourceID, check.Locale, check.Field),
+			},
+		}
+		location := sarifLocation{PhysicalLocation: sarifPhysicalLocationFor(check.Artifact)}
+		if logical := sarifLogicalLocation(check.CheckResult); logical != nil {
+			location.LogicalLocations = []sarifLogicalLocationEntry{*logical}
+		}
+		result.Locations = []sarifLocation{location}
+		properties := map[string]string{}
+		for key, value := range map[string]string{
+			"severity":     string(check.Severity),
+			"remediation":  check.Remediation,
+			"locale":       check.Locale,
+			"field":        check.Field,
+			"resourceType": check.ResourceType,
+			"resourceId":   check.ResourceID,
+		} {
+			if value != "" {
+				properties[key] = value
+			}
+		}
+		result.Properties = properties
+		results = append(results, result)
+	}
+
+	if len(r.Checks) == 0 && r.Failure != "" {
+		ruleIndex[CommandErrorRuleID] = len(rules)
+		rules = append(rules, sarifRule{
+			ID:                   CommandErrorRuleID,
+			ShortDescription:     sarifMessage{Text: "asc command failed"},
+			DefaultConfiguration: sarifRuleConfiguration{Level: "error"},
+		})
+		results = append(results, sarifResult{
+			RuleID:    CommandErrorRuleID,
+			RuleIndex: ruleIndex[CommandErrorRuleID],
+			Level:     "error",
+			Message:   sarifMessage{Text: r.Failure},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocationFor("")}},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchemaURI,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "asc",
+				InformationURI: sarifInformationURI,
+				Version:        r.ToolVersion,
+				Rules:          rules,
+			}},
+			Invocations: []sarifInvocation{{
+				CommandLine:         r.Command,
+				ExecutionSuccessful: r.Failure == "" || len(r.Checks) > 0,
+			}},
+			Results: results,
+		}},
+	}
+
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func sarifLevel(severity validation.Severity) string {
+	switch severity {
+	case validation.SeverityError:
+		return "error"
+	case validation.SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifRuleDescription describes a rule by its ID, so the description stays
+// the same whichever result introduced the rule. "metadata.length.description"
+// becomes "Metadata length: description".
+func sarifRuleDescription(ruleID string) string {
+	segments := strings.Split(ruleID, ".")
+	for i, segment := range segments {
+		words := strings.Split(segment, "_")
+		for j, word := range words {
+			if slices.Contains(sarifRuleAcronyms, word) {
+				words[j] = strings.ToUpper(word)
+			}
+		}
+		segments[i] = strings.Join(words, " ")
+	}
+	description := segments[len(segments)-1]
+	if len(segments) > 1 {
+		description = strings.Join(segments[:len(segments)-1], " ") + ": " + description
+	}
+	if description == "" {
+		return ruleID
+	}
+	return strings.ToUpper(description[:1]) + description[1:]
+}
+
+// sarifRuleAcronyms are rule ID words shown in upper case.
+var sarifRuleAcronyms = []string{"api", "asc", "iap", "id", "ipa", "url"}
+
+// sarifPhysicalLocationFor points at the local file a result was found in,
+// relative to the working directory when possible, or at a placeholder for
+// App Store Connect resources.
+func sarifPhysicalLocationFor(artifact string) sarifPhysicalLocation {
+	uri := sarifAppStoreConnectURI
+	if artifact = strings.TrimSpace(artifact); artifact != "" {
+		uri = filepath.Clean(artifact)
+		if filepath.IsAbs(uri) {
+			if wd, err := os.Getwd(); err == nil {
+				if rel, err := filepath.Rel(wd, uri); err == nil && !strings.HasPrefix(rel, "..") {
+					uri = rel
+				}
+			}
+		}
+		uri = filepath.ToSlash(uri)
+	}
+	return sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: uri}}
+}
+
+// sarifLogicalLocation describes the App Store Connect resource a check is
+// about.
+func sarifLogicalLocation(check validation.CheckResult) *sarifLogicalLocationEntry {
+	parts := make([]string, 0, 4)
+	for _, part := range []string{check.ResourceType, check.ResourceID, check.Locale, check.Field} {
+		if strings.TrimSpace(part) != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	name := check.Field
+	if name == "" {
+		name = parts[len(parts)-1]
+	}
+	kind := "resource"
+	if check.Field != "" {
+		kind = "member"
+	}
+	return &sarifLogicalLocationEntry{
+		Name:               name,
+		FullyQualifiedName: strings.Join(parts, "/"),
+		Kind:               kind,
+	}
+}
+
+func sarifFingerprint(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:16])
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations"`
+	Results     []sarifResult     `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string                 `json:"id"`
+	ShortDescription     sarifMessage           `json:"shortDescription"`
+	Help                 *sarifMessage          `json:"help,omitempty"`
+	DefaultConfiguration sarifRuleConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifRuleConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifInvocation struct {
+	CommandLine         string `json:"commandLine,omitempty"`
+	ExecutionSuccessful bool   `json:"executionSuccessful"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+	Properties          map[string]string `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation       `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocationEntry `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocationEntry struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
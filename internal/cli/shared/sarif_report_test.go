@@ -0,0 +1,209 @@
+package shared
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/validation"
+)
+
+func TestSARIFReport_MapsChecks(t *testing.T) {
+	report := SARIFReport{
+		Command:     "asc validate",
+		ToolVersion: "1.2.3",
+		Checks: []ReportCheck{
+			{CheckResult: validation.CheckResult{
+				ID:           "metadata.length.description",
+				Severity:     validation.SeverityError,
+				Message:      "Description exceeds 4000 characters",
+				Remediation:  "Shorten the description",
+				Locale:       "en-US",
+				Field:        "description",
+				ResourceType: "appStoreVersionLocalizations",
+				ResourceID:   "LOC_ID",
+			}},
+			{
+				CheckResult: validation.CheckResult{
+					ID:       "metadata.length.description",
+					Severity: validation.SeverityError,
+					Message:  "Description exceeds 4000 characters",
+					Locale:   "de-DE",
+				},
+				Artifact: filepath.Join("build", "App.ipa"),
+			},
+			{CheckResult: validation.CheckResult{ID: "screenshots.optional", Severity: validation.SeverityInfo, Message: "No iPad screenshots"}},
+		},
+		Failure: "validate: found 2 blocking issue(s)",
+	}
+
+	path := filepath.Join(t.TempDir(), "report.sarif")
+	if err := report.Write(path); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Version string `json:"version"`
+					Rules   []struct {
+						ID               string `json:"id"`
+						ShortDescription struct {
+							Text string `json:"text"`
+						} `json:"shortDescription"`
+						Help *struct {
+							Text string `json:"text"`
+						} `json:"help"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Invocations []struct {
+				ExecutionSuccessful bool `json:"executionSuccessful"`
+			} `json:"invocations"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				RuleIndex int    `json:"ruleIndex"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+					} `json:"physicalLocation"`
+					LogicalLocations []struct {
+						FullyQualifiedName string `json:"fullyQualifiedName"`
+					} `json:"logicalLocations"`
+				} `json:"locations"`
+				Properties map[string]string `json:"properties"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("unmarshal SARIF: %v", err)
+	}
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected SARIF envelope: %s", data)
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Version != "1.2.3" {
+		t.Fatalf("driver version = %q, want 1.2.3", run.Tool.Driver.Version)
+	}
+	if len(run.Tool.Driver.Rules) != 2 {
+		t.Fatalf("expected duplicate check IDs to share a rule, got %d rules", len(run.Tool.Driver.Rules))
+	}
+	if got := run.Tool.Driver.Rules[0].ShortDescription.Text; got != "Metadata length: description" {
+		t.Fatalf("rule description = %q, want a fixed per-rule description", got)
+	}
+	if run.Tool.Driver.Rules[0].Help == nil || run.Tool.Driver.Rules[0].Help.Text != "Shorten the description" {
+		t.Fatalf("expected remediation as rule help, got %+v", run.Tool.Driver.Rules[0].Help)
+	}
+	if !run.Invocations[0].ExecutionSuccessful {
+		t.Fatal("expected findings to count as a successful execution")
+	}
+	if len(run.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(run.Results))
+	}
+
+	first := run.Results[0]
+	if first.Level != "error" || first.RuleIndex != 0 {
+		t.Fatalf("unexpected first result: %+v", first)
+	}
+	if first.Properties["remediation"] != "Shorten the description" || first.Properties["locale"] != "en-US" {
+		t.Fatalf("unexpected result properties: %+v", first.Properties)
+	}
+	if got := first.Locations[0].LogicalLocations[0].FullyQualifiedName; got != "appStoreVersionLocalizations/LOC_ID/en-US/description" {
+		t.Fatalf("unexpected logical location %q", got)
+	}
+	if run.Results[2].Level != "note" || run.Results[2].RuleIndex != 1 || len(run.Results[2].Locations[0].LogicalLocations) != 0 {
+		t.Fatalf("unexpected info result: %+v", run.Results[2])
+	}
+
+	wantURIs := []string{"app-store-connect", "build/App.ipa", "app-store-connect"}
+	for i, want := range wantURIs {
+		if len(run.Results[i].Locations) != 1 {
+			t.Fatalf("result %d: expected one location, got %+v", i, run.Results[i].Locations)
+		}
+		if got := run.Results[i].Locations[0].PhysicalLocation.ArtifactLocation.URI; got != want {
+			t.Fatalf("result %d: artifact uri = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestSARIFRuleDescription(t *testing.T) {
+	tests := map[string]string{
+		"metadata.length.description":    "Metadata length: description",
+		"ipa.info.encryption_missing":    "IPA info: encryption missing",
+		"build.invalid.processing_state": "Build invalid: processing state",
+		"asc.validation":                 "ASC: validation",
+		"lint":                           "Lint",
+	}
+	for ruleID, want := range tests {
+		if got := sarifRuleDescription(ruleID); got != want {
+			t.Errorf("sarifRuleDescription(%q) = %q, want %q", ruleID, got, want)
+		}
+	}
+}
+
+func TestSARIFPhysicalLocationIsRelativeToWorkingDirectory(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd() error = %v", err)
+	}
+	if got := sarifPhysicalLocationFor(filepath.Join(wd, "build", "App.ipa")).ArtifactLocation.URI; got != "build/App.ipa" {
+		t.Fatalf("uri = %q, want build/App.ipa", got)
+	}
+	outside := filepath.Join(filepath.Dir(wd), "other", "App.ipa")
+	if got := sarifPhysicalLocationFor(outside).ArtifactLocation.URI; got != filepath.ToSlash(outside) {
+		t.Fatalf("uri = %q, want %q", got, filepath.ToSlash(outside))
+	}
+}
+
+func TestSARIFReport_CommandFailureWithoutChecks(t *testing.T) {
+	report := SARIFReport{Command: "asc builds list", Failure: "boom"}
+	data, err := report.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var log struct {
+		Runs []struct {
+			Invocations []struct {
+				ExecutionSuccessful bool `json:"executionSuccessful"`
+			} `json:"invocations"`
+			Results []struct {
+				RuleID  string `json:"ruleId"`
+				Message struct {
+					Text string `json:"text"`
+				} `json:"message"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("unmarshal SARIF: %v", err)
+	}
+	run := log.Runs[0]
+	if run.Invocations[0].ExecutionSuccessful {
+		t.Fatal("expected failed execution")
+	}
+	if len(run.Results) != 1 || run.Results[0].RuleID != CommandErrorRuleID || run.Results[0].Message.Text != "boom" {
+		t.Fatalf("unexpected results: %+v", run.Results)
+	}
+	if len(run.Results[0].Locations) != 1 || run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI != "app-store-connect" {
+		t.Fatalf("expected placeholder artifact location, got %+v", run.Results[0].Locations)
+	}
+}
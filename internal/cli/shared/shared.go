@@ -36,6 +36,9 @@ const (
 	defaultOutputEnvVar    = "ASC_DEFAULT_OUTPUT"
 )
 
+// outputFormats lists the formats accepted by --output and ASC_DEFAULT_OUTPUT.
+var outputFormats = []string{"json", "table", "markdown", "csv", "tsv", "yaml", "ndjson"}
+
 const (
 	PrivateKeyEnvVar       = privateKeyEnvVar
 	PrivateKeyBase64EnvVar = privateKeyBase64EnvVar
@@ -65,6 +68,8 @@ var (
 	debug               OptionalBool
 	apiDebug            OptionalBool
 	noUpdate            bool
+	recordDir           string
+	replayDir           string
 
 	getCredentialsWithSourceFn = auth.GetCredentialsWithSource
 )
@@ -88,7 +93,20 @@ func BindRootFlags(fs *flag.FlagSet) {
 	fs.Var(&debug, "debug", "Enable debug logging to stderr")
 	fs.Var(&apiDebug, "api-debug", "Enable HTTP debug logging to stderr (redacts sensitive values)")
 	fs.BoolVar(&noUpdate, "no-update", false, "Skip update checks and auto-update")
+	fs.StringVar(&recordDir, "record", "", "Record redacted HTTP interactions to a cassette directory")
+	fs.StringVar(&replayDir, "replay", "", "Replay HTTP interactions from a cassette directory instead of the network")
 	BindCIFlags(fs)
+	BindOutputSelectionFlags(fs)
+	requestedOutputFormat = ""
+	streamedOutput = false
+}
+
+// ValidateCassetteFlags rejects conflicting --record/--replay usage.
+func ValidateCassetteFlags() error {
+	if strings.TrimSpace(recordDir) != "" && strings.TrimSpace(replayDir) != "" {
+		return fmt.Errorf("--record and --replay are mutually exclusive")
+	}
+	return nil
 }
 
 // SelectedProfile returns the current profile override.
@@ -374,10 +392,6 @@ func resolveCredentials() (resolvedCredentials, error) {
 }
 
 func getASCClient() (*asc.Client, error) {
-	resolved, err := resolveCredentials()
-	if err != nil {
-		return nil, err
-	}
 	if retryLog.IsSet() {
 		value := retryLog.Value()
 		asc.SetRetryLogOverride(&value)
@@ -396,6 +410,22 @@ func getASCClient() (*asc.Client, error) {
 	} else {
 		asc.SetDebugHTTPOverride(nil)
 	}
+	switch {
+	case strings.TrimSpace(recordDir) != "":
+		asc.SetCassette(asc.CassetteModeRecord, recordDir)
+	case strings.TrimSpace(replayDir) != "":
+		// Replayed responses never reach App Store Connect, so don't
+		// require credentials for them.
+		asc.SetCassette(asc.CassetteModeReplay, replayDir)
+		return asc.NewReplayClient(replayDir)
+	default:
+		asc.SetCassette(asc.CassetteModeOff, "")
+	}
+
+	resolved, err := resolveCredentials()
+	if err != nil {
+		return nil, err
+	}
 	return asc.NewClient(resolved.keyID, resolved.issuerID, resolved.keyPath)
 }
 
@@ -540,20 +570,10 @@ func strictAuthEnabled() bool {
 }
 
 func printOutput(data any, format string, pretty bool) error {
-	format, err := validateOutputFormat(format, pretty)
-	if err != nil {
-		return err
-	}
-	switch format {
-	case "json":
-		return printJSONOutput(data, pretty)
-	case "markdown":
-		return asc.PrintMarkdown(data)
-	case "table":
-		return asc.PrintTable(data)
-	default:
-		return fmt.Errorf("unsupported format: %s", format)
-	}
+	return printOutputWithRenderers(data, format, pretty,
+		func() error { return asc.PrintTable(data) },
+		func() error { return asc.PrintMarkdown(data) },
+	)
 }
 
 func printOutputWithRenderers(data any, format string, pretty bool, tableRenderer, markdownRenderer func() error) error {
@@ -561,22 +581,53 @@ func printOutputWithRenderers(data any, format string, pretty bool, tableRendere
 	if err != nil {
 		return err
 	}
+	if consumeStreamedOutput() {
+		return nil
+	}
+	var render func() error
 	switch format {
-	case "json":
-		return printJSONOutput(data, pretty)
+	case "json", "yaml", "ndjson":
 	case "table":
 		if tableRenderer == nil {
 			return fmt.Errorf("table renderer is required")
 		}
-		return tableRenderer()
+		render = tableRenderer
 	case "markdown":
 		if markdownRenderer == nil {
 			return fmt.Errorf("markdown renderer is required")
 		}
-		return markdownRenderer()
+		render = markdownRenderer
+	case "csv", "tsv":
+		if tableRenderer == nil {
+			return fmt.Errorf("%s output is not supported for this command", format)
+		}
+		comma := ','
+		if format == "tsv" {
+			comma = '\t'
+		}
+		render = func() error {
+			return asc.RenderDelimited(comma, tableRenderer)
+		}
 	default:
 		return fmt.Errorf("unsupported format: %s", format)
 	}
+	if handled, err := printSelectedOutput(data, format, pretty, render); handled {
+		return err
+	}
+	switch format {
+	case "json":
+		return printJSONOutput(data, pretty)
+	case "yaml":
+		return asc.PrintYAML(data)
+	case "ndjson":
+		value, err := toJSONValue(data)
+		if err != nil {
+			return err
+		}
+		return writeNDJSON(value, outputSelection{})
+	default:
+		return render()
+	}
 }
 
 func printJSONOutput(data any, pretty bool) error {
@@ -591,18 +642,22 @@ func NormalizeOutputFormat(format string) string {
 	switch strings.ToLower(strings.TrimSpace(format)) {
 	case "md":
 		return "markdown"
+	case "yml":
+		return "yaml"
+	case "jsonl":
+		return "ndjson"
 	default:
 		return strings.ToLower(strings.TrimSpace(format))
 	}
 }
 
 func validateOutputFormat(format string, pretty bool) (string, error) {
-	return validateOutputFormatAllowed(format, pretty, "json", "table", "markdown")
+	return validateOutputFormatAllowed(format, pretty, outputFormats...)
 }
 
 func validateOutputFormatAllowed(format string, pretty bool, allowed ...string) (string, error) {
 	if len(allowed) == 0 {
-		allowed = []string{"json", "table", "markdown"}
+		allowed = outputFormats
 	}
 	normalized := NormalizeOutputFormat(format)
 	if normalized == "" {
@@ -678,7 +733,7 @@ var (
 
 // DefaultOutputFormat returns the default output format for CLI commands.
 // It checks the ASC_DEFAULT_OUTPUT environment variable first, falling back to "json".
-// Valid values are "json", "table", "markdown", and "md".
+// Valid values are the formats accepted by --output and their aliases.
 func DefaultOutputFormat() string {
 	defaultOutputOnce.Do(func() {
 		defaultOutputValue = resolveDefaultOutput()
@@ -692,13 +747,11 @@ func resolveDefaultOutput() string {
 		return "json"
 	}
 	normalized := strings.ToLower(env)
-	switch normalized {
-	case "json", "table", "markdown", "md":
+	if slices.Contains(outputFormats, NormalizeOutputFormat(normalized)) {
 		return normalized
-	default:
-		fmt.Fprintf(os.Stderr, "Warning: invalid %s value %q (expected json, table, markdown, or md); using json\n", defaultOutputEnvVar, env)
-		return "json"
 	}
+	fmt.Fprintf(os.Stderr, "Warning: invalid %s value %q (expected %s); using json\n", defaultOutputEnvVar, env, strings.Join(outputFormats, ", "))
+	return "json"
 }
 
 // BindOutputFlagsWith registers a custom output-format flag and --pretty.
@@ -707,8 +760,10 @@ func BindOutputFlagsWith(fs *flag.FlagSet, flagName, defaultValue, usage string)
 	if name == "" {
 		name = "output"
 	}
+	value := defaultValue
+	fs.Var(outputFormatFlag{value: &value}, name, usage)
 	return OutputFlags{
-		Output: fs.String(name, defaultValue, usage),
+		Output: &value,
 		Pretty: BindPrettyJSONFlag(fs),
 	}
 }
@@ -720,7 +775,7 @@ func BindPrettyJSONFlag(fs *flag.FlagSet) *bool {
 
 // BindOutputFlags registers --output and --pretty flags on the provided flagset.
 func BindOutputFlags(fs *flag.FlagSet) OutputFlags {
-	return BindOutputFlagsWith(fs, "output", DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	return BindOutputFlagsWith(fs, "output", DefaultOutputFormat(), "Output format: json (default), table, markdown, csv, tsv, yaml, ndjson")
 }
 
 // BindMetadataOutputFlags registers --output-format and --pretty flags on the provided flagset.
@@ -732,7 +787,11 @@ func BindMetadataOutputFlags(fs *flag.FlagSet) MetadataOutputFlags {
 	}
 }
 
-func resolveAppID(appID string) string {
+func resolveAppID(ctx context.Context, appID string) (string, error) {
+	return resolveAppIdentifier(ctx, resolveAppValue(appID))
+}
+
+func resolveAppValue(appID string) string {
 	if appID != "" {
 		return appID
 	}
@@ -797,7 +856,11 @@ func validateNextURL(next string) error {
 	if err != nil {
 		return fmt.Errorf("--next must be a valid URL: %w", err)
 	}
-	if parsed.Scheme != "https" || parsed.Host != "api.appstoreconnect.apple.com" {
+	base, err := url.Parse(asc.ResolveBaseURL())
+	if err != nil {
+		return fmt.Errorf("invalid base URL: %w", err)
+	}
+	if parsed.Scheme != base.Scheme || parsed.Host != base.Host {
 		return fmt.Errorf("--next must be an App Store Connect URL")
 	}
 	return nil
@@ -860,8 +923,17 @@ func IsAppAvailabilityMissing(err error) bool {
 	return isAppAvailabilityMissing(err)
 }
 
-func ResolveAppID(appID string) string {
-	return resolveAppID(appID)
+// ResolveAppID returns the app ID from --app, ASC_APP_ID or the config,
+// resolving bundle IDs and app names through the apps endpoint. It fails when
+// a bundle ID or name matches no app or several apps.
+func ResolveAppID(ctx context.Context, appID string) (string, error) {
+	return resolveAppID(ctx, appID)
+}
+
+// ResolveAppIDValue returns the --app, ASC_APP_ID or config value without
+// resolving bundle IDs or names, for checking whether an app was given.
+func ResolveAppIDValue(appID string) string {
+	return strings.TrimSpace(resolveAppValue(appID))
 }
 
 func ContextWithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
@@ -287,7 +287,10 @@ func TestValidateOutputFormat(t *testing.T) {
 		{name: "json allows pretty", input: "json", pretty: true, wantFormat: "json"},
 		{name: "md alias", input: "md", pretty: false, wantFormat: "markdown"},
 		{name: "table pretty rejected", input: "table", pretty: true, wantErr: "--pretty is only valid with JSON output"},
-		{name: "unsupported rejected", input: "yaml", pretty: false, wantErr: "unsupported format: yaml"},
+		{name: "yml alias", input: "yml", pretty: false, wantFormat: "yaml"},
+		{name: "jsonl alias", input: "jsonl", pretty: false, wantFormat: "ndjson"},
+		{name: "csv pretty rejected", input: "csv", pretty: true, wantErr: "--pretty is only valid with JSON output"},
+		{name: "unsupported rejected", input: "xml", pretty: false, wantErr: "unsupported format: xml"},
 	}
 
 	for _, tc := range tests {
@@ -353,8 +356,8 @@ func TestValidateOutputFormatAllowed_EmptyAllowedFallsBackToDefaultSet(t *testin
 		t.Fatalf("expected table, got %q", got)
 	}
 
-	_, err = ValidateOutputFormatAllowed("yaml", false)
-	if err == nil || !strings.Contains(err.Error(), "unsupported format: yaml") {
+	_, err = ValidateOutputFormatAllowed("xml", false)
+	if err == nil || !strings.Contains(err.Error(), "unsupported format: xml") {
 		t.Fatalf("expected unsupported format error, got %v", err)
 	}
 }
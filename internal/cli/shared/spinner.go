@@ -135,8 +135,12 @@ type FetchFunc func(ctx context.Context) (asc.PaginatedResponse, error)
 
 // PaginateWithSpinner fetches all pages with a spinner on stderr.
 // It wraps both the initial fetch and the pagination loop so the spinner
-// is visible even for single-page results.
+// is visible even for single-page results. With --output ndjson, pages are
+// written as they arrive instead (see streamPagesNDJSON).
 func PaginateWithSpinner(ctx context.Context, fetch FetchFunc, next asc.PaginateFunc) (asc.PaginatedResponse, error) {
+	if streamNDJSONRequested() {
+		return streamPagesNDJSON(ctx, fetch, next)
+	}
 	var result asc.PaginatedResponse
 	err := WithSpinner("", func() error {
 		firstPage, fetchErr := fetch(ctx)
@@ -30,6 +30,14 @@ func ShotsFrameCommand() *ffcli.Command {
 		string(screenshots.DefaultFrameDevice()),
 		fmt.Sprintf("Frame device: %s", strings.Join(screenshots.FrameDeviceValues(), ", ")),
 	)
+	renderer := fs.String(
+		"renderer",
+		string(screenshots.DefaultFrameRenderer()),
+		fmt.Sprintf("Renderer: %s", strings.Join(screenshots.FrameRendererValues(), ", ")),
+	)
+	background := fs.String("background", "", "Background color (#RRGGBB) or comma-separated gradient stops (input mode)")
+	caption := fs.String("caption", "", "Caption text drawn above the device (input mode)")
+	font := fs.String("font", "", "TTF/OTF font file for --caption (input mode; defaults to bundled Go Bold)")
 	output := shared.BindOutputFlags(fs)
 	watch := fs.Bool("watch", false, "Watch config and asset files for changes, auto-regenerate (requires --config)")
 	watchDebounce := fs.Duration("watch-debounce", 500*time.Millisecond, "Debounce delay between change detection and regeneration")
@@ -44,10 +52,28 @@ func ShotsFrameCommand() *ffcli.Command {
 
 Requires Koubou v0.13.0 (pip install koubou==0.13.0).
 
+Use --renderer native to render in-process without external tools. It draws
+bundled bezels for every --device value and supports this Koubou YAML subset:
+  project: device, output_size, output_dir
+  defaults.background / screenshots.<name>.background:
+    type (solid, linear, radial), colors, direction (degrees, 180 = top to bottom)
+  content items:
+    image: asset, position, scale, frame
+    text:  content, position, size, color, weight, alignment, max_width,
+           line_height, font (TTF/OTF path)
+
 Use either --input (auto-generated Koubou config) or --config (explicit Koubou YAML).
+--background, --caption and --font style the auto-generated config and cannot
+be combined with --config.
 
 Use --watch with --config to start a live watcher that auto-regenerates
-framed screenshots whenever the YAML config or referenced raw assets change.`,
+framed screenshots whenever the YAML config or referenced raw assets change.
+
+Examples:
+  asc screenshots frame --input "./screenshots/raw/home.png" --device iphone-air
+  asc screenshots frame --input "./screenshots/raw/home.png" --background "#1E3A8A,#60A5FA" --caption "Track every build"
+  asc screenshots frame --config "./koubou.yaml" --output-dir "./screenshots/framed"
+  asc screenshots frame --config "./koubou.yaml" --renderer native`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -61,10 +87,34 @@ framed screenshots whenever the YAML config or referenced raw assets change.`,
 				fmt.Fprintln(os.Stderr, "Error: use either --input or --config, not both")
 				return flag.ErrHelp
 			}
+			if configVal != "" {
+				for _, styleFlag := range []struct {
+					name  string
+					value string
+				}{
+					{name: "background", value: *background},
+					{name: "caption", value: *caption},
+					{name: "font", value: *font},
+				} {
+					if strings.TrimSpace(styleFlag.value) != "" {
+						fmt.Fprintf(os.Stderr, "Error: --%s only applies with --input; set it in the --config YAML instead\n", styleFlag.name)
+						return flag.ErrHelp
+					}
+				}
+			}
 			if *watch && configVal == "" {
 				fmt.Fprintln(os.Stderr, "Error: --watch requires --config")
 				return flag.ErrHelp
 			}
+			rendererVal, err := screenshots.ParseFrameRenderer(*renderer)
+			if err != nil {
+				fmt.Fprintf(
+					os.Stderr,
+					"Error: --renderer must be one of: %s\n",
+					strings.Join(screenshots.FrameRendererValues(), ", "),
+				)
+				return flag.ErrHelp
+			}
 			if configVal != "" {
 				absConfig, err := filepath.Abs(configVal)
 				if err != nil {
@@ -78,12 +128,10 @@ framed screenshots whenever the YAML config or referenced raw assets change.`,
 			if *watch {
 				watchCtx, stop := signal.NotifyContext(ctx, os.Interrupt)
 				defer stop()
-				var opts *screenshots.WatchOptions
+				opts := &screenshots.WatchOptions{Renderer: rendererVal}
 				if reviewDir := strings.TrimSpace(*watchReviewDir); reviewDir != "" {
-					opts = &screenshots.WatchOptions{
-						ReviewOutputDir: reviewDir,
-						ReviewRawDir:    strings.TrimSpace(*watchRawDir),
-					}
+					opts.ReviewOutputDir = reviewDir
+					opts.ReviewRawDir = strings.TrimSpace(*watchRawDir)
 				}
 				return screenshots.WatchAndRegenerate(watchCtx, configVal, *watchDebounce, nil, opts)
 			}
@@ -122,6 +170,10 @@ framed screenshots whenever the YAML config or referenced raw assets change.`,
 				OutputPath: outPath,
 				Device:     string(deviceVal),
 				ConfigPath: configVal,
+				Renderer:   string(rendererVal),
+				Background: *background,
+				Caption:    *caption,
+				Font:       *font,
 			})
 			if err != nil {
 				return fmt.Errorf("screenshots frame: %w", err)
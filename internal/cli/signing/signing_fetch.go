@@ -79,7 +79,10 @@ Examples:
 			requestCtx, cancel := shared.ContextWithTimeout(ctx)
 			defer cancel()
 
-			resolvedAppID := shared.ResolveAppID(*appID)
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("signing fetch: %w", err)
+			}
 			if resolvedAppID != "" {
 				if err := validateBundleIDMatchesApp(requestCtx, client, resolvedAppID, bundle); err != nil {
 					return fmt.Errorf("signing fetch: %w", err)
@@ -75,7 +75,7 @@ Examples:
 		Exec: func(ctx context.Context, args []string) error {
 			requestedSubID := strings.TrimSpace(*subscriptionID)
 			requestedAppID := strings.TrimSpace(*appID)
-			if requestedSubID == "" && shared.ResolveAppID(requestedAppID) == "" {
+			if requestedSubID == "" && shared.ResolveAppIDValue(requestedAppID) == "" {
 				fmt.Fprintln(os.Stderr, "Error: --app or --subscription-id is required")
 				return flag.ErrHelp
 			}
@@ -105,7 +105,10 @@ Examples:
 				}
 				subs = []subWithGroup{{Sub: resp.Data, GroupName: ""}}
 			} else {
-				resolvedAppID := shared.ResolveAppID(requestedAppID)
+				resolvedAppID, err := shared.ResolveAppID(ctx, requestedAppID)
+				if err != nil {
+					return fmt.Errorf("subscriptions pricing: %w", err)
+				}
 
 				groupsCtx, groupsCancel := shared.ContextWithTimeout(ctx)
 				groupsResp, err := client.GetSubscriptionGroups(groupsCtx, resolvedAppID, asc.WithSubscriptionGroupsLimit(200))
@@ -143,7 +143,11 @@ Examples:
 			idValue := strings.TrimSpace(*id)
 			appValue := ""
 			if idValue == "" {
-				appValue = shared.ResolveAppID(*appID)
+				var err error
+				appValue, err = shared.ResolveAppID(ctx, *appID)
+				if err != nil {
+					return fmt.Errorf("beta-license-agreements get: %w", err)
+				}
 			}
 			if idValue == "" && appValue == "" {
 				fmt.Fprintln(os.Stderr, "Error: --id or --app is required (or set ASC_APP_ID)")
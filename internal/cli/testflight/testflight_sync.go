@@ -94,11 +94,13 @@ func TestFlightSyncCommand() *ffcli.Command {
 		LongHelp: `Sync TestFlight configuration.
 
 Examples:
-  asc testflight sync pull --app "APP_ID" --output "./testflight.yaml"`,
+  asc testflight sync pull --app "APP_ID" --output "./testflight.yaml"
+  asc testflight sync push --input "./testflight.yaml" --dry-run`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
 			TestFlightSyncPullCommand(),
+			TestFlightSyncPushCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			return flag.ErrHelp
@@ -132,7 +134,10 @@ Examples:
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
-			resolvedAppID := shared.ResolveAppID(*appID)
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("testflight sync pull: %w", err)
+			}
 			if resolvedAppID == "" {
 				fmt.Fprintf(os.Stderr, "Error: --app is required (or set ASC_APP_ID)\n\n")
 				return flag.ErrHelp
@@ -336,7 +341,9 @@ func paginateBetaGroups(ctx context.Context, client testFlightSyncClient, appID
 		return &asc.BetaGroupsResponse{}, nil
 	}
 	allPages, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
-		return client.GetBetaGroups(ctx, appID, asc.WithBetaGroupsNextURL(nextURL))
+		requestCtx, cancel := shared.ContextWithTimeout(ctx)
+		defer cancel()
+		return client.GetBetaGroups(requestCtx, appID, asc.WithBetaGroupsNextURL(nextURL))
 	})
 	if err != nil {
 		return nil, err
@@ -356,7 +363,9 @@ func paginateBetaGroupBuilds(ctx context.Context, client testFlightSyncClient, g
 		return &asc.BuildsResponse{}, nil
 	}
 	allPages, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
-		return client.GetBetaGroupBuilds(ctx, groupID, asc.WithBetaGroupBuildsNextURL(nextURL))
+		requestCtx, cancel := shared.ContextWithTimeout(ctx)
+		defer cancel()
+		return client.GetBetaGroupBuilds(requestCtx, groupID, asc.WithBetaGroupBuildsNextURL(nextURL))
 	})
 	if err != nil {
 		return nil, err
@@ -376,7 +385,9 @@ func paginateBetaGroupTesters(ctx context.Context, client testFlightSyncClient,
 		return &asc.BetaTestersResponse{}, nil
 	}
 	allPages, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
-		return client.GetBetaGroupTesters(ctx, groupID, asc.WithBetaGroupTestersNextURL(nextURL))
+		requestCtx, cancel := shared.ContextWithTimeout(ctx)
+		defer cancel()
+		return client.GetBetaGroupTesters(requestCtx, groupID, asc.WithBetaGroupTestersNextURL(nextURL))
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,834 @@
+package testflight
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+	"gopkg.in/yaml.v3"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	testFlightSyncActionCreateGroup   = "create-group"
+	testFlightSyncActionUpdateGroup   = "update-group"
+	testFlightSyncActionAddBuilds     = "add-builds"
+	testFlightSyncActionRemoveBuilds  = "remove-builds"
+	testFlightSyncActionAddTesters    = "add-testers"
+	testFlightSyncActionRemoveTesters = "remove-testers"
+	testFlightSyncActionCreateTester  = "create-tester"
+)
+
+var errTestFlightPublicLinkLimit = errors.New("publicLinkLimit must be greater than 0")
+
+// testFlightSyncChange is a single planned mutation produced by push.
+type testFlightSyncChange struct {
+	Action  string   `json:"action"`
+	Group   string   `json:"group,omitempty"`
+	GroupID string   `json:"groupId,omitempty"`
+	Fields  []string `json:"fields,omitempty"`
+	Builds  []string `json:"builds,omitempty"`
+	Testers []string `json:"testers,omitempty"`
+	Email   string   `json:"email,omitempty"`
+	Groups  []string `json:"groups,omitempty"`
+
+	// group points at the planned group so IDs assigned during apply are visible
+	// to later changes that reference groups created earlier in the plan.
+	group     *testFlightDesiredGroup
+	groups    []*testFlightDesiredGroup
+	firstName string
+	lastName  string
+}
+
+type testFlightSyncPushSummary struct {
+	File    string                 `json:"file"`
+	AppID   string                 `json:"appId"`
+	DryRun  bool                   `json:"dryRun"`
+	Planned int                    `json:"planned"`
+	Applied int                    `json:"applied"`
+	Changes []testFlightSyncChange `json:"changes"`
+}
+
+type testFlightSyncPushClient interface {
+	testFlightSyncClient
+	CreateBetaGroup(ctx context.Context, appID, name string) (*asc.BetaGroupResponse, error)
+	UpdateBetaGroup(ctx context.Context, groupID string, req asc.BetaGroupUpdateRequest) (*asc.BetaGroupResponse, error)
+	AddBetaTestersToGroup(ctx context.Context, groupID string, testerIDs []string) error
+	RemoveBetaTestersFromGroup(ctx context.Context, groupID string, testerIDs []string) error
+	AddBetaGroupsToBuild(ctx context.Context, buildID string, groupIDs []string) error
+	RemoveBetaGroupsFromBuild(ctx context.Context, buildID string, groupIDs []string) error
+	CreateBetaTester(ctx context.Context, email, firstName, lastName string, groupIDs []string) (*asc.BetaTesterResponse, error)
+}
+
+// testFlightDesiredGroup pairs a config group with the live group it maps to.
+type testFlightDesiredGroup struct {
+	config TestFlightGroupConfig
+	id     string
+	live   *asc.Resource[asc.BetaGroupAttributes]
+}
+
+func (g *testFlightDesiredGroup) label() string {
+	if name := strings.TrimSpace(g.config.Name); name != "" {
+		return name
+	}
+	return g.id
+}
+
+// TestFlightSyncPushCommand applies a TestFlight YAML config to App Store Connect.
+func TestFlightSyncPushCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("push", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID env; defaults to app.id in the file)")
+	input := fs.String("input", "", "Input YAML file path (required)")
+	dryRun := fs.Bool("dry-run", false, "Print the plan without mutating network state")
+	confirm := fs.Bool("confirm", false, "Confirm removing builds or testers from groups")
+	format := shared.BindOutputFlagsWith(fs, "format", "json", "Summary output format: json (default), table, markdown")
+
+	return &ffcli.Command{
+		Name:       "push",
+		ShortUsage: "asc testflight sync push --input \"./testflight.yaml\" [flags]",
+		ShortHelp:  "Apply a TestFlight YAML configuration.",
+		LongHelp: `Apply a TestFlight YAML configuration.
+
+Diffs the file against live App Store Connect state, then creates missing
+beta groups, updates group settings (public link, link limit, feedback),
+and reconciles build assignments and tester memberships.
+
+Groups are matched by ID, then by name. Build assignments are reconciled
+only when the file lists builds, and tester memberships only when the file
+lists testers. Group references in builds and testers may use IDs or names.
+
+Plans that remove builds or testers from a group require --confirm. When
+--app or ASC_APP_ID is set and the file has app.id, they must match.
+
+Examples:
+  asc testflight sync push --input "./testflight.yaml" --dry-run
+  asc testflight sync push --app "APP_ID" --input "./testflight.yaml"
+  asc testflight sync push --input "./testflight.yaml" --confirm
+  asc testflight sync push --input "./testflight.yaml" --format table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			inputValue := strings.TrimSpace(*input)
+			if inputValue == "" {
+				fmt.Fprintf(os.Stderr, "Error: --input is required\n\n")
+				return flag.ErrHelp
+			}
+
+			config, err := readTestFlightConfigYAML(inputValue)
+			if err != nil {
+				return fmt.Errorf("testflight sync push: %w", err)
+			}
+
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("testflight sync push: %w", err)
+			}
+			fileAppID := strings.TrimSpace(config.App.ID)
+			if resolvedAppID != "" && fileAppID != "" && resolvedAppID != fileAppID {
+				return shared.UsageErrorf("app %q does not match app.id %q in %s", resolvedAppID, fileAppID, inputValue)
+			}
+			if resolvedAppID == "" {
+				resolvedAppID = fileAppID
+			}
+			if resolvedAppID == "" {
+				fmt.Fprintf(os.Stderr, "Error: --app is required (or set ASC_APP_ID or app.id in the file)\n\n")
+				return flag.ErrHelp
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("testflight sync push: %w", err)
+			}
+
+			changes, err := planTestFlightPush(ctx, client, resolvedAppID, config)
+			if err != nil {
+				return fmt.Errorf("testflight sync push: %w", err)
+			}
+			if !*dryRun && !*confirm && testFlightPlanRemoves(changes) {
+				return shared.UsageError("--confirm is required to remove builds or testers from groups (review the plan with --dry-run)")
+			}
+
+			summary := &testFlightSyncPushSummary{
+				File:    filepath.Clean(inputValue),
+				AppID:   resolvedAppID,
+				DryRun:  *dryRun,
+				Planned: len(changes),
+				Changes: changes,
+			}
+
+			var applyErr error
+			if !*dryRun {
+				summary.Applied, applyErr = applyTestFlightPush(ctx, client, resolvedAppID, changes)
+			}
+
+			if err := shared.PrintOutputWithRenderers(
+				summary,
+				*format.Output,
+				*format.Pretty,
+				func() error { return renderTestFlightPushTables(summary, false) },
+				func() error { return renderTestFlightPushTables(summary, true) },
+			); err != nil {
+				return err
+			}
+
+			if applyErr != nil {
+				return shared.NewReportedError(fmt.Errorf("testflight sync push: %w", applyErr))
+			}
+			return nil
+		},
+	}
+}
+
+func readTestFlightConfigYAML(path string) (*TestFlightConfig, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+
+	var config TestFlightConfig
+	if err := decoder.Decode(&config); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, shared.UsageError("YAML file is empty")
+		}
+		return nil, fmt.Errorf("parse YAML: %w", err)
+	}
+	return &config, nil
+}
+
+// planTestFlightPush computes the ordered list of changes needed to make live
+// state match config. Changes are ordered so groups exist before builds and
+// testers reference them. Each request gets its own timeout.
+func planTestFlightPush(ctx context.Context, client testFlightSyncClient, appID string, config *TestFlightConfig) ([]testFlightSyncChange, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client is required")
+	}
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	groupFirstPage, err := client.GetBetaGroups(requestCtx, appID, asc.WithBetaGroupsLimit(200))
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("fetch beta groups: %w", err)
+	}
+	groupResp, err := paginateBetaGroups(ctx, client, appID, groupFirstPage)
+	if err != nil {
+		return nil, fmt.Errorf("fetch beta groups: %w", err)
+	}
+
+	desired, err := matchDesiredGroups(config.Groups, groupResp.Data)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := newTestFlightGroupRefs(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]testFlightSyncChange, 0)
+	for _, group := range desired {
+		if group.live == nil {
+			fields, err := groupCreateFields(group)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, testFlightSyncChange{
+				Action: testFlightSyncActionCreateGroup,
+				Group:  group.label(),
+				Fields: fields,
+				group:  group,
+			})
+			continue
+		}
+		fields, err := groupSettingDiff(group)
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) > 0 {
+			changes = append(changes, testFlightSyncChange{
+				Action:  testFlightSyncActionUpdateGroup,
+				Group:   group.label(),
+				GroupID: group.id,
+				Fields:  fields,
+				group:   group,
+			})
+		}
+	}
+
+	if testFlightConfigHasBuilds(config) {
+		buildChanges, err := planTestFlightBuildChanges(ctx, client, config, desired, refs)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, buildChanges...)
+	}
+
+	if len(config.Testers) > 0 {
+		testerChanges, err := planTestFlightTesterChanges(ctx, client, config, desired, refs)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, testerChanges...)
+	}
+
+	return changes, nil
+}
+
+func matchDesiredGroups(configs []TestFlightGroupConfig, live []asc.Resource[asc.BetaGroupAttributes]) ([]*testFlightDesiredGroup, error) {
+	liveByID := make(map[string]*asc.Resource[asc.BetaGroupAttributes], len(live))
+	liveByName := make(map[string][]*asc.Resource[asc.BetaGroupAttributes])
+	for i := range live {
+		group := &live[i]
+		liveByID[group.ID] = group
+		key := strings.ToLower(strings.TrimSpace(group.Attributes.Name))
+		liveByName[key] = append(liveByName[key], group)
+	}
+
+	seenNames := make(map[string]struct{}, len(configs))
+	claimed := make(map[string]struct{}, len(configs))
+	desired := make([]*testFlightDesiredGroup, 0, len(configs))
+	for _, cfg := range configs {
+		cfg.ID = strings.TrimSpace(cfg.ID)
+		cfg.Name = strings.TrimSpace(cfg.Name)
+		if cfg.Name == "" {
+			return nil, fmt.Errorf("beta group name is required")
+		}
+		nameKey := strings.ToLower(cfg.Name)
+		if _, ok := seenNames[nameKey]; ok {
+			return nil, fmt.Errorf("duplicate beta group %q in config", cfg.Name)
+		}
+		seenNames[nameKey] = struct{}{}
+
+		group := &testFlightDesiredGroup{config: cfg}
+		if match, ok := liveByID[cfg.ID]; ok && cfg.ID != "" {
+			group.live = match
+		} else {
+			switch matches := liveByName[nameKey]; len(matches) {
+			case 0:
+			case 1:
+				group.live = matches[0]
+			default:
+				return nil, fmt.Errorf("multiple beta groups named %q; use group ID", cfg.Name)
+			}
+		}
+		if group.live != nil {
+			if _, ok := claimed[group.live.ID]; ok {
+				return nil, fmt.Errorf("beta group %q is matched by more than one config group", group.live.ID)
+			}
+			claimed[group.live.ID] = struct{}{}
+			group.id = group.live.ID
+		}
+		desired = append(desired, group)
+	}
+	return desired, nil
+}
+
+// testFlightGroupRefs resolves group references (config IDs or names) used by
+// builds and testers to planned groups.
+type testFlightGroupRefs map[string]*testFlightDesiredGroup
+
+func newTestFlightGroupRefs(groups []*testFlightDesiredGroup) (testFlightGroupRefs, error) {
+	refs := make(testFlightGroupRefs, len(groups)*3)
+	for _, group := range groups {
+		keys := []string{group.config.ID, group.id, strings.ToLower(group.config.Name)}
+		for _, key := range keys {
+			if key == "" {
+				continue
+			}
+			if existing, ok := refs[key]; ok && existing != group {
+				return nil, fmt.Errorf("beta group reference %q is ambiguous", key)
+			}
+			refs[key] = group
+		}
+	}
+	return refs, nil
+}
+
+func (r testFlightGroupRefs) resolve(value string) (*testFlightDesiredGroup, error) {
+	trimmed := strings.TrimSpace(value)
+	if group, ok := r[trimmed]; ok {
+		return group, nil
+	}
+	if group, ok := r[strings.ToLower(trimmed)]; ok {
+		return group, nil
+	}
+	return nil, fmt.Errorf("beta group %q is not defined in config groups", trimmed)
+}
+
+func groupSettingFields(cfg TestFlightGroupConfig, live *asc.BetaGroupAttributes) []string {
+	fields := make([]string, 0, 4)
+	if live == nil {
+		if cfg.IsInternalGroup {
+			fields = append(fields, "isInternalGroup")
+		}
+		if !cfg.IsInternalGroup && cfg.PublicLinkEnabled {
+			fields = append(fields, "publicLinkEnabled")
+		}
+		if !cfg.IsInternalGroup && cfg.PublicLinkLimit != nil {
+			fields = append(fields, "publicLinkLimit")
+		}
+		if cfg.FeedbackEnabled {
+			fields = append(fields, "feedbackEnabled")
+		}
+		return fields
+	}
+
+	if cfg.Name != strings.TrimSpace(live.Name) {
+		fields = append(fields, "name")
+	}
+	if !cfg.IsInternalGroup {
+		if cfg.PublicLinkEnabled != live.PublicLinkEnabled {
+			fields = append(fields, "publicLinkEnabled")
+		}
+		liveLimitEnabled := live.PublicLinkLimitEnabled && live.PublicLinkLimit > 0
+		switch {
+		case cfg.PublicLinkLimit == nil && liveLimitEnabled:
+			fields = append(fields, "publicLinkLimit")
+		case cfg.PublicLinkLimit != nil && (!liveLimitEnabled || *cfg.PublicLinkLimit != live.PublicLinkLimit):
+			fields = append(fields, "publicLinkLimit")
+		}
+	}
+	if cfg.FeedbackEnabled != live.FeedbackEnabled {
+		fields = append(fields, "feedbackEnabled")
+	}
+	return fields
+}
+
+func groupCreateFields(group *testFlightDesiredGroup) ([]string, error) {
+	if err := validateGroupPublicLinkLimit(group); err != nil {
+		return nil, err
+	}
+	return groupSettingFields(group.config, nil), nil
+}
+
+func groupSettingDiff(group *testFlightDesiredGroup) ([]string, error) {
+	attrs := group.live.Attributes
+	if group.config.IsInternalGroup != attrs.IsInternalGroup {
+		return nil, fmt.Errorf("beta group %q: isInternalGroup cannot be changed on an existing group", group.label())
+	}
+	if err := validateGroupPublicLinkLimit(group); err != nil {
+		return nil, err
+	}
+	return groupSettingFields(group.config, &attrs), nil
+}
+
+func validateGroupPublicLinkLimit(group *testFlightDesiredGroup) error {
+	if group.config.PublicLinkLimit != nil && *group.config.PublicLinkLimit <= 0 {
+		return fmt.Errorf("beta group %q: %w", group.label(), errTestFlightPublicLinkLimit)
+	}
+	return nil
+}
+
+func buildGroupUpdateRequest(groupID string, cfg TestFlightGroupConfig, fields []string) asc.BetaGroupUpdateRequest {
+	attrs := &asc.BetaGroupUpdateAttributes{}
+	for _, field := range fields {
+		switch field {
+		case "name":
+			attrs.Name = cfg.Name
+		case "isInternalGroup":
+			value := cfg.IsInternalGroup
+			attrs.IsInternalGroup = &value
+		case "publicLinkEnabled":
+			value := cfg.PublicLinkEnabled
+			attrs.PublicLinkEnabled = &value
+		case "publicLinkLimit":
+			enabled := cfg.PublicLinkLimit != nil
+			attrs.PublicLinkLimitEnabled = &enabled
+			if enabled {
+				attrs.PublicLinkLimit = *cfg.PublicLinkLimit
+			}
+		case "feedbackEnabled":
+			value := cfg.FeedbackEnabled
+			attrs.FeedbackEnabled = &value
+		}
+	}
+	return asc.BetaGroupUpdateRequest{
+		Data: asc.BetaGroupUpdateData{
+			Type:       asc.ResourceTypeBetaGroups,
+			ID:         groupID,
+			Attributes: attrs,
+		},
+	}
+}
+
+func testFlightConfigHasBuilds(config *TestFlightConfig) bool {
+	if len(config.Builds) > 0 {
+		return true
+	}
+	for _, group := range config.Groups {
+		if len(group.Builds) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func planTestFlightBuildChanges(ctx context.Context, client testFlightSyncClient, config *TestFlightConfig, desired []*testFlightDesiredGroup, refs testFlightGroupRefs) ([]testFlightSyncChange, error) {
+	wanted := make(map[*testFlightDesiredGroup][]string, len(desired))
+	for _, group := range desired {
+		wanted[group] = append(wanted[group], group.config.Builds...)
+	}
+	for _, build := range config.Builds {
+		buildID := strings.TrimSpace(build.ID)
+		if buildID == "" {
+			return nil, fmt.Errorf("build id is required")
+		}
+		for _, ref := range build.Groups {
+			group, err := refs.resolve(ref)
+			if err != nil {
+				return nil, fmt.Errorf("build %q: %w", buildID, err)
+			}
+			wanted[group] = append(wanted[group], buildID)
+		}
+	}
+
+	changes := make([]testFlightSyncChange, 0)
+	for _, group := range desired {
+		want := uniqueSortedStrings(wanted[group])
+		var have []string
+		if group.live != nil {
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			firstPage, err := client.GetBetaGroupBuilds(requestCtx, group.id, asc.WithBetaGroupBuildsLimit(200))
+			cancel()
+			if err != nil {
+				return nil, fmt.Errorf("fetch beta group builds: %w", err)
+			}
+			resp, err := paginateBetaGroupBuilds(ctx, client, group.id, firstPage)
+			if err != nil {
+				return nil, fmt.Errorf("fetch beta group builds: %w", err)
+			}
+			for _, build := range resp.Data {
+				have = append(have, build.ID)
+			}
+		}
+		add, remove := diffStringSets(want, uniqueSortedStrings(have))
+		if len(add) > 0 {
+			changes = append(changes, testFlightSyncChange{
+				Action:  testFlightSyncActionAddBuilds,
+				Group:   group.label(),
+				GroupID: group.id,
+				Builds:  add,
+				group:   group,
+			})
+		}
+		if len(remove) > 0 {
+			changes = append(changes, testFlightSyncChange{
+				Action:  testFlightSyncActionRemoveBuilds,
+				Group:   group.label(),
+				GroupID: group.id,
+				Builds:  remove,
+				group:   group,
+			})
+		}
+	}
+	return changes, nil
+}
+
+func planTestFlightTesterChanges(ctx context.Context, client testFlightSyncClient, config *TestFlightConfig, desired []*testFlightDesiredGroup, refs testFlightGroupRefs) ([]testFlightSyncChange, error) {
+	liveMembers := make(map[*testFlightDesiredGroup][]string, len(desired))
+	emailToID := make(map[string]string)
+	for _, group := range desired {
+		if group.live == nil {
+			continue
+		}
+		requestCtx, cancel := shared.ContextWithTimeout(ctx)
+		firstPage, err := client.GetBetaGroupTesters(requestCtx, group.id, asc.WithBetaGroupTestersLimit(200))
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("fetch beta group testers: %w", err)
+		}
+		resp, err := paginateBetaGroupTesters(ctx, client, group.id, firstPage)
+		if err != nil {
+			return nil, fmt.Errorf("fetch beta group testers: %w", err)
+		}
+		for _, tester := range resp.Data {
+			liveMembers[group] = append(liveMembers[group], tester.ID)
+			if email := strings.ToLower(strings.TrimSpace(tester.Attributes.Email)); email != "" {
+				emailToID[email] = tester.ID
+			}
+		}
+	}
+
+	wanted := make(map[*testFlightDesiredGroup][]string, len(desired))
+	changes := make([]testFlightSyncChange, 0)
+	seen := make(map[string]struct{}, len(config.Testers))
+	for _, tester := range config.Testers {
+		testerID := strings.TrimSpace(tester.ID)
+		email := strings.TrimSpace(tester.Email)
+		if testerID == "" && email == "" {
+			return nil, fmt.Errorf("tester id or email is required")
+		}
+		if testerID == "" {
+			testerID = emailToID[strings.ToLower(email)]
+		}
+		key := testerID
+		if key == "" {
+			key = strings.ToLower(email)
+		}
+		if _, ok := seen[key]; ok {
+			return nil, fmt.Errorf("duplicate tester %q in config", key)
+		}
+		seen[key] = struct{}{}
+
+		groups := make([]*testFlightDesiredGroup, 0, len(tester.Groups))
+		for _, ref := range tester.Groups {
+			group, err := refs.resolve(ref)
+			if err != nil {
+				return nil, fmt.Errorf("tester %q: %w", key, err)
+			}
+			groups = append(groups, group)
+		}
+
+		if testerID == "" {
+			if len(groups) == 0 {
+				continue
+			}
+			firstName, lastName := splitTesterName(tester.Name)
+			change := testFlightSyncChange{
+				Action:    testFlightSyncActionCreateTester,
+				Email:     email,
+				groups:    groups,
+				firstName: firstName,
+				lastName:  lastName,
+			}
+			for _, group := range groups {
+				change.Groups = append(change.Groups, group.label())
+			}
+			change.Groups = uniqueSortedStrings(change.Groups)
+			changes = append(changes, change)
+			continue
+		}
+		for _, group := range groups {
+			wanted[group] = append(wanted[group], testerID)
+		}
+	}
+
+	for _, group := range desired {
+		add, remove := diffStringSets(uniqueSortedStrings(wanted[group]), uniqueSortedStrings(liveMembers[group]))
+		if len(add) > 0 {
+			changes = append(changes, testFlightSyncChange{
+				Action:  testFlightSyncActionAddTesters,
+				Group:   group.label(),
+				GroupID: group.id,
+				Testers: add,
+				group:   group,
+			})
+		}
+		if len(remove) > 0 {
+			changes = append(changes, testFlightSyncChange{
+				Action:  testFlightSyncActionRemoveTesters,
+				Group:   group.label(),
+				GroupID: group.id,
+				Testers: remove,
+				group:   group,
+			})
+		}
+	}
+	return changes, nil
+}
+
+// testFlightPlanRemoves reports whether changes remove builds or testers from
+// a group.
+func testFlightPlanRemoves(changes []testFlightSyncChange) bool {
+	for _, change := range changes {
+		switch change.Action {
+		case testFlightSyncActionRemoveBuilds, testFlightSyncActionRemoveTesters:
+			return true
+		}
+	}
+	return false
+}
+
+// applyTestFlightPush executes changes in order and returns how many were
+// applied before the first failure.
+func applyTestFlightPush(ctx context.Context, client testFlightSyncPushClient, appID string, changes []testFlightSyncChange) (int, error) {
+	for i := range changes {
+		change := &changes[i]
+		if err := applyTestFlightChange(ctx, client, appID, change); err != nil {
+			return i, fmt.Errorf("%s %s: %w", change.Action, change.target(), err)
+		}
+	}
+	return len(changes), nil
+}
+
+func applyTestFlightChange(ctx context.Context, client testFlightSyncPushClient, appID string, change *testFlightSyncChange) error {
+	switch change.Action {
+	case testFlightSyncActionCreateGroup:
+		requestCtx, cancel := shared.ContextWithTimeout(ctx)
+		created, err := client.CreateBetaGroup(requestCtx, appID, change.group.config.Name)
+		cancel()
+		if err != nil {
+			return err
+		}
+		groupID := strings.TrimSpace(created.Data.ID)
+		if groupID == "" {
+			return fmt.Errorf("created group returned empty id")
+		}
+		change.group.id = groupID
+		change.GroupID = groupID
+		if len(change.Fields) == 0 {
+			return nil
+		}
+		requestCtx, cancel = shared.ContextWithTimeout(ctx)
+		defer cancel()
+		_, err = client.UpdateBetaGroup(requestCtx, groupID, buildGroupUpdateRequest(groupID, change.group.config, change.Fields))
+		return err
+	case testFlightSyncActionUpdateGroup:
+		requestCtx, cancel := shared.ContextWithTimeout(ctx)
+		defer cancel()
+		_, err := client.UpdateBetaGroup(requestCtx, change.group.id, buildGroupUpdateRequest(change.group.id, change.group.config, change.Fields))
+		return err
+	case testFlightSyncActionAddBuilds:
+		change.GroupID = change.group.id
+		for _, buildID := range change.Builds {
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			err := client.AddBetaGroupsToBuild(requestCtx, buildID, []string{change.group.id})
+			cancel()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case testFlightSyncActionRemoveBuilds:
+		for _, buildID := range change.Builds {
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			err := client.RemoveBetaGroupsFromBuild(requestCtx, buildID, []string{change.group.id})
+			cancel()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case testFlightSyncActionAddTesters:
+		change.GroupID = change.group.id
+		requestCtx, cancel := shared.ContextWithTimeout(ctx)
+		defer cancel()
+		return client.AddBetaTestersToGroup(requestCtx, change.group.id, change.Testers)
+	case testFlightSyncActionRemoveTesters:
+		requestCtx, cancel := shared.ContextWithTimeout(ctx)
+		defer cancel()
+		return client.RemoveBetaTestersFromGroup(requestCtx, change.group.id, change.Testers)
+	case testFlightSyncActionCreateTester:
+		groupIDs := make([]string, 0, len(change.groups))
+		for _, group := range change.groups {
+			groupIDs = append(groupIDs, group.id)
+		}
+		requestCtx, cancel := shared.ContextWithTimeout(ctx)
+		defer cancel()
+		_, err := client.CreateBetaTester(requestCtx, change.Email, change.firstName, change.lastName, uniqueSortedStrings(groupIDs))
+		return err
+	default:
+		return fmt.Errorf("unknown action %q", change.Action)
+	}
+}
+
+func (c testFlightSyncChange) target() string {
+	if c.Email != "" {
+		return c.Email
+	}
+	return fmt.Sprintf("%q", c.Group)
+}
+
+func (c testFlightSyncChange) details() string {
+	switch {
+	case len(c.Fields) > 0:
+		return strings.Join(c.Fields, ", ")
+	case len(c.Builds) > 0:
+		return strings.Join(c.Builds, ", ")
+	case len(c.Testers) > 0:
+		return strings.Join(c.Testers, ", ")
+	case len(c.Groups) > 0:
+		return strings.Join(c.Groups, ", ")
+	default:
+		return ""
+	}
+}
+
+// diffStringSets returns values in want but not have, and in have but not want.
+// Both inputs must be sorted and unique.
+func diffStringSets(want, have []string) ([]string, []string) {
+	haveSet := make(map[string]struct{}, len(have))
+	for _, value := range have {
+		haveSet[value] = struct{}{}
+	}
+	wantSet := make(map[string]struct{}, len(want))
+	add := make([]string, 0)
+	for _, value := range want {
+		wantSet[value] = struct{}{}
+		if _, ok := haveSet[value]; !ok {
+			add = append(add, value)
+		}
+	}
+	remove := make([]string, 0)
+	for _, value := range have {
+		if _, ok := wantSet[value]; !ok {
+			remove = append(remove, value)
+		}
+	}
+	sort.Strings(add)
+	sort.Strings(remove)
+	return add, remove
+}
+
+func splitTesterName(name string) (string, string) {
+	parts := strings.Fields(name)
+	switch len(parts) {
+	case 0:
+		return "", ""
+	case 1:
+		return parts[0], ""
+	default:
+		return parts[0], strings.Join(parts[1:], " ")
+	}
+}
+
+func renderTestFlightPushTables(summary *testFlightSyncPushSummary, markdown bool) error {
+	if summary == nil {
+		return fmt.Errorf("summary is nil")
+	}
+
+	render := asc.RenderTable
+	if markdown {
+		render = asc.RenderMarkdown
+	}
+
+	render(
+		[]string{"App ID", "Input File", "Dry Run", "Planned", "Applied"},
+		[][]string{{
+			summary.AppID,
+			summary.File,
+			fmt.Sprintf("%t", summary.DryRun),
+			fmt.Sprintf("%d", summary.Planned),
+			fmt.Sprintf("%d", summary.Applied),
+		}},
+	)
+
+	if len(summary.Changes) > 0 {
+		rows := make([][]string, 0, len(summary.Changes))
+		for _, change := range summary.Changes {
+			target := change.Group
+			if change.Email != "" {
+				target = change.Email
+			}
+			rows = append(rows, []string{change.Action, target, change.details()})
+		}
+		render([]string{"Action", "Target", "Details"}, rows)
+	}
+
+	return nil
+}
@@ -0,0 +1,272 @@
+package testflight
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+type testFlightSyncPushStub struct {
+	testFlightSyncStub
+
+	createdGroups  []string
+	updatedGroups  map[string]asc.BetaGroupUpdateAttributes
+	addedTesters   map[string][]string
+	removedTesters map[string][]string
+	addedBuilds    map[string][]string
+	removedBuilds  map[string][]string
+	createdTesters map[string][]string
+}
+
+func newTestFlightSyncPushStub(base testFlightSyncStub) *testFlightSyncPushStub {
+	return &testFlightSyncPushStub{
+		testFlightSyncStub: base,
+		updatedGroups:      make(map[string]asc.BetaGroupUpdateAttributes),
+		addedTesters:       make(map[string][]string),
+		removedTesters:     make(map[string][]string),
+		addedBuilds:        make(map[string][]string),
+		removedBuilds:      make(map[string][]string),
+		createdTesters:     make(map[string][]string),
+	}
+}
+
+func (s *testFlightSyncPushStub) CreateBetaGroup(ctx context.Context, appID, name string) (*asc.BetaGroupResponse, error) {
+	s.createdGroups = append(s.createdGroups, name)
+	return &asc.BetaGroupResponse{
+		Data: asc.Resource[asc.BetaGroupAttributes]{ID: "new-" + name, Attributes: asc.BetaGroupAttributes{Name: name}},
+	}, nil
+}
+
+func (s *testFlightSyncPushStub) UpdateBetaGroup(ctx context.Context, groupID string, req asc.BetaGroupUpdateRequest) (*asc.BetaGroupResponse, error) {
+	s.updatedGroups[groupID] = *req.Data.Attributes
+	return &asc.BetaGroupResponse{}, nil
+}
+
+func (s *testFlightSyncPushStub) AddBetaTestersToGroup(ctx context.Context, groupID string, testerIDs []string) error {
+	s.addedTesters[groupID] = append(s.addedTesters[groupID], testerIDs...)
+	return nil
+}
+
+func (s *testFlightSyncPushStub) RemoveBetaTestersFromGroup(ctx context.Context, groupID string, testerIDs []string) error {
+	s.removedTesters[groupID] = append(s.removedTesters[groupID], testerIDs...)
+	return nil
+}
+
+func (s *testFlightSyncPushStub) AddBetaGroupsToBuild(ctx context.Context, buildID string, groupIDs []string) error {
+	for _, groupID := range groupIDs {
+		s.addedBuilds[groupID] = append(s.addedBuilds[groupID], buildID)
+	}
+	return nil
+}
+
+func (s *testFlightSyncPushStub) RemoveBetaGroupsFromBuild(ctx context.Context, buildID string, groupIDs []string) error {
+	for _, groupID := range groupIDs {
+		s.removedBuilds[groupID] = append(s.removedBuilds[groupID], buildID)
+	}
+	return nil
+}
+
+func (s *testFlightSyncPushStub) CreateBetaTester(ctx context.Context, email, firstName, lastName string, groupIDs []string) (*asc.BetaTesterResponse, error) {
+	s.createdTesters[email] = append([]string(nil), groupIDs...)
+	return &asc.BetaTesterResponse{}, nil
+}
+
+func pushTestBase() testFlightSyncStub {
+	return testFlightSyncStub{
+		groups: &asc.BetaGroupsResponse{
+			Data: []asc.Resource[asc.BetaGroupAttributes]{
+				{
+					ID: "group-1",
+					Attributes: asc.BetaGroupAttributes{
+						Name:            "Beta",
+						FeedbackEnabled: true,
+					},
+				},
+			},
+		},
+		buildsByGroup: map[string]*asc.BuildsResponse{
+			"group-1": {Data: []asc.Resource[asc.BuildAttributes]{{ID: "build-old"}}},
+		},
+		testersByGroup: map[string]*asc.BetaTestersResponse{
+			"group-1": {
+				Data: []asc.Resource[asc.BetaTesterAttributes]{
+					{ID: "tester-1", Attributes: asc.BetaTesterAttributes{Email: "ada@example.com"}},
+					{ID: "tester-2", Attributes: asc.BetaTesterAttributes{Email: "grace@example.com"}},
+				},
+			},
+		},
+	}
+}
+
+func TestPlanTestFlightPush_ReconcilesGroupsBuildsAndTesters(t *testing.T) {
+	stub := newTestFlightSyncPushStub(pushTestBase())
+	limit := 50
+	config := &TestFlightConfig{
+		Groups: []TestFlightGroupConfig{
+			{ID: "group-1", Name: "Beta", PublicLinkEnabled: true, PublicLinkLimit: &limit, FeedbackEnabled: true, Builds: []string{"build-new"}},
+			{Name: "Internal", IsInternalGroup: true},
+		},
+		Builds: []TestFlightBuildConfig{
+			{ID: "build-new", Groups: []string{"Internal"}},
+		},
+		Testers: []TestFlightTesterConfig{
+			{Email: "ada@example.com", Groups: []string{"group-1"}},
+			{Email: "new@example.com", Name: "New Person", Groups: []string{"Internal", "Beta"}},
+		},
+	}
+
+	changes, err := planTestFlightPush(context.Background(), stub, "app-1", config)
+	if err != nil {
+		t.Fatalf("plan error: %v", err)
+	}
+
+	got := make([]string, 0, len(changes))
+	for _, change := range changes {
+		got = append(got, change.Action+" "+change.Group+change.Email)
+	}
+	want := []string{
+		"update-group Beta",
+		"create-group Internal",
+		"add-builds Beta",
+		"remove-builds Beta",
+		"add-builds Internal",
+		"create-tester new@example.com",
+		"remove-testers Beta",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected plan:\n got %v\nwant %v", got, want)
+	}
+	if !reflect.DeepEqual(changes[0].Fields, []string{"publicLinkEnabled", "publicLinkLimit"}) {
+		t.Fatalf("unexpected update fields: %v", changes[0].Fields)
+	}
+	if !reflect.DeepEqual(changes[6].Testers, []string{"tester-2"}) {
+		t.Fatalf("unexpected removed testers: %v", changes[6].Testers)
+	}
+
+	applied, err := applyTestFlightPush(context.Background(), stub, "app-1", changes)
+	if err != nil {
+		t.Fatalf("apply error: %v", err)
+	}
+	if applied != len(changes) {
+		t.Fatalf("expected %d applied, got %d", len(changes), applied)
+	}
+	if !reflect.DeepEqual(stub.createdGroups, []string{"Internal"}) {
+		t.Fatalf("unexpected created groups: %v", stub.createdGroups)
+	}
+	internal := stub.updatedGroups["new-Internal"]
+	if internal.IsInternalGroup == nil || !*internal.IsInternalGroup {
+		t.Fatalf("expected created group to be marked internal, got %+v", internal)
+	}
+	beta := stub.updatedGroups["group-1"]
+	if beta.PublicLinkLimitEnabled == nil || !*beta.PublicLinkLimitEnabled || beta.PublicLinkLimit != 50 {
+		t.Fatalf("unexpected public link limit update: %+v", beta)
+	}
+	if !reflect.DeepEqual(stub.addedBuilds["new-Internal"], []string{"build-new"}) {
+		t.Fatalf("expected build assigned to created group, got %v", stub.addedBuilds)
+	}
+	if !reflect.DeepEqual(stub.removedBuilds["group-1"], []string{"build-old"}) {
+		t.Fatalf("unexpected removed builds: %v", stub.removedBuilds)
+	}
+	if !reflect.DeepEqual(stub.createdTesters["new@example.com"], []string{"group-1", "new-Internal"}) {
+		t.Fatalf("unexpected created tester groups: %v", stub.createdTesters)
+	}
+	if !reflect.DeepEqual(stub.removedTesters["group-1"], []string{"tester-2"}) {
+		t.Fatalf("unexpected removed testers: %v", stub.removedTesters)
+	}
+}
+
+func TestPlanTestFlightPush_SkipsBuildsAndTestersWhenOmitted(t *testing.T) {
+	stub := newTestFlightSyncPushStub(pushTestBase())
+	config := &TestFlightConfig{
+		Groups: []TestFlightGroupConfig{{ID: "group-1", Name: "Beta", FeedbackEnabled: true}},
+	}
+
+	changes, err := planTestFlightPush(context.Background(), stub, "app-1", config)
+	if err != nil {
+		t.Fatalf("plan error: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("expected no changes, got %+v", changes)
+	}
+}
+
+func TestPlanTestFlightPush_RejectsInternalGroupChange(t *testing.T) {
+	stub := newTestFlightSyncPushStub(pushTestBase())
+	config := &TestFlightConfig{
+		Groups: []TestFlightGroupConfig{{ID: "group-1", Name: "Beta", IsInternalGroup: true}},
+	}
+
+	if _, err := planTestFlightPush(context.Background(), stub, "app-1", config); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestPlanTestFlightPush_RejectsNonPositivePublicLinkLimit(t *testing.T) {
+	zero := 0
+	tests := map[string]TestFlightGroupConfig{
+		"existing group": {ID: "group-1", Name: "Beta", FeedbackEnabled: true, PublicLinkEnabled: true, PublicLinkLimit: &zero},
+		"new group":      {Name: "Public", PublicLinkEnabled: true, PublicLinkLimit: &zero},
+	}
+	for name, group := range tests {
+		t.Run(name, func(t *testing.T) {
+			stub := newTestFlightSyncPushStub(pushTestBase())
+			config := &TestFlightConfig{Groups: []TestFlightGroupConfig{group}}
+
+			_, err := planTestFlightPush(context.Background(), stub, "app-1", config)
+			if !errors.Is(err, errTestFlightPublicLinkLimit) {
+				t.Fatalf("expected publicLinkLimit error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPlanTestFlightPush_RejectsUnknownGroupReference(t *testing.T) {
+	stub := newTestFlightSyncPushStub(pushTestBase())
+	config := &TestFlightConfig{
+		Groups:  []TestFlightGroupConfig{{ID: "group-1", Name: "Beta", FeedbackEnabled: true}},
+		Testers: []TestFlightTesterConfig{{ID: "tester-1", Groups: []string{"Missing"}}},
+	}
+
+	if _, err := planTestFlightPush(context.Background(), stub, "app-1", config); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestReadTestFlightConfigYAML_RoundTripsPullOutput(t *testing.T) {
+	limit := 10
+	config := &TestFlightConfig{
+		App: TestFlightAppConfig{ID: "app-1", Name: "Demo", BundleID: "com.example.demo"},
+		Groups: []TestFlightGroupConfig{
+			{ID: "group-1", Name: "Beta", PublicLinkEnabled: true, PublicLinkLimit: &limit, Builds: []string{"build-1"}},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "testflight.yaml")
+	if err := writeTestFlightConfigYAML(path, config); err != nil {
+		t.Fatalf("write error: %v", err)
+	}
+
+	got, err := readTestFlightConfigYAML(path)
+	if err != nil {
+		t.Fatalf("read error: %v", err)
+	}
+	if !reflect.DeepEqual(got, config) {
+		t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", got, config)
+	}
+}
+
+func TestReadTestFlightConfigYAML_RejectsUnknownFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testflight.yaml")
+	if err := os.WriteFile(path, []byte("app:\n  id: app-1\nunknown: true\n"), 0o600); err != nil {
+		t.Fatalf("write error: %v", err)
+	}
+
+	if _, err := readTestFlightConfigYAML(path); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
@@ -44,7 +44,10 @@ Examples:
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
-			resolvedAppID := shared.ResolveAppID(*appID)
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("validate iap: %w", err)
+			}
 			if resolvedAppID == "" {
 				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
 				return flag.ErrHelp
@@ -105,6 +108,7 @@ func runValidateIAP(ctx context.Context, opts validateIAPOptions) error {
 		IAPs:  iaps,
 	}, opts.Strict)
 
+	shared.RecordReportChecks(report.Checks)
 	if err := shared.PrintOutput(&report, opts.Output, opts.Pretty); err != nil {
 		return err
 	}
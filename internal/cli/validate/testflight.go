@@ -55,7 +55,10 @@ Examples:
 				return flag.ErrHelp
 			}
 
-			resolvedAppID := shared.ResolveAppID(*appID)
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("validate testflight: %w", err)
+			}
 			if resolvedAppID == "" {
 				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
 				return flag.ErrHelp
@@ -159,6 +162,7 @@ func runValidateTestFlight(ctx context.Context, opts validateTestFlightOptions)
 		BetaBuildLocalizations: betaBuildLocalizations,
 	}, opts.Strict)
 
+	shared.RecordReportChecks(report.Checks)
 	if err := shared.PrintOutput(&report, opts.Output, opts.Pretty); err != nil {
 		return err
 	}
@@ -0,0 +1,293 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/notify"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/webhookserver"
+)
+
+const (
+	webhookSecretEnvVar  = "ASC_WEBHOOK_SECRET"
+	webhookServeShutdown = 5 * time.Second
+	webhookNotifyTitle   = "App Store Connect"
+)
+
+// WebhookServeCommand returns the webhooks serve subcommand.
+func WebhookServeCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+
+	host := fs.String("host", "127.0.0.1", "Interface to listen on (use 0.0.0.0 to accept external connections)")
+	port := fs.Int("port", 8080, "Port to listen on")
+	path := fs.String("path", "/", "Request path that receives deliveries")
+	secret := fs.String("secret", "", "Webhook secret used to verify signatures (or "+webhookSecretEnvVar+" env)")
+	events := fs.String("events", "", "Only dispatch these event types, comma-separated (default: all)")
+	execCommand := fs.String("exec", "", "Shell command to run per event (event JSON on stdin)")
+	execTimeout := fs.Duration("exec-timeout", 30*time.Second, "Maximum run time of --exec per event")
+	logPath := fs.String("log", "", "Append each event as a JSON line to this file")
+	notifyProvider := fs.String("notify", "", "Send a summary of each event with 'asc notify': "+strings.Join(notify.URLProviders, ", "))
+	notifyURL := fs.String("notify-url", "", "Webhook URL for --notify (or the provider's env var, e.g. ASC_SLACK_WEBHOOK)")
+	slackWebhook := fs.String("slack-webhook", "", "Deprecated: use --notify slack --notify-url")
+
+	return &ffcli.Command{
+		Name:       "serve",
+		ShortUsage: "asc webhooks serve --secret SECRET [--exec CMD] [--log FILE] [--notify PROVIDER] [flags]",
+		ShortHelp:  "Receive webhook deliveries and dispatch events.",
+		LongHelp: `Receive webhook deliveries and dispatch events.
+
+Runs an HTTP server for App Store Connect webhook deliveries. Each request's
+X-Apple-SIGNATURE header is verified against the webhook secret (HMAC-SHA256
+of the body); unsigned or mis-signed requests are rejected with 401.
+
+Verified events are decoded and dispatched to every configured target:
+  --exec    Runs the command with 'sh -c'. The event JSON is written to
+            stdin and ASC_EVENT_TYPE, ASC_EVENT_ID, ASC_EVENT_INSTANCE_TYPE,
+            ASC_EVENT_INSTANCE_ID and ASC_EVENT_SUMMARY are set.
+  --log     Appends the event as one JSON line.
+  --notify  Posts a one-line summary to Slack, Microsoft Teams, Discord or a
+            generic JSON webhook. --notify-url defaults to the provider's
+            env var (ASC_SLACK_WEBHOOK, ASC_TEAMS_WEBHOOK, ASC_DISCORD_WEBHOOK
+            or ASC_NOTIFY_WEBHOOK_URL). --slack-webhook URL is a deprecated
+            alias for --notify slack --notify-url URL.
+
+Build upload, app version and external beta state events carry
+payload.oldState/newState; TestFlight feedback events carry payload.kind and
+payload.submissionId.
+
+Verified deliveries are answered with 200 as soon as the event is queued;
+targets run in the background, one event at a time. A redelivery of an event
+ID that is queued or already handled is acknowledged without running the
+targets again. Target failures are logged to stderr and the event can be
+redelivered ('asc webhooks deliveries redeliver'). When the queue is full,
+the delivery is answered with 503. On Ctrl+C, queued events get up to
+--exec-timeout to finish.
+
+Without a target, events are only logged to stderr. Stop with Ctrl+C.
+
+Examples:
+  asc webhooks serve --secret "$WEBHOOK_SECRET" --log events.jsonl
+  asc webhooks serve --host 0.0.0.0 --port 9000 --path /asc --exec './on-event.sh'
+  asc webhooks serve --events BUILD_UPLOAD_STATE_UPDATED --notify slack --notify-url "$SLACK_WEBHOOK"
+  asc webhooks serve --secret "$WEBHOOK_SECRET" --notify teams --notify-url "$TEAMS_WEBHOOK"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			secretValue := strings.TrimSpace(*secret)
+			if secretValue == "" {
+				secretValue = strings.TrimSpace(os.Getenv(webhookSecretEnvVar))
+			}
+			if secretValue == "" {
+				fmt.Fprintf(os.Stderr, "Error: --secret is required (or set %s)\n", webhookSecretEnvVar)
+				return flag.ErrHelp
+			}
+			if *port < 1 || *port > 65535 {
+				fmt.Fprintln(os.Stderr, "Error: --port must be between 1 and 65535")
+				return flag.ErrHelp
+			}
+			pathValue := strings.TrimSpace(*path)
+			if !strings.HasPrefix(pathValue, "/") {
+				fmt.Fprintln(os.Stderr, "Error: --path must start with /")
+				return flag.ErrHelp
+			}
+			if *execTimeout <= 0 {
+				fmt.Fprintln(os.Stderr, "Error: --exec-timeout must be positive")
+				return flag.ErrHelp
+			}
+
+			var eventTypes []asc.WebhookEventType
+			if strings.TrimSpace(*events) != "" {
+				normalized, err := normalizeWebhookEvents(*events)
+				if err != nil {
+					return shared.UsageError(err.Error())
+				}
+				eventTypes = normalized
+			}
+
+			dispatcher := &eventDispatcher{
+				command:     strings.TrimSpace(*execCommand),
+				execTimeout: *execTimeout,
+			}
+			provider := strings.TrimSpace(*notifyProvider)
+			webhookURL := *notifyURL
+			if legacyURL := strings.TrimSpace(*slackWebhook); legacyURL != "" {
+				if strings.TrimSpace(webhookURL) != "" {
+					return shared.UsageError("--slack-webhook and --notify-url are mutually exclusive")
+				}
+				if provider == "" {
+					provider = notify.ProviderSlack
+				}
+				if !strings.EqualFold(provider, notify.ProviderSlack) {
+					return shared.UsageError("--slack-webhook only applies with --notify slack; use --notify-url")
+				}
+				fmt.Fprintln(os.Stderr, "Warning: --slack-webhook is deprecated; use --notify slack --notify-url")
+				webhookURL = legacyURL
+			}
+			if provider != "" {
+				if !slices.Contains(notify.URLProviders, strings.ToLower(provider)) {
+					return shared.UsageErrorf("--notify must be one of: %s", strings.Join(notify.URLProviders, ", "))
+				}
+				notifier, err := notify.NewURLNotifier(provider, webhookURL)
+				if err != nil {
+					return shared.UsageError("--notify-url: " + err.Error())
+				}
+				dispatcher.notifier = notifier
+			}
+
+			if logValue := strings.TrimSpace(*logPath); logValue != "" {
+				file, err := os.OpenFile(logValue, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+				if err != nil {
+					return fmt.Errorf("webhooks serve: failed to open log: %w", err)
+				}
+				defer file.Close()
+				dispatcher.log = file
+			}
+
+			server, err := webhookserver.New(webhookserver.Options{
+				Secret:   secretValue,
+				Path:     pathValue,
+				Events:   eventTypes,
+				Dispatch: dispatcher.dispatch,
+				Logger:   os.Stderr,
+			})
+			if err != nil {
+				return fmt.Errorf("webhooks serve: %w", err)
+			}
+
+			listener, err := net.Listen("tcp", net.JoinHostPort(strings.TrimSpace(*host), strconv.Itoa(*port)))
+			if err != nil {
+				return fmt.Errorf("webhooks serve: %w", err)
+			}
+
+			serveCtx, stop := signal.NotifyContext(ctx, os.Interrupt)
+			defer stop()
+
+			fmt.Fprintf(os.Stderr, "Listening for webhook deliveries on http://%s%s\n", listener.Addr().String(), pathValue)
+			serveErr := serveWebhooks(serveCtx, listener, server)
+
+			drainCtx, cancel := context.WithTimeout(context.Background(), *execTimeout)
+			defer cancel()
+			if err := server.Close(drainCtx); err != nil && serveErr == nil {
+				return fmt.Errorf("webhooks serve: %w", err)
+			}
+			return serveErr
+		},
+	}
+}
+
+func serveWebhooks(ctx context.Context, listener net.Listener, handler http.Handler) error {
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("webhooks serve: %w", err)
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), webhookServeShutdown)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("webhooks serve: %w", err)
+		}
+		return nil
+	}
+}
+
+// eventDispatcher fans an event out to the configured targets. Deliveries can
+// arrive concurrently, so log writes are serialized.
+type eventDispatcher struct {
+	command     string
+	execTimeout time.Duration
+	notifier    notify.Notifier
+
+	mu  sync.Mutex
+	log *os.File
+}
+
+func (d *eventDispatcher) dispatch(ctx context.Context, event *webhookserver.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	if d.log != nil {
+		d.mu.Lock()
+		_, err := d.log.Write(append(data, '\n'))
+		d.mu.Unlock()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("log: %w", err))
+		}
+	}
+	if d.command != "" {
+		if err := d.runCommand(ctx, event, data); err != nil {
+			errs = append(errs, fmt.Errorf("exec: %w", err))
+		}
+	}
+	if d.notifier != nil {
+		if err := d.notifier.Send(ctx, notify.Message{Title: webhookNotifyTitle, Text: event.Summary()}); err != nil {
+			errs = append(errs, fmt.Errorf("notify: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (d *eventDispatcher) runCommand(ctx context.Context, event *webhookserver.Event, data []byte) error {
+	runCtx, cancel := context.WithTimeout(ctx, d.execTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(runCtx, "cmd", "/C", d.command)
+	} else {
+		cmd = exec.CommandContext(runCtx, "sh", "-c", d.command)
+	}
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), eventEnv(event)...)
+	return cmd.Run()
+}
+
+func eventEnv(event *webhookserver.Event) []string {
+	env := []string{
+		"ASC_EVENT_TYPE=" + string(event.Type),
+		"ASC_EVENT_ID=" + event.ID,
+		"ASC_EVENT_SUMMARY=" + event.Summary(),
+	}
+	if event.Instance != nil {
+		env = append(env,
+			"ASC_EVENT_INSTANCE_TYPE="+event.Instance.Type,
+			"ASC_EVENT_INSTANCE_ID="+event.Instance.ID,
+		)
+	}
+	return env
+}
@@ -0,0 +1,78 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/webhookserver"
+)
+
+func TestEventDispatcherWritesLogAndRunsCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "events.jsonl")
+	outPath := filepath.Join(dir, "out.txt")
+
+	logFile, err := os.OpenFile(logPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		t.Fatalf("open log: %v", err)
+	}
+	defer logFile.Close()
+
+	dispatcher := &eventDispatcher{
+		command:     `printf '%s ' "$ASC_EVENT_TYPE" "$ASC_EVENT_INSTANCE_ID" > "` + outPath + `"; cat >> "` + outPath + `"`,
+		execTimeout: 10 * time.Second,
+		log:         logFile,
+	}
+	event, err := webhookserver.Decode([]byte(`{"data":{"type":"buildUploadStateUpdated","id":"event-1","attributes":{"newState":"COMPLETE"},"relationships":{"instance":{"data":{"type":"buildUploads","id":"upload-1"}}}}}`))
+	if err != nil {
+		t.Fatalf("Decode() error: %v", err)
+	}
+	for range 2 {
+		if err := dispatcher.dispatch(context.Background(), event); err != nil {
+			t.Fatalf("dispatch() error: %v", err)
+		}
+	}
+
+	logData, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(logData)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %q", logData)
+	}
+	var logged struct {
+		Type    string `json:"type"`
+		Payload struct {
+			NewState string `json:"newState"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &logged); err != nil {
+		t.Fatalf("decode log line: %v", err)
+	}
+	if logged.Type != "BUILD_UPLOAD_STATE_UPDATED" || logged.Payload.NewState != "COMPLETE" {
+		t.Fatalf("unexpected log line: %s", lines[0])
+	}
+
+	out, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read command output: %v", err)
+	}
+	if !strings.HasPrefix(string(out), "BUILD_UPLOAD_STATE_UPDATED upload-1 {") {
+		t.Fatalf("unexpected command output: %q", out)
+	}
+
+	dispatcher.command = "exit 3"
+	if err := dispatcher.dispatch(context.Background(), event); err == nil || !strings.Contains(err.Error(), "exec:") {
+		t.Fatalf("expected exec error, got %v", err)
+	}
+}
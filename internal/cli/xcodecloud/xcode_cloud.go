@@ -114,7 +114,10 @@ Examples:
 				return shared.UsageError("--poll-interval must be greater than 0")
 			}
 
-			resolvedAppID := shared.ResolveAppID(*appID)
+			resolvedAppID, err := shared.ResolveAppID(ctx, *appID)
+			if err != nil {
+				return fmt.Errorf("xcode-cloud run: %w", err)
+			}
 			if hasWorkflowName && resolvedAppID == "" {
 				fmt.Fprintln(os.Stderr, "Error: --app is required when using --workflow (or set ASC_APP_ID)")
 				return flag.ErrHelp
@@ -516,7 +516,10 @@ func xcodeCloudProductsList(ctx context.Context, appID string, limit int, next s
 		return fmt.Errorf("xcode-cloud products: %w", err)
 	}
 
-	resolvedAppID := shared.ResolveAppID(appID)
+	resolvedAppID, err := shared.ResolveAppID(ctx, appID)
+	if err != nil {
+		return fmt.Errorf("xcode-cloud products: %w", err)
+	}
 	opts := []asc.CiProductsOption{
 		asc.WithCiProductsLimit(limit),
 		asc.WithCiProductsNextURL(next),
@@ -320,7 +320,10 @@ func xcodeCloudWorkflowsList(ctx context.Context, appID string, limit int, next
 		return fmt.Errorf("xcode-cloud workflows: %w", err)
 	}
 
-	resolvedAppID := shared.ResolveAppID(appID)
+	resolvedAppID, err := shared.ResolveAppID(ctx, appID)
+	if err != nil {
+		return fmt.Errorf("xcode-cloud workflows: %w", err)
+	}
 	if resolvedAppID == "" && nextURL == "" {
 		fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
 		return flag.ErrHelp
@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -138,6 +140,43 @@ type Config struct {
 	MaxDelay             string        `json:"max_delay"`
 	RetryLog             string        `json:"retry_log"`
 	Debug                string        `json:"debug"`
+	BaseURL              string        `json:"base_url,omitempty"`
+
+	KeyringBackend        string `json:"keyring_backend,omitempty"`
+	KeyringFileDir        string `json:"keyring_file_dir,omitempty"`
+	KeyringPassphraseFile string `json:"keyring_passphrase_file,omitempty"`
+}
+
+// Keyring backend names accepted by keyring_backend and ASC_KEYRING_BACKEND.
+const (
+	KeyringBackendAuto          = "auto"
+	KeyringBackendKeychain      = "keychain"
+	KeyringBackendWinCred       = "wincred"
+	KeyringBackendSecretService = "secret-service"
+	KeyringBackendKWallet       = "kwallet"
+	KeyringBackendKeyCtl        = "keyctl"
+	KeyringBackendFile          = "file"
+)
+
+// KeyringBackends lists the accepted keyring backend names.
+var KeyringBackends = []string{
+	KeyringBackendAuto,
+	KeyringBackendKeychain,
+	KeyringBackendWinCred,
+	KeyringBackendSecretService,
+	KeyringBackendKWallet,
+	KeyringBackendKeyCtl,
+	KeyringBackendFile,
+}
+
+// ValidateKeyringBackend reports whether raw names a keyring backend.
+// Empty values are allowed and mean "auto".
+func ValidateKeyringBackend(raw string) error {
+	value := strings.ToLower(strings.TrimSpace(raw))
+	if value == "" || slices.Contains(KeyringBackends, value) {
+		return nil
+	}
+	return fmt.Errorf("unknown keyring backend %q (expected one of: %s)", raw, strings.Join(KeyringBackends, ", "))
 }
 
 // ErrNotFound is returned when the config file doesn't exist
@@ -346,6 +385,35 @@ func (c *Config) Validate() error {
 	if baseSet && maxSet && maxDelay < baseDelay {
 		return wrapInvalidConfig(fmt.Errorf("max_delay must be >= base_delay"))
 	}
+	if err := ValidateBaseURL(c.BaseURL); err != nil {
+		return wrapInvalidConfig(fmt.Errorf("base_url: %w", err))
+	}
+	if err := ValidateKeyringBackend(c.KeyringBackend); err != nil {
+		return wrapInvalidConfig(fmt.Errorf("keyring_backend: %w", err))
+	}
+	return nil
+}
+
+// ValidateBaseURL reports whether raw is usable as an API base URL override.
+// Empty values are allowed and mean "use the default".
+func ValidateBaseURL(raw string) error {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q", raw)
+	}
+	if parsed.Scheme != "https" && parsed.Scheme != "http" {
+		return fmt.Errorf("URL %q must use http or https", raw)
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("URL %q must include a host", raw)
+	}
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return fmt.Errorf("URL %q must not include a query or fragment", raw)
+	}
 	return nil
 }
 
@@ -344,3 +344,49 @@ func TestLoadAtRejectsMaxDelayBelowBaseDelay(t *testing.T) {
 		t.Fatalf("expected ErrInvalidConfig, got %v", err)
 	}
 }
+
+func TestLoadAtRejectsInvalidBaseURL(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "config.json")
+	cfg := &Config{
+		BaseURL: "ftp://example.com",
+	}
+	if err := SaveAt(path, cfg); err != nil {
+		t.Fatalf("SaveAt() error: %v", err)
+	}
+
+	_, err := LoadAt(path)
+	if err == nil {
+		t.Fatal("expected error for invalid base URL, got nil")
+	}
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig, got %v", err)
+	}
+}
+
+func TestLoadAtRejectsUnknownKeyringBackend(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "config.json")
+	cfg := &Config{
+		KeyringBackend: "plaintext",
+	}
+	if err := SaveAt(path, cfg); err != nil {
+		t.Fatalf("SaveAt() error: %v", err)
+	}
+
+	_, err := LoadAt(path)
+	if err == nil {
+		t.Fatal("expected error for unknown keyring backend, got nil")
+	}
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig, got %v", err)
+	}
+
+	cfg.KeyringBackend = "File"
+	if err := SaveAt(path, cfg); err != nil {
+		t.Fatalf("SaveAt() error: %v", err)
+	}
+	if _, err := LoadAt(path); err != nil {
+		t.Fatalf("expected file backend to be accepted, got %v", err)
+	}
+}
@@ -0,0 +1,240 @@
+// Package crashgroup clusters TestFlight crash and feedback submissions into
+// deduplicated issues.
+//
+// Crash submissions are keyed by a signature built from the exception type
+// and the normalized top frames of the crashing thread; feedback is keyed by
+// its normalized comment.
+package crashgroup
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Unknown labels a submission without a build, OS version or device model.
+const Unknown = "unknown"
+
+// Signature identifies an issue.
+type Signature struct {
+	// Key is a short stable hash of the normalized signature.
+	Key string
+	// Title is a human readable summary, e.g. the exception and top frame.
+	Title string
+	// Frames are the normalized frames the key was built from.
+	Frames []string
+}
+
+// Submission is one crash or feedback report to cluster.
+type Submission struct {
+	ID          string
+	Signature   Signature
+	Build       string
+	OSVersion   string
+	DeviceModel string
+	CreatedAt   time.Time
+}
+
+// Group is a deduplicated issue.
+type Group struct {
+	Signature     string         `json:"signature"`
+	Title         string         `json:"title"`
+	Frames        []string       `json:"frames,omitempty"`
+	Count         int            `json:"count"`
+	Builds        map[string]int `json:"builds"`
+	OSVersions    map[string]int `json:"osVersions"`
+	DeviceModels  map[string]int `json:"deviceModels"`
+	FirstSeen     *time.Time     `json:"firstSeen,omitempty"`
+	LastSeen      *time.Time     `json:"lastSeen,omitempty"`
+	SubmissionIDs []string       `json:"submissionIds"`
+}
+
+// GroupSubmissions clusters submissions by signature key. Groups are sorted
+// by count, then by most recently seen.
+func GroupSubmissions(submissions []Submission) []Group {
+	index := map[string]*Group{}
+	var order []*Group
+	for _, sub := range submissions {
+		group, ok := index[sub.Signature.Key]
+		if !ok {
+			group = &Group{
+				Signature:    sub.Signature.Key,
+				Title:        sub.Signature.Title,
+				Frames:       sub.Signature.Frames,
+				Builds:       map[string]int{},
+				OSVersions:   map[string]int{},
+				DeviceModels: map[string]int{},
+			}
+			index[sub.Signature.Key] = group
+			order = append(order, group)
+		}
+		group.Count++
+		group.Builds[orUnknown(sub.Build)]++
+		group.OSVersions[orUnknown(sub.OSVersion)]++
+		group.DeviceModels[orUnknown(sub.DeviceModel)]++
+		group.SubmissionIDs = append(group.SubmissionIDs, sub.ID)
+		if !sub.CreatedAt.IsZero() {
+			seen := sub.CreatedAt.UTC()
+			if group.FirstSeen == nil || seen.Before(*group.FirstSeen) {
+				group.FirstSeen = &seen
+			}
+			if group.LastSeen == nil || seen.After(*group.LastSeen) {
+				last := seen
+				group.LastSeen = &last
+			}
+		}
+	}
+
+	groups := make([]Group, 0, len(order))
+	for _, group := range order {
+		groups = append(groups, *group)
+	}
+	slices.SortStableFunc(groups, func(a, b Group) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		if c := compareTimes(b.LastSeen, a.LastSeen); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Signature, b.Signature)
+	})
+	return groups
+}
+
+// Counts is a label and how often it occurred.
+type Counts struct {
+	Label string
+	Count int
+}
+
+// SortedCounts orders a count map by count, then label.
+func SortedCounts(counts map[string]int) []Counts {
+	sorted := make([]Counts, 0, len(counts))
+	for label, count := range counts {
+		sorted = append(sorted, Counts{Label: label, Count: count})
+	}
+	slices.SortFunc(sorted, func(a, b Counts) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Label, b.Label)
+	})
+	return sorted
+}
+
+func compareTimes(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	default:
+		return a.Compare(*b)
+	}
+}
+
+func orUnknown(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return Unknown
+	}
+	return value
+}
+
+func hashKey(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+	return hex.EncodeToString(sum[:6])
+}
+
+// Headers are the table columns produced by Rows.
+var Headers = []string{"Signature", "Count", "Title", "Builds", "OS Versions", "Devices", "First Seen", "Last Seen"}
+
+// Rows formats groups for table and markdown output. Count columns list the
+// three most common values.
+func Rows(groups []Group) [][]string {
+	rows := make([][]string, 0, len(groups))
+	for _, group := range groups {
+		rows = append(rows, []string{
+			group.Signature,
+			strconv.Itoa(group.Count),
+			group.Title,
+			formatCounts(group.Builds),
+			formatCounts(group.OSVersions),
+			formatCounts(group.DeviceModels),
+			formatTime(group.FirstSeen),
+			formatTime(group.LastSeen),
+		})
+	}
+	return rows
+}
+
+func formatCounts(counts map[string]int) string {
+	const maxShown = 3
+	sorted := SortedCounts(counts)
+	parts := make([]string, 0, maxShown+1)
+	for i, entry := range sorted {
+		if i == maxShown {
+			parts = append(parts, fmt.Sprintf("+%d more", len(sorted)-maxShown))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s (%d)", entry.Label, entry.Count))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatTime(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.Format(time.RFC3339)
+}
+
+// BuildLabels maps build IDs to versions from the included resources of a
+// response requested with the build include.
+func BuildLabels(included json.RawMessage) map[string]string {
+	labels := map[string]string{}
+	if len(included) == 0 {
+		return labels
+	}
+	var resources []struct {
+		Type       string `json:"type"`
+		ID         string `json:"id"`
+		Attributes struct {
+			Version string `json:"version"`
+		} `json:"attributes"`
+	}
+	if err := json.Unmarshal(included, &resources); err != nil {
+		return labels
+	}
+	for _, resource := range resources {
+		if resource.Type == "builds" && resource.Attributes.Version != "" {
+			labels[resource.ID] = resource.Attributes.Version
+		}
+	}
+	return labels
+}
+
+// RelatedBuildID returns the build linked in a submission's relationships.
+func RelatedBuildID(relationships json.RawMessage) string {
+	if len(relationships) == 0 {
+		return ""
+	}
+	var parsed struct {
+		Build struct {
+			Data *struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		} `json:"build"`
+	}
+	if err := json.Unmarshal(relationships, &parsed); err != nil || parsed.Build.Data == nil {
+		return ""
+	}
+	return parsed.Build.Data.ID
+}
@@ -0,0 +1,119 @@
+package crashgroup
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const symbolicatedCrash = `Process:             MyApp [4242]
+Exception Type:  EXC_BAD_ACCESS (SIGSEGV)
+
+Thread 0 name:  Dispatch queue: com.apple.main-thread
+Thread 0 Crashed:
+0   libswiftCore.dylib            	0x00000001a1b2c3d4 swift_unknownObjectRetain + 36
+1   MyApp                         	0x0000000100f4c9a8 ProfileViewController.load() + 120 (ProfileViewController.swift:42)
+2   MyApp                         	0x0000000100f4d000 closure #1 in ProfileViewController.viewDidLoad() + 12 (ProfileViewController.swift:18)
+3   UIKitCore                     	0x00000001b0000000 -[UIViewController loadViewIfRequired] + 1000
+
+Thread 1:
+0   libsystem_kernel.dylib        	0x00000001f0000000 mach_msg_trap + 8
+`
+
+func TestCrashSignatureIgnoresOffsetsAndLines(t *testing.T) {
+	sig := CrashSignature(symbolicatedCrash, 3)
+	wantFrames := []string{
+		"libswiftCore.dylib swift_unknownObjectRetain",
+		"MyApp ProfileViewController.load()",
+		"MyApp closure #1 in ProfileViewController.viewDidLoad()",
+	}
+	if strings.Join(sig.Frames, "|") != strings.Join(wantFrames, "|") {
+		t.Fatalf("frames = %q, want %q", sig.Frames, wantFrames)
+	}
+	if sig.Title != "EXC_BAD_ACCESS (SIGSEGV) in MyApp ProfileViewController.load()" {
+		t.Fatalf("unexpected title %q", sig.Title)
+	}
+
+	rebuilt := strings.NewReplacer("+ 120 (ProfileViewController.swift:42)", "+ 132 (ProfileViewController.swift:44)", "0x0000000100f4c9a8", "0x0000000102a00010").Replace(symbolicatedCrash)
+	if other := CrashSignature(rebuilt, 3); other.Key != sig.Key {
+		t.Fatalf("expected rebuilt crash to share key, got %s and %s", sig.Key, other.Key)
+	}
+	if deeper := CrashSignature(symbolicatedCrash, 4); deeper.Key == sig.Key {
+		t.Fatal("expected frame depth to change the key")
+	}
+}
+
+func TestCrashSignatureUnsymbolicatedAndLastExceptionBacktrace(t *testing.T) {
+	report := `Process: MyApp [1]
+Exception Type:  EXC_CRASH (SIGABRT)
+
+Last Exception Backtrace:
+0   CoreFoundation                	0x0000000180000000 0x17ff00000 + 1048576
+1   MyApp                         	0x0000000100f4c9a8 0x100f48000 + 18856
+
+Thread 0 Crashed:
+0   libsystem_kernel.dylib        	0x00000001f0000000 __pthread_kill + 8
+`
+	sig := CrashSignature(report, 5)
+	if strings.Join(sig.Frames, "|") != "CoreFoundation +1048576|MyApp +18856" {
+		t.Fatalf("unexpected frames %q", sig.Frames)
+	}
+	if sig.Title != "EXC_CRASH (SIGABRT) in MyApp +18856" {
+		t.Fatalf("unexpected title %q", sig.Title)
+	}
+
+	if empty := CrashSignature("", 5); empty.Title != "(no crash log)" || empty.Key == "" {
+		t.Fatalf("unexpected empty signature %+v", empty)
+	}
+}
+
+func TestFeedbackSignature(t *testing.T) {
+	a := FeedbackSignature("Crash on login!! (build 42)")
+	b := FeedbackSignature("  crash on LOGIN build 43")
+	if a.Key != b.Key {
+		t.Fatalf("expected near-identical comments to share a key")
+	}
+	if a.Title != "Crash on login!! (build 42)" {
+		t.Fatalf("unexpected title %q", a.Title)
+	}
+	if FeedbackSignature("Dark mode looks wrong").Key == a.Key {
+		t.Fatal("expected different comments to differ")
+	}
+	if FeedbackSignature("   ").Title != "(no comment)" {
+		t.Fatal("expected empty comment title")
+	}
+}
+
+func TestGroupSubmissions(t *testing.T) {
+	crash := CrashSignature(symbolicatedCrash, 3)
+	other := FeedbackSignature("something else")
+	at := func(day int) time.Time { return time.Date(2026, 3, day, 12, 0, 0, 0, time.UTC) }
+
+	groups := GroupSubmissions([]Submission{
+		{ID: "1", Signature: crash, Build: "42", OSVersion: "18.1", DeviceModel: "iPhone15,3", CreatedAt: at(3)},
+		{ID: "2", Signature: other, Build: "42", CreatedAt: at(9)},
+		{ID: "3", Signature: crash, Build: "43", OSVersion: "18.1", DeviceModel: "iPhone16,1", CreatedAt: at(1)},
+		{ID: "4", Signature: crash, Build: "43", OSVersion: "18.2", DeviceModel: "iPhone16,1", CreatedAt: at(5)},
+	})
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(groups))
+	}
+	top := groups[0]
+	if top.Signature != crash.Key || top.Count != 3 || strings.Join(top.SubmissionIDs, ",") != "1,3,4" {
+		t.Fatalf("unexpected top group %+v", top)
+	}
+	if top.Builds["43"] != 2 || top.Builds["42"] != 1 || top.OSVersions["18.1"] != 2 || top.DeviceModels["iPhone16,1"] != 2 {
+		t.Fatalf("unexpected counts %+v", top)
+	}
+	if !top.FirstSeen.Equal(at(1)) || !top.LastSeen.Equal(at(5)) {
+		t.Fatalf("unexpected first/last seen %v %v", top.FirstSeen, top.LastSeen)
+	}
+	if groups[1].OSVersions[Unknown] != 1 || groups[1].DeviceModels[Unknown] != 1 {
+		t.Fatalf("expected missing fields to count as unknown: %+v", groups[1])
+	}
+
+	counts := SortedCounts(top.Builds)
+	if counts[0] != (Counts{Label: "43", Count: 2}) || counts[1] != (Counts{Label: "42", Count: 1}) {
+		t.Fatalf("unexpected sorted counts %+v", counts)
+	}
+}
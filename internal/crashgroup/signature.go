@@ -0,0 +1,138 @@
+package crashgroup
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// DefaultFrames is the number of top frames used for crash signatures.
+const DefaultFrames = 5
+
+var (
+	crashedThreadPattern = regexp.MustCompile(`^Thread \d+( name: .*)? Crashed:`)
+	// 0   MyApp    0x0000000100f4c9a8 ViewController.crash() + 36 (ViewController.swift:42)
+	crashFramePattern = regexp.MustCompile(`^\d+\s+(.+?)\s+0x[0-9a-fA-F]+\s+(.+)$`)
+	// 0x100f48000 + 18856
+	unsymbolicatedPattern = regexp.MustCompile(`^0x[0-9a-fA-F]+\s+\+\s+(\d+)$`)
+	symbolOffsetPattern   = regexp.MustCompile(`\s+\+\s+\d+(\s+\(.*\))?(\s+\[inlined\])?$`)
+)
+
+// CrashSignature builds the signature of a text crash report from its
+// exception type and the top depth frames of the crashing thread (or the
+// last exception backtrace, when present). Offsets and line numbers are
+// dropped from symbolicated frames so rebuilds of the same code still group
+// together.
+func CrashSignature(report string, depth int) Signature {
+	if depth <= 0 {
+		depth = DefaultFrames
+	}
+	lines := strings.Split(strings.ReplaceAll(report, "\r\n", "\n"), "\n")
+
+	var exception, process string
+	for _, line := range lines {
+		if value, ok := strings.CutPrefix(line, "Exception Type:"); ok && exception == "" {
+			exception = strings.Join(strings.Fields(value), " ")
+		}
+		if value, ok := strings.CutPrefix(line, "Process:"); ok && process == "" {
+			fields := strings.Fields(value)
+			if len(fields) > 0 {
+				process = fields[0]
+			}
+		}
+	}
+
+	frames := sectionFrames(lines, func(line string) bool {
+		return strings.HasPrefix(line, "Last Exception Backtrace:")
+	}, depth)
+	if len(frames) == 0 {
+		frames = sectionFrames(lines, crashedThreadPattern.MatchString, depth)
+	}
+
+	if exception == "" && len(frames) == 0 {
+		return Signature{Key: hashKey("crash", "unparsed"), Title: "(no crash log)"}
+	}
+
+	title := exception
+	if top := culpritFrame(frames, process); top != "" {
+		if title != "" {
+			title += " in "
+		}
+		title += top
+	}
+	return Signature{
+		Key:    hashKey(append([]string{"crash", exception}, frames...)...),
+		Title:  title,
+		Frames: frames,
+	}
+}
+
+func sectionFrames(lines []string, isHeader func(string) bool, depth int) []string {
+	for i, line := range lines {
+		if !isHeader(strings.TrimSpace(line)) {
+			continue
+		}
+		var frames []string
+		for _, frameLine := range lines[i+1:] {
+			frameLine = strings.TrimSpace(frameLine)
+			if frameLine == "" || len(frames) == depth {
+				break
+			}
+			if frame, ok := normalizeFrame(frameLine); ok {
+				frames = append(frames, frame)
+			}
+		}
+		return frames
+	}
+	return nil
+}
+
+// normalizeFrame returns "image symbol" for symbolicated frames and
+// "image +offset" for unsymbolicated ones.
+func normalizeFrame(line string) (string, bool) {
+	match := crashFramePattern.FindStringSubmatch(line)
+	if match == nil {
+		return "", false
+	}
+	image := strings.TrimSpace(match[1])
+	location := strings.TrimSpace(match[2])
+	if offset := unsymbolicatedPattern.FindStringSubmatch(location); offset != nil {
+		return image + " +" + offset[1], true
+	}
+	return image + " " + symbolOffsetPattern.ReplaceAllString(location, ""), true
+}
+
+// culpritFrame prefers the first frame in the app's own binary.
+func culpritFrame(frames []string, process string) string {
+	if process != "" {
+		for _, frame := range frames {
+			if strings.HasPrefix(frame, process+" ") {
+				return frame
+			}
+		}
+	}
+	if len(frames) > 0 {
+		return frames[0]
+	}
+	return ""
+}
+
+// maxTitleLength caps feedback titles.
+const maxTitleLength = 80
+
+// FeedbackSignature keys feedback by its comment with case, punctuation and
+// numbers removed, so "Crash on login!!" and "crash on login" group together.
+func FeedbackSignature(comment string) Signature {
+	normalized := strings.Join(strings.FieldsFunc(strings.ToLower(comment), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}), " ")
+	if normalized == "" {
+		return Signature{Key: hashKey("feedback", ""), Title: "(no comment)"}
+	}
+
+	title := strings.Join(strings.Fields(comment), " ")
+	if runes := []rune(title); len(runes) > maxTitleLength {
+		title = string(runes[:maxTitleLength-1]) + "…"
+	}
+	return Signature{Key: hashKey("feedback", normalized), Title: title}
+}
@@ -0,0 +1,217 @@
+// Package ipa reads iOS app archives (.ipa files and .app bundles) offline.
+//
+// It reports what is inside a build before it is uploaded: bundle metadata,
+// the embedded provisioning profile, code-signing entitlements, Mach-O
+// architectures and minimum OS versions, nested extensions and frameworks,
+// and where the bytes go.
+package ipa
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"howett.net/plist"
+)
+
+// maxPlistSize bounds property list reads so a corrupt archive cannot
+// exhaust memory.
+const maxPlistSize = 32 << 20
+
+// File is a regular file in an archive. Names are slash-separated and
+// relative to the archive root, e.g. "Payload/MyApp.app/Info.plist".
+type File struct {
+	Name           string
+	Size           int64
+	CompressedSize int64
+
+	open func() (io.ReadCloser, error)
+}
+
+// Open returns the file contents.
+func (f *File) Open() (io.ReadCloser, error) {
+	return f.open()
+}
+
+// Archive is an .ipa file or an .app bundle on disk. Bundles are presented
+// as if they were zipped under Payload/.
+type Archive struct {
+	// Path is the path the archive was opened from.
+	Path string
+	// AppDir is the top-level app bundle, e.g. "Payload/MyApp.app".
+	AppDir string
+	// Files are sorted by name.
+	Files []*File
+
+	byName map[string]*File
+	closer io.Closer
+}
+
+// Open opens an .ipa file or an .app directory.
+func Open(archivePath string) (*Archive, error) {
+	info, err := os.Stat(archivePath)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := &Archive{Path: archivePath}
+	if info.IsDir() {
+		if err := archive.loadDir(archivePath); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := archive.loadZip(archivePath); err != nil {
+			return nil, err
+		}
+	}
+
+	slices.SortFunc(archive.Files, func(a, b *File) int { return strings.Compare(a.Name, b.Name) })
+	archive.byName = make(map[string]*File, len(archive.Files))
+	for _, file := range archive.Files {
+		archive.byName[file.Name] = file
+	}
+	archive.AppDir = findAppDir(archive.Files)
+	if archive.AppDir == "" {
+		archive.Close()
+		return nil, fmt.Errorf("no app bundle with an Info.plist found under Payload/")
+	}
+	return archive, nil
+}
+
+func (a *Archive) loadZip(archivePath string) error {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("open IPA: %w", err)
+	}
+	a.closer = reader
+	for _, entry := range reader.File {
+		if !entry.Mode().IsRegular() {
+			continue
+		}
+		name := path.Clean(entry.Name)
+		if name == "." || strings.HasPrefix(name, "../") || path.IsAbs(name) {
+			continue
+		}
+		a.Files = append(a.Files, &File{
+			Name:           name,
+			Size:           int64(entry.UncompressedSize64),
+			CompressedSize: int64(entry.CompressedSize64),
+			open:           entry.Open,
+		})
+	}
+	return nil
+}
+
+func (a *Archive) loadDir(dir string) error {
+	if !strings.EqualFold(filepath.Ext(filepath.Clean(dir)), ".app") {
+		return fmt.Errorf("%s is not an .app bundle", dir)
+	}
+	prefix := "Payload/" + filepath.Base(filepath.Clean(dir))
+	return filepath.WalkDir(dir, func(current string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, current)
+		if err != nil {
+			return err
+		}
+		a.Files = append(a.Files, &File{
+			Name: prefix + "/" + filepath.ToSlash(rel),
+			Size: info.Size(),
+			open: func() (io.ReadCloser, error) { return os.Open(current) },
+		})
+		return nil
+	})
+}
+
+// Close releases the underlying zip file.
+func (a *Archive) Close() error {
+	if a.closer == nil {
+		return nil
+	}
+	return a.closer.Close()
+}
+
+// IsIPA reports whether the archive was opened from a zip file.
+func (a *Archive) IsIPA() bool {
+	return a.closer != nil
+}
+
+// Lookup returns the file with the given archive path.
+func (a *Archive) Lookup(name string) (*File, bool) {
+	file, ok := a.byName[name]
+	return file, ok
+}
+
+// ReadFile returns the contents of the named file, reading at most limit
+// bytes (0 = no limit).
+func (a *Archive) ReadFile(name string, limit int64) ([]byte, error) {
+	file, ok := a.Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", name, fs.ErrNotExist)
+	}
+	if limit > 0 && file.Size > limit {
+		return nil, fmt.Errorf("%s: file is larger than %d bytes", name, limit)
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", name, err)
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", name, err)
+	}
+	return data, nil
+}
+
+// ReadPlist decodes an XML or binary property list from the archive.
+func (a *Archive) ReadPlist(name string) (map[string]any, error) {
+	data, err := a.ReadFile(name, maxPlistSize)
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]any
+	if err := plist.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", name, err)
+	}
+	return values, nil
+}
+
+// FilesUnder returns the files inside dir.
+func (a *Archive) FilesUnder(dir string) []*File {
+	prefix := strings.TrimSuffix(dir, "/") + "/"
+	start, _ := slices.BinarySearchFunc(a.Files, prefix, func(file *File, target string) int {
+		return strings.Compare(file.Name, target)
+	})
+	end := start
+	for end < len(a.Files) && strings.HasPrefix(a.Files[end].Name, prefix) {
+		end++
+	}
+	return a.Files[start:end]
+}
+
+// findAppDir returns the Payload/*.app directory containing an Info.plist.
+func findAppDir(files []*File) string {
+	for _, file := range files {
+		dir := path.Dir(file.Name)
+		if path.Base(file.Name) == "Info.plist" && path.Dir(dir) == "Payload" && strings.HasSuffix(dir, ".app") {
+			return dir
+		}
+	}
+	return ""
+}
@@ -0,0 +1,266 @@
+package ipa
+
+import (
+	"cmp"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultSizeDepth groups the size breakdown by top-level directory.
+const DefaultSizeDepth = 1
+
+// Bundle describes the app or one of its nested bundles.
+type Bundle struct {
+	// Path is relative to Payload/, e.g. "MyApp.app/PlugIns/Widget.appex".
+	Path             string         `json:"path"`
+	Name             string         `json:"name,omitempty"`
+	BundleID         string         `json:"bundleId,omitempty"`
+	Version          string         `json:"version,omitempty"`
+	Build            string         `json:"build,omitempty"`
+	MinimumOSVersion string         `json:"minimumOSVersion,omitempty"`
+	ExtensionPoint   string         `json:"extensionPoint,omitempty"`
+	Executable       string         `json:"executable,omitempty"`
+	Architectures    []Architecture `json:"architectures,omitempty"`
+	Entitlements     map[string]any `json:"entitlements,omitempty"`
+	Profile          *Profile       `json:"provisioningProfile,omitempty"`
+	Size             int64          `json:"size"`
+	// Warnings are problems reading parts of the bundle.
+	Warnings []string `json:"warnings,omitempty"`
+
+	// Info is the decoded Info.plist.
+	Info map[string]any `json:"-"`
+}
+
+// SizeEntry is the uncompressed (and, for IPAs, compressed) size of one
+// directory or top-level file.
+type SizeEntry struct {
+	Path           string `json:"path"`
+	Files          int    `json:"files"`
+	Size           int64  `json:"size"`
+	CompressedSize int64  `json:"compressedSize,omitempty"`
+}
+
+// Report is the result of inspecting an archive.
+type Report struct {
+	Path           string      `json:"path"`
+	App            Bundle      `json:"app"`
+	Extensions     []Bundle    `json:"extensions"`
+	Frameworks     []Bundle    `json:"frameworks"`
+	Sizes          []SizeEntry `json:"sizes"`
+	Files          int         `json:"files"`
+	TotalSize      int64       `json:"totalSize"`
+	CompressedSize int64       `json:"compressedSize,omitempty"`
+}
+
+// Options controls Inspect.
+type Options struct {
+	// SizeDepth is how many directory levels below the app bundle the size
+	// breakdown keeps (default DefaultSizeDepth).
+	SizeDepth int
+	// Now is used to decide whether profiles have expired (default
+	// time.Now).
+	Now time.Time
+}
+
+// Inspect reports on the app bundle in an archive, its extensions and
+// frameworks and its size breakdown.
+func Inspect(archive *Archive, opts Options) (*Report, error) {
+	if opts.SizeDepth <= 0 {
+		opts.SizeDepth = DefaultSizeDepth
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	app, err := readBundle(archive, archive.AppDir, opts.Now)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{
+		Path:       archive.Path,
+		App:        *app,
+		Extensions: []Bundle{},
+		Frameworks: []Bundle{},
+	}
+
+	for _, dir := range nestedBundleDirs(archive) {
+		bundle, err := readBundle(archive, dir, opts.Now)
+		if err != nil {
+			return nil, err
+		}
+		if isFrameworkDir(dir) {
+			report.Frameworks = append(report.Frameworks, *bundle)
+		} else {
+			report.Extensions = append(report.Extensions, *bundle)
+		}
+	}
+	report.Frameworks = append(report.Frameworks, standaloneDylibs(archive)...)
+
+	report.Sizes = sizeBreakdown(archive, opts.SizeDepth)
+	for _, file := range archive.Files {
+		report.Files++
+		report.TotalSize += file.Size
+		report.CompressedSize += file.CompressedSize
+	}
+	return report, nil
+}
+
+// readBundle decodes a bundle's Info.plist, executable and profile. Only a
+// missing or unreadable Info.plist is fatal; other problems are recorded as
+// warnings so the rest of the report is still useful.
+func readBundle(archive *Archive, dir string, now time.Time) (*Bundle, error) {
+	info, err := archive.ReadPlist(dir + "/Info.plist")
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &Bundle{
+		Path:             strings.TrimPrefix(dir, "Payload/"),
+		Name:             firstString(info, "CFBundleDisplayName", "CFBundleName"),
+		BundleID:         stringValue(info["CFBundleIdentifier"]),
+		Version:          stringValue(info["CFBundleShortVersionString"]),
+		Build:            stringValue(info["CFBundleVersion"]),
+		MinimumOSVersion: firstString(info, "MinimumOSVersion", "LSMinimumSystemVersion"),
+		Executable:       stringValue(info["CFBundleExecutable"]),
+		Info:             info,
+	}
+	if extension, ok := info["NSExtension"].(map[string]any); ok {
+		bundle.ExtensionPoint = stringValue(extension["NSExtensionPointIdentifier"])
+	}
+	for _, file := range archive.FilesUnder(dir) {
+		bundle.Size += file.Size
+	}
+
+	if bundle.Executable != "" {
+		name := dir + "/" + bundle.Executable
+		if data, err := archive.ReadFile(name, 0); err != nil {
+			bundle.Warnings = append(bundle.Warnings, fmt.Sprintf("executable: %v", err))
+		} else if binary, err := ParseBinary(data); err != nil {
+			bundle.Warnings = append(bundle.Warnings, fmt.Sprintf("executable %s: %v", bundle.Executable, err))
+		} else {
+			bundle.Architectures = binary.Architectures
+			bundle.Entitlements = binary.Entitlements
+		}
+	}
+
+	profileName := dir + "/embedded.mobileprovision"
+	if _, ok := archive.Lookup(profileName); ok {
+		if data, err := archive.ReadFile(profileName, maxPlistSize); err != nil {
+			bundle.Warnings = append(bundle.Warnings, fmt.Sprintf("embedded.mobileprovision: %v", err))
+		} else if profile, err := ParseProfile(data, now); err != nil {
+			bundle.Warnings = append(bundle.Warnings, fmt.Sprintf("embedded.mobileprovision: %v", err))
+		} else {
+			bundle.Profile = profile
+		}
+	}
+	return bundle, nil
+}
+
+// nestedBundleDirs returns the extension (.appex), nested app (watch apps,
+// App Clips) and framework bundles inside the main app, in path order.
+func nestedBundleDirs(archive *Archive) []string {
+	var dirs []string
+	for _, file := range archive.FilesUnder(archive.AppDir) {
+		if path.Base(file.Name) != "Info.plist" {
+			continue
+		}
+		dir := path.Dir(file.Name)
+		if dir == archive.AppDir {
+			continue
+		}
+		switch path.Ext(dir) {
+		case ".appex", ".app", ".framework":
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+func isFrameworkDir(dir string) bool {
+	return path.Ext(dir) == ".framework"
+}
+
+// standaloneDylibs lists Swift and other dynamic libraries shipped directly
+// in the app's Frameworks folder rather than inside a .framework.
+func standaloneDylibs(archive *Archive) []Bundle {
+	var dylibs []Bundle
+	frameworksDir := archive.AppDir + "/Frameworks"
+	for _, file := range archive.FilesUnder(frameworksDir) {
+		if path.Ext(file.Name) != ".dylib" || path.Dir(file.Name) != frameworksDir {
+			continue
+		}
+		dylibs = append(dylibs, Bundle{
+			Path: strings.TrimPrefix(file.Name, "Payload/"),
+			Name: path.Base(file.Name),
+			Size: file.Size,
+		})
+	}
+	return dylibs
+}
+
+// sizeBreakdown sums file sizes per directory, keeping depth levels below
+// the app bundle. Files directly in the app bundle are reported
+// individually; content outside Payload/ (SwiftSupport, Symbols) is grouped
+// by its top-level folder. Entries are sorted largest first.
+func sizeBreakdown(archive *Archive, depth int) []SizeEntry {
+	index := map[string]*SizeEntry{}
+	appPrefix := archive.AppDir + "/"
+	for _, file := range archive.Files {
+		var key string
+		if rel, ok := strings.CutPrefix(file.Name, appPrefix); ok {
+			parts := strings.Split(rel, "/")
+			key = path.Join(strings.TrimPrefix(archive.AppDir, "Payload/"), path.Join(parts[:min(depth, len(parts))]...))
+		} else {
+			key, _, _ = strings.Cut(file.Name, "/")
+		}
+		entry, ok := index[key]
+		if !ok {
+			entry = &SizeEntry{Path: key}
+			index[key] = entry
+		}
+		entry.Files++
+		entry.Size += file.Size
+		entry.CompressedSize += file.CompressedSize
+	}
+
+	entries := make([]SizeEntry, 0, len(index))
+	for _, entry := range index {
+		entries = append(entries, *entry)
+	}
+	slices.SortFunc(entries, func(a, b SizeEntry) int {
+		if c := cmp.Compare(b.Size, a.Size); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Path, b.Path)
+	})
+	return entries
+}
+
+func firstString(values map[string]any, keys ...string) string {
+	for _, key := range keys {
+		if value := stringValue(values[key]); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// FormatSize renders a byte count with decimal units, as Finder and App
+// Store Connect do.
+func FormatSize(size int64) string {
+	const unit = 1000
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size)
+	for _, suffix := range []string{"KB", "MB", "GB"} {
+		value /= unit
+		if value < unit || suffix == "GB" {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+	}
+	return ""
+}
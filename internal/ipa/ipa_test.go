@@ -0,0 +1,380 @@
+package ipa
+
+import (
+	"archive/zip"
+	"bytes"
+	"debug/macho"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testInfoPlist = `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0"><dict>
+<key>CFBundleIdentifier</key><string>com.example.app</string>
+<key>CFBundleDisplayName</key><string>Example</string>
+<key>CFBundleShortVersionString</key><string>1.2.0</string>
+<key>CFBundleVersion</key><string>42</string>
+<key>CFBundleExecutable</key><string>Example</string>
+<key>MinimumOSVersion</key><string>16.0</string>
+</dict></plist>`
+
+const testWidgetInfoPlist = `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0"><dict>
+<key>CFBundleIdentifier</key><string>com.example.app.widget</string>
+<key>CFBundleExecutable</key><string>Widget</string>
+<key>NSExtension</key><dict>
+<key>NSExtensionPointIdentifier</key><string>com.apple.widgetkit-extension</string>
+</dict>
+</dict></plist>`
+
+const testFrameworkInfoPlist = `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0"><dict>
+<key>CFBundleIdentifier</key><string>com.example.Kit</string>
+<key>CFBundleShortVersionString</key><string>3.1</string>
+</dict></plist>`
+
+const testEntitlements = `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0"><dict>
+<key>application-identifier</key><string>TEAM123456.com.example.app</string>
+<key>aps-environment</key><string>production</string>
+<key>get-task-allow</key><false/>
+</dict></plist>`
+
+func testProfile(expiration string, devices bool) string {
+	deviceList := ""
+	if devices {
+		deviceList = `<key>ProvisionedDevices</key><array><string>00008030-001</string></array>`
+	}
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0"><dict>
+<key>Name</key><string>Example App Store</string>
+<key>UUID</key><string>11111111-2222-3333-4444-555555555555</string>
+<key>TeamName</key><string>Example Inc</string>
+<key>TeamIdentifier</key><array><string>TEAM123456</string></array>
+<key>Platform</key><array><string>iOS</string></array>
+<key>CreationDate</key><date>2026-01-01T00:00:00Z</date>
+<key>ExpirationDate</key><date>` + expiration + `</date>
+` + deviceList + `
+<key>Entitlements</key><dict>
+<key>application-identifier</key><string>TEAM123456.com.example.app</string>
+<key>get-task-allow</key><false/>
+</dict>
+</dict></plist>`
+}
+
+// buildMachO returns a minimal arm64 iOS executable with an LC_BUILD_VERSION
+// and, when entitlements is set, an embedded code signature.
+func buildMachO(t *testing.T, minOS uint32, entitlements string) []byte {
+	t.Helper()
+	le := binary.LittleEndian
+
+	var signature []byte
+	if entitlements != "" {
+		blob := binary.BigEndian.AppendUint32(nil, csMagicEntitlements)
+		blob = binary.BigEndian.AppendUint32(blob, uint32(8+len(entitlements)))
+		blob = append(blob, entitlements...)
+
+		signature = binary.BigEndian.AppendUint32(nil, csMagicEmbeddedSignature)
+		signature = binary.BigEndian.AppendUint32(signature, uint32(20+len(blob)))
+		signature = binary.BigEndian.AppendUint32(signature, 1)
+		signature = binary.BigEndian.AppendUint32(signature, csSlotEntitlements)
+		signature = binary.BigEndian.AppendUint32(signature, 20)
+		signature = append(signature, blob...)
+	}
+
+	const headerSize, buildVersionSize, codeSignatureSize = 32, 24, 16
+	ncmds, sizeofcmds := uint32(1), uint32(buildVersionSize)
+	if signature != nil {
+		ncmds++
+		sizeofcmds += codeSignatureSize
+	}
+	sigOffset := uint32(headerSize) + sizeofcmds
+
+	var out []byte
+	out = le.AppendUint32(out, macho.Magic64)
+	out = le.AppendUint32(out, uint32(macho.CpuArm64))
+	out = le.AppendUint32(out, 0)
+	out = le.AppendUint32(out, uint32(macho.TypeExec))
+	out = le.AppendUint32(out, ncmds)
+	out = le.AppendUint32(out, sizeofcmds)
+	out = le.AppendUint32(out, 0)
+	out = le.AppendUint32(out, 0)
+
+	out = le.AppendUint32(out, loadCmdBuildVersion)
+	out = le.AppendUint32(out, buildVersionSize)
+	out = le.AppendUint32(out, 2) // iOS
+	out = le.AppendUint32(out, minOS)
+	out = le.AppendUint32(out, 18<<16|2<<8)
+	out = le.AppendUint32(out, 0)
+
+	if signature != nil {
+		out = le.AppendUint32(out, loadCmdCodeSignature)
+		out = le.AppendUint32(out, codeSignatureSize)
+		out = le.AppendUint32(out, sigOffset)
+		out = le.AppendUint32(out, uint32(len(signature)))
+		out = append(out, signature...)
+	}
+	return out
+}
+
+// wrapFat wraps a thin binary in a universal header at a page offset.
+func wrapFat(thin []byte) []byte {
+	const offset = 4096
+	be := binary.BigEndian
+	out := be.AppendUint32(nil, macho.MagicFat)
+	out = be.AppendUint32(out, 1)
+	out = be.AppendUint32(out, uint32(macho.CpuArm64))
+	out = be.AppendUint32(out, 0)
+	out = be.AppendUint32(out, offset)
+	out = be.AppendUint32(out, uint32(len(thin)))
+	out = be.AppendUint32(out, 12)
+	out = append(out, make([]byte, offset-len(out))...)
+	return append(out, thin...)
+}
+
+func writeTestIPA(t *testing.T, files map[string][]byte) string {
+	t.Helper()
+	ipaPath := filepath.Join(t.TempDir(), "Example.ipa")
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, data := range files {
+		entry, err := writer.Create(name)
+		if err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+		if _, err := entry.Write(data); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close zip: %v", err)
+	}
+	if err := os.WriteFile(ipaPath, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("write ipa: %v", err)
+	}
+	return ipaPath
+}
+
+func testIPAFiles(t *testing.T) map[string][]byte {
+	return map[string][]byte{
+		"Payload/Example.app/Info.plist":                                []byte(testInfoPlist),
+		"Payload/Example.app/Example":                                   wrapFat(buildMachO(t, 16<<16, testEntitlements)),
+		"Payload/Example.app/embedded.mobileprovision":                  []byte(testProfile("2099-01-01T00:00:00Z", false)),
+		"Payload/Example.app/Assets.car":                                bytes.Repeat([]byte("a"), 4000),
+		"Payload/Example.app/PlugIns/Widget.appex/Info.plist":           []byte(testWidgetInfoPlist),
+		"Payload/Example.app/PlugIns/Widget.appex/Widget":               buildMachO(t, 17<<16|1<<8|2, ""),
+		"Payload/Example.app/Frameworks/Kit.framework/Info.plist":       []byte(testFrameworkInfoPlist),
+		"Payload/Example.app/Frameworks/Kit.framework/Kit":              bytes.Repeat([]byte("k"), 2000),
+		"Payload/Example.app/Frameworks/libswiftCore.dylib":             bytes.Repeat([]byte("s"), 100),
+		"Payload/Example.app/Frameworks/Kit.framework/Headers/Kit.h":    []byte("// header"),
+		"SwiftSupport/iphoneos/libswiftCore.dylib":                      bytes.Repeat([]byte("s"), 100),
+		"Payload/Example.app/Base.lproj/LaunchScreen.storyboardc/x.nib": []byte("nib"),
+	}
+}
+
+func TestInspectIPA(t *testing.T) {
+	archive, err := Open(writeTestIPA(t, testIPAFiles(t)))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer archive.Close()
+
+	report, err := Inspect(archive, Options{})
+	if err != nil {
+		t.Fatalf("Inspect() error: %v", err)
+	}
+
+	app := report.App
+	if app.Path != "Example.app" || app.BundleID != "com.example.app" || app.Name != "Example" {
+		t.Fatalf("unexpected app: %+v", app)
+	}
+	if app.Version != "1.2.0" || app.Build != "42" || app.MinimumOSVersion != "16.0" {
+		t.Fatalf("unexpected app versions: %+v", app)
+	}
+	if len(app.Architectures) != 1 {
+		t.Fatalf("expected 1 architecture, got %+v", app.Architectures)
+	}
+	if arch := app.Architectures[0]; arch.Name != "arm64" || arch.Platform != "iOS" || arch.MinOS != "16.0" || arch.SDK != "18.2" {
+		t.Fatalf("unexpected architecture: %+v", arch)
+	}
+	if app.Entitlements["aps-environment"] != "production" {
+		t.Fatalf("expected signed entitlements, got %+v", app.Entitlements)
+	}
+	if len(app.Warnings) != 0 {
+		t.Fatalf("unexpected warnings: %v", app.Warnings)
+	}
+
+	profile := app.Profile
+	if profile == nil {
+		t.Fatal("expected provisioning profile")
+	}
+	if profile.TeamID != "TEAM123456" || profile.TeamName != "Example Inc" || profile.Type != ProfileTypeAppStore || profile.Expired {
+		t.Fatalf("unexpected profile: %+v", profile)
+	}
+	if profile.BundleID() != "com.example.app" {
+		t.Fatalf("expected profile bundle ID com.example.app, got %q", profile.BundleID())
+	}
+
+	if len(report.Extensions) != 1 {
+		t.Fatalf("expected 1 extension, got %+v", report.Extensions)
+	}
+	widget := report.Extensions[0]
+	if widget.Path != "Example.app/PlugIns/Widget.appex" || widget.ExtensionPoint != "com.apple.widgetkit-extension" {
+		t.Fatalf("unexpected extension: %+v", widget)
+	}
+	if len(widget.Architectures) != 1 || widget.Architectures[0].MinOS != "17.1.2" || widget.Entitlements != nil {
+		t.Fatalf("unexpected extension binary: %+v", widget)
+	}
+
+	if len(report.Frameworks) != 2 {
+		t.Fatalf("expected framework and dylib, got %+v", report.Frameworks)
+	}
+	kit := report.Frameworks[0]
+	if kit.Path != "Example.app/Frameworks/Kit.framework" || kit.Version != "3.1" || kit.Size != int64(2000+len(testFrameworkInfoPlist)+len("// header")) {
+		t.Fatalf("unexpected framework: %+v", kit)
+	}
+	if report.Frameworks[1].Name != "libswiftCore.dylib" {
+		t.Fatalf("unexpected dylib: %+v", report.Frameworks[1])
+	}
+
+	if report.Files != 12 || report.CompressedSize == 0 {
+		t.Fatalf("unexpected totals: files=%d compressed=%d", report.Files, report.CompressedSize)
+	}
+	sizes := map[string]SizeEntry{}
+	for _, entry := range report.Sizes {
+		sizes[entry.Path] = entry
+	}
+	if sizes["Example.app/Assets.car"].Size != 4000 {
+		t.Fatalf("expected Assets.car entry, got %+v", report.Sizes)
+	}
+	if sizes["Example.app/Frameworks"].Files != 4 {
+		t.Fatalf("expected Frameworks to hold 4 files, got %+v", sizes["Example.app/Frameworks"])
+	}
+	if sizes["SwiftSupport"].Size != 100 {
+		t.Fatalf("expected SwiftSupport entry, got %+v", report.Sizes)
+	}
+	if report.Sizes[0].Path != "Example.app/Example" && report.Sizes[0].Path != "Example.app/Assets.car" {
+		t.Fatalf("expected largest entry first, got %+v", report.Sizes[0])
+	}
+}
+
+func TestInspectSizeDepth(t *testing.T) {
+	archive, err := Open(writeTestIPA(t, testIPAFiles(t)))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer archive.Close()
+
+	report, err := Inspect(archive, Options{SizeDepth: 2})
+	if err != nil {
+		t.Fatalf("Inspect() error: %v", err)
+	}
+	found := false
+	for _, entry := range report.Sizes {
+		if entry.Path == "Example.app/Frameworks/Kit.framework" {
+			found = true
+		}
+		if entry.Path == "Example.app/Frameworks" {
+			t.Fatalf("depth 2 should split Frameworks, got %+v", report.Sizes)
+		}
+	}
+	if !found {
+		t.Fatalf("expected Kit.framework entry, got %+v", report.Sizes)
+	}
+}
+
+func TestInspectAppDirectory(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "Example.app")
+	for name, data := range map[string][]byte{
+		"Info.plist": []byte(testInfoPlist),
+		"Example":    buildMachO(t, 15<<16, ""),
+	} {
+		if err := os.MkdirAll(root, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, name), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	archive, err := Open(root)
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	if archive.IsIPA() || archive.AppDir != "Payload/Example.app" {
+		t.Fatalf("unexpected archive: ipa=%v appDir=%q", archive.IsIPA(), archive.AppDir)
+	}
+	report, err := Inspect(archive, Options{})
+	if err != nil {
+		t.Fatalf("Inspect() error: %v", err)
+	}
+	if report.App.BundleID != "com.example.app" || report.App.Architectures[0].MinOS != "15.0" || report.CompressedSize != 0 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
+
+func TestOpenRejectsArchiveWithoutApp(t *testing.T) {
+	ipaPath := writeTestIPA(t, map[string][]byte{"Payload/readme.txt": []byte("hi")})
+	if _, err := Open(ipaPath); err == nil {
+		t.Fatal("expected error for IPA without an app bundle")
+	}
+}
+
+func TestInspectRecordsBadExecutableAsWarning(t *testing.T) {
+	files := testIPAFiles(t)
+	files["Payload/Example.app/Example"] = []byte("not mach-o")
+	archive, err := Open(writeTestIPA(t, files))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer archive.Close()
+
+	report, err := Inspect(archive, Options{})
+	if err != nil {
+		t.Fatalf("Inspect() error: %v", err)
+	}
+	if len(report.App.Warnings) != 1 || report.App.Architectures != nil {
+		t.Fatalf("expected one executable warning, got %+v", report.App.Warnings)
+	}
+}
+
+func TestParseProfileTypesAndExpiry(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	expired, err := ParseProfile([]byte(testProfile("2026-05-01T00:00:00Z", false)), now)
+	if err != nil {
+		t.Fatalf("ParseProfile() error: %v", err)
+	}
+	if !expired.Expired {
+		t.Fatalf("expected expired profile, got %+v", expired)
+	}
+
+	adHoc, err := ParseProfile([]byte(testProfile("2027-01-01T00:00:00Z", true)), now)
+	if err != nil {
+		t.Fatalf("ParseProfile() error: %v", err)
+	}
+	if adHoc.Expired || adHoc.Type != ProfileTypeAdHoc || len(adHoc.Devices) != 1 {
+		t.Fatalf("unexpected ad-hoc profile: %+v", adHoc)
+	}
+
+	if _, err := ParseProfile([]byte("  "), now); err == nil {
+		t.Fatal("expected error for empty profile")
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := map[int64]string{
+		512:           "512 B",
+		1500:          "1.5 KB",
+		25_300_000:    "25.3 MB",
+		4_200_000_000: "4.2 GB",
+	}
+	for size, want := range tests {
+		if got := FormatSize(size); got != want {
+			t.Fatalf("FormatSize(%d) = %q, want %q", size, got, want)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package ipa
+
+import (
+	"bytes"
+	"encoding/binary"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/validation"
+)
+
+// privacyManifestName is the privacy manifest file in a bundle root.
+const privacyManifestName = "PrivacyInfo.xcprivacy"
+
+// maxIconSize bounds icon reads; app icons are a few hundred kilobytes.
+const maxIconSize = 16 << 20
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// LintInput gathers the facts validation.ValidateIPA checks from an
+// inspected archive. expectedBundleID is the bundle ID of the target app,
+// or empty to skip that check.
+func LintInput(archive *Archive, report *Report, expectedBundleID string) (validation.IPAInput, error) {
+	app := report.App
+	_, encryptionDeclared := app.Info["ITSAppUsesNonExemptEncryption"]
+	input := validation.IPAInput{
+		Path:               report.Path,
+		BundleID:           app.BundleID,
+		Version:            app.Version,
+		Build:              app.Build,
+		ExpectedBundleID:   expectedBundleID,
+		EncryptionDeclared: encryptionDeclared,
+	}
+
+	icons, err := appIcons(archive, app.Info)
+	if err != nil {
+		return validation.IPAInput{}, err
+	}
+	input.Icons = icons
+	input.AssetCatalogIcon = assetCatalogIcon(archive, app.Info)
+
+	if profile := app.Profile; profile != nil {
+		input.Profile = &validation.IPAProfile{
+			Name:           profile.Name,
+			UUID:           profile.UUID,
+			BundleID:       profile.BundleID(),
+			ExpirationDate: profile.ExpirationDate,
+			Expired:        profile.Expired,
+		}
+	}
+
+	appDeclared, err := bundleDeclaredAPIs(archive, "Payload/"+app.Path)
+	if err != nil {
+		return validation.IPAInput{}, err
+	}
+	bundles := append([]Bundle{app}, report.Extensions...)
+	bundles = append(bundles, report.Frameworks...)
+	for _, bundle := range bundles {
+		executable := bundleExecutable(bundle)
+		if executable == "" {
+			continue
+		}
+		data, err := archive.ReadFile(executable, 0)
+		if err != nil {
+			continue
+		}
+		used := RequiredReasonAPIs(data)
+		if len(used) == 0 {
+			continue
+		}
+		// Nested bundles may rely on their own manifest or the app's.
+		declared := slices.Clone(appDeclared)
+		if bundle.Path != app.Path {
+			own, err := bundleDeclaredAPIs(archive, "Payload/"+bundle.Path)
+			if err != nil {
+				return validation.IPAInput{}, err
+			}
+			declared = append(declared, own...)
+		}
+		input.Bundles = append(input.Bundles, validation.IPABundle{
+			Path:               bundle.Path,
+			RequiredReasonAPIs: used,
+			DeclaredAPIs:       declared,
+		})
+	}
+	return input, nil
+}
+
+// bundleExecutable returns the archive path of a bundle's main binary.
+// Standalone dylibs are their own executable.
+func bundleExecutable(bundle Bundle) string {
+	if path.Ext(bundle.Path) == ".dylib" {
+		return "Payload/" + bundle.Path
+	}
+	if bundle.Executable == "" {
+		return ""
+	}
+	return "Payload/" + bundle.Path + "/" + bundle.Executable
+}
+
+func bundleDeclaredAPIs(archive *Archive, dir string) ([]string, error) {
+	name := dir + "/" + privacyManifestName
+	if _, ok := archive.Lookup(name); !ok {
+		return nil, nil
+	}
+	manifest, err := archive.ReadPlist(name)
+	if err != nil {
+		return nil, err
+	}
+	return DeclaredAPIs(manifest), nil
+}
+
+// appIcons returns the PNG icons named by CFBundleIcons (and the legacy
+// CFBundleIconFiles) in the app bundle root. Icons compiled only into
+// Assets.car, such as the App Store icon, are not covered; see
+// assetCatalogIcon.
+func appIcons(archive *Archive, info map[string]any) ([]validation.IPAIcon, error) {
+	names := iconFileNames(info)
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	var icons []validation.IPAIcon
+	for _, file := range archive.FilesUnder(archive.AppDir) {
+		if path.Dir(file.Name) != archive.AppDir || !strings.EqualFold(path.Ext(file.Name), ".png") {
+			continue
+		}
+		base := path.Base(file.Name)
+		if !slices.ContainsFunc(names, func(name string) bool { return strings.HasPrefix(base, name) }) {
+			continue
+		}
+		data, err := archive.ReadFile(file.Name, maxIconSize)
+		if err != nil {
+			return nil, err
+		}
+		icons = append(icons, validation.IPAIcon{
+			Path:     strings.TrimPrefix(file.Name, "Payload/"),
+			HasAlpha: PNGHasAlpha(data),
+		})
+	}
+	return icons, nil
+}
+
+// assetCatalogIcon returns the path of Assets.car when Info.plist names an
+// icon set compiled into it (CFBundleIconName), or empty otherwise.
+func assetCatalogIcon(archive *Archive, info map[string]any) string {
+	named := false
+	for _, key := range []string{"CFBundleIcons", "CFBundleIcons~ipad"} {
+		icons, _ := info[key].(map[string]any)
+		primary, _ := icons["CFBundlePrimaryIcon"].(map[string]any)
+		if stringValue(primary["CFBundleIconName"]) != "" {
+			named = true
+		}
+	}
+	name := archive.AppDir + "/Assets.car"
+	if _, ok := archive.Lookup(name); !named || !ok {
+		return ""
+	}
+	return strings.TrimPrefix(name, "Payload/")
+}
+
+func iconFileNames(info map[string]any) []string {
+	var names []string
+	add := func(value any) {
+		list, _ := value.([]any)
+		for _, item := range list {
+			if name := strings.TrimSuffix(stringValue(item), ".png"); name != "" && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	for _, key := range []string{"CFBundleIcons", "CFBundleIcons~ipad"} {
+		icons, _ := info[key].(map[string]any)
+		primary, _ := icons["CFBundlePrimaryIcon"].(map[string]any)
+		add(primary["CFBundleIconFiles"])
+	}
+	add(info["CFBundleIconFiles"])
+	return names
+}
+
+// PNGHasAlpha reports whether a PNG declares an alpha channel or a
+// transparency chunk. Xcode's optimized (CgBI) PNGs are always stored as
+// RGBA regardless of the source image, so they are not reported.
+func PNGHasAlpha(data []byte) bool {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return false
+	}
+	offset := len(pngSignature)
+	for offset+8 <= len(data) {
+		length := int(binary.BigEndian.Uint32(data[offset:]))
+		chunk := string(data[offset+4 : offset+8])
+		body := offset + 8
+		if body+length > len(data) {
+			return false
+		}
+		switch chunk {
+		case "CgBI":
+			return false
+		case "IHDR":
+			if length < 13 {
+				return false
+			}
+			// Color types 4 and 6 are grayscale+alpha and RGBA.
+			if colorType := data[body+9]; colorType == 4 || colorType == 6 {
+				return true
+			}
+		case "tRNS":
+			return true
+		case "IDAT", "IEND":
+			return false
+		}
+		offset = body + length + 4
+	}
+	return false
+}
+
+// Lint inspects the archive at archivePath and runs the IPA validation
+// rules. expectedBundleID is the bundle ID of the target app, or empty to
+// skip that check.
+func Lint(archivePath, expectedBundleID string, strict bool) (*validation.IPAReport, error) {
+	archive, err := Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	report, err := Inspect(archive, Options{})
+	if err != nil {
+		return nil, err
+	}
+	input, err := LintInput(archive, report, expectedBundleID)
+	if err != nil {
+		return nil, err
+	}
+	result := validation.ValidateIPA(input, strict)
+	return &result, nil
+}
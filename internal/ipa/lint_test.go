@@ -0,0 +1,177 @@
+package ipa
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"slices"
+	"testing"
+	"time"
+)
+
+const testIconInfoPlist = `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0"><dict>
+<key>CFBundleIdentifier</key><string>com.example.app</string>
+<key>CFBundleShortVersionString</key><string>1.0</string>
+<key>CFBundleVersion</key><string>1</string>
+<key>CFBundleExecutable</key><string>Example</string>
+<key>ITSAppUsesNonExemptEncryption</key><false/>
+<key>CFBundleIcons</key><dict>
+<key>CFBundlePrimaryIcon</key><dict>
+<key>CFBundleIconFiles</key><array><string>AppIcon60x60</string></array>
+</dict>
+</dict>
+</dict></plist>`
+
+const testPrivacyManifest = `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0"><dict>
+<key>NSPrivacyAccessedAPITypes</key><array>
+<dict>
+<key>NSPrivacyAccessedAPIType</key><string>NSPrivacyAccessedAPICategorySystemBootTime</string>
+<key>NSPrivacyAccessedAPITypeReasons</key><array><string>35F9.1</string></array>
+</dict>
+<dict>
+<key>NSPrivacyAccessedAPIType</key><string>NSPrivacyAccessedAPICategoryDiskSpace</string>
+<key>NSPrivacyAccessedAPITypeReasons</key><array/>
+</dict>
+</array>
+</dict></plist>`
+
+func encodeTestPNG(t *testing.T, alpha uint8) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	for x := range 2 {
+		for y := range 2 {
+			img.Set(x, y, color.NRGBA{R: 200, G: 100, B: 50, A: alpha})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestPNGHasAlpha(t *testing.T) {
+	if PNGHasAlpha(encodeTestPNG(t, 255)) {
+		t.Fatal("expected opaque PNG to have no alpha channel")
+	}
+	if !PNGHasAlpha(encodeTestPNG(t, 128)) {
+		t.Fatal("expected translucent PNG to have an alpha channel")
+	}
+	crushed := append([]byte{}, pngSignature...)
+	crushed = append(crushed, 0, 0, 0, 4, 'C', 'g', 'B', 'I', 0, 0, 0, 0, 0, 0, 0, 0)
+	if PNGHasAlpha(crushed) {
+		t.Fatal("expected CgBI PNG to be skipped")
+	}
+	if PNGHasAlpha([]byte("not a png")) {
+		t.Fatal("expected non-PNG data to be skipped")
+	}
+}
+
+func TestRequiredReasonAPIsFromSelectors(t *testing.T) {
+	binary := append(buildMachO(t, 16<<16, ""), []byte("\x00systemUptime\x00activeInputModesX\x00")...)
+	used := RequiredReasonAPIs(binary)
+	if !slices.Equal(used, []string{APICategorySystemBootTime}) {
+		t.Fatalf("expected only system boot time, got %v", used)
+	}
+}
+
+func TestLintInput(t *testing.T) {
+	files := map[string][]byte{
+		"Payload/Example.app/Info.plist":                      []byte(testIconInfoPlist),
+		"Payload/Example.app/Example":                         append(buildMachO(t, 16<<16, ""), []byte("\x00systemUptime\x00")...),
+		"Payload/Example.app/AppIcon60x60@2x.png":             encodeTestPNG(t, 255),
+		"Payload/Example.app/AppIcon60x60@3x.png":             encodeTestPNG(t, 0),
+		"Payload/Example.app/Other.png":                       encodeTestPNG(t, 0),
+		"Payload/Example.app/embedded.mobileprovision":        []byte(testProfile("2020-01-01T00:00:00Z", false)),
+		"Payload/Example.app/PlugIns/Widget.appex/Info.plist": []byte(testWidgetInfoPlist),
+		"Payload/Example.app/PlugIns/Widget.appex/Widget":     append(buildMachO(t, 16<<16, ""), []byte("\x00fileModificationDate\x00")...),
+	}
+	archive, err := Open(writeTestIPA(t, files))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer archive.Close()
+	report, err := Inspect(archive, Options{Now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("Inspect() error: %v", err)
+	}
+
+	input, err := LintInput(archive, report, "com.example.app")
+	if err != nil {
+		t.Fatalf("LintInput() error: %v", err)
+	}
+	if !input.EncryptionDeclared || input.ExpectedBundleID != "com.example.app" || input.BundleID != "com.example.app" {
+		t.Fatalf("unexpected input: %+v", input)
+	}
+	if len(input.Icons) != 2 || input.Icons[0].HasAlpha || !input.Icons[1].HasAlpha {
+		t.Fatalf("unexpected icons: %+v", input.Icons)
+	}
+	if input.Profile == nil || !input.Profile.Expired || input.Profile.BundleID != "com.example.app" {
+		t.Fatalf("unexpected profile: %+v", input.Profile)
+	}
+	if len(input.Bundles) != 2 {
+		t.Fatalf("expected app and widget API usage, got %+v", input.Bundles)
+	}
+	if app := input.Bundles[0]; app.Path != "Example.app" || len(app.DeclaredAPIs) != 0 {
+		t.Fatalf("unexpected app usage: %+v", app)
+	}
+	if widget := input.Bundles[1]; !slices.Equal(widget.RequiredReasonAPIs, []string{APICategoryFileTimestamp}) {
+		t.Fatalf("unexpected widget usage: %+v", widget)
+	}
+
+	files["Payload/Example.app/PrivacyInfo.xcprivacy"] = []byte(testPrivacyManifest)
+	archive2, err := Open(writeTestIPA(t, files))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer archive2.Close()
+	report2, err := Inspect(archive2, Options{})
+	if err != nil {
+		t.Fatalf("Inspect() error: %v", err)
+	}
+	input2, err := LintInput(archive2, report2, "")
+	if err != nil {
+		t.Fatalf("LintInput() error: %v", err)
+	}
+	if declared := input2.Bundles[1].DeclaredAPIs; !slices.Equal(declared, []string{APICategorySystemBootTime}) {
+		t.Fatalf("expected widget to inherit the app manifest (reason-less entries ignored), got %v", declared)
+	}
+}
+
+func TestLintInputReportsAssetCatalogIcon(t *testing.T) {
+	const infoPlist = `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0"><dict>
+<key>CFBundleIdentifier</key><string>com.example.app</string>
+<key>CFBundleExecutable</key><string>Example</string>
+<key>CFBundleIcons</key><dict>
+<key>CFBundlePrimaryIcon</key><dict>
+<key>CFBundleIconName</key><string>AppIcon</string>
+</dict>
+</dict>
+</dict></plist>`
+	files := map[string][]byte{
+		"Payload/Example.app/Info.plist": []byte(infoPlist),
+		"Payload/Example.app/Example":    buildMachO(t, 16<<16, ""),
+		"Payload/Example.app/Assets.car": []byte("BOMStore"),
+	}
+	archive, err := Open(writeTestIPA(t, files))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer archive.Close()
+	report, err := Inspect(archive, Options{})
+	if err != nil {
+		t.Fatalf("Inspect() error: %v", err)
+	}
+
+	input, err := LintInput(archive, report, "")
+	if err != nil {
+		t.Fatalf("LintInput() error: %v", err)
+	}
+	if len(input.Icons) != 0 || input.AssetCatalogIcon != "Example.app/Assets.car" {
+		t.Fatalf("expected only the asset catalog icon, got icons %+v, catalog %q", input.Icons, input.AssetCatalogIcon)
+	}
+}
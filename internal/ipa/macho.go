@@ -0,0 +1,204 @@
+package ipa
+
+import (
+	"bytes"
+	"debug/macho"
+	"encoding/binary"
+	"fmt"
+
+	"howett.net/plist"
+)
+
+// Load commands not modelled by debug/macho.
+const (
+	loadCmdCodeSignature     = 0x1d
+	loadCmdVersionMinMacOS   = 0x24
+	loadCmdVersionMinIOS     = 0x25
+	loadCmdVersionMinTVOS    = 0x2f
+	loadCmdVersionMinWatchOS = 0x30
+	loadCmdBuildVersion      = 0x32
+)
+
+// Code signature blob magics and slots (big-endian).
+const (
+	csMagicEmbeddedSignature = 0xfade0cc0
+	csMagicEntitlements      = 0xfade7171
+	csSlotEntitlements       = 5
+)
+
+// cpuSubtypeARM64E is the arm64e CPU subtype (ignoring capability bits).
+const cpuSubtypeARM64E = 2
+
+var buildPlatforms = map[uint32]string{
+	1:  "macOS",
+	2:  "iOS",
+	3:  "tvOS",
+	4:  "watchOS",
+	5:  "bridgeOS",
+	6:  "macCatalyst",
+	7:  "iOSSimulator",
+	8:  "tvOSSimulator",
+	9:  "watchOSSimulator",
+	10: "driverKit",
+	11: "visionOS",
+	12: "visionOSSimulator",
+}
+
+var versionMinPlatforms = map[uint32]string{
+	loadCmdVersionMinMacOS:   "macOS",
+	loadCmdVersionMinIOS:     "iOS",
+	loadCmdVersionMinTVOS:    "tvOS",
+	loadCmdVersionMinWatchOS: "watchOS",
+}
+
+// Architecture describes one slice of a Mach-O binary.
+type Architecture struct {
+	Name     string `json:"name"`
+	Platform string `json:"platform,omitempty"`
+	MinOS    string `json:"minOS,omitempty"`
+	SDK      string `json:"sdk,omitempty"`
+}
+
+// Binary is a parsed Mach-O executable.
+type Binary struct {
+	Architectures []Architecture
+	// Entitlements are the code-signing entitlements of the first signed
+	// slice.
+	Entitlements map[string]any
+}
+
+// ParseBinary reads the architectures, deployment targets and signed
+// entitlements of a thin or universal Mach-O binary.
+func ParseBinary(data []byte) (*Binary, error) {
+	reader := bytes.NewReader(data)
+	type slice struct {
+		file   *macho.File
+		offset int64
+	}
+	var slices []slice
+	if fat, err := macho.NewFatFile(reader); err == nil {
+		defer fat.Close()
+		for _, arch := range fat.Arches {
+			slices = append(slices, slice{file: arch.File, offset: int64(arch.Offset)})
+		}
+	} else {
+		file, err := macho.NewFile(reader)
+		if err != nil {
+			return nil, fmt.Errorf("not a Mach-O binary: %w", err)
+		}
+		defer file.Close()
+		slices = append(slices, slice{file: file})
+	}
+
+	result := &Binary{}
+	for _, s := range slices {
+		arch, sigOffset, sigSize := parseLoadCommands(s.file)
+		result.Architectures = append(result.Architectures, arch)
+		if result.Entitlements != nil || sigSize == 0 {
+			continue
+		}
+		start := s.offset + int64(sigOffset)
+		end := start + int64(sigSize)
+		if start < 0 || end > int64(len(data)) {
+			return nil, fmt.Errorf("%s: code signature is outside the binary", arch.Name)
+		}
+		entitlements, err := parseEntitlements(data[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", arch.Name, err)
+		}
+		result.Entitlements = entitlements
+	}
+	return result, nil
+}
+
+func parseLoadCommands(file *macho.File) (Architecture, uint32, uint32) {
+	arch := Architecture{Name: cpuName(file.Cpu, file.SubCpu)}
+	var sigOffset, sigSize uint32
+	order := file.ByteOrder
+	for _, load := range file.Loads {
+		raw := load.Raw()
+		if len(raw) < 8 {
+			continue
+		}
+		switch cmd := order.Uint32(raw[0:4]); cmd {
+		case loadCmdBuildVersion:
+			if len(raw) >= 20 {
+				arch.Platform = buildPlatforms[order.Uint32(raw[8:12])]
+				arch.MinOS = formatVersion(order.Uint32(raw[12:16]))
+				arch.SDK = formatVersion(order.Uint32(raw[16:20]))
+			}
+		case loadCmdVersionMinMacOS, loadCmdVersionMinIOS, loadCmdVersionMinTVOS, loadCmdVersionMinWatchOS:
+			if len(raw) >= 16 && arch.Platform == "" {
+				arch.Platform = versionMinPlatforms[cmd]
+				arch.MinOS = formatVersion(order.Uint32(raw[8:12]))
+				arch.SDK = formatVersion(order.Uint32(raw[12:16]))
+			}
+		case loadCmdCodeSignature:
+			if len(raw) >= 16 {
+				sigOffset = order.Uint32(raw[8:12])
+				sigSize = order.Uint32(raw[12:16])
+			}
+		}
+	}
+	return arch, sigOffset, sigSize
+}
+
+// parseEntitlements extracts the XML entitlements blob from an embedded
+// code signature superblob. Unsigned or ad-hoc signed binaries without
+// entitlements return nil.
+func parseEntitlements(signature []byte) (map[string]any, error) {
+	if len(signature) < 12 || binary.BigEndian.Uint32(signature[0:4]) != csMagicEmbeddedSignature {
+		return nil, fmt.Errorf("invalid code signature")
+	}
+	count := binary.BigEndian.Uint32(signature[8:12])
+	for i := range count {
+		index := 12 + int(i)*8
+		if index+8 > len(signature) {
+			return nil, fmt.Errorf("truncated code signature")
+		}
+		if binary.BigEndian.Uint32(signature[index:]) != csSlotEntitlements {
+			continue
+		}
+		offset := int(binary.BigEndian.Uint32(signature[index+4:]))
+		if offset+8 > len(signature) || binary.BigEndian.Uint32(signature[offset:]) != csMagicEntitlements {
+			return nil, fmt.Errorf("invalid entitlements blob")
+		}
+		length := int(binary.BigEndian.Uint32(signature[offset+4:]))
+		if length < 8 || offset+length > len(signature) {
+			return nil, fmt.Errorf("truncated entitlements blob")
+		}
+		var entitlements map[string]any
+		if err := plist.NewDecoder(bytes.NewReader(signature[offset+8 : offset+length])).Decode(&entitlements); err != nil {
+			return nil, fmt.Errorf("decode entitlements: %w", err)
+		}
+		return entitlements, nil
+	}
+	return nil, nil
+}
+
+func cpuName(cpu macho.Cpu, subCpu uint32) string {
+	switch cpu {
+	case macho.CpuArm64:
+		if subCpu&0xff == cpuSubtypeARM64E {
+			return "arm64e"
+		}
+		return "arm64"
+	case macho.CpuArm:
+		return "armv7"
+	case macho.CpuAmd64:
+		return "x86_64"
+	case macho.Cpu386:
+		return "i386"
+	default:
+		return fmt.Sprintf("cpu(%d)", uint32(cpu))
+	}
+}
+
+// formatVersion renders an xxxx.yy.zz packed version, dropping a zero patch.
+func formatVersion(packed uint32) string {
+	major, minor, patch := packed>>16, (packed>>8)&0xff, packed&0xff
+	if patch == 0 {
+		return fmt.Sprintf("%d.%d", major, minor)
+	}
+	return fmt.Sprintf("%d.%d.%d", major, minor, patch)
+}
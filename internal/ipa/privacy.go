@@ -0,0 +1,129 @@
+package ipa
+
+import (
+	"bytes"
+	"debug/macho"
+	"slices"
+)
+
+// Required reason API categories, as declared in NSPrivacyAccessedAPIType.
+const (
+	APICategoryFileTimestamp   = "NSPrivacyAccessedAPICategoryFileTimestamp"
+	APICategorySystemBootTime  = "NSPrivacyAccessedAPICategorySystemBootTime"
+	APICategoryDiskSpace       = "NSPrivacyAccessedAPICategoryDiskSpace"
+	APICategoryActiveKeyboards = "NSPrivacyAccessedAPICategoryActiveKeyboards"
+	APICategoryUserDefaults    = "NSPrivacyAccessedAPICategoryUserDefaults"
+)
+
+// requiredReasonAPI maps a category to the imported symbols and Objective-C
+// selectors that Apple treats as using it.
+type requiredReasonAPI struct {
+	category  string
+	symbols   []string
+	selectors []string
+}
+
+var requiredReasonAPIs = []requiredReasonAPI{
+	{
+		category: APICategoryFileTimestamp,
+		symbols: []string{
+			"_stat", "_fstat", "_lstat", "_fstatat",
+			"_getattrlist", "_fgetattrlist", "_getattrlistat", "_getattrlistbulk",
+			"_NSFileCreationDate", "_NSFileModificationDate",
+			"_NSURLContentModificationDateKey", "_NSURLCreationDateKey",
+		},
+		selectors: []string{"fileCreationDate", "fileModificationDate"},
+	},
+	{
+		category:  APICategorySystemBootTime,
+		symbols:   []string{"_mach_absolute_time"},
+		selectors: []string{"systemUptime"},
+	},
+	{
+		category: APICategoryDiskSpace,
+		symbols: []string{
+			"_statfs", "_fstatfs", "_statvfs", "_fstatvfs",
+			"_NSFileSystemFreeSize", "_NSFileSystemSize",
+			"_NSURLVolumeAvailableCapacityKey",
+			"_NSURLVolumeAvailableCapacityForImportantUsageKey",
+			"_NSURLVolumeAvailableCapacityForOpportunisticUsageKey",
+			"_NSURLVolumeTotalCapacityKey",
+		},
+	},
+	{
+		category:  APICategoryActiveKeyboards,
+		selectors: []string{"activeInputModes"},
+	},
+	{
+		category: APICategoryUserDefaults,
+		symbols:  []string{"_OBJC_CLASS_$_NSUserDefaults"},
+	},
+}
+
+// RequiredReasonAPIs returns the required reason API categories a Mach-O
+// binary references, judged by its imported symbols and selector names.
+func RequiredReasonAPIs(data []byte) []string {
+	imported := map[string]bool{}
+	for _, symbol := range importedSymbols(data) {
+		imported[symbol] = true
+	}
+
+	var categories []string
+	for _, api := range requiredReasonAPIs {
+		if slices.ContainsFunc(api.symbols, func(symbol string) bool { return imported[symbol] }) ||
+			slices.ContainsFunc(api.selectors, func(selector string) bool { return containsCString(data, selector) }) {
+			categories = append(categories, api.category)
+		}
+	}
+	return categories
+}
+
+func importedSymbols(data []byte) []string {
+	reader := bytes.NewReader(data)
+	if fat, err := macho.NewFatFile(reader); err == nil {
+		defer fat.Close()
+		var symbols []string
+		for _, arch := range fat.Arches {
+			if imported, err := arch.ImportedSymbols(); err == nil {
+				symbols = append(symbols, imported...)
+			}
+		}
+		return symbols
+	}
+	file, err := macho.NewFile(reader)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+	symbols, _ := file.ImportedSymbols()
+	return symbols
+}
+
+// containsCString looks for a NUL-terminated string, as selector names are
+// stored in __objc_methname.
+func containsCString(data []byte, value string) bool {
+	needle := make([]byte, 0, len(value)+2)
+	needle = append(needle, 0)
+	needle = append(needle, value...)
+	needle = append(needle, 0)
+	return bytes.Contains(data, needle)
+}
+
+// DeclaredAPIs returns the API categories a privacy manifest declares with
+// at least one reason.
+func DeclaredAPIs(manifest map[string]any) []string {
+	entries, _ := manifest["NSPrivacyAccessedAPITypes"].([]any)
+	var declared []string
+	for _, entry := range entries {
+		values, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+		category := stringValue(values["NSPrivacyAccessedAPIType"])
+		reasons, _ := values["NSPrivacyAccessedAPITypeReasons"].([]any)
+		if category != "" && len(reasons) > 0 && !slices.Contains(declared, category) {
+			declared = append(declared, category)
+		}
+	}
+	return declared
+}
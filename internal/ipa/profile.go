@@ -0,0 +1,129 @@
+package ipa
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mozilla.org/pkcs7"
+	"howett.net/plist"
+)
+
+// Provisioning profile distribution types.
+const (
+	ProfileTypeDevelopment = "development"
+	ProfileTypeAdHoc       = "ad-hoc"
+	ProfileTypeAppStore    = "app-store"
+	ProfileTypeEnterprise  = "enterprise"
+)
+
+// Profile is an embedded.mobileprovision.
+type Profile struct {
+	Name                 string         `json:"name"`
+	UUID                 string         `json:"uuid"`
+	Type                 string         `json:"type"`
+	TeamID               string         `json:"teamId,omitempty"`
+	TeamName             string         `json:"teamName,omitempty"`
+	AppIDName            string         `json:"appIdName,omitempty"`
+	ApplicationID        string         `json:"applicationIdentifier,omitempty"`
+	Platforms            []string       `json:"platforms,omitempty"`
+	CreationDate         time.Time      `json:"creationDate"`
+	ExpirationDate       time.Time      `json:"expirationDate"`
+	Expired              bool           `json:"expired"`
+	ProvisionsAllDevices bool           `json:"provisionsAllDevices,omitempty"`
+	Devices              []string       `json:"devices,omitempty"`
+	Entitlements         map[string]any `json:"entitlements,omitempty"`
+}
+
+type rawProfile struct {
+	UUID                 string         `plist:"UUID"`
+	Name                 string         `plist:"Name"`
+	AppIDName            string         `plist:"AppIDName"`
+	TeamName             string         `plist:"TeamName"`
+	TeamIdentifier       []string       `plist:"TeamIdentifier"`
+	Platform             []string       `plist:"Platform"`
+	CreationDate         time.Time      `plist:"CreationDate"`
+	ExpirationDate       time.Time      `plist:"ExpirationDate"`
+	ProvisionedDevices   []string       `plist:"ProvisionedDevices"`
+	ProvisionsAllDevices bool           `plist:"ProvisionsAllDevices"`
+	Entitlements         map[string]any `plist:"Entitlements"`
+}
+
+// ParseProfile decodes a CMS-signed (or bare plist) provisioning profile.
+// now determines whether the profile has expired.
+func ParseProfile(data []byte, now time.Time) (*Profile, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, fmt.Errorf("profile is empty")
+	}
+	plistBytes := data
+	if p7, err := pkcs7.Parse(data); err == nil && len(p7.Content) > 0 {
+		plistBytes = p7.Content
+	}
+
+	var raw rawProfile
+	if err := plist.NewDecoder(bytes.NewReader(plistBytes)).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decode profile: %w", err)
+	}
+
+	profile := &Profile{
+		Name:                 raw.Name,
+		UUID:                 raw.UUID,
+		TeamName:             raw.TeamName,
+		AppIDName:            raw.AppIDName,
+		ApplicationID:        stringValue(raw.Entitlements["application-identifier"]),
+		Platforms:            raw.Platform,
+		CreationDate:         raw.CreationDate.UTC(),
+		ExpirationDate:       raw.ExpirationDate.UTC(),
+		Expired:              !raw.ExpirationDate.IsZero() && !now.Before(raw.ExpirationDate),
+		ProvisionsAllDevices: raw.ProvisionsAllDevices,
+		Devices:              raw.ProvisionedDevices,
+		Entitlements:         raw.Entitlements,
+	}
+	if len(raw.TeamIdentifier) > 0 {
+		profile.TeamID = strings.TrimSpace(raw.TeamIdentifier[0])
+	}
+	switch {
+	case raw.ProvisionsAllDevices:
+		profile.Type = ProfileTypeEnterprise
+	case len(raw.ProvisionedDevices) == 0:
+		profile.Type = ProfileTypeAppStore
+	case raw.Entitlements["get-task-allow"] == true:
+		profile.Type = ProfileTypeDevelopment
+	default:
+		profile.Type = ProfileTypeAdHoc
+	}
+	return profile, nil
+}
+
+// BundleID returns the bundle ID part of the profile's application
+// identifier ("TEAMID.com.example.app"), which may be a wildcard.
+func (p *Profile) BundleID() string {
+	if p == nil || p.ApplicationID == "" {
+		return ""
+	}
+	if p.TeamID != "" {
+		if bundleID, ok := strings.CutPrefix(p.ApplicationID, p.TeamID+"."); ok {
+			return bundleID
+		}
+	}
+	if _, bundleID, ok := strings.Cut(p.ApplicationID, "."); ok {
+		return bundleID
+	}
+	return ""
+}
+
+func stringValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case []byte:
+		return strings.TrimSpace(string(v))
+	case fmt.Stringer:
+		return strings.TrimSpace(v.String())
+	case nil:
+		return ""
+	default:
+		return strings.TrimSpace(fmt.Sprint(v))
+	}
+}
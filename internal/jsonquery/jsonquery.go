@@ -0,0 +1,529 @@
+// Package jsonquery implements a small, dependency-free subset of jq for
+// filtering JSON output.
+//
+// Supported syntax:
+//
+//	.                      identity
+//	.foo  ."foo bar"  .[0]  .[-1]  .[]  .["foo"]
+//	a | b                  pipe
+//	a, b                   multiple outputs
+//	[ ... ]                collect outputs into an array
+//	{id, name: .attributes.name}
+//	==  !=  <  <=  >  >=  and  or
+//	select(f)  map(f)  length  keys  not  has("key")  contains("text")
+//	"string"  123  true  false  null
+package jsonquery
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Query is a compiled expression.
+type Query struct {
+	expr string
+	root node
+}
+
+// Parse compiles expr.
+func Parse(expr string) (*Query, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q at offset %d", p.peek().text, p.peek().pos)
+	}
+	return &Query{expr: expr, root: root}, nil
+}
+
+// String returns the source expression.
+func (q *Query) String() string {
+	return q.expr
+}
+
+// Run evaluates the query against input, which must be a value produced by
+// encoding/json (maps, slices, strings, float64, bool, nil).
+func (q *Query) Run(input any) ([]any, error) {
+	return q.root.eval(input)
+}
+
+// RunJSON decodes data and evaluates the query against it.
+func (q *Query) RunJSON(data []byte) ([]any, error) {
+	var input any
+	if err := json.Unmarshal(data, &input); err != nil {
+		return nil, fmt.Errorf("invalid JSON input: %w", err)
+	}
+	return q.Run(input)
+}
+
+// WriteResults writes each result on its own line. Strings are written
+// without quotes so results can be used directly in shell scripts; other
+// values are written as JSON.
+func WriteResults(w io.Writer, results []any, pretty bool) error {
+	for _, result := range results {
+		if text, ok := result.(string); ok {
+			if _, err := fmt.Fprintln(w, text); err != nil {
+				return err
+			}
+			continue
+		}
+		var (
+			data []byte
+			err  error
+		)
+		if pretty {
+			data, err = json.MarshalIndent(result, "", "  ")
+		} else {
+			data, err = json.Marshal(result)
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(w, string(data)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type node interface {
+	eval(input any) ([]any, error)
+}
+
+type identityNode struct{}
+
+func (identityNode) eval(input any) ([]any, error) {
+	return []any{input}, nil
+}
+
+type literalNode struct {
+	value any
+}
+
+func (n literalNode) eval(any) ([]any, error) {
+	return []any{n.value}, nil
+}
+
+type pipeNode struct {
+	left, right node
+}
+
+func (n pipeNode) eval(input any) ([]any, error) {
+	lefts, err := n.left.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	var out []any
+	for _, value := range lefts {
+		rights, err := n.right.eval(value)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, rights...)
+	}
+	return out, nil
+}
+
+type commaNode struct {
+	left, right node
+}
+
+func (n commaNode) eval(input any) ([]any, error) {
+	lefts, err := n.left.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	rights, err := n.right.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	return append(lefts, rights...), nil
+}
+
+// indexNode applies .key, .[n] or .[] to every output of target.
+type indexNode struct {
+	target  node
+	key     node // nil with iterate
+	iterate bool
+}
+
+func (n indexNode) eval(input any) ([]any, error) {
+	targets, err := n.target.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	var out []any
+	for _, target := range targets {
+		if n.iterate {
+			values, err := iterate(target)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, values...)
+			continue
+		}
+		keys, err := n.key.eval(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			value, err := index(target, key)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, value)
+		}
+	}
+	return out, nil
+}
+
+func index(target, key any) (any, error) {
+	switch k := key.(type) {
+	case string:
+		switch t := target.(type) {
+		case nil:
+			return nil, nil
+		case map[string]any:
+			return t[k], nil
+		default:
+			return nil, fmt.Errorf("cannot index %s with %q", typeName(target), k)
+		}
+	case float64:
+		switch t := target.(type) {
+		case nil:
+			return nil, nil
+		case []any:
+			i := int(k)
+			if i < 0 {
+				i += len(t)
+			}
+			if i < 0 || i >= len(t) {
+				return nil, nil
+			}
+			return t[i], nil
+		default:
+			return nil, fmt.Errorf("cannot index %s with number", typeName(target))
+		}
+	default:
+		return nil, fmt.Errorf("cannot index with %s", typeName(key))
+	}
+}
+
+func iterate(target any) ([]any, error) {
+	switch t := target.(type) {
+	case []any:
+		return t, nil
+	case map[string]any:
+		keys := sortedKeys(t)
+		values := make([]any, 0, len(keys))
+		for _, key := range keys {
+			values = append(values, t[key])
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("cannot iterate over %s", typeName(target))
+	}
+}
+
+type arrayNode struct {
+	body node // nil for []
+}
+
+func (n arrayNode) eval(input any) ([]any, error) {
+	if n.body == nil {
+		return []any{[]any{}}, nil
+	}
+	values, err := n.body.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	if values == nil {
+		values = []any{}
+	}
+	return []any{values}, nil
+}
+
+type objectNode struct {
+	keys   []string
+	values []node
+}
+
+// eval builds one object per combination of value outputs, like jq.
+func (n objectNode) eval(input any) ([]any, error) {
+	results := []map[string]any{{}}
+	for i, key := range n.keys {
+		values, err := n.values[i].eval(input)
+		if err != nil {
+			return nil, err
+		}
+		next := make([]map[string]any, 0, len(results)*len(values))
+		for _, partial := range results {
+			for _, value := range values {
+				object := make(map[string]any, len(partial)+1)
+				for k, v := range partial {
+					object[k] = v
+				}
+				object[key] = value
+				next = append(next, object)
+			}
+		}
+		results = next
+	}
+	out := make([]any, 0, len(results))
+	for _, object := range results {
+		out = append(out, object)
+	}
+	return out, nil
+}
+
+type compareNode struct {
+	op          string
+	left, right node
+}
+
+func (n compareNode) eval(input any) ([]any, error) {
+	lefts, err := n.left.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	rights, err := n.right.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	var out []any
+	for _, left := range lefts {
+		for _, right := range rights {
+			result, err := compare(n.op, left, right)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, result)
+		}
+	}
+	return out, nil
+}
+
+func compare(op string, left, right any) (bool, error) {
+	switch op {
+	case "==":
+		return reflect.DeepEqual(left, right), nil
+	case "!=":
+		return !reflect.DeepEqual(left, right), nil
+	}
+	var cmp int
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		if !ok {
+			return false, fmt.Errorf("cannot compare number with %s", typeName(right))
+		}
+		switch {
+		case l < r:
+			cmp = -1
+		case l > r:
+			cmp = 1
+		}
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			return false, fmt.Errorf("cannot compare string with %s", typeName(right))
+		}
+		cmp = strings.Compare(l, r)
+	default:
+		return false, fmt.Errorf("cannot order %s values", typeName(left))
+	}
+	switch op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	default:
+		return cmp >= 0, nil
+	}
+}
+
+type logicNode struct {
+	and         bool
+	left, right node
+}
+
+func (n logicNode) eval(input any) ([]any, error) {
+	lefts, err := n.left.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	var out []any
+	for _, left := range lefts {
+		if n.and && !truthy(left) {
+			out = append(out, false)
+			continue
+		}
+		if !n.and && truthy(left) {
+			out = append(out, true)
+			continue
+		}
+		rights, err := n.right.eval(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, right := range rights {
+			out = append(out, truthy(right))
+		}
+	}
+	return out, nil
+}
+
+type funcNode struct {
+	name string
+	arg  node
+}
+
+func (n funcNode) eval(input any) ([]any, error) {
+	switch n.name {
+	case "select":
+		conds, err := n.arg.eval(input)
+		if err != nil {
+			return nil, err
+		}
+		var out []any
+		for _, cond := range conds {
+			if truthy(cond) {
+				out = append(out, input)
+			}
+		}
+		return out, nil
+	case "map":
+		return arrayNode{body: pipeNode{left: indexNode{target: identityNode{}, iterate: true}, right: n.arg}}.eval(input)
+	case "has", "contains":
+		args, err := n.arg.eval(input)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]any, 0, len(args))
+		for _, arg := range args {
+			result, err := builtinWithArg(n.name, input, arg)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, result)
+		}
+		return out, nil
+	case "length":
+		switch t := input.(type) {
+		case nil:
+			return []any{float64(0)}, nil
+		case string:
+			return []any{float64(len([]rune(t)))}, nil
+		case []any:
+			return []any{float64(len(t))}, nil
+		case map[string]any:
+			return []any{float64(len(t))}, nil
+		case float64:
+			return []any{math.Abs(t)}, nil
+		default:
+			return nil, fmt.Errorf("%s has no length", typeName(input))
+		}
+	case "keys":
+		switch t := input.(type) {
+		case map[string]any:
+			keys := sortedKeys(t)
+			out := make([]any, 0, len(keys))
+			for _, key := range keys {
+				out = append(out, key)
+			}
+			return []any{out}, nil
+		case []any:
+			out := make([]any, 0, len(t))
+			for i := range t {
+				out = append(out, float64(i))
+			}
+			return []any{out}, nil
+		default:
+			return nil, fmt.Errorf("%s has no keys", typeName(input))
+		}
+	case "not":
+		return []any{!truthy(input)}, nil
+	default:
+		return nil, fmt.Errorf("unknown function %s", n.name)
+	}
+}
+
+func builtinWithArg(name string, input, arg any) (bool, error) {
+	if name == "has" {
+		switch t := input.(type) {
+		case map[string]any:
+			key, ok := arg.(string)
+			if !ok {
+				return false, fmt.Errorf("cannot check whether object has a key of type %s", typeName(arg))
+			}
+			_, exists := t[key]
+			return exists, nil
+		case []any:
+			i, ok := arg.(float64)
+			if !ok {
+				return false, fmt.Errorf("cannot check whether array has a key of type %s", typeName(arg))
+			}
+			return i >= 0 && int(i) < len(t), nil
+		default:
+			return false, fmt.Errorf("cannot check whether %s has a key", typeName(input))
+		}
+	}
+	text, ok := input.(string)
+	needle, needleOK := arg.(string)
+	if !ok || !needleOK {
+		return false, fmt.Errorf("contains expects string input and argument")
+	}
+	return strings.Contains(text, needle), nil
+}
+
+func truthy(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	default:
+		return true
+	}
+}
+
+func sortedKeys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func typeName(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -0,0 +1,88 @@
+package jsonquery
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+const appsDocument = `{
+  "data": [
+    {"id": "1", "type": "apps", "attributes": {"name": "Tally", "bundleId": "com.example.tally", "rank": 2}},
+    {"id": "2", "type": "apps", "attributes": {"name": "Tally Pro", "bundleId": "com.example.tallypro", "rank": 1}}
+  ],
+  "links": {"self": "https://api.appstoreconnect.apple.com/v1/apps"}
+}`
+
+func runQuery(t *testing.T, expr string) string {
+	t.Helper()
+	query, err := Parse(expr)
+	if err != nil {
+		t.Fatalf("Parse(%q) error: %v", expr, err)
+	}
+	results, err := query.RunJSON([]byte(appsDocument))
+	if err != nil {
+		t.Fatalf("Run(%q) error: %v", expr, err)
+	}
+	data, err := json.Marshal(results)
+	if err != nil {
+		t.Fatalf("marshal results: %v", err)
+	}
+	return string(data)
+}
+
+func TestQueries(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{".data[0].id", `["1"]`},
+		{".data[-1].attributes.name", `["Tally Pro"]`},
+		{".data[].id", `["1","2"]`},
+		{".data | length", `[2]`},
+		{`.links["self"]`, `["https://api.appstoreconnect.apple.com/v1/apps"]`},
+		{`.data[] | select(.attributes.rank < 2) | .id`, `["2"]`},
+		{`.data[] | select(.attributes.name == "Tally" or .id == "2") | .id`, `["1","2"]`},
+		{`.data[] | select(.attributes.bundleId | contains("pro")) | .attributes.name`, `["Tally Pro"]`},
+		{`[.data[] | {id, name: .attributes.name}]`, `[[{"id":"1","name":"Tally"},{"id":"2","name":"Tally Pro"}]]`},
+		{`.data | map(.id)`, `[["1","2"]]`},
+		{`.data[0] | keys`, `[["attributes","id","type"]]`},
+		{`.data[0] | has("links") | not`, `[true]`},
+		{`.missing.value`, `[null]`},
+		{`.data[0].id, .data[1].id`, `["1","2"]`},
+		{`[.data[] | select(.id == "3")]`, `[[]]`},
+	}
+	for _, test := range tests {
+		if got := runQuery(t, test.expr); got != test.want {
+			t.Errorf("%s = %s, want %s", test.expr, got, test.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{"", ".data[", `."unterminated`, ".data | bogus", ".a $ .b", "{1: .a}"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) expected error", expr)
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	query, err := Parse(".data.id")
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if _, err := query.RunJSON([]byte(appsDocument)); err == nil {
+		t.Fatal("expected error indexing an array with a string")
+	}
+}
+
+func TestWriteResultsPrintsStringsRaw(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteResults(&buf, []any{"abc", float64(2), map[string]any{"a": true}}, false); err != nil {
+		t.Fatalf("WriteResults() error: %v", err)
+	}
+	if got, want := buf.String(), "abc\n2\n{\"a\":true}\n"; got != want {
+		t.Fatalf("WriteResults() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,382 @@
+package jsonquery
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenPunct tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+var twoCharOperators = []string{"==", "!=", "<=", ">="}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"':
+			start := i
+			var b strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+					switch runes[i] {
+					case 'n':
+						b.WriteRune('\n')
+					case 't':
+						b.WriteRune('\t')
+					default:
+						b.WriteRune(runes[i])
+					}
+					continue
+				}
+				b.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at offset %d", start)
+			}
+			i++
+			tokens = append(tokens, token{kind: tokenString, text: b.String(), pos: start})
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: string(runes[start:i]), pos: start})
+		case r == '_' || unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (runes[i] == '_' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[start:i]), pos: start})
+		default:
+			if i+1 < len(runes) {
+				pair := string(runes[i : i+2])
+				matched := false
+				for _, op := range twoCharOperators {
+					if pair == op {
+						tokens = append(tokens, token{kind: tokenPunct, text: op, pos: i})
+						i += 2
+						matched = true
+						break
+					}
+				}
+				if matched {
+					continue
+				}
+			}
+			if !strings.ContainsRune(".[]{}()|,:<>", r) {
+				return nil, fmt.Errorf("unexpected character %q at offset %d", r, i)
+			}
+			tokens = append(tokens, token{kind: tokenPunct, text: string(r), pos: i})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	if p.done() {
+		return token{kind: tokenPunct, text: "end of query", pos: -1}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) isPunct(text string) bool {
+	return !p.done() && p.tokens[p.pos].kind == tokenPunct && p.tokens[p.pos].text == text
+}
+
+func (p *parser) isKeyword(text string) bool {
+	return !p.done() && p.tokens[p.pos].kind == tokenIdent && p.tokens[p.pos].text == text
+}
+
+func (p *parser) expect(text string) error {
+	if !p.isPunct(text) {
+		next := p.peek()
+		if next.pos < 0 {
+			return fmt.Errorf("expected %q before end of query", text)
+		}
+		return fmt.Errorf("expected %q at offset %d, got %q", text, next.pos, next.text)
+	}
+	p.pos++
+	return nil
+}
+
+// parsePipe parses the lowest-precedence level: a | b.
+func (p *parser) parsePipe() (node, error) {
+	left, err := p.parseComma()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("|") {
+		p.pos++
+		right, err := p.parseComma()
+		if err != nil {
+			return nil, err
+		}
+		left = pipeNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseComma() (node, error) {
+	left, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct(",") {
+		p.pos++
+		right, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		left = commaNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logicNode{and: false, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.pos++
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		left = logicNode{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parsePostfix()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.isPunct(op) {
+			p.pos++
+			right, err := p.parsePostfix()
+			if err != nil {
+				return nil, err
+			}
+			return compareNode{op: op, left: left, right: right}, nil
+		}
+	}
+	return left, nil
+}
+
+// parsePostfix parses a primary followed by any number of .key, [n] or []
+// suffixes.
+func (p *parser) parsePostfix() (node, error) {
+	current, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case p.isPunct(".") && p.pos+1 < len(p.tokens) && p.tokens[p.pos+1].kind != tokenPunct:
+			p.pos++
+			key, err := p.parseFieldName()
+			if err != nil {
+				return nil, err
+			}
+			current = indexNode{target: current, key: key}
+		case p.isPunct(".") && p.pos+1 < len(p.tokens) && p.tokens[p.pos+1].text == "[":
+			p.pos++
+		case p.isPunct("["):
+			current, err = p.parseBracket(current)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return current, nil
+		}
+	}
+}
+
+func (p *parser) parseFieldName() (node, error) {
+	next := p.peek()
+	if next.kind != tokenIdent && next.kind != tokenString {
+		return nil, fmt.Errorf("expected field name at offset %d", next.pos)
+	}
+	p.pos++
+	return literalNode{value: next.text}, nil
+}
+
+// parseBracket parses [], [n] or ["key"] applied to target.
+func (p *parser) parseBracket(target node) (node, error) {
+	if err := p.expect("["); err != nil {
+		return nil, err
+	}
+	if p.isPunct("]") {
+		p.pos++
+		return indexNode{target: target, iterate: true}, nil
+	}
+	key, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect("]"); err != nil {
+		return nil, err
+	}
+	return indexNode{target: target, key: key}, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	next := p.peek()
+	switch {
+	case p.isPunct("."):
+		p.pos++
+		if !p.done() && (p.peek().kind == tokenIdent || p.peek().kind == tokenString) {
+			key, err := p.parseFieldName()
+			if err != nil {
+				return nil, err
+			}
+			return indexNode{target: identityNode{}, key: key}, nil
+		}
+		return identityNode{}, nil
+	case p.isPunct("("):
+		p.pos++
+		inner, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		return inner, p.expect(")")
+	case p.isPunct("["):
+		p.pos++
+		if p.isPunct("]") {
+			p.pos++
+			return arrayNode{}, nil
+		}
+		body, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		return arrayNode{body: body}, p.expect("]")
+	case p.isPunct("{"):
+		return p.parseObject()
+	case next.kind == tokenString:
+		p.pos++
+		return literalNode{value: next.text}, nil
+	case next.kind == tokenNumber:
+		p.pos++
+		value, err := strconv.ParseFloat(next.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at offset %d", next.text, next.pos)
+		}
+		return literalNode{value: value}, nil
+	case next.kind == tokenIdent:
+		return p.parseIdent()
+	case next.pos < 0:
+		return nil, fmt.Errorf("unexpected end of query")
+	default:
+		return nil, fmt.Errorf("unexpected %q at offset %d", next.text, next.pos)
+	}
+}
+
+func (p *parser) parseIdent() (node, error) {
+	name := p.peek()
+	p.pos++
+	switch name.text {
+	case "true":
+		return literalNode{value: true}, nil
+	case "false":
+		return literalNode{value: false}, nil
+	case "null":
+		return literalNode{value: nil}, nil
+	case "length", "keys", "not":
+		return funcNode{name: name.text}, nil
+	case "select", "map", "has", "contains":
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		arg, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		return funcNode{name: name.text, arg: arg}, p.expect(")")
+	default:
+		return nil, fmt.Errorf("unknown function %q at offset %d", name.text, name.pos)
+	}
+}
+
+// parseObject parses {key, key: value, "key": value}. A bare key is
+// shorthand for key: .key.
+func (p *parser) parseObject() (node, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	object := objectNode{}
+	for !p.isPunct("}") {
+		next := p.peek()
+		if next.kind != tokenIdent && next.kind != tokenString {
+			return nil, fmt.Errorf("expected object key at offset %d", next.pos)
+		}
+		p.pos++
+		var value node = indexNode{target: identityNode{}, key: literalNode{value: next.text}}
+		if p.isPunct(":") {
+			p.pos++
+			var err error
+			value, err = p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+		}
+		object.keys = append(object.keys, next.text)
+		object.values = append(object.values, value)
+		if !p.isPunct(",") {
+			break
+		}
+		p.pos++
+	}
+	return object, p.expect("}")
+}
@@ -0,0 +1,212 @@
+// Package mcpserver implements the tool subset of the Model Context Protocol
+// over stdio: newline-delimited JSON-RPC 2.0 messages with initialize, ping,
+// tools/list and tools/call.
+package mcpserver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// ProtocolVersion is the newest protocol revision the server speaks.
+const ProtocolVersion = "2025-06-18"
+
+var supportedProtocolVersions = []string{ProtocolVersion, "2025-03-26", "2024-11-05"}
+
+// JSON-RPC error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// maxMessageSize bounds a single JSON-RPC message read from the client.
+const maxMessageSize = 16 << 20
+
+// Tool describes a callable tool.
+type Tool struct {
+	Name        string           `json:"name"`
+	Title       string           `json:"title,omitempty"`
+	Description string           `json:"description,omitempty"`
+	InputSchema map[string]any   `json:"inputSchema"`
+	Annotations *ToolAnnotations `json:"annotations,omitempty"`
+}
+
+// ToolAnnotations are hints about a tool's behavior. Clients use
+// DestructiveHint to ask for confirmation before calling a tool.
+type ToolAnnotations struct {
+	ReadOnlyHint    bool `json:"readOnlyHint"`
+	DestructiveHint bool `json:"destructiveHint"`
+}
+
+// Content is a single content block of a tool result.
+type Content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// ToolResult is the result of a tools/call request. Tool failures are
+// reported with IsError rather than as protocol errors.
+type ToolResult struct {
+	Content           []Content `json:"content"`
+	StructuredContent any       `json:"structuredContent,omitempty"`
+	IsError           bool      `json:"isError,omitempty"`
+}
+
+// TextResult returns a result with a single text block.
+func TextResult(text string, isError bool) *ToolResult {
+	return &ToolResult{Content: []Content{{Type: "text", Text: text}}, IsError: isError}
+}
+
+// Handler lists and calls tools.
+type Handler interface {
+	Tools() []Tool
+	CallTool(ctx context.Context, name string, arguments map[string]any) (*ToolResult, error)
+}
+
+// ErrUnknownTool is returned by Handler.CallTool for a tool it does not
+// provide; it is reported to the client as invalid params.
+var ErrUnknownTool = errors.New("unknown tool")
+
+// Server answers MCP requests read from a stream.
+type Server struct {
+	name    string
+	version string
+	handler Handler
+	out     io.Writer
+}
+
+// New returns a Server that identifies itself as name and version.
+func New(name, version string, handler Handler) *Server {
+	return &Server{name: name, version: version, handler: handler}
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *responseError  `json:"error,omitempty"`
+}
+
+type responseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Serve reads requests from in and writes responses to out until in is
+// exhausted or ctx is canceled. Requests are handled one at a time.
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	s.out = out
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return nil
+		}
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		if err := s.handleMessage(ctx, line); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func (s *Server) handleMessage(ctx context.Context, line []byte) error {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return s.writeError(json.RawMessage("null"), codeParseError, "parse error")
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		if len(req.ID) == 0 {
+			return nil
+		}
+		return s.writeError(req.ID, codeInvalidRequest, "invalid request")
+	}
+	// Notifications (no id) never get a response.
+	if len(req.ID) == 0 {
+		return nil
+	}
+
+	result, rpcErr := s.dispatch(ctx, req)
+	if rpcErr != nil {
+		return s.writeError(req.ID, rpcErr.Code, rpcErr.Message)
+	}
+	return s.write(response{JSONRPC: "2.0", ID: req.ID, Result: result})
+}
+
+func (s *Server) dispatch(ctx context.Context, req request) (any, *responseError) {
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(req.Params, &params)
+		version := ProtocolVersion
+		if slices.Contains(supportedProtocolVersions, params.ProtocolVersion) {
+			version = params.ProtocolVersion
+		}
+		return map[string]any{
+			"protocolVersion": version,
+			"capabilities": map[string]any{
+				"tools": map[string]any{"listChanged": false},
+			},
+			"serverInfo": map[string]any{"name": s.name, "version": s.version},
+		}, nil
+	case "ping":
+		return map[string]any{}, nil
+	case "tools/list":
+		tools := s.handler.Tools()
+		if tools == nil {
+			tools = []Tool{}
+		}
+		return map[string]any{"tools": tools}, nil
+	case "tools/call":
+		var params struct {
+			Name      string         `json:"name"`
+			Arguments map[string]any `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil || params.Name == "" {
+			return nil, &responseError{Code: codeInvalidParams, Message: "tools/call requires a tool name"}
+		}
+		result, err := s.handler.CallTool(ctx, params.Name, params.Arguments)
+		if errors.Is(err, ErrUnknownTool) {
+			return nil, &responseError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool: %s", params.Name)}
+		}
+		if err != nil {
+			return TextResult(err.Error(), true), nil
+		}
+		return result, nil
+	default:
+		return nil, &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+	}
+}
+
+func (s *Server) writeError(id json.RawMessage, code int, message string) error {
+	return s.write(response{JSONRPC: "2.0", ID: id, Error: &responseError{Code: code, Message: message}})
+}
+
+func (s *Server) write(resp response) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	_, err = s.out.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,160 @@
+package mcpserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type stubHandler struct {
+	calls []string
+}
+
+func (h *stubHandler) Tools() []Tool {
+	return []Tool{{
+		Name:        "apps_list",
+		InputSchema: map[string]any{"type": "object"},
+		Annotations: &ToolAnnotations{ReadOnlyHint: true},
+	}}
+}
+
+func (h *stubHandler) CallTool(ctx context.Context, name string, arguments map[string]any) (*ToolResult, error) {
+	h.calls = append(h.calls, name)
+	switch name {
+	case "apps_list":
+		result := TextResult(`{"data":[]}`, false)
+		result.StructuredContent = map[string]any{"data": []any{}, "limit": arguments["limit"]}
+		return result, nil
+	case "fails":
+		return nil, errors.New("boom")
+	default:
+		return nil, ErrUnknownTool
+	}
+}
+
+func serveLines(t *testing.T, handler Handler, lines ...string) []map[string]any {
+	t.Helper()
+	var out bytes.Buffer
+	server := New("asc", "1.2.3", handler)
+	if err := server.Serve(context.Background(), strings.NewReader(strings.Join(lines, "\n")+"\n"), &out); err != nil {
+		t.Fatalf("Serve() error: %v", err)
+	}
+	var responses []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var resp map[string]any
+		if err := json.Unmarshal([]byte(line), &resp); err != nil {
+			t.Fatalf("invalid response %q: %v", line, err)
+		}
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+func TestServerInitializeAndListTools(t *testing.T) {
+	responses := serveLines(t, &stubHandler{},
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":"two","method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"ping"}`,
+	)
+	if len(responses) != 3 {
+		t.Fatalf("expected 3 responses (no reply to notifications), got %d: %v", len(responses), responses)
+	}
+
+	initResult := responses[0]["result"].(map[string]any)
+	if initResult["protocolVersion"] != "2025-03-26" {
+		t.Fatalf("expected negotiated protocol version, got %v", initResult["protocolVersion"])
+	}
+	serverInfo := initResult["serverInfo"].(map[string]any)
+	if serverInfo["name"] != "asc" || serverInfo["version"] != "1.2.3" {
+		t.Fatalf("unexpected serverInfo %v", serverInfo)
+	}
+
+	if responses[1]["id"] != "two" {
+		t.Fatalf("expected string id to be echoed, got %v", responses[1]["id"])
+	}
+	tools := responses[1]["result"].(map[string]any)["tools"].([]any)
+	tool := tools[0].(map[string]any)
+	if tool["name"] != "apps_list" {
+		t.Fatalf("unexpected tool %v", tool)
+	}
+	annotations := tool["annotations"].(map[string]any)
+	if annotations["readOnlyHint"] != true || annotations["destructiveHint"] != false {
+		t.Fatalf("unexpected annotations %v", annotations)
+	}
+
+	if _, ok := responses[2]["result"].(map[string]any); !ok {
+		t.Fatalf("expected empty ping result, got %v", responses[2])
+	}
+}
+
+func TestServerInitializeFallsBackToLatestProtocol(t *testing.T) {
+	responses := serveLines(t, &stubHandler{},
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"1999-01-01"}}`,
+	)
+	if got := responses[0]["result"].(map[string]any)["protocolVersion"]; got != ProtocolVersion {
+		t.Fatalf("protocolVersion = %v, want %s", got, ProtocolVersion)
+	}
+}
+
+func TestServerCallTool(t *testing.T) {
+	handler := &stubHandler{}
+	responses := serveLines(t, handler,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"apps_list","arguments":{"limit":5}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"fails"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"missing"}}`,
+	)
+
+	result := responses[0]["result"].(map[string]any)
+	if structured := result["structuredContent"].(map[string]any); structured["limit"] != float64(5) {
+		t.Fatalf("expected arguments to reach the handler, got %v", structured)
+	}
+	if _, isError := result["isError"]; isError {
+		t.Fatalf("expected success result, got %v", result)
+	}
+
+	failed := responses[1]["result"].(map[string]any)
+	if failed["isError"] != true {
+		t.Fatalf("expected tool error result, got %v", failed)
+	}
+	content := failed["content"].([]any)[0].(map[string]any)
+	if content["text"] != "boom" {
+		t.Fatalf("unexpected error content %v", content)
+	}
+
+	rpcErr := responses[2]["error"].(map[string]any)
+	if rpcErr["code"] != float64(codeInvalidParams) || !strings.Contains(rpcErr["message"].(string), "missing") {
+		t.Fatalf("unexpected error %v", rpcErr)
+	}
+	if len(handler.calls) != 3 {
+		t.Fatalf("expected 3 handler calls, got %v", handler.calls)
+	}
+}
+
+func TestServerProtocolErrors(t *testing.T) {
+	responses := serveLines(t, &stubHandler{},
+		`not json`,
+		`{"jsonrpc":"1.0","id":1,"method":"ping"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"resources/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{}}`,
+	)
+	wantCodes := []float64{codeParseError, codeInvalidRequest, codeMethodNotFound, codeInvalidParams}
+	if len(responses) != len(wantCodes) {
+		t.Fatalf("expected %d responses, got %d", len(wantCodes), len(responses))
+	}
+	for i, want := range wantCodes {
+		rpcErr, ok := responses[i]["error"].(map[string]any)
+		if !ok || rpcErr["code"] != want {
+			t.Fatalf("response %d: expected error code %v, got %v", i, want, responses[i])
+		}
+	}
+	if responses[0]["id"] != nil {
+		t.Fatalf("expected null id for parse error, got %v", responses[0]["id"])
+	}
+}
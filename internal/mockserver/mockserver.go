@@ -0,0 +1,176 @@
+// Package mockserver serves recorded App Store Connect JSON:API fixtures over
+// HTTP so asc workflows can run without reaching Apple.
+//
+// Fixtures live under a root directory, one file per method and path:
+//
+//	<root>/<METHOD>/<path>.json
+//	<root>/<METHOD>/<path>.<query>.json
+//
+// where <query> is the request query encoded with url.Values.Encode (keys
+// sorted). A request first matches the query-specific fixture, then the
+// plain one. Requests carrying a pagination cursor only match exact
+// fixtures so recorded "next" links cannot loop back to the first page.
+package mockserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// AppleBaseURL is rewritten to the server's own base URL in served bodies so
+// recorded pagination links stay on the mock server.
+const AppleBaseURL = "https://api.appstoreconnect.apple.com"
+
+// fixtureMethods are the request methods with fixture directories. Others are
+// rejected so the method can never name a path outside the fixture root.
+var fixtureMethods = []string{http.MethodGet, http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete}
+
+// Server serves fixtures from a directory.
+type Server struct {
+	root    string
+	baseURL string
+	logger  io.Writer
+}
+
+// New returns a Server rooted at dir. baseURL is the externally visible URL
+// of the server and replaces AppleBaseURL in fixture bodies.
+func New(dir, baseURL string, logger io.Writer) (*Server, error) {
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		return nil, fmt.Errorf("fixture directory is required")
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("fixture directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("fixture directory %q is not a directory", dir)
+	}
+	if logger == nil {
+		logger = io.Discard
+	}
+	return &Server{
+		root:    filepath.Clean(dir),
+		baseURL: strings.TrimRight(baseURL, "/"),
+		logger:  logger,
+	}, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fixture, err := s.resolve(r)
+	if err != nil {
+		fmt.Fprintf(s.logger, "%s %s -> 404 (%v)\n", r.Method, r.URL.RequestURI(), err)
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "No fixture", err.Error())
+		return
+	}
+
+	body, err := os.ReadFile(fixture)
+	if err != nil {
+		fmt.Fprintf(s.logger, "%s %s -> 500 (%v)\n", r.Method, r.URL.RequestURI(), err)
+		writeError(w, http.StatusInternalServerError, "FIXTURE_READ_FAILED", "Fixture read failed", err.Error())
+		return
+	}
+	if s.baseURL != "" {
+		body = bytes.ReplaceAll(body, []byte(AppleBaseURL), []byte(s.baseURL))
+	}
+
+	status := fixtureStatus(r.Method, body)
+	rel, _ := filepath.Rel(s.root, fixture)
+	fmt.Fprintf(s.logger, "%s %s -> %d (%s)\n", r.Method, r.URL.RequestURI(), status, filepath.ToSlash(rel))
+
+	if status == http.StatusNoContent || len(bytes.TrimSpace(body)) == 0 {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+func (s *Server) resolve(r *http.Request) (string, error) {
+	cleaned := path.Clean("/" + r.URL.Path)
+	if cleaned == "/" {
+		return "", fmt.Errorf("no fixture for root path")
+	}
+	method := strings.ToUpper(r.Method)
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+	if !slices.Contains(fixtureMethods, method) {
+		return "", fmt.Errorf("no fixtures for method %q", r.Method)
+	}
+	base := filepath.Join(s.root, method, filepath.FromSlash(strings.TrimPrefix(cleaned, "/")))
+
+	query := r.URL.Query()
+	if len(query) > 0 {
+		candidate := base + "." + query.Encode() + ".json"
+		if fileExists(candidate) {
+			return candidate, nil
+		}
+		if query.Has("cursor") {
+			return "", fmt.Errorf("no fixture for %s %s", method, cleaned+"?"+query.Encode())
+		}
+	}
+
+	candidate := base + ".json"
+	if fileExists(candidate) {
+		return candidate, nil
+	}
+	return "", fmt.Errorf("no fixture for %s %s", method, cleaned)
+}
+
+// fixtureStatus picks the response status: JSON:API error documents use the
+// first error's status, otherwise the conventional success code per method.
+func fixtureStatus(method string, body []byte) int {
+	var doc struct {
+		Errors []struct {
+			Status string `json:"status"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &doc); err == nil && len(doc.Errors) > 0 {
+		if code, err := strconv.Atoi(doc.Errors[0].Status); err == nil && code >= 400 && code <= 599 {
+			return code
+		}
+	}
+	switch strings.ToUpper(method) {
+	case http.MethodPost:
+		return http.StatusCreated
+	case http.MethodDelete:
+		return http.StatusNoContent
+	default:
+		return http.StatusOK
+	}
+}
+
+func fileExists(name string) bool {
+	info, err := os.Stat(name)
+	if err != nil {
+		return false
+	}
+	return !info.IsDir()
+}
+
+func writeError(w http.ResponseWriter, status int, code, title, detail string) {
+	payload := map[string]any{
+		"errors": []map[string]string{{
+			"status": strconv.Itoa(status),
+			"code":   code,
+			"title":  title,
+			"detail": detail,
+		}},
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
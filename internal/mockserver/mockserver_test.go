@@ -0,0 +1,117 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFixture(t *testing.T, root, rel, body string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir error: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatalf("write fixture error: %v", err)
+	}
+}
+
+func TestServerServesFixtures(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, "GET/v1/apps.json", `{"data":[],"links":{"next":"https://api.appstoreconnect.apple.com/v1/apps?cursor=abc"}}`)
+	writeFixture(t, root, "GET/v1/apps.cursor=abc.json", `{"data":[{"type":"apps","id":"2"}]}`)
+	writeFixture(t, root, "POST/v1/betaGroups.json", `{"data":{"type":"betaGroups","id":"g"}}`)
+	writeFixture(t, root, "DELETE/v1/betaGroups/g.json", ``)
+	writeFixture(t, root, "GET/v1/apps/missing.json", `{"errors":[{"status":"404","code":"NOT_FOUND"}]}`)
+
+	server, err := New(root, "http://mock.local", nil)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "plain fixture rewrites base URL", method: http.MethodGet, target: "/v1/apps?limit=200", wantStatus: http.StatusOK, wantBody: `{"data":[],"links":{"next":"http://mock.local/v1/apps?cursor=abc"}}`},
+		{name: "query fixture", method: http.MethodGet, target: "/v1/apps?cursor=abc", wantStatus: http.StatusOK, wantBody: `{"data":[{"type":"apps","id":"2"}]}`},
+		{name: "unknown cursor does not fall back", method: http.MethodGet, target: "/v1/apps?cursor=zzz", wantStatus: http.StatusNotFound},
+		{name: "post uses created", method: http.MethodPost, target: "/v1/betaGroups", wantStatus: http.StatusCreated, wantBody: `{"data":{"type":"betaGroups","id":"g"}}`},
+		{name: "delete uses no content", method: http.MethodDelete, target: "/v1/betaGroups/g", wantStatus: http.StatusNoContent},
+		{name: "error fixture status", method: http.MethodGet, target: "/v1/apps/missing", wantStatus: http.StatusNotFound, wantBody: `{"errors":[{"status":"404","code":"NOT_FOUND"}]}`},
+		{name: "missing fixture", method: http.MethodGet, target: "/v1/builds", wantStatus: http.StatusNotFound},
+		{name: "path traversal stays in root", method: http.MethodGet, target: "/../../etc/passwd", wantStatus: http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(test.method, test.target, nil)
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+
+			if rec.Code != test.wantStatus {
+				t.Fatalf("status = %d, want %d (body %q)", rec.Code, test.wantStatus, rec.Body.String())
+			}
+			if test.wantBody != "" && rec.Body.String() != test.wantBody {
+				t.Fatalf("body = %q, want %q", rec.Body.String(), test.wantBody)
+			}
+		})
+	}
+}
+
+func TestServerRejectsUnknownMethods(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, "v1/apps.json", `{"data":[]}`)
+	root := filepath.Join(dir, "fixtures")
+	writeFixture(t, root, "GET/v1/apps.json", `{"data":[]}`)
+
+	server, err := New(root, "", nil)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	for _, method := range []string{"..", "OPTIONS"} {
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, httptest.NewRequest(method, "/v1/apps", nil))
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("%s status = %d, want %d (body %q)", method, rec.Code, http.StatusNotFound, rec.Body.String())
+		}
+	}
+}
+
+func TestServerMissingFixtureReturnsJSONAPIError(t *testing.T) {
+	server, err := New(t.TempDir(), "", io.Discard)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/apps", nil))
+
+	var payload struct {
+		Errors []struct {
+			Status string `json:"status"`
+			Code   string `json:"code"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	if len(payload.Errors) != 1 || payload.Errors[0].Status != "404" || payload.Errors[0].Code != "NOT_FOUND" {
+		t.Fatalf("unexpected error payload: %+v", payload)
+	}
+}
+
+func TestNewRejectsMissingDirectory(t *testing.T) {
+	if _, err := New(filepath.Join(t.TempDir(), "missing"), "", nil); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
@@ -0,0 +1,56 @@
+package reportstore
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseSegment reads the columns and records of a decompressed analytics
+// report segment. Segments are delimited text with a header row; the delimiter
+// (tab or comma) is detected from the header.
+func ParseSegment(r io.Reader) (AnalyticsSegment, error) {
+	buffered := bufio.NewReader(r)
+	firstLine, err := buffered.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return AnalyticsSegment{}, err
+	}
+	if strings.TrimSpace(firstLine) == "" {
+		return AnalyticsSegment{}, fmt.Errorf("analytics segment is empty")
+	}
+
+	reader := csv.NewReader(io.MultiReader(strings.NewReader(firstLine), buffered))
+	reader.Comma = ','
+	if strings.Contains(firstLine, "\t") {
+		reader.Comma = '\t'
+	}
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return AnalyticsSegment{}, fmt.Errorf("analytics segment header: %w", err)
+	}
+	columns := make([]string, len(header))
+	for i, column := range header {
+		columns[i] = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+	}
+
+	records := make([][]string, 0)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return AnalyticsSegment{}, fmt.Errorf("analytics segment: %w", err)
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		records = append(records, record)
+	}
+	return AnalyticsSegment{Columns: columns, Records: records}, nil
+}
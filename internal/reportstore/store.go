@@ -0,0 +1,394 @@
+// Package reportstore keeps downloaded App Store Connect analytics, sales and
+// finance reports in a local SQLite database so they can be queried with SQL.
+//
+// Every report is written in a single transaction together with a sync_log
+// entry keyed by source, vendor number and report key, so an interrupted sync never leaves a
+// partially stored report behind and a later sync can skip what is already
+// stored.
+package reportstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/salesreports"
+)
+
+// Report sources recorded in sync_log.
+const (
+	SourceAnalytics = "analytics"
+	SourceSales     = "sales"
+	SourceFinance   = "finance"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS sync_log (
+	source        TEXT NOT NULL,
+	vendor_number TEXT NOT NULL DEFAULT '',
+	key           TEXT NOT NULL,
+	synced_at     TEXT NOT NULL,
+	row_count     INTEGER NOT NULL,
+	PRIMARY KEY (source, vendor_number, key)
+);
+
+CREATE TABLE IF NOT EXISTS sales_report_rows (
+	report_key        TEXT NOT NULL,
+	vendor_number     TEXT NOT NULL,
+	report_type       TEXT NOT NULL,
+	report_subtype    TEXT NOT NULL,
+	frequency         TEXT NOT NULL,
+	report_date       TEXT NOT NULL,
+	sku               TEXT,
+	title             TEXT,
+	product_type      TEXT,
+	territory         TEXT,
+	units             INTEGER NOT NULL,
+	proceeds          REAL NOT NULL,
+	proceeds_currency TEXT,
+	customer_price    REAL NOT NULL,
+	customer_currency TEXT,
+	begin_date        TEXT,
+	end_date          TEXT
+);
+CREATE INDEX IF NOT EXISTS sales_report_rows_report_key ON sales_report_rows (report_key);
+CREATE INDEX IF NOT EXISTS sales_report_rows_begin_date ON sales_report_rows (begin_date);
+
+CREATE TABLE IF NOT EXISTS finance_report_rows (
+	report_key        TEXT NOT NULL,
+	vendor_number     TEXT NOT NULL,
+	report_type       TEXT NOT NULL,
+	region_code       TEXT NOT NULL,
+	report_date       TEXT NOT NULL,
+	sku               TEXT,
+	title             TEXT,
+	product_type      TEXT,
+	territory         TEXT,
+	units             INTEGER NOT NULL,
+	proceeds          REAL NOT NULL,
+	proceeds_currency TEXT,
+	customer_price    REAL NOT NULL,
+	customer_currency TEXT,
+	begin_date        TEXT,
+	end_date          TEXT
+);
+CREATE INDEX IF NOT EXISTS finance_report_rows_report_key ON finance_report_rows (report_key);
+
+CREATE TABLE IF NOT EXISTS exchange_rates (
+	vendor_number TEXT NOT NULL,
+	report_key    TEXT NOT NULL,
+	report_date   TEXT NOT NULL,
+	bank_currency TEXT NOT NULL,
+	currency      TEXT NOT NULL,
+	rate          REAL NOT NULL,
+	PRIMARY KEY (vendor_number, report_key, currency)
+);
+
+CREATE TABLE IF NOT EXISTS analytics_reports (
+	report_id    TEXT PRIMARY KEY,
+	request_id   TEXT NOT NULL,
+	name         TEXT,
+	category     TEXT
+);
+
+CREATE TABLE IF NOT EXISTS analytics_instances (
+	instance_id     TEXT PRIMARY KEY,
+	report_id       TEXT NOT NULL,
+	report_date     TEXT,
+	processing_date TEXT,
+	granularity     TEXT
+);
+
+CREATE TABLE IF NOT EXISTS analytics_segments (
+	segment_id  TEXT PRIMARY KEY,
+	instance_id TEXT NOT NULL,
+	checksum    TEXT,
+	columns     TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS analytics_rows (
+	segment_id  TEXT NOT NULL,
+	instance_id TEXT NOT NULL,
+	report_id   TEXT NOT NULL,
+	report_name TEXT,
+	report_date TEXT,
+	row_number  INTEGER NOT NULL,
+	date        TEXT,
+	data        TEXT NOT NULL,
+	PRIMARY KEY (segment_id, row_number)
+);
+CREATE INDEX IF NOT EXISTS analytics_rows_report_name_date ON analytics_rows (report_name, date);
+`
+
+// Store is a report database.
+type Store struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+// SalesReport is one downloaded sales and trends report.
+type SalesReport struct {
+	Key           string
+	VendorNumber  string
+	ReportType    string
+	ReportSubType string
+	Frequency     string
+	ReportDate    string
+	Rows          []salesreports.Row
+}
+
+// FinanceReport is one downloaded finance report and its exchange rates.
+type FinanceReport struct {
+	Key          string
+	VendorNumber string
+	ReportType   string
+	RegionCode   string
+	ReportDate   string
+	Rows         []salesreports.Row
+	Rates        *salesreports.ExchangeRates
+}
+
+// AnalyticsInstance is one analytics report instance with every segment
+// downloaded for it.
+type AnalyticsInstance struct {
+	RequestID      string
+	ReportID       string
+	ReportName     string
+	Category       string
+	InstanceID     string
+	ReportDate     string
+	ProcessingDate string
+	Granularity    string
+	Segments       []AnalyticsSegment
+}
+
+// AnalyticsSegment holds the parsed records of one analytics report segment.
+type AnalyticsSegment struct {
+	SegmentID string
+	Checksum  string
+	Columns   []string
+	Records   [][]string
+}
+
+// Open opens or creates the database at path and ensures the schema exists.
+func Open(ctx context.Context, path string) (*Store, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, fmt.Errorf("database path is required")
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows a single writer; one connection keeps transactions simple.
+	db.SetMaxOpenConns(1)
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("create schema: %w", err)
+	}
+	return &Store{db: db, now: time.Now}, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// DB exposes the underlying database for queries.
+func (s *Store) DB() *sql.DB {
+	return s.db
+}
+
+// Synced reports whether the report identified by source, vendor number and
+// key is stored. Analytics reports are not vendor scoped and use an empty
+// vendor number.
+func (s *Store) Synced(ctx context.Context, source, vendorNumber, key string) (bool, error) {
+	var found int
+	err := s.db.QueryRowContext(ctx,
+		`SELECT 1 FROM sync_log WHERE source = ? AND vendor_number = ? AND key = ?`,
+		source, vendorNumber, key,
+	).Scan(&found)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SaveSalesReport stores a sales report and marks it synced.
+func (s *Store) SaveSalesReport(ctx context.Context, report SalesReport) error {
+	return s.withTx(ctx, SourceSales, report.VendorNumber, report.Key, len(report.Rows), func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, `INSERT INTO sales_report_rows (
+			report_key, vendor_number, report_type, report_subtype, frequency, report_date,
+			sku, title, product_type, territory, units, proceeds, proceeds_currency,
+			customer_price, customer_currency, begin_date, end_date
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, row := range report.Rows {
+			if _, err := stmt.ExecContext(ctx,
+				report.Key, report.VendorNumber, report.ReportType, report.ReportSubType, report.Frequency, report.ReportDate,
+				row.SKU, row.Title, row.ProductType, row.Territory, row.Units, row.Proceeds, row.ProceedsCurrency,
+				row.CustomerPrice, row.CustomerCurrency, formatDate(row.BeginDate), formatDate(row.EndDate),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SaveFinanceReport stores a finance report with its exchange rates and marks
+// it synced.
+func (s *Store) SaveFinanceReport(ctx context.Context, report FinanceReport) error {
+	return s.withTx(ctx, SourceFinance, report.VendorNumber, report.Key, len(report.Rows), func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, `INSERT INTO finance_report_rows (
+			report_key, vendor_number, report_type, region_code, report_date,
+			sku, title, product_type, territory, units, proceeds, proceeds_currency,
+			customer_price, customer_currency, begin_date, end_date
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, row := range report.Rows {
+			if _, err := stmt.ExecContext(ctx,
+				report.Key, report.VendorNumber, report.ReportType, report.RegionCode, report.ReportDate,
+				row.SKU, row.Title, row.ProductType, row.Territory, row.Units, row.Proceeds, row.ProceedsCurrency,
+				row.CustomerPrice, row.CustomerCurrency, formatDate(row.BeginDate), formatDate(row.EndDate),
+			); err != nil {
+				return err
+			}
+		}
+
+		if report.Rates == nil {
+			return nil
+		}
+		for currency, rate := range report.Rates.Rates {
+			if _, err := tx.ExecContext(ctx,
+				`INSERT OR REPLACE INTO exchange_rates (vendor_number, report_key, report_date, bank_currency, currency, rate) VALUES (?, ?, ?, ?, ?, ?)`,
+				report.VendorNumber, report.Key, report.ReportDate, report.Rates.Currency, currency, rate,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SaveAnalyticsInstance stores every segment of an analytics report instance
+// and marks the instance synced. Each record is kept as a JSON object keyed by
+// column name; a "Date" column, when present, is copied into analytics_rows.date.
+func (s *Store) SaveAnalyticsInstance(ctx context.Context, instance AnalyticsInstance) error {
+	rows := 0
+	for _, segment := range instance.Segments {
+		rows += len(segment.Records)
+	}
+	return s.withTx(ctx, SourceAnalytics, "", instance.InstanceID, rows, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT OR REPLACE INTO analytics_reports (report_id, request_id, name, category) VALUES (?, ?, ?, ?)`,
+			instance.ReportID, instance.RequestID, instance.ReportName, instance.Category,
+		); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT OR REPLACE INTO analytics_instances (instance_id, report_id, report_date, processing_date, granularity) VALUES (?, ?, ?, ?, ?)`,
+			instance.InstanceID, instance.ReportID, instance.ReportDate, instance.ProcessingDate, instance.Granularity,
+		); err != nil {
+			return err
+		}
+
+		stmt, err := tx.PrepareContext(ctx, `INSERT OR REPLACE INTO analytics_rows (
+			segment_id, instance_id, report_id, report_name, report_date, row_number, date, data
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, segment := range instance.Segments {
+			columns, err := json.Marshal(segment.Columns)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx,
+				`INSERT OR REPLACE INTO analytics_segments (segment_id, instance_id, checksum, columns) VALUES (?, ?, ?, ?)`,
+				segment.SegmentID, instance.InstanceID, segment.Checksum, string(columns),
+			); err != nil {
+				return err
+			}
+
+			dateColumn := -1
+			for i, column := range segment.Columns {
+				if strings.EqualFold(strings.TrimSpace(column), "date") {
+					dateColumn = i
+					break
+				}
+			}
+			for i, record := range segment.Records {
+				values := make(map[string]string, len(segment.Columns))
+				for j, column := range segment.Columns {
+					if j < len(record) {
+						values[column] = record[j]
+					}
+				}
+				data, err := json.Marshal(values)
+				if err != nil {
+					return err
+				}
+				var date any
+				if dateColumn >= 0 && dateColumn < len(record) {
+					date = record[dateColumn]
+				}
+				if _, err := stmt.ExecContext(ctx,
+					segment.SegmentID, instance.InstanceID, instance.ReportID, instance.ReportName, instance.ReportDate,
+					i+1, date, string(data),
+				); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Store) withTx(ctx context.Context, source, vendorNumber, key string, rows int, fn func(*sql.Tx) error) error {
+	if strings.TrimSpace(key) == "" {
+		return fmt.Errorf("%s report key is required", source)
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("store %s report %s: %w", source, key, err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO sync_log (source, vendor_number, key, synced_at, row_count) VALUES (?, ?, ?, ?, ?)`,
+		source, vendorNumber, key, s.now().UTC().Format(time.RFC3339), rows,
+	); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("store %s report %s: %w", source, key, err)
+	}
+	return tx.Commit()
+}
+
+func formatDate(value time.Time) any {
+	if value.IsZero() {
+		return nil
+	}
+	return value.Format("2006-01-02")
+}
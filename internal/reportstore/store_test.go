@@ -0,0 +1,177 @@
+package reportstore
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/salesreports"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := Open(context.Background(), filepath.Join(t.TempDir(), "reports.sqlite"))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	return store
+}
+
+func TestParseSegmentDetectsDelimiter(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "tab", input: "\ufeffDate\tApp Name\tCounts\n2024-01-20\tExample\t12\n\n2024-01-21\tExample\t7\n"},
+		{name: "comma", input: "Date,App Name,Counts\n2024-01-20,Example,12\n2024-01-21,\"Example\",7\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			segment, err := ParseSegment(strings.NewReader(test.input))
+			if err != nil {
+				t.Fatalf("ParseSegment() error: %v", err)
+			}
+			if strings.Join(segment.Columns, "|") != "Date|App Name|Counts" {
+				t.Fatalf("unexpected columns: %q", segment.Columns)
+			}
+			if len(segment.Records) != 2 || segment.Records[1][2] != "7" {
+				t.Fatalf("unexpected records: %q", segment.Records)
+			}
+		})
+	}
+
+	if _, err := ParseSegment(strings.NewReader("\n")); err == nil {
+		t.Fatal("expected error for empty segment")
+	}
+}
+
+func TestSaveSalesReportMarksSynced(t *testing.T) {
+	ctx := context.Background()
+	store := openTestStore(t)
+
+	report := SalesReport{
+		Key:           "12345678/SALES/SUMMARY/DAILY/2024-01-20",
+		VendorNumber:  "12345678",
+		ReportType:    "SALES",
+		ReportSubType: "SUMMARY",
+		Frequency:     "DAILY",
+		ReportDate:    "2024-01-20",
+		Rows: []salesreports.Row{
+			{SKU: "com.example.app", Units: 3, Proceeds: 0.7, ProceedsCurrency: "USD", BeginDate: time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)},
+			{SKU: "com.example.app", Units: 2, Proceeds: 0.62, ProceedsCurrency: "EUR"},
+		},
+	}
+	if err := store.SaveSalesReport(ctx, report); err != nil {
+		t.Fatalf("SaveSalesReport() error: %v", err)
+	}
+	synced, err := store.Synced(ctx, SourceSales, report.VendorNumber, report.Key)
+	if err != nil || !synced {
+		t.Fatalf("expected report to be synced, got %v (%v)", synced, err)
+	}
+	if synced, _ := store.Synced(ctx, SourceFinance, report.VendorNumber, report.Key); synced {
+		t.Fatal("expected sync keys to be scoped by source")
+	}
+
+	var units int64
+	var beginDate string
+	if err := store.DB().QueryRowContext(ctx, `SELECT SUM(units), MAX(begin_date) FROM sales_report_rows`).Scan(&units, &beginDate); err != nil {
+		t.Fatalf("query rows: %v", err)
+	}
+	if units != 5 || beginDate != "2024-01-20" {
+		t.Fatalf("unexpected stored rows: units=%d begin=%q", units, beginDate)
+	}
+
+	if err := store.SaveSalesReport(ctx, report); err == nil {
+		t.Fatal("expected saving the same report twice to fail")
+	}
+	var count int
+	if err := store.DB().QueryRowContext(ctx, `SELECT COUNT(*) FROM sales_report_rows`).Scan(&count); err != nil {
+		t.Fatalf("count rows: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("expected failed save to roll back, got %d rows", count)
+	}
+}
+
+func TestSaveFinanceReportScopesByVendorNumber(t *testing.T) {
+	ctx := context.Background()
+	store := openTestStore(t)
+
+	for i, vendor := range []string{"11111111", "22222222"} {
+		report := FinanceReport{
+			Key:          "FINANCIAL/ZZ/2024-01",
+			VendorNumber: vendor,
+			ReportType:   "FINANCIAL",
+			RegionCode:   "ZZ",
+			ReportDate:   "2024-01",
+			Rows:         []salesreports.Row{{SKU: "com.example.app", Units: 1}},
+			Rates: &salesreports.ExchangeRates{
+				Currency: "USD",
+				Rates:    map[string]float64{"EUR": float64(i + 1)},
+			},
+		}
+		if synced, _ := store.Synced(ctx, SourceFinance, vendor, report.Key); synced {
+			t.Fatalf("expected vendor %s report not to be synced yet", vendor)
+		}
+		if err := store.SaveFinanceReport(ctx, report); err != nil {
+			t.Fatalf("SaveFinanceReport(%s) error: %v", vendor, err)
+		}
+	}
+
+	rows, err := store.DB().QueryContext(ctx, `SELECT vendor_number, rate FROM exchange_rates ORDER BY vendor_number`)
+	if err != nil {
+		t.Fatalf("query exchange rates: %v", err)
+	}
+	defer rows.Close()
+	rates := map[string]float64{}
+	for rows.Next() {
+		var vendor string
+		var rate float64
+		if err := rows.Scan(&vendor, &rate); err != nil {
+			t.Fatalf("scan exchange rate: %v", err)
+		}
+		rates[vendor] = rate
+	}
+	if len(rates) != 2 || rates["11111111"] != 1 || rates["22222222"] != 2 {
+		t.Fatalf("expected exchange rates for both vendors, got %v", rates)
+	}
+}
+
+func TestSaveAnalyticsInstanceStoresJSONRows(t *testing.T) {
+	ctx := context.Background()
+	store := openTestStore(t)
+
+	instance := AnalyticsInstance{
+		RequestID:  "request-1",
+		ReportID:   "report-1",
+		ReportName: "App Downloads Standard",
+		InstanceID: "instance-1",
+		ReportDate: "2024-01-20",
+		Segments: []AnalyticsSegment{{
+			SegmentID: "segment-1",
+			Columns:   []string{"Date", "Counts"},
+			Records:   [][]string{{"2024-01-20", "12"}, {"2024-01-21", "7"}},
+		}},
+	}
+	if err := store.SaveAnalyticsInstance(ctx, instance); err != nil {
+		t.Fatalf("SaveAnalyticsInstance() error: %v", err)
+	}
+
+	var total int
+	if err := store.DB().QueryRowContext(ctx,
+		`SELECT SUM(CAST(json_extract(data, '$.Counts') AS INTEGER)) FROM analytics_rows WHERE report_name = ? AND date >= ?`,
+		"App Downloads Standard", "2024-01-21",
+	).Scan(&total); err != nil {
+		t.Fatalf("query rows: %v", err)
+	}
+	if total != 7 {
+		t.Fatalf("expected 7 counts, got %d", total)
+	}
+	synced, err := store.Synced(ctx, SourceAnalytics, "", "instance-1")
+	if err != nil || !synced {
+		t.Fatalf("expected instance to be synced, got %v (%v)", synced, err)
+	}
+}
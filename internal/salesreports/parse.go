@@ -0,0 +1,295 @@
+// Package salesreports parses App Store Connect sales and trends reports and
+// the exchange rates published in finance reports.
+package salesreports
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/secureopen"
+)
+
+// Row is one line of a sales and trends report. Amounts are per unit, in the
+// currency named next to them.
+type Row struct {
+	SKU              string
+	Title            string
+	ProductType      string
+	Territory        string
+	Units            int64
+	Proceeds         float64
+	ProceedsCurrency string
+	CustomerPrice    float64
+	CustomerCurrency string
+	BeginDate        time.Time
+	EndDate          time.Time
+}
+
+// Column aliases cover the SUMMARY and DETAILED schemas of the SALES,
+// PRE_ORDER, NEWSSTAND and SUBSCRIPTION_EVENT/SUBSCRIBER report types.
+var (
+	skuColumns              = []string{"sku", "vendor identifier", "subscription apple id", "app apple id"}
+	titleColumns            = []string{"title", "subscription name", "app name"}
+	productTypeColumns      = []string{"product type identifier"}
+	territoryColumns        = []string{"country code", "country", "country of sale"}
+	unitsColumns            = []string{"units", "quantity"}
+	proceedsColumns         = []string{"developer proceeds", "partner share"}
+	proceedsCurrencyColumns = []string{"currency of proceeds", "proceeds currency", "partner share currency"}
+	customerPriceColumns    = []string{"customer price"}
+	customerCurrencyColumns = []string{"customer currency"}
+	beginDateColumns        = []string{"begin date", "event date", "start date", "transaction date"}
+	endDateColumns          = []string{"end date"}
+)
+
+var dateLayouts = []string{"01/02/2006", "2006-01-02"}
+
+// Open opens a report file, transparently inflating it when it is gzip
+// compressed.
+func Open(path string) (io.ReadCloser, error) {
+	file, err := secureopen.OpenExistingNoFollow(path)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := NewReader(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &readCloser{Reader: reader, closers: []io.Closer{reader, file}}, nil
+}
+
+// NewReader returns a reader over r's report content, inflating it when r is
+// gzip compressed. Closing the returned reader does not close r.
+func NewReader(r io.Reader) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(r)
+	magic, err := buffered.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, err
+		}
+		return gz, nil
+	}
+	return io.NopCloser(buffered), nil
+}
+
+type readCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r *readCloser) Close() error {
+	var firstErr error
+	for _, closer := range r.closers {
+		if err := closer.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// ParseSales reads a tab-separated sales and trends report. The first
+// non-empty line must be the header; columns are matched by name so both the
+// SUMMARY and DETAILED layouts are accepted.
+func ParseSales(r io.Reader) ([]Row, error) {
+	scanner := newLineScanner(r)
+
+	var header columnIndex
+	rows := make([]Row, 0)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if header == nil {
+			header = newColumnIndex(fields)
+			if !header.has(unitsColumns) && !header.has(proceedsColumns) {
+				return nil, fmt.Errorf("unrecognized sales report header: expected Units or Developer Proceeds columns")
+			}
+			continue
+		}
+
+		row, err := parseSalesRow(header, fields)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("sales report is empty")
+	}
+	return rows, nil
+}
+
+// ParseFinance reads the transaction rows of a tab-separated finance report.
+// Lines before the first header with SKU and Quantity or Partner Share columns
+// are skipped, and reading stops at the blank line that ends the block, so the
+// totals and exchange rate sections that follow are ignored.
+func ParseFinance(r io.Reader) ([]Row, error) {
+	scanner := newLineScanner(r)
+
+	var header columnIndex
+	rows := make([]Row, 0)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			if header != nil {
+				break
+			}
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if header == nil {
+			candidate := newColumnIndex(fields)
+			if candidate.has(skuColumns) && (candidate.has(unitsColumns) || candidate.has(proceedsColumns)) {
+				header = candidate
+			}
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(fields[0])), "total") {
+			continue
+		}
+
+		row, err := parseSalesRow(header, fields)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("unrecognized finance report: no transaction header found")
+	}
+	return rows, nil
+}
+
+func parseSalesRow(header columnIndex, fields []string) (Row, error) {
+	row := Row{
+		SKU:              header.value(fields, skuColumns),
+		Title:            header.value(fields, titleColumns),
+		ProductType:      header.value(fields, productTypeColumns),
+		Territory:        strings.ToUpper(header.value(fields, territoryColumns)),
+		ProceedsCurrency: strings.ToUpper(header.value(fields, proceedsCurrencyColumns)),
+		CustomerCurrency: strings.ToUpper(header.value(fields, customerCurrencyColumns)),
+		Units:            1,
+	}
+
+	var err error
+	if header.has(unitsColumns) {
+		if row.Units, err = parseUnits(header.value(fields, unitsColumns)); err != nil {
+			return Row{}, err
+		}
+	}
+	if row.Proceeds, err = parseAmount(header.value(fields, proceedsColumns)); err != nil {
+		return Row{}, fmt.Errorf("proceeds: %w", err)
+	}
+	if row.CustomerPrice, err = parseAmount(header.value(fields, customerPriceColumns)); err != nil {
+		return Row{}, fmt.Errorf("customer price: %w", err)
+	}
+	if row.BeginDate, err = parseDate(header.value(fields, beginDateColumns)); err != nil {
+		return Row{}, fmt.Errorf("begin date: %w", err)
+	}
+	if row.EndDate, err = parseDate(header.value(fields, endDateColumns)); err != nil {
+		return Row{}, fmt.Errorf("end date: %w", err)
+	}
+	if row.EndDate.IsZero() {
+		row.EndDate = row.BeginDate
+	}
+	return row, nil
+}
+
+// columnIndex maps lower-cased header names to field positions.
+type columnIndex map[string]int
+
+func newColumnIndex(fields []string) columnIndex {
+	index := make(columnIndex, len(fields))
+	for i, field := range fields {
+		name := strings.ToLower(strings.TrimSpace(field))
+		if _, exists := index[name]; !exists {
+			index[name] = i
+		}
+	}
+	return index
+}
+
+func (c columnIndex) lookup(aliases []string) (int, bool) {
+	for _, alias := range aliases {
+		if i, ok := c[alias]; ok {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (c columnIndex) has(aliases []string) bool {
+	_, ok := c.lookup(aliases)
+	return ok
+}
+
+func (c columnIndex) value(fields []string, aliases []string) string {
+	i, ok := c.lookup(aliases)
+	if !ok || i >= len(fields) {
+		return ""
+	}
+	return strings.TrimSpace(fields[i])
+}
+
+func newLineScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	return scanner
+}
+
+func parseUnits(value string) (int64, error) {
+	value = strings.ReplaceAll(value, ",", "")
+	if value == "" {
+		return 0, nil
+	}
+	units, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("units: invalid value %q", value)
+	}
+	return units, nil
+}
+
+func parseAmount(value string) (float64, error) {
+	value = strings.ReplaceAll(value, ",", "")
+	if value == "" {
+		return 0, nil
+	}
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	return amount, nil
+}
+
+func parseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range dateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
@@ -0,0 +1,111 @@
+package salesreports
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	rateColumns         = []string{"exchange rate"}
+	rateCurrencyColumns = []string{"partner share currency", "currency"}
+	bankCurrencyColumns = []string{"bank account currency"}
+)
+
+// ExchangeRates converts local currencies into the bank account currency a
+// finance report was paid out in.
+type ExchangeRates struct {
+	// Currency is the bank account currency that amounts convert into.
+	Currency string
+	// Rates maps a currency code to the amount of Currency paid per unit.
+	Rates map[string]float64
+}
+
+// ParseExchangeRates reads the exchange rate rows of a finance report. Any
+// block whose header has a currency column and an "Exchange Rate" column is
+// read until the next blank line.
+func ParseExchangeRates(r io.Reader) (*ExchangeRates, error) {
+	scanner := newLineScanner(r)
+
+	rates := &ExchangeRates{Rates: make(map[string]float64)}
+	var header columnIndex
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			header = nil
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if candidate := newColumnIndex(fields); candidate.has(rateColumns) && candidate.has(rateCurrencyColumns) {
+			header = candidate
+			continue
+		}
+		if header == nil {
+			continue
+		}
+
+		currency := strings.ToUpper(header.value(fields, rateCurrencyColumns))
+		rateValue := header.value(fields, rateColumns)
+		if currency == "" || rateValue == "" {
+			continue
+		}
+		rate, err := parseAmount(rateValue)
+		if err != nil {
+			return nil, fmt.Errorf("exchange rate for %s: %w", currency, err)
+		}
+		if rate <= 0 {
+			continue
+		}
+		if bank := strings.ToUpper(header.value(fields, bankCurrencyColumns)); bank != "" {
+			if rates.Currency != "" && rates.Currency != bank {
+				return nil, fmt.Errorf("finance report mixes bank account currencies %s and %s", rates.Currency, bank)
+			}
+			rates.Currency = bank
+		}
+		rates.Rates[currency] = rate
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(rates.Rates) == 0 {
+		return nil, fmt.Errorf("no exchange rates found in finance report")
+	}
+	if rates.Currency == "" {
+		return nil, fmt.Errorf("finance report has no Bank Account Currency column")
+	}
+	return rates, nil
+}
+
+// Merge adds other's rates, replacing rates already present for the same
+// currency.
+func (e *ExchangeRates) Merge(other *ExchangeRates) error {
+	if other == nil {
+		return nil
+	}
+	if e.Currency != "" && other.Currency != "" && e.Currency != other.Currency {
+		return fmt.Errorf("finance reports use different bank account currencies: %s and %s", e.Currency, other.Currency)
+	}
+	if e.Currency == "" {
+		e.Currency = other.Currency
+	}
+	if e.Rates == nil {
+		e.Rates = make(map[string]float64, len(other.Rates))
+	}
+	for currency, rate := range other.Rates {
+		e.Rates[currency] = rate
+	}
+	return nil
+}
+
+// Convert returns amount expressed in e.Currency.
+func (e *ExchangeRates) Convert(amount float64, currency string) (float64, bool) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == e.Currency {
+		return amount, true
+	}
+	rate, ok := e.Rates[currency]
+	if !ok {
+		return 0, false
+	}
+	return amount * rate, true
+}
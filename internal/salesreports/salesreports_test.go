@@ -0,0 +1,229 @@
+package salesreports
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const salesSummaryTSV = "Provider\tProvider Country\tSKU\tDeveloper\tTitle\tVersion\tProduct Type Identifier\tUnits\tDeveloper Proceeds\tBegin Date\tEnd Date\tCustomer Currency\tCountry Code\tCurrency of Proceeds\tApple Identifier\tCustomer Price\n" +
+	"APPLE\tUS\tcom.example.app\tExample\tExample App\t1.0\t1\t3\t0.70\t01/20/2024\t01/20/2024\tUSD\tUS\tUSD\t123\t0.99\n" +
+	"APPLE\tUS\tcom.example.app\tExample\tExample App\t1.0\t1\t2\t0.62\t01/20/2024\t01/20/2024\tEUR\tDE\tEUR\t123\t0.99\n" +
+	"APPLE\tUS\tcom.example.pro\tExample\tPro Unlock\t\tIA1\t1\t6.99\t01/21/2024\t01/21/2024\tUSD\tUS\tUSD\t456\t9.99\n" +
+	"APPLE\tUS\tcom.example.app\tExample\tExample App\t1.0\t1\t-1\t0.70\t01/21/2024\t01/21/2024\tUSD\tUS\tUSD\t123\t0.99\n"
+
+const subscriberDetailedTSV = "Event Date\tApp Name\tApp Apple ID\tSubscription Name\tSubscription Apple ID\tCustomer Price\tCustomer Currency\tDeveloper Proceeds\tProceeds Currency\tCountry\tUnits\n" +
+	"2024-01-20\tExample App\t123\tMonthly\t789\t4.99\tGBP\t3.49\tGBP\tGB\t1\n"
+
+const financeDetailTSV = "Transaction Date\tSettlement Date\tApple Identifier\tSKU\tQuantity\tPartner Share\tPartner Share Currency\n" +
+	"01/20/2024\t02/01/2024\t123\tcom.example.app\t3\t0.70\tUSD\n" +
+	"\n" +
+	"Country Of Sale\tPartner Share Currency\tQuantity\tExtended Partner Share\tExchange Rate\tProceeds\tBank Account Currency\n" +
+	"Americas\tUSD\t3\t2.10\t1.000000\t2.10\tUSD\n" +
+	"Euro-Zone\tEUR\t2\t1.24\t1.085000\t1.35\tUSD\n" +
+	"United Kingdom\tGBP\t1\t3.49\t1.27\t4.43\tUSD\n"
+
+func TestParseSalesSummary(t *testing.T) {
+	rows, err := ParseSales(strings.NewReader(salesSummaryTSV))
+	if err != nil {
+		t.Fatalf("ParseSales() error: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("expected 4 rows, got %d", len(rows))
+	}
+	row := rows[1]
+	if row.SKU != "com.example.app" || row.Territory != "DE" || row.Units != 2 || row.Proceeds != 0.62 || row.ProceedsCurrency != "EUR" {
+		t.Fatalf("unexpected row: %+v", row)
+	}
+	if want := time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC); !row.BeginDate.Equal(want) {
+		t.Fatalf("begin date = %v, want %v", row.BeginDate, want)
+	}
+	if rows[3].Units != -1 {
+		t.Fatalf("expected refund units -1, got %d", rows[3].Units)
+	}
+}
+
+func TestParseSalesDetailedSubscriber(t *testing.T) {
+	rows, err := ParseSales(strings.NewReader(subscriberDetailedTSV))
+	if err != nil {
+		t.Fatalf("ParseSales() error: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	row := rows[0]
+	if row.SKU != "789" || row.Title != "Monthly" || row.Territory != "GB" || row.ProceedsCurrency != "GBP" || row.CustomerPrice != 4.99 {
+		t.Fatalf("unexpected row: %+v", row)
+	}
+	if !row.EndDate.Equal(row.BeginDate) {
+		t.Fatalf("expected end date to default to begin date, got %v", row.EndDate)
+	}
+}
+
+func TestParseSalesErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "empty", input: "\n\n", want: "empty"},
+		{name: "unknown header", input: "Foo\tBar\n1\t2\n", want: "unrecognized"},
+		{name: "bad units", input: "SKU\tUnits\nabc\tmany\n", want: "line 2: units"},
+		{name: "bad date", input: "SKU\tUnits\tBegin Date\nabc\t1\t2024/01/01\n", want: "begin date"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseSales(strings.NewReader(test.input))
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("expected error containing %q, got %v", test.want, err)
+			}
+		})
+	}
+}
+
+func TestParseFinanceStopsAtFirstBlock(t *testing.T) {
+	input := "Start Date\tEnd Date\tVendor Identifier\tQuantity\tPartner Share\tPartner Share Currency\tCountry Of Sale\n" +
+		"01/01/2024\t01/31/2024\tcom.example.app\t4\t0.70\tUSD\tUS\n" +
+		"Total_Rows\t1\n" +
+		"\n" + financeDetailTSV
+	rows, err := ParseFinance(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseFinance() error: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d: %+v", len(rows), rows)
+	}
+	row := rows[0]
+	if row.SKU != "com.example.app" || row.Units != 4 || row.Proceeds != 0.7 || row.Territory != "US" {
+		t.Fatalf("unexpected row: %+v", row)
+	}
+
+	detail, err := ParseFinance(strings.NewReader("Payments report\n\n" + financeDetailTSV))
+	if err != nil {
+		t.Fatalf("ParseFinance() detail error: %v", err)
+	}
+	if len(detail) != 1 || detail[0].Units != 3 {
+		t.Fatalf("unexpected detail rows: %+v", detail)
+	}
+
+	if _, err := ParseFinance(strings.NewReader("Foo\tBar\n")); err == nil {
+		t.Fatal("expected error for report without transaction header")
+	}
+}
+
+func TestOpenDetectsGzip(t *testing.T) {
+	dir := t.TempDir()
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write([]byte(salesSummaryTSV)); err != nil {
+		t.Fatalf("gzip write: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip close: %v", err)
+	}
+
+	paths := map[string][]byte{
+		filepath.Join(dir, "report.tsv.gz"): compressed.Bytes(),
+		filepath.Join(dir, "report.tsv"):    []byte(salesSummaryTSV),
+	}
+	for path, data := range paths {
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+		reader, err := Open(path)
+		if err != nil {
+			t.Fatalf("Open(%s) error: %v", path, err)
+		}
+		content, err := io.ReadAll(reader)
+		_ = reader.Close()
+		if err != nil {
+			t.Fatalf("read %s: %v", path, err)
+		}
+		if string(content) != salesSummaryTSV {
+			t.Fatalf("unexpected content for %s", path)
+		}
+	}
+}
+
+func TestParseExchangeRates(t *testing.T) {
+	rates, err := ParseExchangeRates(strings.NewReader(financeDetailTSV))
+	if err != nil {
+		t.Fatalf("ParseExchangeRates() error: %v", err)
+	}
+	if rates.Currency != "USD" {
+		t.Fatalf("currency = %q, want USD", rates.Currency)
+	}
+	if rates.Rates["EUR"] != 1.085 || rates.Rates["GBP"] != 1.27 {
+		t.Fatalf("unexpected rates: %+v", rates.Rates)
+	}
+	if _, err := ParseExchangeRates(strings.NewReader(salesSummaryTSV)); err == nil {
+		t.Fatal("expected error for report without exchange rates")
+	}
+}
+
+func TestSummarizeBySKUWithoutRates(t *testing.T) {
+	rows, err := ParseSales(strings.NewReader(salesSummaryTSV))
+	if err != nil {
+		t.Fatalf("ParseSales() error: %v", err)
+	}
+	summary, err := Summarize(rows, Options{GroupBy: []string{"sku"}})
+	if err != nil {
+		t.Fatalf("Summarize() error: %v", err)
+	}
+	if len(summary.Groups) != 3 {
+		t.Fatalf("expected SKU groups split by currency, got %+v", summary.Groups)
+	}
+	usd := summary.Groups[1]
+	if usd.SKU != "com.example.app" || usd.ProceedsCurrency != "USD" || usd.Units != 2 || usd.Proceeds != 1.4 || usd.CustomerPrice != 1.98 || usd.Rows != 2 {
+		t.Fatalf("unexpected USD group: %+v", usd)
+	}
+	if summary.Totals.Units != 5 || summary.Totals.Proceeds != nil {
+		t.Fatalf("expected units total without mixed-currency proceeds, got %+v", summary.Totals)
+	}
+}
+
+func TestSummarizeWithRatesAndDateRange(t *testing.T) {
+	rows, err := ParseSales(strings.NewReader(salesSummaryTSV))
+	if err != nil {
+		t.Fatalf("ParseSales() error: %v", err)
+	}
+	rates := &ExchangeRates{Currency: "USD", Rates: map[string]float64{"EUR": 1.1}}
+	day := time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)
+	summary, err := Summarize(rows, Options{GroupBy: []string{"territory", "date"}, From: day, To: day, Rates: rates})
+	if err != nil {
+		t.Fatalf("Summarize() error: %v", err)
+	}
+	if summary.Currency != "USD" || summary.Rows != 2 || len(summary.Groups) != 2 {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+	de := summary.Groups[0]
+	if de.Territory != "DE" || de.Date != "2024-01-20" || de.Proceeds != 1.36 || de.ProceedsCurrency != "" {
+		t.Fatalf("unexpected DE group: %+v", de)
+	}
+	if summary.Totals.Proceeds == nil || *summary.Totals.Proceeds != 3.46 {
+		t.Fatalf("unexpected totals: %+v", summary.Totals)
+	}
+
+	_, err = Summarize(rows, Options{Rates: &ExchangeRates{Currency: "GBP", Rates: map[string]float64{}}})
+	if err == nil || !strings.Contains(err.Error(), "no exchange rate for EUR, USD") {
+		t.Fatalf("expected missing rate error, got %v", err)
+	}
+}
+
+func TestNormalizeGroupBy(t *testing.T) {
+	got, err := NormalizeGroupBy([]string{"SKU", " territory", "sku", ""})
+	if err != nil {
+		t.Fatalf("NormalizeGroupBy() error: %v", err)
+	}
+	if strings.Join(got, ",") != "sku,territory" {
+		t.Fatalf("unexpected group-by: %v", got)
+	}
+	if _, err := NormalizeGroupBy([]string{"month"}); err == nil {
+		t.Fatal("expected error for unsupported group-by")
+	}
+}
@@ -0,0 +1,247 @@
+package salesreports
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Group-by dimensions accepted by Summarize.
+const (
+	GroupBySKU         = "sku"
+	GroupByTerritory   = "territory"
+	GroupByProductType = "product-type"
+	GroupByDate        = "date"
+)
+
+// GroupByValues lists every supported group-by dimension.
+var GroupByValues = []string{GroupBySKU, GroupByTerritory, GroupByProductType, GroupByDate}
+
+// Options controls how rows are filtered and aggregated.
+type Options struct {
+	// GroupBy lists the dimensions to aggregate by, in output order.
+	GroupBy []string
+	// From and To bound the row begin date, inclusive. Zero means unbounded.
+	From time.Time
+	To   time.Time
+	// Rates converts every amount into a single currency when set. Without
+	// rates, groups are also split by currency.
+	Rates *ExchangeRates
+}
+
+// Summary is the aggregated view of one or more sales reports.
+type Summary struct {
+	From     string   `json:"from,omitempty"`
+	To       string   `json:"to,omitempty"`
+	GroupBy  []string `json:"groupBy"`
+	Currency string   `json:"currency,omitempty"`
+	Rows     int      `json:"rows"`
+	Groups   []Group  `json:"groups"`
+	Totals   Totals   `json:"totals"`
+}
+
+// Group holds the totals for one combination of group-by values.
+type Group struct {
+	SKU              string  `json:"sku,omitempty"`
+	Title            string  `json:"title,omitempty"`
+	Territory        string  `json:"territory,omitempty"`
+	ProductType      string  `json:"productType,omitempty"`
+	Date             string  `json:"date,omitempty"`
+	ProceedsCurrency string  `json:"proceedsCurrency,omitempty"`
+	CustomerCurrency string  `json:"customerCurrency,omitempty"`
+	Units            int64   `json:"units"`
+	Proceeds         float64 `json:"proceeds"`
+	CustomerPrice    float64 `json:"customerPrice"`
+	Rows             int     `json:"rows"`
+}
+
+// Totals sums every group. Each amount is only set when all groups share its
+// currency.
+type Totals struct {
+	Units         int64    `json:"units"`
+	Proceeds      *float64 `json:"proceeds,omitempty"`
+	CustomerPrice *float64 `json:"customerPrice,omitempty"`
+}
+
+// NormalizeGroupBy validates and de-duplicates group-by dimensions.
+func NormalizeGroupBy(values []string) ([]string, error) {
+	normalized := make([]string, 0, len(values))
+	seen := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			continue
+		}
+		valid := false
+		for _, allowed := range GroupByValues {
+			if value == allowed {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("unsupported group-by %q (allowed: %s)", value, strings.Join(GroupByValues, ", "))
+		}
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		normalized = append(normalized, value)
+	}
+	return normalized, nil
+}
+
+// Summarize aggregates units, proceeds and customer price across rows.
+// Per-unit amounts are multiplied by units before they are summed.
+func Summarize(rows []Row, opts Options) (*Summary, error) {
+	groupBy, err := NormalizeGroupBy(opts.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{GroupBy: groupBy, Groups: make([]Group, 0)}
+	if !opts.From.IsZero() {
+		summary.From = opts.From.Format("2006-01-02")
+	}
+	if !opts.To.IsZero() {
+		summary.To = opts.To.Format("2006-01-02")
+	}
+	if opts.Rates != nil {
+		summary.Currency = opts.Rates.Currency
+	}
+
+	groups := make(map[string]*Group)
+	missingRates := make(map[string]struct{})
+	for _, row := range rows {
+		if !opts.From.IsZero() && row.BeginDate.Before(opts.From) {
+			continue
+		}
+		if !opts.To.IsZero() && row.BeginDate.After(opts.To) {
+			continue
+		}
+
+		proceeds := float64(row.Units) * row.Proceeds
+		customerPrice := float64(row.Units) * row.CustomerPrice
+
+		key := Group{}
+		for _, dimension := range groupBy {
+			switch dimension {
+			case GroupBySKU:
+				key.SKU = row.SKU
+			case GroupByTerritory:
+				key.Territory = row.Territory
+			case GroupByProductType:
+				key.ProductType = row.ProductType
+			case GroupByDate:
+				if !row.BeginDate.IsZero() {
+					key.Date = row.BeginDate.Format("2006-01-02")
+				}
+			}
+		}
+		if opts.Rates != nil {
+			var ok bool
+			if proceeds, ok = convert(opts.Rates, proceeds, row.ProceedsCurrency); !ok {
+				missingRates[row.ProceedsCurrency] = struct{}{}
+			}
+			if customerPrice, ok = convert(opts.Rates, customerPrice, row.CustomerCurrency); !ok {
+				missingRates[row.CustomerCurrency] = struct{}{}
+			}
+		} else {
+			key.ProceedsCurrency = row.ProceedsCurrency
+			key.CustomerCurrency = row.CustomerCurrency
+		}
+
+		id := groupKey(key, groupBy)
+		group, ok := groups[id]
+		if !ok {
+			group = &key
+			if key.SKU != "" {
+				group.Title = row.Title
+			}
+			groups[id] = group
+		}
+		group.Units += row.Units
+		group.Proceeds += proceeds
+		group.CustomerPrice += customerPrice
+		group.Rows++
+		summary.Rows++
+	}
+
+	if len(missingRates) > 0 {
+		currencies := make([]string, 0, len(missingRates))
+		for currency := range missingRates {
+			currencies = append(currencies, currency)
+		}
+		sort.Strings(currencies)
+		return nil, fmt.Errorf("no exchange rate for %s", strings.Join(currencies, ", "))
+	}
+
+	keys := make([]string, 0, len(groups))
+	for id := range groups {
+		keys = append(keys, id)
+	}
+	sort.Strings(keys)
+
+	sameProceedsCurrency, sameCustomerCurrency := true, true
+	var totalProceeds, totalCustomerPrice float64
+	for _, id := range keys {
+		group := groups[id]
+		group.Proceeds = roundAmount(group.Proceeds)
+		group.CustomerPrice = roundAmount(group.CustomerPrice)
+		summary.Groups = append(summary.Groups, *group)
+
+		first := summary.Groups[0]
+		sameProceedsCurrency = sameProceedsCurrency && group.ProceedsCurrency == first.ProceedsCurrency
+		sameCustomerCurrency = sameCustomerCurrency && group.CustomerCurrency == first.CustomerCurrency
+		summary.Totals.Units += group.Units
+		totalProceeds += group.Proceeds
+		totalCustomerPrice += group.CustomerPrice
+	}
+	if len(keys) > 0 && sameProceedsCurrency {
+		totalProceeds = roundAmount(totalProceeds)
+		summary.Totals.Proceeds = &totalProceeds
+	}
+	if len(keys) > 0 && sameCustomerCurrency {
+		totalCustomerPrice = roundAmount(totalCustomerPrice)
+		summary.Totals.CustomerPrice = &totalCustomerPrice
+	}
+
+	return summary, nil
+}
+
+func convert(rates *ExchangeRates, amount float64, currency string) (float64, bool) {
+	if amount == 0 {
+		return 0, true
+	}
+	return rates.Convert(amount, currency)
+}
+
+// groupKey joins group fields in group-by order, followed by currencies, so
+// sorting keys orders groups by the requested dimensions.
+func groupKey(group Group, groupBy []string) string {
+	parts := make([]string, 0, len(groupBy)+2)
+	for _, dimension := range groupBy {
+		switch dimension {
+		case GroupBySKU:
+			parts = append(parts, group.SKU)
+		case GroupByTerritory:
+			parts = append(parts, group.Territory)
+		case GroupByProductType:
+			parts = append(parts, group.ProductType)
+		case GroupByDate:
+			parts = append(parts, group.Date)
+		}
+	}
+	parts = append(parts, group.ProceedsCurrency, group.CustomerCurrency)
+	return strings.Join(parts, "\t")
+}
+
+func roundAmount(value float64) float64 {
+	rounded := math.Round(value*100) / 100
+	if rounded == 0 {
+		return 0
+	}
+	return rounded
+}
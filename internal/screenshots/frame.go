@@ -83,8 +83,14 @@ type FrameRequest struct {
 	OutputPath string // optional for custom config mode; required for input mode
 	Device     string // device slug; defaults to iphone-air when empty
 	ConfigPath string // optional Koubou YAML config path
+	Renderer   string // koubou (default) or native
 
-	// Kept for backwards compatibility; ignored in Koubou mode.
+	// Input mode styling; ignored when ConfigPath is set.
+	Background string // "#RRGGBB" or comma-separated gradient stops
+	Caption    string // optional caption drawn above the device
+	Font       string // optional TTF/OTF path for the caption
+
+	// Kept for backwards compatibility; ignored by both renderers.
 	FrameRoot   string
 	ScreenBleed int
 }
@@ -124,9 +130,21 @@ type frameExecutionMetadata struct {
 
 type koubouDefaultConfig struct {
 	Project     koubouProjectConfig                    `yaml:"project"`
+	Defaults    *koubouDefaults                        `yaml:"defaults,omitempty"`
 	Screenshots map[string]koubouDefaultScreenshotSpec `yaml:"screenshots"`
 }
 
+type koubouDefaults struct {
+	Background *koubouBackground `yaml:"background,omitempty"`
+}
+
+type koubouBackground struct {
+	Type      string   `yaml:"type,omitempty"`
+	Colors    []string `yaml:"colors,omitempty"`
+	Color     string   `yaml:"color,omitempty"`
+	Direction *float64 `yaml:"direction,omitempty"`
+}
+
 type koubouProjectConfig struct {
 	Name       string `yaml:"name"`
 	OutputDir  string `yaml:"output_dir"`
@@ -135,15 +153,26 @@ type koubouProjectConfig struct {
 }
 
 type koubouDefaultScreenshotSpec struct {
-	Content []koubouDefaultContentItem `yaml:"content"`
+	Background *koubouBackground          `yaml:"background,omitempty"`
+	Content    []koubouDefaultContentItem `yaml:"content"`
 }
 
 type koubouDefaultContentItem struct {
 	Type     string    `yaml:"type"`
-	Asset    string    `yaml:"asset"`
+	Asset    string    `yaml:"asset,omitempty"`
+	Content  string    `yaml:"content,omitempty"`
 	Position [2]string `yaml:"position"`
-	Scale    float64   `yaml:"scale"`
-	Frame    bool      `yaml:"frame"`
+	Scale    float64   `yaml:"scale,omitempty"`
+	Frame    bool      `yaml:"frame,omitempty"`
+
+	// Text styling.
+	Size       float64 `yaml:"size,omitempty"`
+	Color      string  `yaml:"color,omitempty"`
+	Weight     string  `yaml:"weight,omitempty"`
+	Alignment  string  `yaml:"alignment,omitempty"`
+	MaxWidth   string  `yaml:"max_width,omitempty"`
+	LineHeight float64 `yaml:"line_height,omitempty"`
+	Font       string  `yaml:"font,omitempty"`
 }
 
 // DefaultFrameDevice returns the default frame device.
@@ -194,7 +223,8 @@ func ParseFrameDevice(raw string) (FrameDevice, error) {
 	)
 }
 
-// Frame composes screenshots through Koubou's YAML pipeline.
+// Frame composes screenshots through Koubou's YAML pipeline, rendered either
+// natively or by the pinned kou binary.
 func Frame(ctx context.Context, req FrameRequest) (*FrameResult, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
@@ -204,6 +234,10 @@ func Frame(ctx context.Context, req FrameRequest) (*FrameResult, error) {
 	if err != nil {
 		return nil, err
 	}
+	renderer, err := ParseFrameRenderer(req.Renderer)
+	if err != nil {
+		return nil, err
+	}
 
 	outputPath := strings.TrimSpace(req.OutputPath)
 	configPath := strings.TrimSpace(req.ConfigPath)
@@ -225,6 +259,10 @@ func Frame(ctx context.Context, req FrameRequest) (*FrameResult, error) {
 		if !ok {
 			return nil, fmt.Errorf("no Koubou mapping configured for device %q", device)
 		}
+		style, err := newDefaultFrameStyle(req)
+		if err != nil {
+			return nil, err
+		}
 
 		absInputPath, err := filepath.Abs(inputPath)
 		if err != nil {
@@ -234,7 +272,7 @@ func Frame(ctx context.Context, req FrameRequest) (*FrameResult, error) {
 			return nil, fmt.Errorf("read input screenshot: %w", err)
 		}
 
-		generatedConfigPath, generatedMetadata, generatedWorkDir, err := createDefaultKoubouConfig(absInputPath, spec)
+		generatedConfigPath, generatedMetadata, generatedWorkDir, err := createDefaultKoubouConfig(absInputPath, spec, style)
 		if err != nil {
 			return nil, err
 		}
@@ -258,7 +296,7 @@ func Frame(ctx context.Context, req FrameRequest) (*FrameResult, error) {
 		}
 	}
 
-	generatedResults, err := runKoubouGenerate(ctx, configPath)
+	generatedResults, err := generateFromConfig(ctx, configPath, renderer)
 	if err != nil {
 		return nil, err
 	}
@@ -283,7 +321,7 @@ func Frame(ctx context.Context, req FrameRequest) (*FrameResult, error) {
 	}
 
 	if err := asc.ValidateImageFile(finalPath); err != nil {
-		return nil, fmt.Errorf("koubou output invalid: %w", err)
+		return nil, fmt.Errorf("framed output invalid: %w", err)
 	}
 	dimensions, err := asc.ReadImageDimensions(finalPath)
 	if err != nil {
@@ -309,9 +347,60 @@ func Frame(ctx context.Context, req FrameRequest) (*FrameResult, error) {
 	}, nil
 }
 
+// defaultFrameStyle holds the optional input-mode background and caption.
+type defaultFrameStyle struct {
+	Background   *koubouBackground
+	Caption      string
+	CaptionColor string
+	Font         string
+}
+
+func newDefaultFrameStyle(req FrameRequest) (defaultFrameStyle, error) {
+	style := defaultFrameStyle{
+		Caption: strings.TrimSpace(req.Caption),
+	}
+	if fontPath := strings.TrimSpace(req.Font); fontPath != "" {
+		absFontPath, err := filepath.Abs(fontPath)
+		if err != nil {
+			return defaultFrameStyle{}, fmt.Errorf("resolve font path: %w", err)
+		}
+		style.Font = absFontPath
+	}
+	if background := strings.TrimSpace(req.Background); background != "" {
+		colors := splitFrameColors(background)
+		for _, value := range colors {
+			if _, err := parseFrameColor(value); err != nil {
+				return defaultFrameStyle{}, fmt.Errorf("background: %w", err)
+			}
+		}
+		style.Background = &koubouBackground{Type: "solid", Colors: colors}
+		if len(colors) > 1 {
+			style.Background.Type = "linear"
+		}
+		// Keep the caption readable on dark backgrounds.
+		first, _ := parseFrameColor(colors[0])
+		if isDarkColor(flattenColor(first)) {
+			style.CaptionColor = "#FFFFFF"
+		}
+	}
+	return style, nil
+}
+
+func splitFrameColors(raw string) []string {
+	parts := strings.Split(raw, ",")
+	colors := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			colors = append(colors, trimmed)
+		}
+	}
+	return colors
+}
+
 func createDefaultKoubouConfig(
 	absInputPath string,
 	spec frameDeviceKoubouSpec,
+	style defaultFrameStyle,
 ) (string, frameExecutionMetadata, string, error) {
 	workDir, err := os.MkdirTemp("", "asc-shots-kou-*")
 	if err != nil {
@@ -324,6 +413,29 @@ func createDefaultKoubouConfig(
 	}
 
 	configPath := filepath.Join(workDir, "frame.yaml")
+	content := []koubouDefaultContentItem{
+		{
+			Type:     "image",
+			Asset:    absInputPath,
+			Position: [2]string{"50%", "50%"},
+			Scale:    1.0,
+			Frame:    true,
+		},
+	}
+	if style.Caption != "" {
+		// Leave the top of the canvas for the caption.
+		content[0].Position = [2]string{"50%", "58%"}
+		content[0].Scale = 0.8
+		content = append(content, koubouDefaultContentItem{
+			Type:      "text",
+			Content:   style.Caption,
+			Position:  [2]string{"50%", "5%"},
+			Color:     style.CaptionColor,
+			Weight:    "bold",
+			Alignment: "center",
+			Font:      style.Font,
+		})
+	}
 	config := koubouDefaultConfig{
 		Project: koubouProjectConfig{
 			Name:       "ASC Shots Frame",
@@ -332,19 +444,12 @@ func createDefaultKoubouConfig(
 			OutputSize: spec.OutputSize,
 		},
 		Screenshots: map[string]koubouDefaultScreenshotSpec{
-			"framed": {
-				Content: []koubouDefaultContentItem{
-					{
-						Type:     "image",
-						Asset:    absInputPath,
-						Position: [2]string{"50%", "50%"},
-						Scale:    1.0,
-						Frame:    true,
-					},
-				},
-			},
+			"framed": {Content: content},
 		},
 	}
+	if style.Background != nil {
+		config.Defaults = &koubouDefaults{Background: style.Background}
+	}
 
 	data, err := yaml.Marshal(config)
 	if err != nil {
@@ -661,9 +766,9 @@ func selectGeneratedScreenshot(configPath string, results []koubouGenerateResult
 	}
 
 	if len(failures) > 0 {
-		return "", fmt.Errorf("koubou generation failed: %s", strings.Join(failures, "; "))
+		return "", fmt.Errorf("screenshot generation failed: %s", strings.Join(failures, "; "))
 	}
-	return "", fmt.Errorf("koubou generation produced no successful output")
+	return "", fmt.Errorf("screenshot generation produced no successful output")
 }
 
 func copyFile(sourcePath, destinationPath string) error {
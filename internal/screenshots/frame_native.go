@@ -0,0 +1,352 @@
+package screenshots
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg" // register JPEG decoding for raw assets
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	xdraw "golang.org/x/image/draw"
+	"gopkg.in/yaml.v3"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+// FrameRenderer selects the engine used to compose framed screenshots.
+type FrameRenderer string
+
+const (
+	// FrameRendererNative composes screenshots in-process with bundled bezels.
+	FrameRendererNative FrameRenderer = "native"
+	// FrameRendererKoubou delegates rendering to the pinned kou binary.
+	FrameRendererKoubou FrameRenderer = "koubou"
+
+	defaultNativeOutputDir = "output"
+)
+
+var supportedFrameRenderers = []FrameRenderer{
+	FrameRendererNative,
+	FrameRendererKoubou,
+}
+
+// DefaultFrameRenderer returns the default frame renderer.
+func DefaultFrameRenderer() FrameRenderer {
+	return FrameRendererKoubou
+}
+
+// FrameRendererValues returns allowed --renderer values.
+func FrameRendererValues() []string {
+	values := make([]string, 0, len(supportedFrameRenderers))
+	for _, renderer := range supportedFrameRenderers {
+		values = append(values, string(renderer))
+	}
+	return values
+}
+
+// ParseFrameRenderer normalizes and validates a frame renderer value.
+func ParseFrameRenderer(raw string) (FrameRenderer, error) {
+	normalized := strings.ToLower(strings.TrimSpace(raw))
+	if normalized == "" {
+		return DefaultFrameRenderer(), nil
+	}
+	for _, allowed := range supportedFrameRenderers {
+		if FrameRenderer(normalized) == allowed {
+			return allowed, nil
+		}
+	}
+	return "", fmt.Errorf(
+		"unsupported frame renderer %q (allowed: %s)",
+		raw,
+		strings.Join(FrameRendererValues(), ", "),
+	)
+}
+
+// generateFromConfig renders every screenshot in a Koubou YAML config with the
+// selected renderer.
+func generateFromConfig(ctx context.Context, configPath string, renderer FrameRenderer) ([]koubouGenerateResult, error) {
+	if renderer == FrameRendererKoubou {
+		return runKoubouGenerate(ctx, configPath)
+	}
+	return renderNativeConfig(ctx, configPath)
+}
+
+type nativeFrameConfig struct {
+	Project struct {
+		OutputDir  string `yaml:"output_dir"`
+		Device     string `yaml:"device"`
+		OutputSize any    `yaml:"output_size"`
+	} `yaml:"project"`
+	Defaults struct {
+		Background *koubouBackground `yaml:"background"`
+	} `yaml:"defaults"`
+	// Screenshots is decoded node by node so output follows file order.
+	Screenshots yaml.Node `yaml:"screenshots"`
+}
+
+type nativeScreenshot struct {
+	Name string
+	Spec koubouDefaultScreenshotSpec
+}
+
+// renderNativeConfig renders the supported subset of a Koubou YAML config
+// without external tools. Per-screenshot failures are reported in the results,
+// matching kou generate.
+func renderNativeConfig(ctx context.Context, configPath string) ([]koubouGenerateResult, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+	var config nativeFrameConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parse config file: %w", err)
+	}
+
+	baseDir := filepath.Dir(configPath)
+	device, finish, err := resolveNativeDevice(config.Project.Device)
+	if err != nil {
+		return nil, err
+	}
+	width, height, ok := resolveKoubouOutputSize(config.Project.OutputSize)
+	if config.Project.OutputSize == nil {
+		width, height, ok = resolveKoubouOutputSize(frameDeviceKoubouSpecs[device].OutputSize)
+	}
+	if !ok || width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("unsupported output_size %v", config.Project.OutputSize)
+	}
+
+	shots, err := decodeNativeScreenshots(&config.Screenshots)
+	if err != nil {
+		return nil, err
+	}
+	if len(shots) == 0 {
+		return nil, fmt.Errorf("config defines no screenshots")
+	}
+
+	outputDir := strings.TrimSpace(config.Project.OutputDir)
+	if outputDir == "" {
+		outputDir = defaultNativeOutputDir
+	}
+	if !filepath.IsAbs(outputDir) {
+		outputDir = filepath.Join(baseDir, outputDir)
+	}
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return nil, fmt.Errorf("create output directory: %w", err)
+	}
+
+	profile, err := loadNativeDevice(device, finish)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]koubouGenerateResult, 0, len(shots))
+	for _, shot := range shots {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		background := shot.Spec.Background
+		if background == nil {
+			background = config.Defaults.Background
+		}
+		canvas, err := composeNativeScreenshot(baseDir, width, height, profile, background, shot.Spec.Content)
+		if err == nil {
+			outputPath := filepath.Join(outputDir, shot.Name+".png")
+			err = writeNativePNG(outputPath, canvas)
+			if err == nil {
+				results = append(results, koubouGenerateResult{Name: shot.Name, Path: outputPath, Success: true})
+				continue
+			}
+		}
+		results = append(results, koubouGenerateResult{Name: shot.Name, Error: err.Error()})
+	}
+	return results, nil
+}
+
+func decodeNativeScreenshots(node *yaml.Node) ([]nativeScreenshot, error) {
+	if node.Kind == 0 {
+		return nil, nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("parse config file: screenshots must be a mapping")
+	}
+	shots := make([]nativeScreenshot, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name := strings.TrimSpace(node.Content[i].Value)
+		if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+			return nil, fmt.Errorf("invalid screenshot name %q", node.Content[i].Value)
+		}
+		var spec koubouDefaultScreenshotSpec
+		if err := node.Content[i+1].Decode(&spec); err != nil {
+			return nil, fmt.Errorf("parse screenshot %q: %w", name, err)
+		}
+		shots = append(shots, nativeScreenshot{Name: name, Spec: spec})
+	}
+	return shots, nil
+}
+
+// resolveNativeDevice maps a Koubou frame name ("iPhone 17 Pro - Silver -
+// Portrait") or an asc device slug to a bundled bezel and optional finish.
+func resolveNativeDevice(frameRef string) (FrameDevice, *color.RGBA, error) {
+	trimmed := strings.TrimSpace(frameRef)
+	if trimmed == "" {
+		return DefaultFrameDevice(), nil, nil
+	}
+	if device, err := ParseFrameDevice(resolveFrameDeviceForConfig(trimmed, "")); err == nil {
+		return device, nativeFinishForFrameName(trimmed), nil
+	}
+	parts := strings.Split(trimmed, " - ")
+	if device, err := ParseFrameDevice(parts[0]); err == nil {
+		return device, nativeFinishForFrameName(trimmed), nil
+	}
+	return "", nil, fmt.Errorf(
+		"native renderer has no bezel for device %q (supported: %s); use --renderer koubou for other frames",
+		trimmed,
+		strings.Join(FrameDeviceValues(), ", "),
+	)
+}
+
+func nativeFinishForFrameName(frameName string) *color.RGBA {
+	parts := strings.Split(frameName, " - ")
+	if len(parts) < 2 {
+		return nil
+	}
+	finish, ok := nativeFinishColors[strings.ToLower(strings.TrimSpace(parts[1]))]
+	if !ok {
+		return nil
+	}
+	return &finish
+}
+
+func composeNativeScreenshot(
+	baseDir string,
+	width, height int,
+	profile nativeDevice,
+	background *koubouBackground,
+	content []koubouDefaultContentItem,
+) (*image.RGBA, error) {
+	canvas := image.NewRGBA(image.Rect(0, 0, width, height))
+	if err := fillNativeBackground(canvas, background); err != nil {
+		return nil, err
+	}
+
+	for index, item := range content {
+		var err error
+		switch strings.ToLower(strings.TrimSpace(item.Type)) {
+		case "image":
+			err = drawNativeImageItem(canvas, baseDir, profile, item)
+		case "text":
+			err = drawNativeTextItem(canvas, baseDir, item)
+		default:
+			err = fmt.Errorf("unsupported content type %q", item.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("content[%d]: %w", index, err)
+		}
+	}
+	return canvas, nil
+}
+
+func drawNativeImageItem(canvas *image.RGBA, baseDir string, profile nativeDevice, item koubouDefaultContentItem) error {
+	assetPath := strings.TrimSpace(item.Asset)
+	if assetPath == "" {
+		return fmt.Errorf("image asset is required")
+	}
+	if !filepath.IsAbs(assetPath) {
+		assetPath = filepath.Join(baseDir, assetPath)
+	}
+	asset, err := loadNativeImage(assetPath)
+	if err != nil {
+		return err
+	}
+
+	bounds := canvas.Bounds()
+	centerX, err := parseNativePosition(item.Position[0], bounds.Dx(), 0.5)
+	if err != nil {
+		return err
+	}
+	centerY, err := parseNativePosition(item.Position[1], bounds.Dy(), 0.5)
+	if err != nil {
+		return err
+	}
+	scale := item.Scale
+	if scale <= 0 {
+		scale = 1
+	}
+
+	if item.Frame {
+		deviceBounds := profile.bezel.Bounds()
+		fit := math.Min(float64(bounds.Dx())/float64(deviceBounds.Dx()), float64(bounds.Dy())/float64(deviceBounds.Dy()))
+		drawNativeDevice(canvas, profile, asset, centerX, centerY, math.Min(scale, fit))
+		return nil
+	}
+
+	assetBounds := asset.Bounds()
+	targetWidth := float64(assetBounds.Dx()) * scale
+	targetHeight := float64(assetBounds.Dy()) * scale
+	target := image.Rect(
+		int(math.Round(centerX-targetWidth/2)),
+		int(math.Round(centerY-targetHeight/2)),
+		int(math.Round(centerX+targetWidth/2)),
+		int(math.Round(centerY+targetHeight/2)),
+	)
+	xdraw.CatmullRom.Scale(canvas, target, asset, assetBounds, xdraw.Over, nil)
+	return nil
+}
+
+func loadNativeImage(path string) (image.Image, error) {
+	if err := asc.ValidateImageFile(path); err != nil {
+		return nil, fmt.Errorf("read image asset: %w", err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("read image asset: %w", err)
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("decode image asset %q: %w", path, err)
+	}
+	return img, nil
+}
+
+// parseNativePosition resolves "50%", "120" or "120px" against extent.
+func parseNativePosition(raw string, extent int, fallback float64) (float64, error) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return fallback * float64(extent), nil
+	}
+	if percent, ok := strings.CutSuffix(value, "%"); ok {
+		number, err := strconv.ParseFloat(strings.TrimSpace(percent), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid position %q", raw)
+		}
+		return number / 100 * float64(extent), nil
+	}
+	number, err := strconv.ParseFloat(strings.TrimSuffix(value, "px"), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid position %q", raw)
+	}
+	return number, nil
+}
+
+func writeNativePNG(path string, img image.Image) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create framed screenshot: %w", err)
+	}
+	if err := png.Encode(file, img); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("encode framed screenshot: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("write framed screenshot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package screenshots
+
+import (
+	"embed"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"sync"
+)
+
+// Bezels are bundled per FrameDevice as PNGs with a transparent screen. The
+// finish band is white so any frame color can be applied by tinting.
+//
+//go:embed bezels/*.png
+var nativeBezelFS embed.FS
+
+// nativeBezel describes a bundled bezel image.
+type nativeBezel struct {
+	// Screen is the screen area inside the bezel image, in its pixels.
+	Screen image.Rectangle
+	// ScreenRadius is the screen's corner radius, in bezel pixels.
+	ScreenRadius float64
+	// Finish is the default frame color.
+	Finish color.RGBA
+}
+
+var nativeBezels = map[FrameDevice]nativeBezel{
+	FrameDeviceIPhoneAir:   {Screen: image.Rect(65, 65, 65+1260, 65+2736), ScreenRadius: 176.4, Finish: nativeFinishColors["light gold"]},
+	FrameDeviceIPhone17Pro: {Screen: image.Rect(63, 63, 63+1206, 63+2622), ScreenRadius: 168.84, Finish: nativeFinishColors["silver"]},
+	FrameDeviceIPhone17PM:  {Screen: image.Rect(68, 68, 68+1320, 68+2868), ScreenRadius: 184.8, Finish: nativeFinishColors["silver"]},
+	FrameDeviceIPhone17:    {Screen: image.Rect(63, 63, 63+1206, 63+2622), ScreenRadius: 168.84, Finish: nativeFinishColors["teal"]},
+	FrameDeviceIPhone16e:   {Screen: image.Rect(60, 60, 60+1170, 60+2532), ScreenRadius: 163.8, Finish: nativeFinishColors["white"]},
+}
+
+// nativeFinishColors maps Koubou frame color names to bezel finishes.
+var nativeFinishColors = map[string]color.RGBA{
+	"black":         {R: 0x2b, G: 0x2b, B: 0x2d, A: 0xff},
+	"cloud white":   {R: 0xe9, G: 0xe8, B: 0xe4, A: 0xff},
+	"cosmic orange": {R: 0xd9, G: 0x6b, B: 0x2b, A: 0xff},
+	"deep blue":     {R: 0x33, G: 0x3d, B: 0x55, A: 0xff},
+	"lavender":      {R: 0xc9, G: 0xbd, B: 0xe0, A: 0xff},
+	"light gold":    {R: 0xe6, G: 0xd5, B: 0xb8, A: 0xff},
+	"mist blue":     {R: 0xa9, G: 0xbe, B: 0xd6, A: 0xff},
+	"sage":          {R: 0xb5, G: 0xc2, B: 0xa5, A: 0xff},
+	"silver":        {R: 0xd4, G: 0xd5, B: 0xd7, A: 0xff},
+	"sky blue":      {R: 0xbc, G: 0xd4, B: 0xe6, A: 0xff},
+	"space black":   {R: 0x32, G: 0x32, B: 0x34, A: 0xff},
+	"teal":          {R: 0x8c, G: 0xb8, B: 0xb5, A: 0xff},
+	"white":         {R: 0xf2, G: 0xf2, B: 0xf0, A: 0xff},
+}
+
+// nativeDevice is a bezel image tinted with a finish, ready to draw.
+type nativeDevice struct {
+	bezel        *image.RGBA
+	screen       image.Rectangle
+	screenRadius float64
+}
+
+var (
+	nativeBezelImagesMu sync.Mutex
+	nativeBezelImages   = map[FrameDevice]*image.RGBA{}
+)
+
+// loadNativeDevice returns the bundled bezel for device, tinted with finish
+// or the device's default finish when finish is nil.
+func loadNativeDevice(device FrameDevice, finish *color.RGBA) (nativeDevice, error) {
+	bezel, ok := nativeBezels[device]
+	if !ok {
+		return nativeDevice{}, fmt.Errorf("native renderer has no bezel for device %q", device)
+	}
+	template, err := loadNativeBezelImage(device)
+	if err != nil {
+		return nativeDevice{}, err
+	}
+	tint := bezel.Finish
+	if finish != nil {
+		tint = *finish
+	}
+	return nativeDevice{
+		bezel:        tintNativeBezel(template, tint),
+		screen:       bezel.Screen,
+		screenRadius: bezel.ScreenRadius,
+	}, nil
+}
+
+func loadNativeBezelImage(device FrameDevice) (*image.RGBA, error) {
+	nativeBezelImagesMu.Lock()
+	defer nativeBezelImagesMu.Unlock()
+	if img, ok := nativeBezelImages[device]; ok {
+		return img, nil
+	}
+	file, err := nativeBezelFS.Open("bezels/" + string(device) + ".png")
+	if err != nil {
+		return nil, fmt.Errorf("open bezel for %q: %w", device, err)
+	}
+	defer file.Close()
+	decoded, err := png.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("decode bezel for %q: %w", device, err)
+	}
+	img := image.NewRGBA(decoded.Bounds())
+	draw.Draw(img, img.Bounds(), decoded, decoded.Bounds().Min, draw.Src)
+	nativeBezelImages[device] = img
+	return img, nil
+}
+
+// tintNativeBezel multiplies the bezel's colors by finish, turning the white
+// finish band into the frame color and leaving the black border dark.
+func tintNativeBezel(template *image.RGBA, finish color.RGBA) *image.RGBA {
+	tinted := image.NewRGBA(template.Bounds())
+	for i := 0; i+3 < len(template.Pix); i += 4 {
+		tinted.Pix[i] = uint8(uint16(template.Pix[i]) * uint16(finish.R) / 0xff)
+		tinted.Pix[i+1] = uint8(uint16(template.Pix[i+1]) * uint16(finish.G) / 0xff)
+		tinted.Pix[i+2] = uint8(uint16(template.Pix[i+2]) * uint16(finish.B) / 0xff)
+		tinted.Pix[i+3] = template.Pix[i+3]
+	}
+	return tinted
+}
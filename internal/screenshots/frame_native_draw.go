@@ -0,0 +1,329 @@
+package screenshots
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	defaultTextColor    = "#000000"
+	defaultTextWidthPct = 0.9
+	defaultLineHeight   = 1.2
+)
+
+// drawNativeDevice draws the device's bezel centered at (centerX, centerY),
+// scaled by scale relative to its native pixels, with screenshot filling the
+// screen behind it.
+func drawNativeDevice(canvas *image.RGBA, device nativeDevice, screenshot image.Image, centerX, centerY, scale float64) {
+	bezelBounds := device.bezel.Bounds()
+	originX := centerX - float64(bezelBounds.Dx())*scale/2
+	originY := centerY - float64(bezelBounds.Dy())*scale/2
+	place := func(r image.Rectangle) image.Rectangle {
+		return image.Rect(
+			int(math.Round(originX+float64(r.Min.X)*scale)),
+			int(math.Round(originY+float64(r.Min.Y)*scale)),
+			int(math.Round(originX+float64(r.Max.X)*scale)),
+			int(math.Round(originY+float64(r.Max.Y)*scale)),
+		)
+	}
+
+	drawCover(canvas, place(device.screen), screenshot, device.screenRadius*scale)
+	xdraw.CatmullRom.Scale(canvas, place(bezelBounds), device.bezel, bezelBounds, xdraw.Over, nil)
+}
+
+// drawCover scales src to cover target, center-cropping any aspect mismatch,
+// and clips it to target's rounded corners.
+func drawCover(canvas *image.RGBA, target image.Rectangle, src image.Image, radius float64) {
+	srcBounds := src.Bounds()
+	coverScale := math.Max(
+		float64(target.Dx())/float64(srcBounds.Dx()),
+		float64(target.Dy())/float64(srcBounds.Dy()),
+	)
+	cropWidth := int(math.Round(float64(target.Dx()) / coverScale))
+	cropHeight := int(math.Round(float64(target.Dy()) / coverScale))
+	crop := image.Rect(0, 0, cropWidth, cropHeight).Add(image.Pt(
+		srcBounds.Min.X+(srcBounds.Dx()-cropWidth)/2,
+		srcBounds.Min.Y+(srcBounds.Dy()-cropHeight)/2,
+	))
+	scaled := image.NewRGBA(target)
+	xdraw.CatmullRom.Scale(scaled, target, src, crop, xdraw.Src, nil)
+	draw.DrawMask(canvas, target, scaled, target.Min, roundedMask{rect: target, radius: radius}, target.Min, draw.Over)
+}
+
+// roundedMask is an anti-aliased alpha mask for a rounded rectangle.
+type roundedMask struct {
+	rect   image.Rectangle
+	radius float64
+}
+
+func (m roundedMask) ColorModel() color.Model { return color.AlphaModel }
+
+func (m roundedMask) Bounds() image.Rectangle { return m.rect }
+
+func (m roundedMask) At(x, y int) color.Color {
+	halfWidth := float64(m.rect.Dx()) / 2
+	halfHeight := float64(m.rect.Dy()) / 2
+	radius := math.Min(m.radius, math.Min(halfWidth, halfHeight))
+	qx := math.Abs(float64(x)+0.5-float64(m.rect.Min.X)-halfWidth) - (halfWidth - radius)
+	qy := math.Abs(float64(y)+0.5-float64(m.rect.Min.Y)-halfHeight) - (halfHeight - radius)
+	outside := math.Hypot(math.Max(qx, 0), math.Max(qy, 0))
+	distance := outside + math.Min(math.Max(qx, qy), 0) - radius
+	coverage := math.Min(math.Max(0.5-distance, 0), 1)
+	return color.Alpha{A: uint8(math.Round(coverage * 0xff))}
+}
+
+// fillNativeBackground paints a solid, linear or radial background. Without a
+// background the canvas is white so the output stays opaque.
+func fillNativeBackground(canvas *image.RGBA, background *koubouBackground) error {
+	if background == nil {
+		draw.Draw(canvas, canvas.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+		return nil
+	}
+
+	rawColors := background.Colors
+	if len(rawColors) == 0 && strings.TrimSpace(background.Color) != "" {
+		rawColors = []string{background.Color}
+	}
+	if len(rawColors) == 0 {
+		return fmt.Errorf("background requires at least one color")
+	}
+	stops := make([]color.RGBA, 0, len(rawColors))
+	for _, raw := range rawColors {
+		parsed, err := parseFrameColor(raw)
+		if err != nil {
+			return fmt.Errorf("background: %w", err)
+		}
+		// Flatten onto white so translucent stops never leave alpha in the PNG.
+		stops = append(stops, flattenColor(parsed))
+	}
+
+	kind := strings.ToLower(strings.TrimSpace(background.Type))
+	if kind == "" {
+		kind = "solid"
+		if len(stops) > 1 {
+			kind = "linear"
+		}
+	}
+
+	bounds := canvas.Bounds()
+	width := float64(bounds.Dx())
+	height := float64(bounds.Dy())
+	switch kind {
+	case "solid":
+		draw.Draw(canvas, bounds, image.NewUniform(stops[0]), image.Point{}, draw.Src)
+	case "linear":
+		direction := 180.0
+		if background.Direction != nil {
+			direction = *background.Direction
+		}
+		// CSS-style angle: 0 points up, 90 right, 180 down.
+		radians := direction * math.Pi / 180
+		dx, dy := math.Sin(radians), -math.Cos(radians)
+		extent := math.Abs(width*dx) + math.Abs(height*dy)
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				projection := (float64(x)+0.5-width/2)*dx + (float64(y)+0.5-height/2)*dy
+				canvas.SetRGBA(x, y, gradientColor(stops, projection/extent+0.5))
+			}
+		}
+	case "radial":
+		maxDistance := math.Hypot(width/2, height/2)
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				distance := math.Hypot(float64(x)+0.5-width/2, float64(y)+0.5-height/2)
+				canvas.SetRGBA(x, y, gradientColor(stops, distance/maxDistance))
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported background type %q (allowed: solid, linear, radial)", background.Type)
+	}
+	return nil
+}
+
+func gradientColor(stops []color.RGBA, t float64) color.RGBA {
+	if len(stops) == 1 {
+		return stops[0]
+	}
+	t = math.Min(math.Max(t, 0), 1)
+	position := t * float64(len(stops)-1)
+	index := int(position)
+	if index >= len(stops)-1 {
+		return stops[len(stops)-1]
+	}
+	fraction := position - float64(index)
+	from, to := stops[index], stops[index+1]
+	mix := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + (float64(b)-float64(a))*fraction))
+	}
+	return color.RGBA{R: mix(from.R, to.R), G: mix(from.G, to.G), B: mix(from.B, to.B), A: 0xff}
+}
+
+func flattenColor(c color.RGBA) color.RGBA {
+	// c is non-premultiplied; blend over white.
+	alpha := float64(c.A) / 255
+	blend := func(channel uint8) uint8 {
+		return uint8(math.Round(float64(channel)*alpha + 255*(1-alpha)))
+	}
+	return color.RGBA{R: blend(c.R), G: blend(c.G), B: blend(c.B), A: 0xff}
+}
+
+func isDarkColor(c color.RGBA) bool {
+	luminance := 0.2126*float64(c.R) + 0.7152*float64(c.G) + 0.0722*float64(c.B)
+	return luminance < 128
+}
+
+// parseFrameColor parses #RGB, #RRGGBB or #RRGGBBAA into a non-premultiplied
+// color.
+func parseFrameColor(raw string) (color.RGBA, error) {
+	value := strings.TrimPrefix(strings.TrimSpace(raw), "#")
+	if len(value) == 3 {
+		value = string([]byte{value[0], value[0], value[1], value[1], value[2], value[2]})
+	}
+	if len(value) == 6 {
+		value += "ff"
+	}
+	if len(value) != 8 {
+		return color.RGBA{}, fmt.Errorf("invalid color %q (expected #RRGGBB)", raw)
+	}
+	parsed, err := strconv.ParseUint(value, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid color %q (expected #RRGGBB)", raw)
+	}
+	return color.RGBA{
+		R: uint8(parsed >> 24),
+		G: uint8(parsed >> 16),
+		B: uint8(parsed >> 8),
+		A: uint8(parsed),
+	}, nil
+}
+
+// drawNativeTextItem renders a caption. Position is the top anchor of the
+// text block: its center for center alignment, otherwise the left or right
+// edge.
+func drawNativeTextItem(canvas *image.RGBA, baseDir string, item koubouDefaultContentItem) error {
+	text := strings.TrimSpace(item.Content)
+	if text == "" {
+		return nil
+	}
+	bounds := canvas.Bounds()
+	size := item.Size
+	if size <= 0 {
+		size = math.Round(float64(bounds.Dx()) * 0.06)
+	}
+	face, err := loadNativeFontFace(baseDir, item.Font, item.Weight, size)
+	if err != nil {
+		return err
+	}
+	defer face.Close()
+
+	anchorX, err := parseNativePosition(item.Position[0], bounds.Dx(), 0.5)
+	if err != nil {
+		return err
+	}
+	top, err := parseNativePosition(item.Position[1], bounds.Dy(), 0.1)
+	if err != nil {
+		return err
+	}
+	maxWidth := defaultTextWidthPct * float64(bounds.Dx())
+	if strings.TrimSpace(item.MaxWidth) != "" {
+		maxWidth, err = parseNativePosition(item.MaxWidth, bounds.Dx(), defaultTextWidthPct)
+		if err != nil {
+			return fmt.Errorf("invalid max_width %q", item.MaxWidth)
+		}
+	}
+	rawColor := item.Color
+	if strings.TrimSpace(rawColor) == "" {
+		rawColor = defaultTextColor
+	}
+	textColor, err := parseFrameColor(rawColor)
+	if err != nil {
+		return err
+	}
+	lineHeight := item.LineHeight
+	if lineHeight <= 0 {
+		lineHeight = defaultLineHeight
+	}
+
+	drawer := &font.Drawer{Dst: canvas, Src: image.NewUniform(textColor), Face: face}
+	ascent := face.Metrics().Ascent.Round()
+	for index, line := range wrapNativeText(drawer, text, maxWidth) {
+		lineWidth := float64(drawer.MeasureString(line).Round())
+		x := anchorX - lineWidth/2
+		switch strings.ToLower(strings.TrimSpace(item.Alignment)) {
+		case "left":
+			x = anchorX
+		case "right":
+			x = anchorX - lineWidth
+		}
+		y := top + float64(ascent) + float64(index)*size*lineHeight
+		drawer.Dot = fixed.P(int(math.Round(x)), int(math.Round(y)))
+		drawer.DrawString(line)
+	}
+	return nil
+}
+
+func wrapNativeText(drawer *font.Drawer, text string, maxWidth float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+		line := words[0]
+		for _, word := range words[1:] {
+			candidate := line + " " + word
+			if float64(drawer.MeasureString(candidate).Round()) > maxWidth {
+				lines = append(lines, line)
+				line = word
+				continue
+			}
+			line = candidate
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// loadNativeFontFace opens a TTF/OTF font file when fontPath is set, otherwise
+// the bundled Go font matching weight.
+func loadNativeFontFace(baseDir, fontPath, weight string, size float64) (font.Face, error) {
+	data := goregular.TTF
+	if strings.EqualFold(strings.TrimSpace(weight), "bold") {
+		data = gobold.TTF
+	}
+	if path := strings.TrimSpace(fontPath); path != "" {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		fontData, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read font: %w", err)
+		}
+		data = fontData
+	}
+
+	parsed, err := opentype.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse font: %w", err)
+	}
+	face, err := opentype.NewFace(parsed, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingNone})
+	if err != nil {
+		return nil, fmt.Errorf("load font: %w", err)
+	}
+	return face, nil
+}
@@ -0,0 +1,282 @@
+package screenshots
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseFrameRenderer(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    FrameRenderer
+		wantErr bool
+	}{
+		{raw: "", want: FrameRendererKoubou},
+		{raw: " Koubou ", want: FrameRendererKoubou},
+		{raw: "native", want: FrameRendererNative},
+		{raw: "imagemagick", wantErr: true},
+	}
+
+	for _, test := range tests {
+		got, err := ParseFrameRenderer(test.raw)
+		if test.wantErr {
+			if err == nil {
+				t.Fatalf("ParseFrameRenderer(%q) expected error", test.raw)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("ParseFrameRenderer(%q) error = %v", test.raw, err)
+		}
+		if got != test.want {
+			t.Fatalf("ParseFrameRenderer(%q) = %q, want %q", test.raw, got, test.want)
+		}
+	}
+}
+
+func TestParseFrameColor(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    color.RGBA
+		wantErr bool
+	}{
+		{raw: "#fff", want: color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}},
+		{raw: "#1E3A8A", want: color.RGBA{R: 0x1e, G: 0x3a, B: 0x8a, A: 0xff}},
+		{raw: "00000080", want: color.RGBA{A: 0x80}},
+		{raw: "blue", wantErr: true},
+	}
+
+	for _, test := range tests {
+		got, err := parseFrameColor(test.raw)
+		if test.wantErr {
+			if err == nil {
+				t.Fatalf("parseFrameColor(%q) expected error", test.raw)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("parseFrameColor(%q) error = %v", test.raw, err)
+		}
+		if got != test.want {
+			t.Fatalf("parseFrameColor(%q) = %+v, want %+v", test.raw, got, test.want)
+		}
+	}
+}
+
+func TestResolveNativeDevice(t *testing.T) {
+	device, finish, err := resolveNativeDevice("iPhone 17 Pro - Silver - Portrait")
+	if err != nil {
+		t.Fatalf("resolveNativeDevice() error = %v", err)
+	}
+	if device != FrameDeviceIPhone17Pro || finish == nil || *finish != nativeFinishColors["silver"] {
+		t.Fatalf("unexpected device %q finish %+v", device, finish)
+	}
+
+	device, finish, err = resolveNativeDevice("iPhone 17 Pro Max - Cosmic Orange - Portrait")
+	if err != nil {
+		t.Fatalf("resolveNativeDevice() error = %v", err)
+	}
+	if device != FrameDeviceIPhone17PM || finish == nil || *finish != nativeFinishColors["cosmic orange"] {
+		t.Fatalf("unexpected device %q finish %+v", device, finish)
+	}
+
+	if device, _, err = resolveNativeDevice("iphone-16e"); err != nil || device != FrameDeviceIPhone16e {
+		t.Fatalf("resolveNativeDevice(slug) = %q, %v", device, err)
+	}
+	if _, _, err := resolveNativeDevice("iPad Pro 13 - Space Black - Portrait"); err == nil {
+		t.Fatal("expected error for device without bundled bezel")
+	}
+}
+
+func TestLoadNativeDevice_BundlesEveryFrameDevice(t *testing.T) {
+	for _, value := range FrameDeviceValues() {
+		device, err := loadNativeDevice(FrameDevice(value), nil)
+		if err != nil {
+			t.Fatalf("loadNativeDevice(%q) error = %v", value, err)
+		}
+		bounds := device.bezel.Bounds()
+		if !device.screen.In(bounds) {
+			t.Fatalf("%s: screen %v outside bezel %v", value, device.screen, bounds)
+		}
+		center := image.Pt((device.screen.Min.X+device.screen.Max.X)/2, (device.screen.Min.Y+device.screen.Max.Y)/2)
+		if alpha := device.bezel.RGBAAt(center.X, center.Y).A; alpha != 0 {
+			t.Fatalf("%s: expected transparent screen center, got alpha %d", value, alpha)
+		}
+		if alpha := device.bezel.RGBAAt(bounds.Dx()/2, device.screen.Min.Y-1).A; alpha != 0xff {
+			t.Fatalf("%s: expected opaque border above the screen, got alpha %d", value, alpha)
+		}
+	}
+
+	white := color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	orange := nativeFinishColors["cosmic orange"]
+	plain, err := loadNativeDevice(FrameDeviceIPhone17PM, &white)
+	if err != nil {
+		t.Fatalf("loadNativeDevice() error = %v", err)
+	}
+	tinted, err := loadNativeDevice(FrameDeviceIPhone17PM, &orange)
+	if err != nil {
+		t.Fatalf("loadNativeDevice() error = %v", err)
+	}
+	// The outer band of the bezel, inside the side buttons, is the finish.
+	x, y := 20, plain.bezel.Bounds().Dy()/2
+	if got := plain.bezel.RGBAAt(x, y); got != white {
+		t.Fatalf("untinted finish = %v, want %v", got, white)
+	}
+	if got := tinted.bezel.RGBAAt(x, y); got != orange {
+		t.Fatalf("tinted finish = %v, want %v", got, orange)
+	}
+}
+
+func TestFrame_NativeRendererInputMode(t *testing.T) {
+	t.Setenv("PATH", t.TempDir()) // kou must not be needed
+
+	rawPath := filepath.Join(t.TempDir(), "raw.png")
+	writeFrameTestPNG(t, rawPath, makeSolidFrameTestImage(1206, 2622, color.RGBA{R: 0xff, A: 0xff}))
+
+	outputPath := filepath.Join(t.TempDir(), "framed", "home.png")
+	result, err := Frame(context.Background(), FrameRequest{
+		InputPath:  rawPath,
+		OutputPath: outputPath,
+		Device:     string(FrameDeviceIPhone17Pro),
+		Renderer:   string(FrameRendererNative),
+		Background: "#00FF00",
+		Caption:    "Ship faster",
+	})
+	if err != nil {
+		t.Fatalf("Frame() error = %v", err)
+	}
+	if result.Width != 1290 || result.Height != 2796 || !result.Normalized {
+		t.Fatalf("unexpected result dimensions: %+v", result)
+	}
+	if result.DisplayType != "APP_IPHONE_67" || result.Device != string(FrameDeviceIPhone17Pro) {
+		t.Fatalf("unexpected result metadata: %+v", result)
+	}
+
+	img := readFrameTestPNG(t, result.Path)
+	assertFrameTestColor(t, img, 2, 2790, color.RGBA{G: 0xff, A: 0xff})
+	assertFrameTestColor(t, img, 645, 1600, color.RGBA{R: 0xff, A: 0xff})
+
+	// Caption pixels are drawn in the top band above the device.
+	captionPixels := 0
+	for y := 0; y < 400; y++ {
+		for x := 0; x < img.Bounds().Dx(); x++ {
+			if color.RGBAModel.Convert(img.At(x, y)) != (color.RGBA{G: 0xff, A: 0xff}) {
+				captionPixels++
+			}
+		}
+	}
+	if captionPixels == 0 {
+		t.Fatal("expected caption pixels in the top band")
+	}
+}
+
+func TestRenderNativeConfig_FollowsFileOrderAndReportsItemErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFrameTestPNG(t, filepath.Join(dir, "raw", "home.png"), makeSolidFrameTestImage(100, 200, color.RGBA{B: 0xff, A: 0xff}))
+
+	configPath := filepath.Join(dir, "frame.yaml")
+	config := `project:
+  device: "iPhone 16e - White - Portrait"
+  output_size: [600, 1200]
+  output_dir: "./out"
+defaults:
+  background:
+    type: linear
+    colors: ["#000000", "#FFFFFF"]
+screenshots:
+  zeta:
+    content:
+      - type: "image"
+        asset: "raw/home.png"
+        position: ["50%", "50%"]
+        scale: 0.3
+        frame: true
+  alpha:
+    content:
+      - type: "image"
+        asset: "raw/missing.png"
+        frame: true
+`
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	results, err := renderNativeConfig(context.Background(), configPath)
+	if err != nil {
+		t.Fatalf("renderNativeConfig() error = %v", err)
+	}
+	if len(results) != 2 || results[0].Name != "zeta" || results[1].Name != "alpha" {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if !results[0].Success || results[0].Path != filepath.Join(dir, "out", "zeta.png") {
+		t.Fatalf("expected zeta to render, got %+v", results[0])
+	}
+	if results[1].Success || results[1].Error == "" {
+		t.Fatalf("expected alpha to fail, got %+v", results[1])
+	}
+
+	img := readFrameTestPNG(t, results[0].Path)
+	if img.Bounds().Dx() != 600 || img.Bounds().Dy() != 1200 {
+		t.Fatalf("unexpected output size %v", img.Bounds())
+	}
+	top := color.RGBAModel.Convert(img.At(1, 1)).(color.RGBA)
+	bottom := color.RGBAModel.Convert(img.At(1, 1198)).(color.RGBA)
+	if top.R > 0x10 || bottom.R < 0xf0 {
+		t.Fatalf("expected top-to-bottom gradient, got top %+v bottom %+v", top, bottom)
+	}
+	assertFrameTestColor(t, img, 300, 600, color.RGBA{B: 0xff, A: 0xff})
+}
+
+func TestRenderNativeConfig_RejectsUnknownDevice(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "frame.yaml")
+	config := `project:
+  device: "Galaxy S25 - Black - Portrait"
+screenshots:
+  home:
+    content: []
+`
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if _, err := renderNativeConfig(context.Background(), configPath); err == nil {
+		t.Fatal("expected unsupported device error")
+	}
+}
+
+func makeSolidFrameTestImage(width, height int, fill color.RGBA) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = fill.R, fill.G, fill.B, fill.A
+	}
+	return img
+}
+
+func readFrameTestPNG(t *testing.T, path string) image.Image {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open(%q) error: %v", path, err)
+	}
+	defer file.Close()
+
+	img, err := png.Decode(file)
+	if err != nil {
+		t.Fatalf("png.Decode(%q) error: %v", path, err)
+	}
+	return img
+}
+
+func assertFrameTestColor(t *testing.T, img image.Image, x, y int, want color.RGBA) {
+	t.Helper()
+
+	got := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
+	if got != want {
+		t.Fatalf("pixel (%d,%d) = %+v, want %+v", x, y, got, want)
+	}
+}
@@ -202,6 +202,7 @@ screenshots:
 	result, err := Frame(context.Background(), FrameRequest{
 		ConfigPath: configPath,
 		Device:     string(DefaultFrameDevice()),
+		Renderer:   string(FrameRendererKoubou),
 	})
 	if err != nil {
 		t.Fatalf("Frame() error = %v", err)
@@ -225,6 +226,7 @@ func TestFrame_InputModeCleansTemporaryKoubouDirectory(t *testing.T) {
 		InputPath:  rawPath,
 		OutputPath: outputPath,
 		Device:     string(DefaultFrameDevice()),
+		Renderer:   string(FrameRendererKoubou),
 	})
 	if err != nil {
 		t.Fatalf("Frame() error = %v", err)
@@ -16,14 +16,16 @@ import (
 // WatchOptions configures optional review regeneration after each watch cycle.
 type WatchOptions struct {
 	// ReviewOutputDir, when non-empty, triggers automatic review HTML/manifest
-	// regeneration after each successful kou generate cycle.
+	// regeneration after each successful generation cycle.
 	ReviewOutputDir string
 	// ReviewRawDir is the raw screenshots directory for review generation.
 	ReviewRawDir string
+	// Renderer selects koubou (default) or native rendering.
+	Renderer FrameRenderer
 }
 
 // WatchAndRegenerate watches a Koubou YAML config file (and the raw asset
-// directories it references) for changes, then re-runs kou generate on each
+// directories it references) for changes, then re-renders the config on each
 // change.  It blocks until ctx is cancelled.
 func WatchAndRegenerate(ctx context.Context, configPath string, debounce time.Duration, onCycle func(results []WatchCycleResult, err error), opts *WatchOptions) error {
 	absConfig, err := filepath.Abs(configPath)
@@ -62,6 +64,11 @@ func WatchAndRegenerate(ctx context.Context, configPath string, debounce time.Du
 	fmt.Fprintf(os.Stderr, "Watching %s for changes (debounce %s)…\n", absConfig, debounce)
 	fmt.Fprintf(os.Stderr, "Press Ctrl-C to stop.\n")
 
+	renderer := DefaultFrameRenderer()
+	if opts != nil && opts.Renderer != "" {
+		renderer = opts.Renderer
+	}
+
 	// Resolve review options once up front.
 	var reviewReq *ReviewRequest
 	if opts != nil && opts.ReviewOutputDir != "" {
@@ -82,9 +89,9 @@ func WatchAndRegenerate(ctx context.Context, configPath string, debounce time.Du
 	}
 
 	// Run one initial generation so the user sees output immediately.
-	runGeneration(ctx, absConfig, reviewReq, onCycle)
+	runGeneration(ctx, absConfig, renderer, reviewReq, onCycle)
 	coalescer := newGenerationCoalescer(func() {
-		runGeneration(ctx, absConfig, reviewReq, onCycle)
+		runGeneration(ctx, absConfig, renderer, reviewReq, onCycle)
 	})
 
 	var timer *time.Timer
@@ -160,8 +167,8 @@ type WatchCycleResult struct {
 	Error   string `json:"error,omitempty"`
 }
 
-func runGeneration(ctx context.Context, configPath string, reviewReq *ReviewRequest, onCycle func([]WatchCycleResult, error)) {
-	results, err := runKoubouGenerate(ctx, configPath)
+func runGeneration(ctx context.Context, configPath string, renderer FrameRenderer, reviewReq *ReviewRequest, onCycle func([]WatchCycleResult, error)) {
+	results, err := generateFromConfig(ctx, configPath, renderer)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "generation error: %v\n", err)
 		if onCycle != nil {
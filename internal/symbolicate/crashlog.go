@@ -0,0 +1,207 @@
+package symbolicate
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// 0x100f48000 -        0x100f53fff MyApp arm64  <1a2b3c4d...> /private/var/.../MyApp
+	binaryImagePattern = regexp.MustCompile(`^\s*(0x[0-9a-fA-F]+)\s*-\s*(0x[0-9a-fA-F]+)\s+\+?(.+?)\s+(\S+)\s+<([0-9a-fA-F-]{32,36})>`)
+	// 0   MyApp                         0x0000000100f4c9a8 0x100f48000 + 18856
+	framePattern = regexp.MustCompile(`^(\d+\s+.+?\s+(0x[0-9a-fA-F]+)\s+)(\S.*?\s\+\s\d+.*)$`)
+)
+
+// Symbolicator resolves crash report frames against loaded dSYM images.
+type Symbolicator struct {
+	images map[string]*Image
+}
+
+// New returns a Symbolicator for images, keyed by UUID.
+func New(images []*Image) *Symbolicator {
+	s := &Symbolicator{images: make(map[string]*Image, len(images))}
+	for _, image := range images {
+		s.images[normalizeUUID(image.UUID)] = image
+	}
+	return s
+}
+
+// Result summarizes one symbolicated crash report.
+type Result struct {
+	// Text is the crash report with resolved frames rewritten.
+	Text string
+	// Frames counts the stack frames that belong to a listed binary image.
+	Frames int
+	// Symbolicated counts the frames that were resolved.
+	Symbolicated int
+	// MissingImages lists binary images with frames but no matching dSYM,
+	// sorted by name.
+	MissingImages []MissingImage
+}
+
+// MissingImage is a binary image without a matching dSYM.
+type MissingImage struct {
+	Name string
+	UUID string
+}
+
+type binaryImage struct {
+	start, end uint64
+	name       string
+	uuid       string
+}
+
+// Symbolicate rewrites the stack frames of a text crash report. Frames are
+// matched to binary images by address and to dSYMs by UUID; frames that
+// cannot be resolved are left untouched.
+func (s *Symbolicator) Symbolicate(report string) (Result, error) {
+	images := parseBinaryImages(report)
+	if len(images) == 0 {
+		return Result{}, fmt.Errorf("no Binary Images section found; only text crash reports are supported")
+	}
+
+	result := Result{}
+	missing := map[string]MissingImage{}
+	lines := strings.SplitAfter(report, "\n")
+	for i, line := range lines {
+		body := strings.TrimRight(line, "\r\n")
+		match := framePattern.FindStringSubmatch(body)
+		if match == nil {
+			continue
+		}
+		addr, err := strconv.ParseUint(match[2], 0, 64)
+		if err != nil {
+			continue
+		}
+		image := findImage(images, addr)
+		if image == nil {
+			continue
+		}
+		result.Frames++
+
+		dsym := s.images[image.uuid]
+		if dsym == nil {
+			missing[image.uuid] = MissingImage{Name: image.name, UUID: image.uuid}
+			continue
+		}
+		frame, ok := dsym.Lookup(addr - image.start)
+		if !ok {
+			continue
+		}
+		result.Symbolicated++
+		lines[i] = match[1] + formatFrame(frame) + line[len(body):]
+	}
+
+	result.Text = strings.Join(lines, "")
+	for _, image := range missing {
+		result.MissingImages = append(result.MissingImages, image)
+	}
+	sort.Slice(result.MissingImages, func(i, j int) bool {
+		return result.MissingImages[i].Name < result.MissingImages[j].Name
+	})
+	return result, nil
+}
+
+// formatFrame matches atos output: "function + offset (File.swift:42)".
+func formatFrame(frame Frame) string {
+	text := fmt.Sprintf("%s + %d", frame.Function, frame.Offset)
+	if frame.File != "" && frame.Line > 0 {
+		text += fmt.Sprintf(" (%s:%d)", frame.File, frame.Line)
+	}
+	return text
+}
+
+func parseBinaryImages(report string) []binaryImage {
+	var images []binaryImage
+	inSection := false
+	scanner := bufio.NewScanner(strings.NewReader(report))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(strings.TrimSpace(line), "Binary Images:") {
+			inSection = true
+			continue
+		}
+		if !inSection {
+			continue
+		}
+		match := binaryImagePattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		start, err1 := strconv.ParseUint(match[1], 0, 64)
+		end, err2 := strconv.ParseUint(match[2], 0, 64)
+		if err1 != nil || err2 != nil || end < start {
+			continue
+		}
+		images = append(images, binaryImage{
+			start: start,
+			end:   end,
+			name:  match[3],
+			uuid:  normalizeUUID(match[5]),
+		})
+	}
+	sort.Slice(images, func(i, j int) bool { return images[i].start < images[j].start })
+	return images
+}
+
+func findImage(images []binaryImage, addr uint64) *binaryImage {
+	i := sort.Search(len(images), func(i int) bool { return images[i].start > addr }) - 1
+	if i < 0 || addr > images[i].end {
+		return nil
+	}
+	return &images[i]
+}
+
+// ExtractReports returns the crash reports in data. Plain text is returned
+// as-is; JSON from 'asc testflight beta-crash-logs get' (a single resource
+// or a list) yields the logText of each resource.
+func ExtractReports(data []byte) ([]string, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return []string{string(data)}, nil
+	}
+
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(trimmed, &envelope); err != nil || len(envelope.Data) == 0 {
+		// Not an API response, e.g. a JSON .ips report.
+		return nil, fmt.Errorf("unsupported JSON crash report; export the text crash log instead")
+	}
+
+	type resource struct {
+		Attributes struct {
+			LogText string `json:"logText"`
+		} `json:"attributes"`
+	}
+	var resources []resource
+	if envelope.Data[0] == '[' {
+		if err := json.Unmarshal(envelope.Data, &resources); err != nil {
+			return nil, fmt.Errorf("invalid crash log response: %w", err)
+		}
+	} else {
+		var single resource
+		if err := json.Unmarshal(envelope.Data, &single); err != nil {
+			return nil, fmt.Errorf("invalid crash log response: %w", err)
+		}
+		resources = []resource{single}
+	}
+
+	reports := make([]string, 0, len(resources))
+	for _, r := range resources {
+		if strings.TrimSpace(r.Attributes.LogText) != "" {
+			reports = append(reports, r.Attributes.LogText)
+		}
+	}
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("response contains no logText")
+	}
+	return reports, nil
+}
@@ -0,0 +1,301 @@
+// Package symbolicate rewrites Apple crash reports using the DWARF debug
+// information in dSYM bundles.
+//
+// It only relies on debug/macho and debug/dwarf, so crash logs can be
+// symbolicated on any platform without Xcode's atos.
+package symbolicate
+
+import (
+	"debug/dwarf"
+	"debug/macho"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const loadCmdUUID macho.LoadCmd = 0x1b
+
+// Image is the debug information for one architecture of a binary.
+type Image struct {
+	// UUID is the lowercase hex LC_UUID without dashes, as printed in the
+	// Binary Images section of a crash report.
+	UUID string
+	// Arch is the CPU architecture, e.g. arm64.
+	Arch string
+	// Path is the Mach-O file the image was read from.
+	Path string
+
+	textAddr  uint64
+	dwarf     *dwarf.Data
+	functions []function
+	symbols   []symbol
+}
+
+// Frame is a resolved address.
+type Frame struct {
+	Function string
+	// Offset is the distance from the start of Function in bytes.
+	Offset uint64
+	// File is the source file name without its directory, if known.
+	File string
+	Line int
+}
+
+type function struct {
+	low, high uint64
+	name      string
+	unit      *dwarf.Entry
+}
+
+type symbol struct {
+	addr uint64
+	name string
+}
+
+// LoadDSYM reads every Mach-O image under path. path may be a .dSYM bundle,
+// a directory containing dSYM bundles (such as an unzipped dSYMs.zip), or a
+// Mach-O file. Universal binaries contribute one image per architecture.
+func LoadDSYM(path string) ([]*Image, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return loadMachO(path)
+	}
+
+	var images []*Image
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() || !strings.HasSuffix(filepath.Dir(p), filepath.Join("Contents", "Resources", "DWARF")) {
+			return nil
+		}
+		loaded, err := loadMachO(p)
+		if err != nil {
+			return err
+		}
+		images = append(images, loaded...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(images) == 0 {
+		return nil, fmt.Errorf("no DWARF files found in %s", path)
+	}
+	return images, nil
+}
+
+func loadMachO(path string) ([]*Image, error) {
+	if fat, err := macho.OpenFat(path); err == nil {
+		defer fat.Close()
+		images := make([]*Image, 0, len(fat.Arches))
+		for _, arch := range fat.Arches {
+			image, err := newImage(path, arch.File)
+			if err != nil {
+				return nil, err
+			}
+			images = append(images, image)
+		}
+		return images, nil
+	} else if !errors.Is(err, macho.ErrNotFat) {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	file, err := macho.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	defer file.Close()
+	image, err := newImage(path, file)
+	if err != nil {
+		return nil, err
+	}
+	return []*Image{image}, nil
+}
+
+func newImage(path string, file *macho.File) (*Image, error) {
+	image := &Image{Path: path, Arch: archName(file.Cpu, file.SubCpu)}
+	for _, load := range file.Loads {
+		raw := load.Raw()
+		if len(raw) >= 24 && macho.LoadCmd(file.ByteOrder.Uint32(raw)) == loadCmdUUID {
+			image.UUID = hex.EncodeToString(raw[8:24])
+			break
+		}
+	}
+	if image.UUID == "" {
+		return nil, fmt.Errorf("%s (%s): missing LC_UUID", path, image.Arch)
+	}
+	if text := file.Segment("__TEXT"); text != nil {
+		image.textAddr = text.Addr
+	}
+
+	data, err := file.DWARF()
+	if err != nil {
+		return nil, fmt.Errorf("%s (%s): %w", path, image.Arch, err)
+	}
+	image.dwarf = data
+	if err := image.loadFunctions(); err != nil {
+		return nil, fmt.Errorf("%s (%s): %w", path, image.Arch, err)
+	}
+	image.loadSymbols(file)
+	return image, nil
+}
+
+// loadFunctions indexes every subprogram with code by address range.
+func (img *Image) loadFunctions() error {
+	reader := img.dwarf.Reader()
+	var unit *dwarf.Entry
+	for {
+		entry, err := reader.Next()
+		if err != nil {
+			return err
+		}
+		if entry == nil {
+			break
+		}
+		switch entry.Tag {
+		case dwarf.TagCompileUnit:
+			unit = entry
+			continue
+		case dwarf.TagSubprogram:
+		default:
+			continue
+		}
+
+		ranges, err := img.dwarf.Ranges(entry)
+		if err != nil || len(ranges) == 0 {
+			continue
+		}
+		name := img.functionName(entry)
+		if name == "" {
+			continue
+		}
+		for _, r := range ranges {
+			if r[1] > r[0] {
+				img.functions = append(img.functions, function{low: r[0], high: r[1], name: name, unit: unit})
+			}
+		}
+	}
+	sort.Slice(img.functions, func(i, j int) bool { return img.functions[i].low < img.functions[j].low })
+	return nil
+}
+
+// functionName prefers the source name and follows specification and
+// abstract origin references used for methods and inlined definitions.
+func (img *Image) functionName(entry *dwarf.Entry) string {
+	for range 4 {
+		if name, ok := entry.Val(dwarf.AttrName).(string); ok && name != "" {
+			return name
+		}
+		if name, ok := entry.Val(dwarf.AttrLinkageName).(string); ok && name != "" {
+			return name
+		}
+		ref, ok := entry.Val(dwarf.AttrSpecification).(dwarf.Offset)
+		if !ok {
+			ref, ok = entry.Val(dwarf.AttrAbstractOrigin).(dwarf.Offset)
+		}
+		if !ok {
+			return ""
+		}
+		reader := img.dwarf.Reader()
+		reader.Seek(ref)
+		next, err := reader.Next()
+		if err != nil || next == nil {
+			return ""
+		}
+		entry = next
+	}
+	return ""
+}
+
+// loadSymbols keeps defined section symbols as a fallback for code without
+// DWARF entries.
+func (img *Image) loadSymbols(file *macho.File) {
+	if file.Symtab == nil {
+		return
+	}
+	const (
+		nStab = 0xe0
+		nType = 0x0e
+		nSect = 0x0e
+	)
+	for _, sym := range file.Symtab.Syms {
+		if sym.Type&nStab != 0 || sym.Type&nType != nSect || sym.Name == "" {
+			continue
+		}
+		img.symbols = append(img.symbols, symbol{addr: sym.Value, name: strings.TrimPrefix(sym.Name, "_")})
+	}
+	sort.Slice(img.symbols, func(i, j int) bool { return img.symbols[i].addr < img.symbols[j].addr })
+}
+
+// Lookup resolves an offset from the image's load address, as printed in
+// crash reports ("0x100f48000 + 18856").
+func (img *Image) Lookup(offset uint64) (Frame, bool) {
+	addr := img.textAddr + offset
+
+	i := sort.Search(len(img.functions), func(i int) bool { return img.functions[i].low > addr }) - 1
+	for ; i >= 0; i-- {
+		fn := img.functions[i]
+		if addr >= fn.high {
+			// Ranges may nest, so keep looking at earlier starts.
+			continue
+		}
+		frame := Frame{Function: fn.name, Offset: addr - fn.low}
+		frame.File, frame.Line = img.line(fn.unit, addr)
+		return frame, true
+	}
+
+	j := sort.Search(len(img.symbols), func(j int) bool { return img.symbols[j].addr > addr }) - 1
+	if j >= 0 {
+		sym := img.symbols[j]
+		return Frame{Function: sym.name, Offset: addr - sym.addr}, true
+	}
+	return Frame{}, false
+}
+
+func (img *Image) line(unit *dwarf.Entry, addr uint64) (string, int) {
+	if unit == nil {
+		return "", 0
+	}
+	reader, err := img.dwarf.LineReader(unit)
+	if err != nil || reader == nil {
+		return "", 0
+	}
+	var entry dwarf.LineEntry
+	if err := reader.SeekPC(addr, &entry); err != nil || entry.File == nil {
+		return "", 0
+	}
+	return filepath.Base(strings.ReplaceAll(entry.File.Name, "\\", "/")), entry.Line
+}
+
+func archName(cpu macho.Cpu, subCPU uint32) string {
+	switch cpu {
+	case macho.CpuArm64:
+		// CPU_SUBTYPE_ARM64E, ignoring the capability bits.
+		if subCPU&0x00ffffff == 2 {
+			return "arm64e"
+		}
+		return "arm64"
+	case macho.CpuArm:
+		return "armv7"
+	case macho.CpuAmd64:
+		return "x86_64"
+	case macho.Cpu386:
+		return "i386"
+	default:
+		return strings.ToLower(cpu.String())
+	}
+}
+
+// normalizeUUID lowercases a UUID and strips dashes.
+func normalizeUUID(value string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(value), "-", ""))
+}
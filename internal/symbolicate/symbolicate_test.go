@@ -0,0 +1,121 @@
+package symbolicate
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testdata/hello-amd64-debug.base64 is the dSYM companion of hello.c from
+// Go's debug/macho test data: main spans 0x100000f6a-0x100000f81.
+const helloUUID = "220efad905598307f95e9f873725396f"
+
+func writeDSYM(t *testing.T) string {
+	t.Helper()
+	encoded, err := os.ReadFile(filepath.Join("testdata", "hello-amd64-debug.base64"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(string(encoded)), ""))
+	if err != nil {
+		t.Fatalf("decode fixture: %v", err)
+	}
+	bundle := filepath.Join(t.TempDir(), "hello.dSYM")
+	dwarfDir := filepath.Join(bundle, "Contents", "Resources", "DWARF")
+	if err := os.MkdirAll(dwarfDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dwarfDir, "hello"), data, 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+	return bundle
+}
+
+const crashReport = `Incident Identifier: 6A1B2C3D-0000-0000-0000-000000000000
+Hardware Model:      iPhone15,3
+Process:             hello [123]
+
+Thread 0 Crashed:
+0   libsystem_kernel.dylib        	0x00000001f0a1c1a8 0x1f0a14000 + 33192
+1   hello                         	0x0000000104000f6e 0x104000000 + 3950
+2   hello                         	0x0000000104000f7f 0x104000000 + 3967
+3   dyld                          	0x0000000190a0cdec 0x190a00000 + 52716
+
+Binary Images:
+       0x104000000 -        0x104001fff hello x86_64  <220efad9-0559-8307-f95e-9f873725396f> /private/var/containers/Bundle/Application/X/hello.app/hello
+       0x1f0a14000 -        0x1f0a4bfff libsystem_kernel.dylib arm64e  <a1b2c3d4e5f60718293a4b5c6d7e8f90> /usr/lib/system/libsystem_kernel.dylib
+`
+
+func TestLoadDSYMBundle(t *testing.T) {
+	images, err := LoadDSYM(filepath.Dir(writeDSYM(t)))
+	if err != nil {
+		t.Fatalf("LoadDSYM() error: %v", err)
+	}
+	if len(images) != 1 || images[0].UUID != helloUUID || images[0].Arch != "x86_64" {
+		t.Fatalf("unexpected images: %+v", images)
+	}
+
+	frame, ok := images[0].Lookup(0xf7a)
+	if !ok {
+		t.Fatal("expected main to resolve")
+	}
+	if frame.Function != "main" || frame.Offset != 0x10 || frame.File != "hello.c" || frame.Line != 5 {
+		t.Fatalf("unexpected frame: %+v", frame)
+	}
+	if _, ok := images[0].Lookup(0x10); ok {
+		t.Fatal("expected address outside main not to resolve")
+	}
+}
+
+func TestSymbolicateRewritesMatchingFrames(t *testing.T) {
+	images, err := LoadDSYM(writeDSYM(t))
+	if err != nil {
+		t.Fatalf("LoadDSYM() error: %v", err)
+	}
+	result, err := New(images).Symbolicate(crashReport)
+	if err != nil {
+		t.Fatalf("Symbolicate() error: %v", err)
+	}
+
+	for _, want := range []string{
+		"1   hello                         \t0x0000000104000f6e main + 4 (hello.c:4)\n",
+		"2   hello                         \t0x0000000104000f7f main + 21 (hello.c:6)\n",
+		"0   libsystem_kernel.dylib        \t0x00000001f0a1c1a8 0x1f0a14000 + 33192\n",
+		"3   dyld                          \t0x0000000190a0cdec 0x190a00000 + 52716\n",
+	} {
+		if !strings.Contains(result.Text, want) {
+			t.Fatalf("expected %q in:\n%s", want, result.Text)
+		}
+	}
+	if result.Frames != 3 || result.Symbolicated != 2 {
+		t.Fatalf("frames = %d, symbolicated = %d", result.Frames, result.Symbolicated)
+	}
+	if len(result.MissingImages) != 1 || result.MissingImages[0].Name != "libsystem_kernel.dylib" {
+		t.Fatalf("unexpected missing images: %+v", result.MissingImages)
+	}
+
+	if _, err := New(images).Symbolicate("Thread 0 Crashed:\n0 hello 0x1 0x0 + 1\n"); err == nil {
+		t.Fatal("expected error without Binary Images section")
+	}
+}
+
+func TestExtractReports(t *testing.T) {
+	reports, err := ExtractReports([]byte(crashReport))
+	if err != nil || len(reports) != 1 || reports[0] != crashReport {
+		t.Fatalf("expected text report unchanged, got %d reports, %v", len(reports), err)
+	}
+
+	reports, err = ExtractReports([]byte(`{"data":{"type":"betaCrashLogs","id":"1","attributes":{"logText":"one"}}}`))
+	if err != nil || len(reports) != 1 || reports[0] != "one" {
+		t.Fatalf("unexpected single response result: %v, %v", reports, err)
+	}
+	reports, err = ExtractReports([]byte(`{"data":[{"attributes":{"logText":"one"}},{"attributes":{"logText":"two"}}]}`))
+	if err != nil || len(reports) != 2 {
+		t.Fatalf("unexpected list response result: %v, %v", reports, err)
+	}
+	if _, err := ExtractReports([]byte("{\"app_name\":\"hello\"}\n{\"threads\":[]}")); err == nil {
+		t.Fatal("expected error for .ips JSON")
+	}
+}
@@ -0,0 +1,160 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ValidateIPA runs the offline IPA rules that predict App Store Connect
+// processing failures and returns a report.
+func ValidateIPA(input IPAInput, strict bool) IPAReport {
+	checks := make([]CheckResult, 0)
+	checks = append(checks, ipaBundleIDChecks(input.BundleID, input.ExpectedBundleID)...)
+	checks = append(checks, ipaEncryptionChecks(input.EncryptionDeclared)...)
+	checks = append(checks, ipaIconChecks(input.Icons, input.AssetCatalogIcon)...)
+	checks = append(checks, ipaProfileChecks(input.BundleID, input.Profile)...)
+	checks = append(checks, ipaPrivacyChecks(input.Bundles)...)
+
+	return IPAReport{
+		Path:     input.Path,
+		BundleID: input.BundleID,
+		Version:  input.Version,
+		Build:    input.Build,
+		Summary:  summarize(checks, strict),
+		Checks:   checks,
+		Strict:   strict,
+	}
+}
+
+func ipaBundleIDChecks(bundleID, expected string) []CheckResult {
+	bundleID = strings.TrimSpace(bundleID)
+	expected = strings.TrimSpace(expected)
+	if expected == "" || bundleID == expected {
+		return nil
+	}
+	return []CheckResult{
+		{
+			ID:           "ipa.bundle_id.mismatch",
+			Severity:     SeverityError,
+			Field:        "CFBundleIdentifier",
+			ResourceType: "app",
+			Message:      fmt.Sprintf("IPA bundle ID %s does not match the app's bundle ID %s", bundleID, expected),
+			Remediation:  "Upload to the app registered for this bundle ID, or fix PRODUCT_BUNDLE_IDENTIFIER",
+		},
+	}
+}
+
+func ipaEncryptionChecks(declared bool) []CheckResult {
+	if declared {
+		return nil
+	}
+	return []CheckResult{
+		{
+			ID:          "ipa.info.encryption_missing",
+			Severity:    SeverityWarning,
+			Field:       "ITSAppUsesNonExemptEncryption",
+			Message:     "Info.plist does not set ITSAppUsesNonExemptEncryption",
+			Remediation: "Add ITSAppUsesNonExemptEncryption to Info.plist so the build does not wait on an export compliance answer",
+		},
+	}
+}
+
+func ipaIconChecks(icons []IPAIcon, assetCatalog string) []CheckResult {
+	var checks []CheckResult
+	for _, icon := range icons {
+		if !icon.HasAlpha {
+			continue
+		}
+		checks = append(checks, CheckResult{
+			ID:           "ipa.icon.alpha",
+			Severity:     SeverityError,
+			ResourceType: "file",
+			ResourceID:   icon.Path,
+			Message:      "app icon has an alpha channel",
+			Remediation:  "Export the app icon without transparency or an alpha channel",
+		})
+	}
+	// The 1024px App Store icon lives only in the compiled asset catalog,
+	// which is not decoded, so say so instead of passing it silently.
+	if assetCatalog != "" {
+		checks = append(checks, CheckResult{
+			ID:           "ipa.icon.asset_catalog_unchecked",
+			Severity:     SeverityInfo,
+			ResourceType: "file",
+			ResourceID:   assetCatalog,
+			Message:      "app icon in the asset catalog was not checked for an alpha channel",
+			Remediation:  "Make sure the 1024px App Store icon in the AppIcon set has no transparency or alpha channel",
+		})
+	}
+	return checks
+}
+
+func ipaProfileChecks(bundleID string, profile *IPAProfile) []CheckResult {
+	if profile == nil {
+		return []CheckResult{
+			{
+				ID:          "ipa.profile.missing",
+				Severity:    SeverityWarning,
+				Field:       "embedded.mobileprovision",
+				Message:     "app has no embedded provisioning profile",
+				Remediation: "Export the archive with an App Store distribution profile",
+			},
+		}
+	}
+
+	var checks []CheckResult
+	if profile.Expired {
+		checks = append(checks, CheckResult{
+			ID:           "ipa.profile.expired",
+			Severity:     SeverityError,
+			Field:        "embedded.mobileprovision",
+			ResourceType: "profile",
+			ResourceID:   profile.UUID,
+			Message:      fmt.Sprintf("provisioning profile %q expired on %s", profile.Name, profile.ExpirationDate.Format("2006-01-02")),
+			Remediation:  "Regenerate the distribution profile and re-export the archive",
+		})
+	}
+	if profile.BundleID != "" && strings.TrimSpace(bundleID) != "" && !bundleIDMatches(profile.BundleID, bundleID) {
+		checks = append(checks, CheckResult{
+			ID:           "ipa.profile.bundle_id_mismatch",
+			Severity:     SeverityError,
+			Field:        "embedded.mobileprovision",
+			ResourceType: "profile",
+			ResourceID:   profile.UUID,
+			Message:      fmt.Sprintf("provisioning profile is for %s, not %s", profile.BundleID, bundleID),
+			Remediation:  "Sign the app with a profile for its bundle ID",
+		})
+	}
+	return checks
+}
+
+// bundleIDMatches compares a bundle ID with a profile app ID that may end in
+// a wildcard ("*" or "com.example.*").
+func bundleIDMatches(pattern, bundleID string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(bundleID, prefix)
+	}
+	return pattern == bundleID
+}
+
+func ipaPrivacyChecks(bundles []IPABundle) []CheckResult {
+	var checks []CheckResult
+	for _, bundle := range bundles {
+		for _, api := range bundle.RequiredReasonAPIs {
+			if slices.Contains(bundle.DeclaredAPIs, api) {
+				continue
+			}
+			checks = append(checks, CheckResult{
+				ID:           "ipa.privacy.api_declaration_missing",
+				Severity:     SeverityError,
+				Field:        "NSPrivacyAccessedAPITypes",
+				ResourceType: "bundle",
+				ResourceID:   bundle.Path,
+				Message:      fmt.Sprintf("binary uses required reason API %s but no PrivacyInfo.xcprivacy declares it", api),
+				Remediation:  "Declare " + api + " with an approved reason in PrivacyInfo.xcprivacy",
+			})
+		}
+	}
+	return checks
+}
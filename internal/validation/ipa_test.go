@@ -0,0 +1,107 @@
+package validation
+
+import (
+	"testing"
+	"time"
+)
+
+func validIPAInput() IPAInput {
+	return IPAInput{
+		Path:               "app.ipa",
+		BundleID:           "com.example.app",
+		ExpectedBundleID:   "com.example.app",
+		EncryptionDeclared: true,
+		Icons:              []IPAIcon{{Path: "Example.app/AppIcon60x60@2x.png"}},
+		Profile: &IPAProfile{
+			Name:           "Example App Store",
+			UUID:           "profile-1",
+			BundleID:       "com.example.*",
+			ExpirationDate: time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Bundles: []IPABundle{{
+			Path:               "Example.app",
+			RequiredReasonAPIs: []string{"NSPrivacyAccessedAPICategoryUserDefaults"},
+			DeclaredAPIs:       []string{"NSPrivacyAccessedAPICategoryUserDefaults"},
+		}},
+	}
+}
+
+func TestValidateIPA_NoIssues(t *testing.T) {
+	report := ValidateIPA(validIPAInput(), true)
+	if len(report.Checks) != 0 || report.Summary.Blocking != 0 {
+		t.Fatalf("expected no checks, got %+v", report.Checks)
+	}
+	if report.BundleID != "com.example.app" || report.Path != "app.ipa" {
+		t.Fatalf("unexpected report header: %+v", report)
+	}
+}
+
+func TestValidateIPA_Failures(t *testing.T) {
+	input := validIPAInput()
+	input.ExpectedBundleID = "com.example.other"
+	input.EncryptionDeclared = false
+	input.Icons = append(input.Icons, IPAIcon{Path: "Example.app/AppIcon76x76@2x~ipad.png", HasAlpha: true})
+	input.Profile.Expired = true
+	input.Profile.BundleID = "com.other.app"
+	input.Bundles[0].RequiredReasonAPIs = append(input.Bundles[0].RequiredReasonAPIs, "NSPrivacyAccessedAPICategoryFileTimestamp")
+
+	report := ValidateIPA(input, false)
+	for _, id := range []string{
+		"ipa.bundle_id.mismatch",
+		"ipa.info.encryption_missing",
+		"ipa.icon.alpha",
+		"ipa.profile.expired",
+		"ipa.profile.bundle_id_mismatch",
+		"ipa.privacy.api_declaration_missing",
+	} {
+		if !hasCheckID(report.Checks, id) {
+			t.Fatalf("expected %s check, got %+v", id, report.Checks)
+		}
+	}
+	if report.Summary.Errors != 5 || report.Summary.Warnings != 1 || report.Summary.Blocking != 5 {
+		t.Fatalf("unexpected summary: %+v", report.Summary)
+	}
+}
+
+func TestValidateIPA_MissingProfileIsWarning(t *testing.T) {
+	input := validIPAInput()
+	input.Profile = nil
+
+	report := ValidateIPA(input, false)
+	if !hasCheckID(report.Checks, "ipa.profile.missing") || report.Summary.Blocking != 0 {
+		t.Fatalf("expected non-blocking ipa.profile.missing, got %+v", report)
+	}
+	strictReport := ValidateIPA(input, true)
+	if strictReport.Summary.Blocking != 1 {
+		t.Fatalf("expected strict mode to block on warnings, got %+v", strictReport.Summary)
+	}
+}
+
+func TestValidateIPA_AssetCatalogIconIsInfo(t *testing.T) {
+	input := validIPAInput()
+	input.Icons = nil
+	input.AssetCatalogIcon = "Example.app/Assets.car"
+
+	report := ValidateIPA(input, true)
+	if !hasCheckID(report.Checks, "ipa.icon.asset_catalog_unchecked") || report.Summary.Infos != 1 || report.Summary.Blocking != 0 {
+		t.Fatalf("expected non-blocking ipa.icon.asset_catalog_unchecked, got %+v", report)
+	}
+}
+
+func TestBundleIDMatches(t *testing.T) {
+	tests := []struct {
+		pattern, bundleID string
+		want              bool
+	}{
+		{"com.example.app", "com.example.app", true},
+		{"com.example.*", "com.example.app", true},
+		{"*", "com.example.app", true},
+		{"com.example.app", "com.example.app.widget", false},
+		{"com.other.*", "com.example.app", false},
+	}
+	for _, test := range tests {
+		if got := bundleIDMatches(test.pattern, test.bundleID); got != test.want {
+			t.Fatalf("bundleIDMatches(%q, %q) = %v, want %v", test.pattern, test.bundleID, got, test.want)
+		}
+	}
+}
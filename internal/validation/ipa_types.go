@@ -0,0 +1,59 @@
+package validation
+
+import "time"
+
+// IPAInput collects the facts read from an IPA for pre-upload validation.
+type IPAInput struct {
+	Path     string
+	BundleID string
+	Version  string
+	Build    string
+	// ExpectedBundleID is the bundle ID of the target App Store Connect app,
+	// when known.
+	ExpectedBundleID string
+	// EncryptionDeclared reports whether Info.plist sets
+	// ITSAppUsesNonExemptEncryption.
+	EncryptionDeclared bool
+
+	Icons []IPAIcon
+	// AssetCatalogIcon is the path of the Assets.car the app icon is compiled
+	// into, when Info.plist names one. Its images are not inspected.
+	AssetCatalogIcon string
+
+	Profile *IPAProfile
+	Bundles []IPABundle
+}
+
+// IPAIcon is an app icon image shipped in the bundle.
+type IPAIcon struct {
+	Path     string
+	HasAlpha bool
+}
+
+// IPAProfile is the embedded provisioning profile of the app.
+type IPAProfile struct {
+	Name           string
+	UUID           string
+	BundleID       string
+	ExpirationDate time.Time
+	Expired        bool
+}
+
+// IPABundle records the required reason APIs an executable references and
+// the API categories its privacy manifests declare.
+type IPABundle struct {
+	Path               string
+	RequiredReasonAPIs []string
+	DeclaredAPIs       []string
+}
+
+// IPAReport is the top-level IPA lint output.
+type IPAReport struct {
+	Path     string        `json:"path"`
+	BundleID string        `json:"bundleId,omitempty"`
+	Version  string        `json:"version,omitempty"`
+	Build    string        `json:"build,omitempty"`
+	Summary  Summary       `json:"summary"`
+	Checks   []CheckResult `json:"checks"`
+	Strict   bool          `json:"strict,omitempty"`
+}
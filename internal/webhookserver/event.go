@@ -0,0 +1,163 @@
+package webhookserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+// EventTypePing is sent by 'asc webhooks ping' and when a webhook is created.
+const EventTypePing asc.WebhookEventType = "WEBHOOK_PING_CREATED"
+
+// Event is a decoded App Store Connect webhook notification.
+type Event struct {
+	ID         string               `json:"id"`
+	Type       asc.WebhookEventType `json:"type"`
+	Version    int                  `json:"version,omitempty"`
+	Timestamp  string               `json:"timestamp,omitempty"`
+	Instance   *Instance            `json:"instance,omitempty"`
+	Payload    any                  `json:"payload,omitempty"`
+	Attributes json.RawMessage      `json:"attributes,omitempty"`
+	ReceivedAt time.Time            `json:"receivedAt"`
+}
+
+// Instance identifies the resource an event is about.
+type Instance struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// StateChange is the payload of events that report a resource moving from
+// one state to another: BUILD_UPLOAD_STATE_UPDATED,
+// APP_STORE_VERSION_APP_VERSION_STATE_UPDATED,
+// BUILD_BETA_DETAIL_EXTERNAL_BUILD_STATE_UPDATED and the background asset
+// state events.
+type StateChange struct {
+	OldState string `json:"oldState,omitempty"`
+	NewState string `json:"newState"`
+}
+
+// BetaFeedback is the payload of BETA_FEEDBACK_CRASH_SUBMISSION_CREATED and
+// BETA_FEEDBACK_SCREENSHOT_SUBMISSION_CREATED.
+type BetaFeedback struct {
+	Kind         string `json:"kind"`
+	SubmissionID string `json:"submissionId"`
+}
+
+type payload struct {
+	Data struct {
+		Type          string          `json:"type"`
+		ID            string          `json:"id"`
+		Version       int             `json:"version"`
+		Attributes    json.RawMessage `json:"attributes"`
+		Relationships struct {
+			Instance struct {
+				Data *Instance `json:"data"`
+			} `json:"instance"`
+		} `json:"relationships"`
+	} `json:"data"`
+}
+
+type stateAttributes struct {
+	Timestamp string `json:"timestamp"`
+	OldValue  string `json:"oldValue"`
+	NewValue  string `json:"newValue"`
+	OldState  string `json:"oldState"`
+	NewState  string `json:"newState"`
+}
+
+// Decode parses a webhook request body.
+func Decode(body []byte) (*Event, error) {
+	var raw payload
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("invalid event payload: %w", err)
+	}
+	if strings.TrimSpace(raw.Data.Type) == "" {
+		return nil, fmt.Errorf("invalid event payload: data.type is required")
+	}
+
+	event := &Event{
+		ID:       raw.Data.ID,
+		Type:     eventType(raw.Data.Type),
+		Version:  raw.Data.Version,
+		Instance: raw.Data.Relationships.Instance.Data,
+	}
+	if len(raw.Data.Attributes) > 0 && string(raw.Data.Attributes) != "null" {
+		event.Attributes = raw.Data.Attributes
+	}
+
+	var attrs stateAttributes
+	if event.Attributes != nil {
+		if err := json.Unmarshal(event.Attributes, &attrs); err != nil {
+			return nil, fmt.Errorf("invalid %s attributes: %w", event.Type, err)
+		}
+	}
+	event.Timestamp = attrs.Timestamp
+
+	switch event.Type {
+	case asc.WebhookEventBuildUploadStateUpdated,
+		asc.WebhookEventAppStoreVersionStateUpdated,
+		asc.WebhookEventBuildBetaDetailExternalBuildStateUpdated,
+		asc.WebhookEventBackgroundAssetVersionStateUpdated,
+		asc.WebhookEventBackgroundAssetVersionAppStoreReleaseStateUpdated,
+		asc.WebhookEventBackgroundAssetVersionExternalBetaReleaseStateUpdated:
+		change := StateChange{OldState: attrs.OldState, NewState: attrs.NewState}
+		if change.OldState == "" {
+			change.OldState = attrs.OldValue
+		}
+		if change.NewState == "" {
+			change.NewState = attrs.NewValue
+		}
+		event.Payload = change
+	case asc.WebhookEventBetaFeedbackCrashSubmissionCreated, asc.WebhookEventBetaFeedbackScreenshotSubmissionCreated:
+		feedback := BetaFeedback{Kind: "screenshot"}
+		if event.Type == asc.WebhookEventBetaFeedbackCrashSubmissionCreated {
+			feedback.Kind = "crash"
+		}
+		if event.Instance != nil {
+			feedback.SubmissionID = event.Instance.ID
+		}
+		event.Payload = feedback
+	}
+	return event, nil
+}
+
+// Summary is a one-line human readable description of the event.
+func (e *Event) Summary() string {
+	subject := string(e.Type)
+	if e.Instance != nil && e.Instance.ID != "" {
+		subject += " " + e.Instance.Type + "/" + e.Instance.ID
+	}
+	switch payload := e.Payload.(type) {
+	case StateChange:
+		if payload.OldState != "" {
+			return fmt.Sprintf("%s: %s -> %s", subject, payload.OldState, payload.NewState)
+		}
+		return fmt.Sprintf("%s: %s", subject, payload.NewState)
+	case BetaFeedback:
+		return fmt.Sprintf("%s: new TestFlight %s feedback", subject, payload.Kind)
+	}
+	return subject
+}
+
+// eventType converts the camelCase payload type (buildUploadStateUpdated) to
+// the event type constant used when creating webhooks
+// (BUILD_UPLOAD_STATE_UPDATED).
+func eventType(value string) asc.WebhookEventType {
+	value = strings.TrimSpace(value)
+	if strings.ToUpper(value) == value {
+		return asc.WebhookEventType(value)
+	}
+	var b strings.Builder
+	for i, r := range value {
+		if unicode.IsUpper(r) && i > 0 {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return asc.WebhookEventType(b.String())
+}
@@ -0,0 +1,299 @@
+// Package webhookserver receives App Store Connect webhook notifications,
+// verifies their signature and hands decoded events to a dispatcher.
+//
+// Verified deliveries are acknowledged as soon as they are queued; a single
+// worker dispatches queued events in order, so slow targets never hold a
+// delivery open. Events are deduplicated by ID, so a redelivered event that is
+// queued or already dispatched does not run the targets again.
+//
+// App Store Connect signs each delivery with the webhook secret: the
+// X-Apple-SIGNATURE header carries "hmacsha256=" followed by the hex encoded
+// HMAC-SHA256 of the raw request body.
+package webhookserver
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+// SignatureHeader is the request header carrying the payload signature.
+const SignatureHeader = "X-Apple-SIGNATURE"
+
+const (
+	signaturePrefix  = "hmacsha256="
+	maxBodyBytes     = 1 << 20
+	defaultQueueSize = 64
+	// seenEventLimit bounds how many dispatched event IDs are remembered for
+	// deduplication.
+	seenEventLimit = 1024
+)
+
+// ErrInvalidSignature is returned when a payload signature does not match.
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// Dispatcher handles a verified event. Errors are logged; the event ID is
+// forgotten so a later redelivery of the same event is dispatched again.
+type Dispatcher func(ctx context.Context, event *Event) error
+
+// Options configures a Server.
+type Options struct {
+	// Secret is the webhook secret used to verify signatures.
+	Secret string
+	// Path is the request path to accept. Empty accepts every path.
+	Path string
+	// Events limits dispatch to these event types. Other verified events are
+	// acknowledged and dropped. Empty dispatches every event.
+	Events []asc.WebhookEventType
+	// Dispatch receives every accepted event.
+	Dispatch Dispatcher
+	// QueueSize is how many events may wait for dispatch. Deliveries arriving
+	// while the queue is full are answered with 503. Defaults to 64.
+	QueueSize int
+	// Logger receives one line per request.
+	Logger io.Writer
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+// Server is an http.Handler for App Store Connect webhook deliveries. Call
+// Close to stop dispatching once the HTTP server has shut down.
+type Server struct {
+	secret   []byte
+	path     string
+	events   map[asc.WebhookEventType]struct{}
+	dispatch Dispatcher
+	logger   io.Writer
+	now      func() time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	queue  chan *Event
+	done   chan struct{}
+
+	logMu     sync.Mutex
+	mu        sync.Mutex
+	closed    bool
+	seen      map[string]struct{}
+	seenOrder []string
+}
+
+// New returns a Server for opts.
+func New(opts Options) (*Server, error) {
+	if strings.TrimSpace(opts.Secret) == "" {
+		return nil, fmt.Errorf("webhook secret is required")
+	}
+	if opts.Dispatch == nil {
+		return nil, fmt.Errorf("dispatcher is required")
+	}
+	queueSize := opts.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+	server := &Server{
+		secret:   []byte(opts.Secret),
+		path:     strings.TrimSpace(opts.Path),
+		dispatch: opts.Dispatch,
+		logger:   opts.Logger,
+		now:      opts.Now,
+		queue:    make(chan *Event, queueSize),
+		done:     make(chan struct{}),
+		seen:     make(map[string]struct{}),
+	}
+	server.ctx, server.cancel = context.WithCancel(context.Background())
+	if server.logger == nil {
+		server.logger = io.Discard
+	}
+	if server.now == nil {
+		server.now = time.Now
+	}
+	if len(opts.Events) > 0 {
+		server.events = make(map[asc.WebhookEventType]struct{}, len(opts.Events))
+		for _, eventType := range opts.Events {
+			server.events[eventType] = struct{}{}
+		}
+	}
+	go server.work()
+	return server, nil
+}
+
+// Close stops accepting events and waits for queued events to be dispatched.
+// When ctx ends first, in-flight dispatches are cancelled and ctx's error is
+// returned.
+func (s *Server) Close(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.queue)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-s.done:
+		s.cancel()
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		<-s.done
+		return ctx.Err()
+	}
+}
+
+// Sign returns the X-Apple-SIGNATURE header value for body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks header against the HMAC-SHA256 of body.
+func VerifySignature(secret string, body []byte, header string) error {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return ErrInvalidSignature
+	}
+	if len(header) >= len(signaturePrefix) && strings.EqualFold(header[:len(signaturePrefix)], signaturePrefix) {
+		header = header[len(signaturePrefix):]
+	}
+	got, err := hex.DecodeString(header)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.path != "" && r.URL.Path != s.path {
+		s.reply(w, r, http.StatusNotFound, "unknown path")
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		s.reply(w, r, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		s.reply(w, r, http.StatusRequestEntityTooLarge, "payload too large")
+		return
+	}
+	if err := VerifySignature(string(s.secret), body, r.Header.Get(SignatureHeader)); err != nil {
+		s.reply(w, r, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	event, err := Decode(body)
+	if err != nil {
+		s.reply(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	event.ReceivedAt = s.now().UTC()
+
+	if s.events != nil {
+		if _, ok := s.events[event.Type]; !ok {
+			s.reply(w, r, http.StatusOK, "ignored "+string(event.Type))
+			return
+		}
+	}
+	switch s.enqueue(event) {
+	case enqueueDuplicate:
+		s.reply(w, r, http.StatusOK, "duplicate "+event.Summary())
+	case enqueueFull:
+		s.reply(w, r, http.StatusServiceUnavailable, event.Summary()+": dispatch queue is full")
+	default:
+		s.reply(w, r, http.StatusOK, "queued "+event.Summary())
+	}
+}
+
+type enqueueResult int
+
+const (
+	enqueueQueued enqueueResult = iota
+	enqueueDuplicate
+	enqueueFull
+)
+
+func (s *Server) enqueue(event *Event) enqueueResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return enqueueFull
+	}
+	if event.ID != "" {
+		if _, ok := s.seen[event.ID]; ok {
+			return enqueueDuplicate
+		}
+	}
+	select {
+	case s.queue <- event:
+	default:
+		return enqueueFull
+	}
+	s.remember(event.ID)
+	return enqueueQueued
+}
+
+// remember records id as seen, forgetting the oldest IDs past seenEventLimit.
+// Callers hold s.mu.
+func (s *Server) remember(id string) {
+	if id == "" {
+		return
+	}
+	s.seen[id] = struct{}{}
+	s.seenOrder = append(s.seenOrder, id)
+	if len(s.seenOrder) > seenEventLimit {
+		delete(s.seen, s.seenOrder[0])
+		s.seenOrder = s.seenOrder[1:]
+	}
+}
+
+func (s *Server) forget(id string) {
+	if id == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.seen, id)
+}
+
+func (s *Server) work() {
+	defer close(s.done)
+	for event := range s.queue {
+		if err := s.dispatch(s.ctx, event); err != nil {
+			s.forget(event.ID)
+			s.logf("dispatch %s failed: %v", event.Summary(), err)
+			continue
+		}
+		s.logf("dispatched %s", event.Summary())
+	}
+}
+
+func (s *Server) reply(w http.ResponseWriter, r *http.Request, status int, message string) {
+	s.logf("%s %s -> %d %s", r.Method, r.URL.Path, status, message)
+	w.WriteHeader(status)
+}
+
+// logf writes one line to the logger; requests and the dispatch worker log
+// concurrently.
+func (s *Server) logf(format string, args ...any) {
+	s.logMu.Lock()
+	defer s.logMu.Unlock()
+	fmt.Fprintf(s.logger, format+"\n", args...)
+}
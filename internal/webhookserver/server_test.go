@@ -0,0 +1,248 @@
+package webhookserver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+const (
+	testSecret         = "secret123"
+	buildUploadPayload = `{"data":{"type":"buildUploadStateUpdated","id":"event-1","version":1,"attributes":{"oldState":"PROCESSING","newState":"COMPLETE","timestamp":"2025-06-10T10:00:00Z"},"relationships":{"instance":{"data":{"type":"buildUploads","id":"upload-1"}}}}}`
+	versionPayload     = `{"data":{"type":"appStoreVersionAppVersionStateUpdated","id":"event-2","version":1,"attributes":{"oldValue":"WAITING_FOR_REVIEW","newValue":"IN_REVIEW","timestamp":"2025-06-10T10:00:00Z"},"relationships":{"instance":{"data":{"type":"appStoreVersions","id":"version-1"}}}}}`
+	feedbackPayload    = `{"data":{"type":"betaFeedbackScreenshotSubmissionCreated","id":"event-3","version":1,"attributes":{"timestamp":"2025-06-10T10:00:00Z"},"relationships":{"instance":{"data":{"type":"betaFeedbackScreenshotSubmissions","id":"feedback-1"}}}}}`
+	pingPayload        = `{"data":{"type":"webhookPingCreated","id":"event-4","version":1,"attributes":{"timestamp":"2025-06-10T10:00:00Z"}}}`
+)
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(buildUploadPayload)
+	signature := Sign(testSecret, body)
+	if !strings.HasPrefix(signature, "hmacsha256=") {
+		t.Fatalf("unexpected signature format %q", signature)
+	}
+	if err := VerifySignature(testSecret, body, signature); err != nil {
+		t.Fatalf("VerifySignature() error: %v", err)
+	}
+	if err := VerifySignature(testSecret, body, strings.TrimPrefix(signature, "hmacsha256=")); err != nil {
+		t.Fatalf("expected bare hex signature to verify, got %v", err)
+	}
+
+	for name, header := range map[string]string{
+		"empty":        "",
+		"wrong secret": Sign("other", body),
+		"not hex":      "hmacsha256=zz",
+	} {
+		if err := VerifySignature(testSecret, body, header); !errors.Is(err, ErrInvalidSignature) {
+			t.Fatalf("%s: expected ErrInvalidSignature, got %v", name, err)
+		}
+	}
+}
+
+func TestDecodeTypedPayloads(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    asc.WebhookEventType
+		payload any
+		summary string
+	}{
+		{
+			name:    "build upload",
+			body:    buildUploadPayload,
+			want:    asc.WebhookEventBuildUploadStateUpdated,
+			payload: StateChange{OldState: "PROCESSING", NewState: "COMPLETE"},
+			summary: "BUILD_UPLOAD_STATE_UPDATED buildUploads/upload-1: PROCESSING -> COMPLETE",
+		},
+		{
+			name:    "app version",
+			body:    versionPayload,
+			want:    asc.WebhookEventAppStoreVersionStateUpdated,
+			payload: StateChange{OldState: "WAITING_FOR_REVIEW", NewState: "IN_REVIEW"},
+			summary: "APP_STORE_VERSION_APP_VERSION_STATE_UPDATED appStoreVersions/version-1: WAITING_FOR_REVIEW -> IN_REVIEW",
+		},
+		{
+			name:    "feedback",
+			body:    feedbackPayload,
+			want:    asc.WebhookEventBetaFeedbackScreenshotSubmissionCreated,
+			payload: BetaFeedback{Kind: "screenshot", SubmissionID: "feedback-1"},
+			summary: "BETA_FEEDBACK_SCREENSHOT_SUBMISSION_CREATED betaFeedbackScreenshotSubmissions/feedback-1: new TestFlight screenshot feedback",
+		},
+		{
+			name:    "ping",
+			body:    pingPayload,
+			want:    EventTypePing,
+			summary: "WEBHOOK_PING_CREATED",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			event, err := Decode([]byte(test.body))
+			if err != nil {
+				t.Fatalf("Decode() error: %v", err)
+			}
+			if event.Type != test.want {
+				t.Fatalf("type = %q, want %q", event.Type, test.want)
+			}
+			if event.Payload != test.payload {
+				t.Fatalf("payload = %#v, want %#v", event.Payload, test.payload)
+			}
+			if event.Timestamp != "2025-06-10T10:00:00Z" {
+				t.Fatalf("unexpected timestamp %q", event.Timestamp)
+			}
+			if got := event.Summary(); got != test.summary {
+				t.Fatalf("summary = %q, want %q", got, test.summary)
+			}
+		})
+	}
+
+	if _, err := Decode([]byte(`{"data":{}}`)); err == nil {
+		t.Fatal("expected error for payload without type")
+	}
+}
+
+func TestServerVerifiesAndDispatches(t *testing.T) {
+	dispatched := make(chan *Event, 10)
+	var logs bytes.Buffer
+	server, err := New(Options{
+		Secret: testSecret,
+		Path:   "/asc",
+		Events: []asc.WebhookEventType{asc.WebhookEventBuildUploadStateUpdated, asc.WebhookEventAppStoreVersionStateUpdated},
+		Dispatch: func(ctx context.Context, event *Event) error {
+			dispatched <- event
+			return nil
+		},
+		Logger: &logs,
+		Now:    func() time.Time { return time.Date(2025, 6, 10, 10, 0, 1, 0, time.UTC) },
+	})
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	send := func(method, path, body, signature string) int {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if signature != "" {
+			req.Header.Set(SignatureHeader, signature)
+		}
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := send(http.MethodPost, "/asc", buildUploadPayload, Sign(testSecret, []byte(buildUploadPayload))); code != http.StatusOK {
+		t.Fatalf("signed delivery: status %d", code)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		sig    string
+		want   int
+	}{
+		{name: "unsigned", method: http.MethodPost, path: "/asc", body: buildUploadPayload, want: http.StatusUnauthorized},
+		{name: "tampered", method: http.MethodPost, path: "/asc", body: versionPayload, sig: Sign(testSecret, []byte(buildUploadPayload)), want: http.StatusUnauthorized},
+		{name: "wrong path", method: http.MethodPost, path: "/other", body: buildUploadPayload, sig: Sign(testSecret, []byte(buildUploadPayload)), want: http.StatusNotFound},
+		{name: "get", method: http.MethodGet, path: "/asc", want: http.StatusMethodNotAllowed},
+		{name: "invalid json", method: http.MethodPost, path: "/asc", body: "{", sig: Sign(testSecret, []byte("{")), want: http.StatusBadRequest},
+		{name: "filtered", method: http.MethodPost, path: "/asc", body: pingPayload, sig: Sign(testSecret, []byte(pingPayload)), want: http.StatusOK},
+		{name: "duplicate", method: http.MethodPost, path: "/asc", body: buildUploadPayload, sig: Sign(testSecret, []byte(buildUploadPayload)), want: http.StatusOK},
+	}
+	for _, test := range tests {
+		if code := send(test.method, test.path, test.body, test.sig); code != test.want {
+			t.Fatalf("%s: status %d, want %d", test.name, code, test.want)
+		}
+	}
+
+	if err := server.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+	close(dispatched)
+	var events []*Event
+	for event := range dispatched {
+		events = append(events, event)
+	}
+	if len(events) != 1 || events[0].ID != "event-1" || events[0].ReceivedAt.IsZero() {
+		t.Fatalf("expected only the first delivery to dispatch, got %+v", events)
+	}
+	if code := send(http.MethodPost, "/asc", versionPayload, Sign(testSecret, []byte(versionPayload))); code != http.StatusServiceUnavailable {
+		t.Fatalf("delivery after Close: status %d, want 503", code)
+	}
+}
+
+func TestServerAcknowledgesBeforeDispatch(t *testing.T) {
+	release := make(chan error)
+	logs := make(chan string, 20)
+	var calls atomic.Int32
+	server, err := New(Options{
+		Secret: testSecret,
+		Dispatch: func(ctx context.Context, event *Event) error {
+			calls.Add(1)
+			return <-release
+		},
+		Logger: lineWriter(logs),
+	})
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	send := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(versionPayload))
+		req.Header.Set(SignatureHeader, Sign(testSecret, []byte(versionPayload)))
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	waitFor := func(prefix string) {
+		t.Helper()
+		for {
+			select {
+			case line := <-logs:
+				if strings.HasPrefix(line, prefix) {
+					return
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("timed out waiting for %q log", prefix)
+			}
+		}
+	}
+
+	// The dispatcher is blocked, so deliveries must be answered without it.
+	if code := send(); code != http.StatusOK {
+		t.Fatalf("first delivery: status %d, want 200", code)
+	}
+	if code := send(); code != http.StatusOK {
+		t.Fatalf("redelivery while queued: status %d, want 200", code)
+	}
+
+	// A failed dispatch forgets the event so a later redelivery runs again.
+	release <- errors.New("boom")
+	waitFor("dispatch ")
+	if code := send(); code != http.StatusOK {
+		t.Fatalf("redelivery after failure: status %d, want 200", code)
+	}
+	release <- nil
+	waitFor("dispatched ")
+
+	if err := server.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected 2 dispatches, got %d", got)
+	}
+}
+
+type lineWriter chan string
+
+func (w lineWriter) Write(p []byte) (int, error) {
+	w <- string(p)
+	return len(p), nil
+}